@@ -11,7 +11,6 @@ import (
 	"compress/gzip"
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -76,7 +75,7 @@ func notFoundInArch(filename, archname string) error {
 // GET OBJECT: archive //
 /////////////////////////
 
-func (goi *getObjInfo) freadArch(file *os.File, mime string) (cos.ReadCloseSizer, error) {
+func (goi *getObjInfo) freadArch(file cos.LomReader, mime string) (cos.ReadCloseSizer, error) {
 	archname := filepath.Join(goi.lom.Bck().Name, goi.lom.ObjName)
 	filename := goi.archive.filename
 	switch mime {
@@ -94,7 +93,7 @@ func (goi *getObjInfo) freadArch(file *os.File, mime string) (cos.ReadCloseSizer
 	}
 }
 
-func (goi *getObjInfo) mime(file *os.File) (m string, err error) {
+func (goi *getObjInfo) mime(file cos.LomReader) (m string, err error) {
 	// either ok or non-empty user-defined mime type (that must work)
 	if m, err = cos.Mime(goi.archive.mime, goi.lom.ObjName); err == nil || goi.archive.mime != "" {
 		return
@@ -36,6 +36,7 @@ import (
 const (
 	// environment variable to globally override the default 'https://s3.amazonaws.com' endpoint
 	// NOTE: the same can be done on a per-bucket basis, via bucket prop `Extra.AWS.Endpoint`
+	// that is either URL or alias of the named endpoint (see cmn.BackendConfAWS)
 	// (bucket override will always take precedence)
 	awsEnvS3Endpoint = "S3_ENDPOINT"
 )
@@ -45,19 +46,23 @@ type (
 		t cluster.TargetPut
 	}
 	sessConf struct {
-		bck    *cmn.Bck
-		region string
+		bck      *cmn.Bck
+		region   string
+		endpoint string // URL or alias (when the bucket is not in BMD yet - see HeadBucket)
 	}
 )
 
 var (
-	clients    map[string]map[string]*s3.S3 // one client per (region, endpoint)
+	clients    map[string]map[string]*s3.S3 // one client per (region, endpoint [, profile, path-style])
 	cmu        sync.RWMutex
 	s3Endpoint string
 )
 
 // interface guard
-var _ cluster.BackendProvider = (*awsProvider)(nil)
+var (
+	_ cluster.BackendProvider = (*awsProvider)(nil)
+	_ cluster.RangeReader     = (*awsProvider)(nil)
+)
 
 func NewAWS(t cluster.TargetPut) (cluster.BackendProvider, error) {
 	clients = make(map[string]map[string]*s3.S3, 2)
@@ -82,14 +87,20 @@ func (*awsProvider) CreateBucket(_ *cluster.Bck) (int, error) {
 // HEAD BUCKET //
 /////////////////
 
-func (*awsProvider) HeadBucket(_ ctx, bck *cluster.Bck) (bckProps cos.StrKVs, errCode int, err error) {
+func (*awsProvider) HeadBucket(ctx context.Context, bck *cluster.Bck) (bckProps cos.StrKVs, errCode int, err error) {
 	var (
 		svc      *s3.S3
 		region   string
 		errC     error
 		cloudBck = bck.RemoteBck()
+		endpoint string
 	)
-	svc, region, errC = newClient(sessConf{bck: cloudBck}, "")
+	if bck.Props != nil {
+		endpoint = bck.Props.Extra.AWS.Endpoint
+	} else if v, ok := ctx.Value(cos.CtxS3Endpoint).(string); ok {
+		endpoint = v
+	}
+	svc, region, errC = newClient(sessConf{bck: cloudBck, endpoint: endpoint}, "")
 	if verbose {
 		glog.Infof("[head_bucket] %s (%v)", cloudBck.Name, errC)
 	}
@@ -101,7 +112,7 @@ func (*awsProvider) HeadBucket(_ ctx, bck *cluster.Bck) (bckProps cos.StrKVs, er
 			return
 		}
 		// Create new svc with the region details.
-		if svc, _, err = newClient(sessConf{region: region}, ""); err != nil {
+		if svc, _, err = newClient(sessConf{bck: cloudBck, region: region, endpoint: endpoint}, ""); err != nil {
 			errCode, err = awsErrorToAISError(err, cloudBck)
 			return
 		}
@@ -113,10 +124,7 @@ func (*awsProvider) HeadBucket(_ ctx, bck *cluster.Bck) (bckProps cos.StrKVs, er
 	bckProps = make(cos.StrKVs, 4)
 	bckProps[apc.HdrBackendProvider] = apc.AWS
 	bckProps[apc.HdrS3Region] = region
-	bckProps[apc.HdrS3Endpoint] = ""
-	if bck.Props != nil {
-		bckProps[apc.HdrS3Endpoint] = bck.Props.Extra.AWS.Endpoint
-	}
+	bckProps[apc.HdrS3Endpoint] = endpoint
 	versioned, errV := getBucketVersioning(svc, cloudBck)
 	if errV != nil {
 		errCode, err = awsErrorToAISError(errV, cloudBck)
@@ -378,6 +386,34 @@ func (*awsProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (r io.Re
 	return wrapReader(ctx, obj.Body), expCksum, 0, nil
 }
 
+// (compare with GetObjReader above)
+func (*awsProvider) GetObjRange(ctx context.Context, lom *cluster.LOM, oa *cmn.ObjAttrs, offset, length int64) (
+	r io.ReadCloser, errCode int, err error) {
+	var (
+		obj      *s3.GetObjectOutput
+		svc      *s3.S3
+		cloudBck = lom.Bck().RemoteBck()
+		input    = &s3.GetObjectInput{
+			Bucket: aws.String(cloudBck.Name),
+			Key:    aws.String(lom.ObjName),
+			Range:  aws.String(cmn.MakeRangeHdr(offset, length).Get(cos.HdrRange)),
+		}
+	)
+	if etag, ok := oa.GetCustomKey(cmn.ETag); ok {
+		input.IfMatch = aws.String(quoteETag(etag))
+	}
+	svc, _, err = newClient(sessConf{bck: cloudBck}, "[get_object_range]")
+	if err != nil && verbose {
+		glog.Warning(err)
+	}
+	obj, err = svc.GetObjectWithContext(ctx, input)
+	if err != nil {
+		errCode, err = awsErrorToAISError(err, cloudBck)
+		return
+	}
+	return obj.Body, 0, nil
+}
+
 func getobjCustom(lom *cluster.LOM, obj *s3.GetObjectOutput) (expCksum *cos.Cksum) {
 	h := cmn.BackendHelpers.Amazon
 	if v, ok := h.EncodeVersion(obj.VersionId); ok {
@@ -485,21 +521,37 @@ func (*awsProvider) DeleteObj(lom *cluster.LOM) (errCode int, err error) {
 // "S3 methods are safe to use concurrently. It is not safe to modify mutate
 // any of the struct's properties though."
 func newClient(conf sessConf, tag string) (svc *s3.S3, region string, err error) {
-	endpoint := s3Endpoint
+	var (
+		ep    = cmn.S3Endpoint{URL: s3Endpoint}
+		alias = conf.endpoint
+	)
 	region = conf.region
 	if conf.bck != nil && conf.bck.Props != nil {
 		if region == "" {
 			region = conf.bck.Props.Extra.AWS.CloudRegion
 		}
-		if conf.bck.Props.Extra.AWS.Endpoint != "" {
-			endpoint = conf.bck.Props.Extra.AWS.Endpoint
+		if alias == "" {
+			alias = conf.bck.Props.Extra.AWS.Endpoint
+		}
+	}
+	if alias != "" {
+		var ok bool
+		if ep, ok = cmn.GCO.Get().Backend.S3Endpoint(alias); !ok {
+			ep = cmn.S3Endpoint{URL: alias} // not a named endpoint - URL or hostname
 		}
 	}
+	if region == "" {
+		region = ep.Region
+	}
+	epKey := ep.URL
+	if ep.Profile != "" || ep.PathStyle {
+		epKey += "|" + ep.Profile + "|" + strconv.FormatBool(ep.PathStyle)
+	}
 
 	// reuse
 	if region != "" {
 		cmu.RLock()
-		svc = clients[region][endpoint]
+		svc = clients[region][epKey]
 		cmu.RUnlock()
 		if svc != nil {
 			return
@@ -507,7 +559,7 @@ func newClient(conf sessConf, tag string) (svc *s3.S3, region string, err error)
 	}
 	// create
 	var (
-		sess    = _session(endpoint)
+		sess    = _session(&ep)
 		awsConf = &aws.Config{}
 	)
 	if region == "" {
@@ -528,17 +580,22 @@ func newClient(conf sessConf, tag string) (svc *s3.S3, region string, err error)
 		eps = make(map[string]*s3.S3, 1)
 		clients[region] = eps
 	}
-	eps[endpoint] = svc
+	eps[epKey] = svc
 	cmu.Unlock()
 	return
 }
 
-// Create session using default creds from ~/.aws/credentials and environment variables.
-func _session(endpoint string) *session.Session {
+// Create session using default creds from ~/.aws/credentials and environment variables
+// (or the named profile, if specified).
+func _session(ep *cmn.S3Endpoint) *session.Session {
 	config := aws.Config{HTTPClient: cmn.NewClient(cmn.TransportArgs{})}
-	config.WithEndpoint(endpoint) // normally empty but could also be `Props.Extra.AWS.Endpoint` or `os.Getenv(awsEnvS3Endpoint)`
+	config.WithEndpoint(ep.URL) // normally empty but could also be `Props.Extra.AWS.Endpoint` or `os.Getenv(awsEnvS3Endpoint)`
+	if ep.PathStyle {
+		config.WithS3ForcePathStyle(true)
+	}
 	return session.Must(session.NewSessionWithOptions(session.Options{
 		SharedConfigState: session.SharedConfigEnable,
+		Profile:           ep.Profile,
 		Config:            config,
 	}))
 }
@@ -0,0 +1,106 @@
+//go:build aws
+
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+// minimal MinIO-like S3 endpoint: records requests and reports versioning enabled
+type s3mock struct {
+	srv  *httptest.Server
+	mu   sync.Mutex
+	reqs []string // "method path?query"
+}
+
+func newS3Mock() *s3mock {
+	m := &s3mock{}
+	m.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		m.reqs = append(m.reqs, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+		m.mu.Unlock()
+		if _, ok := r.URL.Query()["versioning"]; ok {
+			w.Header().Set(cos.HdrContentType, "application/xml")
+			w.Write([]byte(`<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`))
+		}
+	}))
+	return m
+}
+
+func (m *s3mock) requests() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string{}, m.reqs...)
+}
+
+func TestAWSNamedEndpoints(t *testing.T) {
+	minio, ceph := newS3Mock(), newS3Mock()
+	defer minio.srv.Close()
+	defer ceph.srv.Close()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv(awsEnvS3Endpoint, "")
+
+	oldConfig := cmn.GCO.Get()
+	defer func() {
+		cmn.GCO.BeginUpdate()
+		cmn.GCO.CommitUpdate(oldConfig)
+	}()
+	config := cmn.GCO.BeginUpdate()
+	config.Backend.Conf = map[string]any{apc.AWS: map[string]any{
+		"minio": map[string]any{"url": minio.srv.URL, "region": "us-east-1", "path_style": true},
+		"ceph":  map[string]any{"url": ceph.srv.URL, "region": "default", "path_style": true},
+	}}
+	err := config.Backend.Validate()
+	cmn.GCO.CommitUpdate(config)
+	tassert.CheckFatal(t, err)
+
+	awsp, err := NewAWS(nil)
+	tassert.CheckFatal(t, err)
+
+	// bucket that is not in BMD yet (see QparamS3Endpoint)
+	bck := cluster.NewBck("data", apc.AWS, cmn.NsGlobal)
+	ctx := context.WithValue(context.Background(), cos.CtxS3Endpoint, "minio")
+	props, _, err := awsp.HeadBucket(ctx, bck)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, props[apc.HdrS3Region] == "us-east-1", "expected region us-east-1, got %q", props[apc.HdrS3Region])
+	tassert.Errorf(t, props[apc.HdrS3Endpoint] == "minio", "expected endpoint minio, got %q", props[apc.HdrS3Endpoint])
+	tassert.Errorf(t, props[apc.HdrBucketVerEnabled] == "true", "expected versioning enabled")
+
+	// same-name bucket at the other endpoint, selected via bucket props
+	bck = cluster.NewBck("data", apc.AWS, cmn.NsGlobal, &cmn.BucketProps{
+		Extra: cmn.ExtraProps{AWS: cmn.ExtraPropsAWS{Endpoint: "ceph"}},
+	})
+	props, _, err = awsp.HeadBucket(context.Background(), bck)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, props[apc.HdrS3Region] == "default", "expected region default, got %q", props[apc.HdrS3Region])
+
+	for _, m := range []*s3mock{minio, ceph} {
+		reqs := m.requests()
+		tassert.Fatalf(t, len(reqs) == 1, "expected exactly one request, got %v", reqs)
+		// path-style: bucket name in the path (rather than hostname)
+		tassert.Errorf(t, strings.HasPrefix(reqs[0], http.MethodGet+" /data?"), "unexpected request %q", reqs[0])
+	}
+
+	// not a named endpoint - must be used as is
+	svc, region, err := newClient(sessConf{endpoint: minio.srv.URL, region: "us-west-2"}, "")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, region == "us-west-2", "expected region us-west-2, got %q", region)
+	tassert.Errorf(t, svc.Endpoint == minio.srv.URL, "expected endpoint %q, got %q", minio.srv.URL, svc.Endpoint)
+}
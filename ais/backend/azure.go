@@ -66,6 +66,7 @@ var (
 
 	// interface guard
 	_ cluster.BackendProvider = (*azureProvider)(nil)
+	_ cluster.RangeReader     = (*azureProvider)(nil)
 )
 
 func azureProto() string {
@@ -425,6 +426,32 @@ func (ap *azureProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (re
 	return wrapReader(ctx, resp.Body(retryOpts)), expCksum, 0, nil
 }
 
+// (compare with GetObjReader above)
+func (ap *azureProvider) GetObjRange(ctx context.Context, lom *cluster.LOM, oa *cmn.ObjAttrs, offset, length int64) (
+	r io.ReadCloser, errCode int, err error) {
+	var (
+		cloudBck = lom.Bck().RemoteBck()
+		cntURL   = ap.s.NewContainerURL(cloudBck.Name)
+		blobURL  = cntURL.NewBlobURL(lom.ObjName)
+		ac       azblob.BlobAccessConditions
+	)
+	if etag, ok := oa.GetCustomKey(cmn.ETag); ok {
+		ac.ModifiedAccessConditions.IfMatch = azblob.ETag(quoteETag(etag))
+	}
+	resp, err := blobURL.Download(ctx, offset, length, ac, false, defaultKeyOptions)
+	if err != nil {
+		errCode, err = azureErrorToAISError(err, cloudBck, lom.ObjName)
+		return nil, errCode, err
+	}
+	if resp.StatusCode() >= http.StatusBadRequest {
+		err := cmn.NewErrFailedTo(apc.Azure, "get object range", cloudBck.Name+"/"+lom.ObjName,
+			azureErrStatus(resp.StatusCode()))
+		return nil, resp.StatusCode(), err
+	}
+	retryOpts := azblob.RetryReaderOptions{MaxRetryRequests: 3}
+	return resp.Body(retryOpts), 0, nil
+}
+
 ////////////////
 // PUT OBJECT //
 ////////////////
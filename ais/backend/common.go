@@ -7,6 +7,7 @@ package backend
 import (
 	"context"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -37,6 +38,9 @@ func setSize(ctx context.Context, size int64) {
 
 func fmtTime(t time.Time) string { return t.Format(time.RFC3339) }
 
+// (custom ETag metadata is stored unquoted - see cmn.BackendHelpers)
+func quoteETag(etag string) string { return "\"" + strings.Trim(etag, "\"") + "\"" }
+
 func calcPageSize(pageSize, maxPageSize uint) uint {
 	if pageSize == 0 {
 		return maxPageSize
@@ -0,0 +1,427 @@
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// fs:// buckets: external POSIX directory tree (e.g., NFS mount) that must be accessible
+// by all targets under the same path (bucket property `extra.fs.ref_directory`).
+// There's no content checksum and no versioning: both object version and ETag derive
+// from the file's mtime and size - enough for `versioning.validate_warm_get` to detect
+// out-of-band changes.
+
+type (
+	fsProvider struct {
+		t cluster.TargetPut
+	}
+	// (see ListObjects)
+	fsLister struct {
+		msg     *apc.LsoMsg
+		lst     *cmn.LsoResult
+		idx     int
+		wantCus bool
+	}
+	fsDirEntry struct {
+		de  os.DirEntry
+		key string // object name, with trailing '/' for directories
+	}
+)
+
+// interface guard
+var _ cluster.BackendProvider = (*fsProvider)(nil)
+
+var errFsPageDone = errors.New("page done")
+
+func NewFS(t cluster.TargetPut) cluster.BackendProvider { return &fsProvider{t: t} }
+
+func (*fsProvider) Provider() string  { return apc.FS }
+func (*fsProvider) MaxPageSize() uint { return 10000 }
+
+func fsErrorToAISError(err error) (int, error) {
+	switch {
+	case os.IsNotExist(err):
+		return http.StatusNotFound, err
+	case os.IsExist(err):
+		return http.StatusConflict, err
+	case os.IsPermission(err):
+		return http.StatusForbidden, err
+	default:
+		return http.StatusInternalServerError, err
+	}
+}
+
+// version and ETag
+func fsVersion(fi os.FileInfo) string { return strconv.FormatInt(fi.ModTime().UnixNano(), 10) }
+
+func fsETag(fi os.FileInfo) string {
+	return strconv.FormatInt(fi.ModTime().UnixNano(), 16) + "-" + strconv.FormatInt(fi.Size(), 16)
+}
+
+// resolve object name to the file path while making sure that the latter
+// stays within the reference directory - both lexically and upon resolving
+// symbolic links (that may point anywhere outside the directory)
+func fsPath(bck *cluster.Bck, objName string) (string, error) {
+	debug.Assert(bck.Props != nil)
+	var (
+		refDirectory = bck.Props.Extra.FS.RefDirectory
+		path         = filepath.Join(refDirectory, objName)
+	)
+	if !strings.HasPrefix(path, filepath.Clean(refDirectory)+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid object name %q (bucket %s, directory %q)", objName, bck, refDirectory)
+	}
+	realDir, err := filepath.EvalSymlinks(refDirectory)
+	if err != nil {
+		return "", err
+	}
+	realPath, err := fsEvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(realPath, realDir+string(filepath.Separator)) {
+		return "", fmt.Errorf("object name %q resolves outside bucket %s directory %q", objName, bck, refDirectory)
+	}
+	return path, nil
+}
+
+// same as filepath.EvalSymlinks but also works for paths that do not exist yet (e.g., PUT):
+// resolves the longest existing prefix, with dangling symlinks not permitted
+func fsEvalSymlinks(path string) (string, error) {
+	var rest string
+	for {
+		resolved, err := filepath.EvalSymlinks(path)
+		if err == nil {
+			return filepath.Join(resolved, rest), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		if fi, errL := os.Lstat(path); errL == nil && fi.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("%q is a dangling symlink", path)
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return "", err
+		}
+		rest = filepath.Join(filepath.Base(path), rest)
+		path = parent
+	}
+}
+
+func fsSetCustom(fi os.FileInfo, set func(key, value string)) {
+	set(cmn.SourceObjMD, apc.FS)
+	set(cmn.VersionObjMD, fsVersion(fi))
+	set(cmn.ETag, fsETag(fi))
+	set(cmn.LastModified, fmtTime(fi.ModTime()))
+}
+
+///////////////////
+// CREATE BUCKET //
+///////////////////
+
+func (fsp *fsProvider) CreateBucket(bck *cluster.Bck) (errCode int, err error) {
+	return fsp.checkDirectoryExists(bck)
+}
+
+func (*fsProvider) checkDirectoryExists(bck *cluster.Bck) (errCode int, err error) {
+	debug.Assert(bck.Props != nil)
+	refDirectory := bck.Props.Extra.FS.RefDirectory
+	debug.Assert(refDirectory != "")
+
+	fi, err := os.Stat(refDirectory)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	if !fi.IsDir() {
+		return http.StatusBadRequest, fmt.Errorf("specified path %q does not point to directory", refDirectory)
+	}
+	return 0, nil
+}
+
+/////////////////
+// HEAD BUCKET //
+/////////////////
+
+func (fsp *fsProvider) HeadBucket(_ ctx, bck *cluster.Bck) (bckProps cos.StrKVs, errCode int, err error) {
+	if errCode, err = fsp.checkDirectoryExists(bck); err != nil {
+		return
+	}
+	bckProps = make(cos.StrKVs)
+	bckProps[apc.HdrBackendProvider] = apc.FS
+	bckProps[apc.HdrBucketVerEnabled] = "true"
+	return
+}
+
+//////////////////
+// LIST OBJECTS //
+//////////////////
+
+// Walks the directory tree in the lexicographical order of the resulting object names
+// (which is not the same as `filepath.WalkDir` order - e.g., "a.txt" < "a/b");
+// skips subdirectories that cannot contain names matching the prefix or greater
+// than the continuation token.
+func (fsp *fsProvider) ListObjects(bck *cluster.Bck, msg *apc.LsoMsg, lst *cmn.LsoResult) (int, error) {
+	msg.PageSize = calcPageSize(msg.PageSize, fsp.MaxPageSize())
+	l := &fsLister{msg: msg, lst: lst, wantCus: msg.WantProp(apc.GetPropsCustom)}
+	err := l.walk(bck.Props.Extra.FS.RefDirectory, "")
+	if err != nil && err != errFsPageDone {
+		return fsErrorToAISError(err)
+	}
+	lst.Entries = lst.Entries[:l.idx]
+	// set continuation token only if we reached the page size
+	lst.ContinuationToken = ""
+	if uint(l.idx) >= msg.PageSize {
+		lst.ContinuationToken = lst.Entries[l.idx-1].Name
+	}
+	if verbose {
+		glog.Infof("[list_objects] count %d", len(lst.Entries))
+	}
+	return 0, nil
+}
+
+func (l *fsLister) walk(dir, prefix string) error {
+	des, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	entries := make([]fsDirEntry, 0, len(des))
+	for _, de := range des {
+		key := prefix + de.Name()
+		if de.IsDir() {
+			key += "/"
+		}
+		entries = append(entries, fsDirEntry{de: de, key: key})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	marker := l.msg.ContinuationToken
+	if l.msg.StartAfter > marker {
+		marker = l.msg.StartAfter
+	}
+	for _, e := range entries {
+		if e.de.IsDir() {
+			if !cmn.DirHasOrIsPrefix(e.key, l.msg.Prefix) {
+				continue
+			}
+			if marker != "" && e.key < marker && !strings.HasPrefix(marker, e.key) {
+				continue // all names in the subtree are smaller
+			}
+			if err := l.walk(filepath.Join(dir, e.de.Name()), e.key); err != nil {
+				return err
+			}
+			continue
+		}
+		if !cmn.ObjHasPrefix(e.key, l.msg.Prefix) || (marker != "" && e.key <= marker) {
+			continue
+		}
+		fi, err := e.de.Info()
+		if err != nil || !fi.Mode().IsRegular() {
+			continue // (removed in the meantime, symlink, etc.)
+		}
+		if uint(l.idx) >= l.msg.PageSize {
+			return errFsPageDone
+		}
+		l.add(e.key, fi)
+	}
+	return nil
+}
+
+func (l *fsLister) add(objName string, fi os.FileInfo) {
+	var entry *cmn.LsoEntry
+	if l.idx < len(l.lst.Entries) {
+		entry = l.lst.Entries[l.idx]
+		*entry = cmn.LsoEntry{}
+	} else {
+		entry = &cmn.LsoEntry{}
+		l.lst.Entries = append(l.lst.Entries, entry)
+	}
+	l.idx++
+	entry.Name, entry.Size = objName, fi.Size()
+	entry.Version = fsVersion(fi)
+	if l.wantCus {
+		custom := cos.StrKVs{cmn.ETag: fsETag(fi), cmn.LastModified: fmtTime(fi.ModTime())}
+		entry.Custom = cmn.CustomMD2S(custom)
+	}
+}
+
+//////////////////
+// LIST BUCKETS //
+//////////////////
+
+func (*fsProvider) ListBuckets(cmn.QueryBcks) (buckets cmn.Bcks, errCode int, err error) {
+	debug.Assert(false)
+	return
+}
+
+/////////////////
+// HEAD OBJECT //
+/////////////////
+
+func (fsp *fsProvider) HeadObj(_ ctx, lom *cluster.LOM) (oa *cmn.ObjAttrs, errCode int, err error) {
+	path, err := fsPath(lom.Bck(), lom.ObjName)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		errCode, err = fsErrorToAISError(err)
+		return
+	}
+	if fi.IsDir() {
+		return nil, http.StatusNotFound, cmn.NewErrNotFound("%s: object %s (is a directory)", fsp.t, lom)
+	}
+	oa = &cmn.ObjAttrs{}
+	oa.Size = fi.Size()
+	oa.Ver = fsVersion(fi)
+	fsSetCustom(fi, oa.SetCustomKey)
+	if verbose {
+		glog.Infof("[head_object] %s", lom)
+	}
+	return
+}
+
+////////////////
+// GET OBJECT //
+////////////////
+
+func (fsp *fsProvider) GetObj(ctx context.Context, lom *cluster.LOM, owt cmn.OWT) (errCode int, err error) {
+	reader, _, errCode, err := fsp.GetObjReader(ctx, lom)
+	if err != nil {
+		return errCode, err
+	}
+	params := cluster.AllocPutObjParams()
+	{
+		params.WorkTag = fs.WorkfileColdget
+		params.Reader = reader
+		params.OWT = owt
+		params.Atime = time.Now()
+	}
+	err = fsp.t.PutObject(lom, params)
+	cluster.FreePutObjParams(params)
+	if err != nil {
+		return
+	}
+	if verbose {
+		glog.Infof("[get_object] %s", lom)
+	}
+	return
+}
+
+////////////////////
+// GET OBJ READER //
+////////////////////
+
+func (fsp *fsProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (r io.ReadCloser,
+	expectedCksm *cos.Cksum, errCode int, err error) {
+	path, err := fsPath(lom.Bck(), lom.ObjName)
+	if err != nil {
+		return nil, nil, http.StatusBadRequest, err
+	}
+	fh, err := os.Open(path)
+	if err != nil {
+		errCode, err = fsErrorToAISError(err)
+		return
+	}
+	fi, err := fh.Stat()
+	if err == nil && fi.IsDir() {
+		err = cmn.NewErrNotFound("%s: object %s (is a directory)", fsp.t, lom)
+	}
+	if err != nil {
+		cos.Close(fh)
+		errCode, err = fsErrorToAISError(err)
+		return
+	}
+	lom.SetVersion(fsVersion(fi))
+	fsSetCustom(fi, lom.SetCustomKey)
+	setSize(ctx, fi.Size())
+	return wrapReader(ctx, fh), nil, 0, nil
+}
+
+////////////////
+// PUT OBJECT //
+////////////////
+
+// write-through: write temp file in the destination directory and rename
+func (fsp *fsProvider) PutObj(r io.ReadCloser, lom *cluster.LOM) (errCode int, err error) {
+	var (
+		fh   *os.File
+		fi   os.FileInfo
+		path string
+	)
+	defer cos.Close(r)
+	if path, err = fsPath(lom.Bck(), lom.ObjName); err != nil {
+		return http.StatusBadRequest, err
+	}
+	dir := filepath.Dir(path)
+	if err = cos.CreateDir(dir); err != nil {
+		return fsErrorToAISError(err)
+	}
+	if fh, err = os.CreateTemp(dir, "."+filepath.Base(path)+".*"); err != nil {
+		return fsErrorToAISError(err)
+	}
+	tmp := fh.Name()
+	if _, err = io.Copy(fh, r); err != nil {
+		cos.Close(fh)
+		goto rm
+	}
+	if err = fh.Close(); err != nil {
+		goto rm
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		goto rm
+	}
+	if fi, err = os.Stat(path); err != nil {
+		return fsErrorToAISError(err)
+	}
+	// compare with GetObjReader
+	lom.SetVersion(fsVersion(fi))
+	fsSetCustom(fi, lom.SetCustomKey)
+	if verbose {
+		glog.Infof("[put_object] %s", lom)
+	}
+	return 0, nil
+rm:
+	if errRm := os.Remove(tmp); errRm != nil && !os.IsNotExist(errRm) {
+		glog.Errorf("failed to remove %q: %v", tmp, errRm)
+	}
+	return fsErrorToAISError(err)
+}
+
+///////////////////
+// DELETE OBJECT //
+///////////////////
+
+func (*fsProvider) DeleteObj(lom *cluster.LOM) (errCode int, err error) {
+	path, err := fsPath(lom.Bck(), lom.ObjName)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	if err := os.Remove(path); err != nil {
+		return fsErrorToAISError(err)
+	}
+	if verbose {
+		glog.Infof("[delete_object] %s", lom)
+	}
+	return 0, nil
+}
@@ -0,0 +1,142 @@
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	cmock "github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestFSBackend(t *testing.T) {
+	var (
+		tmpDir  = t.TempDir()
+		mpath   = filepath.Join(tmpDir, "mpath")
+		refDir  = filepath.Join(tmpDir, "ref")
+		outside = filepath.Join(tmpDir, "outside")
+		bck     = cluster.NewBck("fsbck", apc.FS, cmn.NsGlobal, &cmn.BucketProps{
+			Extra: cmn.ExtraProps{FS: cmn.ExtraPropsFS{RefDirectory: refDir}},
+			BID:   1,
+		})
+		fsp = NewFS(cmock.NewTarget(cmock.NewBaseBownerMock(bck)))
+		ctx = context.Background()
+	)
+	for _, dir := range []string{mpath, refDir, outside} {
+		tassert.CheckFatal(t, cos.CreateDir(dir))
+	}
+	fs.TestNew(nil)
+	fs.TestDisableValidation()
+	_, err := fs.Add(mpath, "daeID")
+	tassert.CheckFatal(t, err)
+	defer fs.Remove(mpath)
+
+	newLOM := func(objName string) *cluster.LOM {
+		lom := &cluster.LOM{ObjName: objName}
+		tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+		return lom
+	}
+	put := func(objName, content string) (int, error) {
+		return fsp.PutObj(io.NopCloser(strings.NewReader(content)), newLOM(objName))
+	}
+	get := func(objName string) (string, int, error) {
+		r, _, errCode, err := fsp.GetObjReader(ctx, newLOM(objName))
+		if err != nil {
+			return "", errCode, err
+		}
+		defer r.Close()
+		b, err := io.ReadAll(r)
+		return string(b), 0, err
+	}
+	list := func(prefix string) []string {
+		lst := &cmn.LsoResult{}
+		_, err := fsp.ListObjects(bck, &apc.LsoMsg{Prefix: prefix}, lst)
+		tassert.CheckFatal(t, err)
+		names := make([]string, 0, len(lst.Entries))
+		for _, en := range lst.Entries {
+			names = append(names, en.Name)
+		}
+		return names
+	}
+
+	// put, head, get, list
+	for objName, content := range map[string]string{"a.txt": "aaa", "a/b.txt": "bb", "a/c/d.txt": "d"} {
+		_, err := put(objName, content)
+		tassert.CheckFatal(t, err)
+	}
+	oa, _, err := fsp.HeadObj(ctx, newLOM("a/b.txt"))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, oa.Size == 2 && oa.Ver != "", "unexpected attributes %+v", oa)
+	_, errCode, err := fsp.HeadObj(ctx, newLOM("a/c"))
+	tassert.Errorf(t, errCode == http.StatusNotFound, "directory: expected 404, got %d (%v)", errCode, err)
+
+	content, _, err := get("a/c/d.txt")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, content == "d", "expected %q, got %q", "d", content)
+	_, err = fsp.GetObj(ctx, newLOM("a.txt"), cmn.OwtGetPrefetchLock)
+	tassert.CheckFatal(t, err)
+
+	names := list("")
+	tassert.Errorf(t, reflect.DeepEqual(names, []string{"a.txt", "a/b.txt", "a/c/d.txt"}), "unexpected list %v", names)
+	names = list("a/c")
+	tassert.Errorf(t, reflect.DeepEqual(names, []string{"a/c/d.txt"}), "unexpected list %v", names)
+
+	// delete
+	_, err = fsp.DeleteObj(newLOM("a/b.txt"))
+	tassert.CheckFatal(t, err)
+	_, errCode, err = get("a/b.txt")
+	tassert.Errorf(t, errCode == http.StatusNotFound, "deleted: expected 404, got %d (%v)", errCode, err)
+	errCode, err = fsp.DeleteObj(newLOM("a/b.txt"))
+	tassert.Errorf(t, errCode == http.StatusNotFound, "deleted twice: expected 404, got %d (%v)", errCode, err)
+
+	// lexical escape
+	_, errCode, err = get("../outside/secret")
+	tassert.Errorf(t, errCode == http.StatusBadRequest, "'..': expected 400, got %d (%v)", errCode, err)
+
+	// symlinks that lead outside the reference directory: neither listed nor accessible
+	tassert.CheckFatal(t, os.WriteFile(filepath.Join(outside, "secret"), []byte("secret"), cos.PermRWR))
+	tassert.CheckFatal(t, os.Symlink(filepath.Join(outside, "secret"), filepath.Join(refDir, "file-link")))
+	tassert.CheckFatal(t, os.Symlink(outside, filepath.Join(refDir, "dir-link")))
+	tassert.CheckFatal(t, os.Symlink(filepath.Join(outside, "nonexistent"), filepath.Join(refDir, "dangling")))
+
+	names = list("")
+	tassert.Errorf(t, reflect.DeepEqual(names, []string{"a.txt", "a/c/d.txt"}), "unexpected list %v", names)
+
+	for _, objName := range []string{"file-link", "dir-link/secret"} {
+		_, errCode, err = fsp.HeadObj(ctx, newLOM(objName))
+		tassert.Errorf(t, errCode == http.StatusBadRequest, "head %q: expected 400, got %d (%v)", objName, errCode, err)
+		_, errCode, err = get(objName)
+		tassert.Errorf(t, errCode == http.StatusBadRequest, "get %q: expected 400, got %d (%v)", objName, errCode, err)
+		errCode, err = fsp.DeleteObj(newLOM(objName))
+		tassert.Errorf(t, errCode == http.StatusBadRequest, "delete %q: expected 400, got %d (%v)", objName, errCode, err)
+	}
+	for _, objName := range []string{"file-link", "dir-link/new", "dangling", "dangling/new"} {
+		errCode, err = put(objName, "overwrite")
+		tassert.Errorf(t, errCode == http.StatusBadRequest, "put %q: expected 400, got %d (%v)", objName, errCode, err)
+	}
+	b, err := os.ReadFile(filepath.Join(outside, "secret"))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, string(b) == "secret", "file outside the reference directory was modified: %q", b)
+	_, err = os.Stat(filepath.Join(outside, "new"))
+	tassert.Errorf(t, os.IsNotExist(err), "file created outside the reference directory (%v)", err)
+
+	// symlinks within the reference directory are fine
+	tassert.CheckFatal(t, os.Symlink(filepath.Join(refDir, "a"), filepath.Join(refDir, "a-link")))
+	content, _, err = get("a-link/c/d.txt")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, content == "d", "expected %q, got %q", "d", content)
+}
@@ -13,6 +13,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -58,6 +59,7 @@ var (
 
 	// interface guard
 	_ cluster.BackendProvider = (*gcpProvider)(nil)
+	_ cluster.RangeReader     = (*gcpProvider)(nil)
 )
 
 func NewGCP(t cluster.TargetPut) (bp cluster.BackendProvider, err error) {
@@ -348,6 +350,24 @@ func (*gcpProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (r io.Re
 	return
 }
 
+// (compare with GetObjReader above)
+func (*gcpProvider) GetObjRange(ctx context.Context, lom *cluster.LOM, oa *cmn.ObjAttrs, offset, length int64) (
+	r io.ReadCloser, errCode int, err error) {
+	var (
+		cloudBck = lom.Bck().RemoteBck()
+		o        = gcpClient.Bucket(cloudBck.Name).Object(lom.ObjName)
+	)
+	if v, ok := oa.GetCustomKey(cmn.VersionObjMD); ok {
+		if gen, errP := strconv.ParseInt(v, 10, 64); errP == nil {
+			o = o.Generation(gen)
+		}
+	}
+	if r, err = o.NewRangeReader(ctx, offset, length); err != nil {
+		errCode, err = gcpErrorToAISError(err, cloudBck)
+	}
+	return
+}
+
 func setCustomGs(lom *cluster.LOM, attrs *storage.ObjectAttrs) (expCksum *cos.Cksum) {
 	h := cmn.BackendHelpers.Google
 	if v, ok := h.EncodeVersion(attrs.Generation); ok {
@@ -10,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -26,6 +27,8 @@ type (
 		t           cluster.TargetPut
 		httpClient  *http.Client
 		httpsClient *http.Client
+		lists       map[string]*httpList // bucket uname => cached list of objects (see httplist.go)
+		mu          sync.Mutex
 	}
 )
 
@@ -33,7 +36,7 @@ type (
 var _ cluster.BackendProvider = (*httpProvider)(nil)
 
 func NewHTTP(t cluster.TargetPut, config *cmn.Config) cluster.BackendProvider {
-	hp := &httpProvider{t: t}
+	hp := &httpProvider{t: t, lists: make(map[string]*httpList, 4)}
 	hp.httpClient = cmn.NewClient(cmn.TransportArgs{
 		Timeout:         config.Client.TimeoutLong.D(),
 		WriteBufferSize: config.Net.HTTP.WriteBufferSize,
@@ -100,11 +103,6 @@ func (hp *httpProvider) HeadBucket(ctx context.Context, bck *cluster.Bck) (bckPr
 	return
 }
 
-func (*httpProvider) ListObjects(*cluster.Bck, *apc.LsoMsg, *cmn.LsoResult) (errCode int, err error) {
-	debug.Assert(false)
-	return
-}
-
 func (*httpProvider) ListBuckets(cmn.QueryBcks) (bcks cmn.Bcks, errCode int, err error) {
 	debug.Assert(false)
 	return
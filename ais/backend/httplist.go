@@ -0,0 +1,317 @@
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+	"golang.org/x/net/html"
+)
+
+// Listing ht:// buckets
+// HTTP has no notion of listing; the list of objects is obtained either from:
+// 1. manifest (`extra.http.manifest` bucket property) - URL of a plain-text list of object
+//    names (one per line; empty lines and lines starting with '#' are skipped) or a JSON array
+//    where each element is either a name or an object {"name": ..., "size": ...}; or else
+// 2. HTML index pages at the bucket's original URL, as generated by Apache (mod_autoindex),
+//    nginx (autoindex), `python -m http.server`, and the like; subdirectories are crawled
+//    recursively.
+// Either way, names may be relative to the bucket's original URL or absolute URLs under it.
+// The (sorted) list is cached for the duration of a paginated listing.
+
+const (
+	httpListTTL      = time.Minute
+	httpListMaxDepth = 32
+)
+
+type (
+	httpListEntry struct {
+		name string
+		size int64
+	}
+	httpList struct {
+		added   time.Time
+		entries []httpListEntry // sorted by name
+		bid     uint64
+	}
+	httpManifestEntry struct {
+		Name string `json:"name"`
+		Size int64  `json:"size"`
+	}
+)
+
+func (hp *httpProvider) ListObjects(bck *cluster.Bck, msg *apc.LsoMsg, lst *cmn.LsoResult) (errCode int, err error) {
+	var (
+		entries []httpListEntry
+		marker  = msg.ContinuationToken
+	)
+	msg.PageSize = calcPageSize(msg.PageSize, hp.MaxPageSize())
+	if entries, errCode, err = hp.list(bck, msg.ContinuationToken == ""); err != nil {
+		return
+	}
+	if msg.StartAfter > marker {
+		marker = msg.StartAfter
+	}
+	i := sort.Search(len(entries), func(i int) bool {
+		return entries[i].name > marker && entries[i].name >= msg.Prefix
+	})
+	lst.Entries = lst.Entries[:0]
+	lst.ContinuationToken = ""
+	for ; i < len(entries) && cmn.ObjHasPrefix(entries[i].name, msg.Prefix); i++ {
+		if uint(len(lst.Entries)) >= msg.PageSize {
+			lst.ContinuationToken = lst.Entries[len(lst.Entries)-1].Name
+			break
+		}
+		lst.Entries = append(lst.Entries, &cmn.LsoEntry{Name: entries[i].name, Size: entries[i].size})
+	}
+	if verbose {
+		glog.Infof("[list_objects] %s: count %d", bck, len(lst.Entries))
+	}
+	return
+}
+
+// returns cached list unless asked to refresh (which is what the first page does)
+func (hp *httpProvider) list(bck *cluster.Bck, refresh bool) ([]httpListEntry, int, error) {
+	uname := bck.MakeUname("")
+	if !refresh {
+		hp.mu.Lock()
+		l, ok := hp.lists[uname]
+		hp.mu.Unlock()
+		if ok && l.bid == bck.Props.BID && time.Since(l.added) < httpListTTL {
+			return l.entries, 0, nil
+		}
+	}
+	var (
+		names    = make(map[string]int64, 64)
+		origURL  = bck.Props.Extra.HTTP.OrigURLBck
+		manifest = bck.Props.Extra.HTTP.Manifest
+		errCode  int
+		err      error
+	)
+	if !strings.HasSuffix(origURL, "/") {
+		origURL += "/"
+	}
+	if manifest != "" {
+		errCode, err = hp.readManifest(manifest, origURL, names)
+	} else {
+		errCode, err = hp.crawl(origURL, "", 0, names)
+	}
+	if err != nil {
+		return nil, errCode, err
+	}
+	entries := make([]httpListEntry, 0, len(names))
+	for name, size := range names {
+		entries = append(entries, httpListEntry{name: name, size: size})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+
+	now := time.Now()
+	hp.mu.Lock()
+	for uname, l := range hp.lists {
+		if now.Sub(l.added) > httpListTTL {
+			delete(hp.lists, uname)
+		}
+	}
+	hp.lists[uname] = &httpList{added: now, entries: entries, bid: bck.Props.BID}
+	hp.mu.Unlock()
+	return entries, 0, nil
+}
+
+func (hp *httpProvider) fetch(u string) (*http.Response, int, error) {
+	resp, err := hp.client(u).Get(u) //nolint:bodyclose // is closed by the caller
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, resp.StatusCode, fmt.Errorf("GET(%s) failed, status %d", u, resp.StatusCode)
+	}
+	return resp, 0, nil
+}
+
+//////////////
+// manifest //
+//////////////
+
+func (hp *httpProvider) readManifest(manifest, origURL string, names map[string]int64) (int, error) {
+	resp, errCode, err := hp.fetch(manifest)
+	if err != nil {
+		return errCode, err
+	}
+	defer resp.Body.Close()
+
+	br := bufio.NewReader(resp.Body)
+	if b, err := br.Peek(1); err == nil && b[0] == '[' {
+		return 0, parseJSONManifest(br, manifest, origURL, names)
+	}
+	scanner := bufio.NewScanner(br)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		name, err := httpObjName(line, origURL)
+		if err != nil {
+			return http.StatusBadRequest, fmt.Errorf("manifest %q: %v", manifest, err)
+		}
+		names[name] = 0
+	}
+	if err := scanner.Err(); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to read manifest %q: %v", manifest, err)
+	}
+	return 0, nil
+}
+
+func parseJSONManifest(r io.Reader, manifest, origURL string, names map[string]int64) error {
+	var list []jsoniter.RawMessage
+	if err := jsoniter.NewDecoder(r).Decode(&list); err != nil {
+		return fmt.Errorf("failed to parse manifest %q: %v", manifest, err)
+	}
+	for _, raw := range list {
+		var entry httpManifestEntry
+		if err := jsoniter.Unmarshal(raw, &entry.Name); err != nil {
+			if err := jsoniter.Unmarshal(raw, &entry); err != nil {
+				return fmt.Errorf("manifest %q: invalid entry %s (expecting name or {\"name\": ..., \"size\": ...})",
+					manifest, raw)
+			}
+		}
+		name, err := httpObjName(entry.Name, origURL)
+		if err != nil {
+			return fmt.Errorf("manifest %q: %v", manifest, err)
+		}
+		names[name] = entry.Size
+	}
+	return nil
+}
+
+// object name relative to the bucket's URL
+func httpObjName(s, origURL string) (string, error) {
+	name := s
+	if strings.Contains(s, apc.BckProviderSeparator) {
+		if !strings.HasPrefix(s, origURL) {
+			return "", fmt.Errorf("%q is not located under the bucket's URL %q", s, origURL)
+		}
+		name = strings.TrimPrefix(s, origURL)
+	}
+	name = strings.TrimPrefix(name, "./")
+	if name == "" || strings.HasSuffix(name, "/") {
+		return "", fmt.Errorf("invalid object name %q", s)
+	}
+	return name, nil
+}
+
+////////////////
+// index page //
+////////////////
+
+func (hp *httpProvider) crawl(dirURL, prefix string, depth int, names map[string]int64) (int, error) {
+	base, err := url.Parse(dirURL)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	resp, errCode, err := hp.fetch(dirURL)
+	if err != nil {
+		return errCode, err
+	}
+	if ct := resp.Header.Get(cos.HdrContentType); !strings.HasPrefix(ct, "text/html") {
+		resp.Body.Close()
+		return http.StatusNotImplemented, fmt.Errorf("cannot list %q: expecting HTML index page, got %q"+
+			" (consider setting bucket property 'extra.http.manifest')", dirURL, ct)
+	}
+	children, subdirs := parseIndexPage(resp.Body, base)
+	resp.Body.Close()
+
+	for _, child := range children {
+		names[prefix+child] = 0
+	}
+	if depth >= httpListMaxDepth {
+		if len(subdirs) > 0 {
+			glog.Warningf("%q: exceeded max depth %d, skipping %d subdirectories", dirURL, depth, len(subdirs))
+		}
+		return 0, nil
+	}
+	for _, sub := range subdirs {
+		if errCode, err := hp.crawl(base.ResolveReference(&url.URL{Path: sub}).String(), prefix+sub, depth+1, names); err != nil {
+			return errCode, err
+		}
+	}
+	return 0, nil
+}
+
+// returns the names of the files and subdirectories (the latter with trailing '/')
+// that are linked from the page and are located directly under it - thus skipping
+// parent directory, column-sorting links (e.g., "?C=M;O=A"), external links, etc.
+func parseIndexPage(r io.Reader, base *url.URL) (files, subdirs []string) {
+	var (
+		z    = html.NewTokenizer(r)
+		seen = make(cos.StrSet, 16)
+	)
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return // (io.EOF or malformed HTML - either way, done)
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+		tag, hasAttr := z.TagName()
+		if string(tag) != "a" || !hasAttr {
+			continue
+		}
+		for {
+			key, val, more := z.TagAttr()
+			if string(key) == "href" {
+				if rel, ok := indexLink(string(val), base); ok && !seen.Contains(rel) {
+					seen.Add(rel)
+					if strings.HasSuffix(rel, "/") {
+						subdirs = append(subdirs, rel)
+					} else {
+						files = append(files, rel)
+					}
+				}
+				break
+			}
+			if !more {
+				break
+			}
+		}
+	}
+}
+
+func indexLink(href string, base *url.URL) (string, bool) {
+	if href == "" || href[0] == '?' || href[0] == '#' {
+		return "", false
+	}
+	ref, err := url.Parse(href)
+	if err != nil || ref.RawQuery != "" {
+		return "", false
+	}
+	u := base.ResolveReference(ref)
+	if u.Scheme != base.Scheme || u.Host != base.Host || !strings.HasPrefix(u.Path, base.Path) {
+		return "", false
+	}
+	rel := strings.TrimPrefix(u.Path, base.Path)
+	if rel == "" || rel == "/" {
+		return "", false
+	}
+	// direct children only (a file or a subdirectory with trailing '/')
+	if i := strings.IndexByte(rel, '/'); i >= 0 && i != len(rel)-1 {
+		return "", false
+	}
+	return rel, true
+}
@@ -0,0 +1,119 @@
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+var httpListPages = map[string]string{
+	// Apache (mod_autoindex)
+	"/data/": `<html><body><h1>Index of /data</h1><table>
+<tr><th><a href="?C=N;O=D">Name</a></th><th><a href="?C=M;O=A">Last modified</a></th></tr>
+<tr><td><a href="/">Parent Directory</a></td></tr>
+<tr><td><a href="b%20c.tar">b c.tar</a></td></tr>
+<tr><td><a href="a.tar">a.tar</a></td></tr>
+<tr><td><a href="sub/">sub/</a></td></tr>
+<tr><td><a href="http://example.com/x.tar">elsewhere</a></td></tr>
+</table></body></html>`,
+	// nginx (autoindex)
+	"/data/sub/": `<html><head><title>Index of /data/sub/</title></head><body><pre>
+<a href="../">../</a>
+<a href="d.tar">d.tar</a>                  01-Jan-2023 00:00     1024
+<a href="d.tar">d.tar</a>
+</pre></body></html>`,
+}
+
+func newHTTPListServer() *httptest.Server {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/data/manifest.txt":
+			w.Write([]byte("# comment\nx.tar\n\n./y/z.tar\n" + srv.URL + "/data/w.tar\n"))
+		case "/data/manifest.json":
+			w.Header().Set(cos.HdrContentType, cos.ContentJSON)
+			w.Write([]byte(`["x.tar", {"name": "` + srv.URL + `/data/y.tar", "size": 10}]`))
+		case "/plain/":
+			w.Header().Set(cos.HdrContentType, "text/plain")
+			w.Write([]byte("not an index"))
+		default:
+			page, ok := httpListPages[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set(cos.HdrContentType, "text/html; charset=utf-8")
+			w.Write([]byte(page))
+		}
+	}))
+	return srv
+}
+
+func httpListNames(t *testing.T, hp cluster.BackendProvider, bck *cluster.Bck, msg *apc.LsoMsg) []string {
+	lst := &cmn.LsoResult{}
+	_, err := hp.ListObjects(bck, msg, lst)
+	tassert.CheckFatal(t, err)
+	names := make([]string, 0, len(lst.Entries))
+	for _, en := range lst.Entries {
+		names = append(names, en.Name)
+	}
+	msg.ContinuationToken = lst.ContinuationToken
+	return names
+}
+
+func TestHTTPListObjects(t *testing.T) {
+	srv := newHTTPListServer()
+	defer srv.Close()
+
+	var (
+		hp     = NewHTTP(nil, cmn.GCO.Get())
+		newBck = func(origURL, manifest string) *cluster.Bck {
+			return cluster.NewBck(cmn.OrigURLBck2Name(origURL), apc.HTTP, cmn.NsGlobal, &cmn.BucketProps{
+				Extra: cmn.ExtraProps{HTTP: cmn.ExtraPropsHTTP{OrigURLBck: origURL, Manifest: manifest}},
+				BID:   1,
+			})
+		}
+		check = func(what string, names, expected []string) {
+			tassert.Errorf(t, reflect.DeepEqual(names, expected), "%s: expected %v, got %v", what, expected, names)
+		}
+	)
+
+	// index pages
+	bck := newBck(srv.URL+"/data/", "")
+	check("index", httpListNames(t, hp, bck, &apc.LsoMsg{}), []string{"a.tar", "b c.tar", "sub/d.tar"})
+	check("prefix", httpListNames(t, hp, bck, &apc.LsoMsg{Prefix: "sub/"}), []string{"sub/d.tar"})
+
+	msg := &apc.LsoMsg{PageSize: 2}
+	check("page 1", httpListNames(t, hp, bck, msg), []string{"a.tar", "b c.tar"})
+	tassert.Fatalf(t, msg.ContinuationToken == "b c.tar", "unexpected continuation token %q", msg.ContinuationToken)
+	check("page 2", httpListNames(t, hp, bck, msg), []string{"sub/d.tar"})
+	tassert.Errorf(t, msg.ContinuationToken == "", "expected last page, got token %q", msg.ContinuationToken)
+
+	// manifests
+	bck = newBck(srv.URL+"/data/", srv.URL+"/data/manifest.txt")
+	check("text manifest", httpListNames(t, hp, bck, &apc.LsoMsg{}), []string{"w.tar", "x.tar", "y/z.tar"})
+
+	bck = newBck(srv.URL+"/data/", srv.URL+"/data/manifest.json")
+	lst := &cmn.LsoResult{}
+	_, err := hp.ListObjects(bck, &apc.LsoMsg{}, lst)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(lst.Entries) == 2, "json manifest: expected 2 entries, got %d", len(lst.Entries))
+	tassert.Errorf(t, lst.Entries[1].Name == "y.tar" && lst.Entries[1].Size == 10,
+		"json manifest: unexpected entry %+v", lst.Entries[1])
+
+	// neither
+	bck = newBck(srv.URL+"/plain/", "")
+	_, err = hp.ListObjects(bck, &apc.LsoMsg{}, &cmn.LsoResult{})
+	tassert.Errorf(t, err != nil, "expected error listing non-index page")
+}
@@ -28,6 +28,7 @@ type dpq struct {
 	fltPresence         string // QparamFltPresence
 	dontAddRemote       string // QparamDontAddRemote
 	etlName             string // QparamETLName
+	version             string // QparamVersion
 }
 
 var (
@@ -108,6 +109,12 @@ func (dpq *dpq) fromRawQ(rawQuery string) (err error) {
 			dpq.dontAddRemote = value
 		case apc.QparamETLName:
 			dpq.etlName = value
+		case apc.QparamVersion:
+			dpq.version = value
+		case s3.QparamVersionID:
+			if value != s3.NullVersionID {
+				dpq.version = value
+			}
 
 		case s3.QparamMptUploadID, s3.QparamMptUploads, s3.QparamMptPartNo:
 			// TODO: ignore for now
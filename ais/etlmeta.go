@@ -119,6 +119,22 @@ func (e *etlMD) del(id string) (exists bool) {
 	return
 }
 
+// returns the name of a pipeline that has the ETL as one of its stages, if any
+func (e *etlMD) usedBy(id string) string {
+	for name, msg := range e.ETLs {
+		pmsg, ok := msg.(*etl.InitPipelineMsg)
+		if !ok {
+			continue
+		}
+		for _, stage := range pmsg.Stages {
+			if stage == id {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
 //////////////////
 // etlMDOwnerBase //
 //////////////////
@@ -73,6 +73,7 @@ func (g *fsprungroup) _postAdd(action string, mi *fs.Mountpath) {
 	}()
 
 	g.checkEnable(action, mi.Path)
+	g.t.quotas.invalidate()
 
 	tstats := g.t.statsT.(*stats.Trunner)
 	for _, disk := range mi.Disks {
@@ -177,6 +178,7 @@ func (g *fsprungroup) postDD(rmi *fs.Mountpath, action string, xres *xs.Resilver
 		return
 	}
 	fspathsConfigAddDel(rmi.Path, false /*add*/)
+	g.t.quotas.invalidate()
 	glog.Infof("%s: %s %q %s done", g.t, rmi, action, xres)
 
 	// 3. the case of multiple overlapping detach _or_ disable operations
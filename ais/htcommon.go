@@ -25,6 +25,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/ext/dload"
 	"github.com/NVIDIA/aistore/ext/etl"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/xact/xreg"
@@ -421,6 +422,7 @@ type (
 	cresEM struct{} // -> etl.CPUMemUsed
 	cresIC struct{} // -> icBundle
 	cresBM struct{} // -> bucketMD
+	cresDS struct{} // -> []*dload.ScheduleSpec
 
 	cresLso   struct{} // -> cmn.LsoResult
 	cresBsumm struct{} // -> cmn.AllBsummResults
@@ -437,6 +439,7 @@ var (
 	_ cresv = cresEM{}
 	_ cresv = cresIC{}
 	_ cresv = cresBM{}
+	_ cresv = cresDS{}
 	_ cresv = cresBsumm{}
 )
 
@@ -481,6 +484,9 @@ func (c cresIC) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jr
 func (cresBM) newV() any                              { return &bucketMD{} }
 func (c cresBM) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
 
+func (cresDS) newV() any                              { return &[]*dload.ScheduleSpec{} }
+func (c cresDS) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
+
 func (cresBsumm) newV() any                              { return &cmn.AllBsummResults{} }
 func (c cresBsumm) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
 
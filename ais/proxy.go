@@ -35,6 +35,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/fname"
 	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/ext/dsort"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/stats"
@@ -85,6 +86,7 @@ type (
 			in  atomic.Bool
 		}
 		inPrimaryTransition atomic.Bool
+		dlsched             atomic.Bool // running scheduled downloads (see prxdl.go)
 	}
 )
 
@@ -184,6 +186,7 @@ func (p *proxy) Run() error {
 	p.notifs.init(p)
 	p.ic.init(p)
 	p.qm.init()
+	hk.Reg("download-schedules"+hk.NameSuffix, p.dlSchedulesHK, dlSchedulesInterval)
 
 	//
 	// REST API: register proxy handlers and start listening
@@ -762,6 +765,7 @@ func (p *proxy) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 3. action
+	// (object lock: targets make sure there are no retained objects - see `chkLockedBck`)
 	switch msg.Action {
 	case apc.ActEvictRemoteBck:
 		if !bck.IsRemote() {
@@ -769,8 +773,8 @@ func (p *proxy) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		keepMD := cos.IsParseBool(apireq.query.Get(apc.QparamKeepRemote))
-		// HDFS buckets will always keep metadata so they can re-register later
-		if bck.IsHDFS() || keepMD {
+		// HDFS and fs:// buckets will always keep metadata so they can re-register later
+		if bck.IsHDFS() || bck.IsFS() || keepMD {
 			if err := p.destroyBucketData(msg, bck); err != nil {
 				p.writeErr(w, r, err)
 			}
@@ -1221,6 +1225,17 @@ func (p *proxy) hpostBucket(w http.ResponseWriter, r *http.Request, msg *apc.Act
 			return
 		}
 		w.Write([]byte(xid))
+	case apc.ActSyncBck:
+		if !bck.IsRemote() {
+			p.writeErrf(w, r, fmtNotRemote, bucket)
+			return
+		}
+		var xid string
+		if xid, err = p.doListRange(r.Method, bucket, msg, query); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		w.Write([]byte(xid))
 	case apc.ActInvalListCache:
 		p.qm.c.invalidate(bck.Bucket())
 	case apc.ActMakeNCopies:
@@ -1266,9 +1281,15 @@ func (p *proxy) hpostCreateBucket(w http.ResponseWriter, r *http.Request, query
 			errors.New("property 'extra.hdfs.ref_directory' must be specified when creating HDFS bucket"))
 		return
 	}
+	if bck.IsFS() && msg.Value == nil {
+		p.writeErr(w, r,
+			errors.New("property 'extra.fs.ref_directory' must be specified when creating fs:// bucket"))
+		return
+	}
 	// remote: check existence and get (cloud) props
-	if bck.IsRemote() {
-		rhdr, statusCode, err := p.headRemoteBck(bck.RemoteBck(), nil)
+	// (fs:// reference directory gets checked by each target - see `backend.fsProvider`)
+	if bck.IsRemote() && !bck.IsFS() {
+		rhdr, statusCode, err := p.headRemoteBck(bck.RemoteBck(), s3EndpointQuery(bck, msg))
 		if err != nil {
 			if bck.IsCloud() {
 				statusCode = http.StatusNotImplemented
@@ -1325,6 +1346,24 @@ func (p *proxy) hpostCreateBucket(w http.ResponseWriter, r *http.Request, query
 	}
 }
 
+// s3:// bucket to be created with the named S3 endpoint (see cmn.BackendConfAWS)
+// must be looked up at that endpoint
+func s3EndpointQuery(bck *cluster.Bck, msg *apc.ActMsg) (q url.Values) {
+	if bck.Provider != apc.AWS || msg.Value == nil {
+		return
+	}
+	propsToUpdate := cmn.BucketPropsToUpdate{}
+	if err := cos.MorphMarshal(msg.Value, &propsToUpdate); err != nil {
+		return // (will fail later)
+	}
+	if propsToUpdate.Extra == nil || propsToUpdate.Extra.AWS == nil || propsToUpdate.Extra.AWS.Endpoint == nil {
+		return
+	}
+	q = url.Values{}
+	q.Set(apc.QparamS3Endpoint, *propsToUpdate.Extra.AWS.Endpoint)
+	return
+}
+
 func crerrStatus(err error) (errCode int) {
 	switch err.(type) {
 	case *cmn.ErrBucketAlreadyExists:
@@ -1353,10 +1392,23 @@ func (p *proxy) listObjects(w http.ResponseWriter, r *http.Request, bck *cluster
 	case lsmsg.WantOnlyName():
 		lsmsg.SetFlag(apc.LsNameOnly)
 	}
-	// ht:// backend doesn't have `ListObjects`; can only locally list archived content
-	if bck.IsHTTP() || lsmsg.IsFlagSet(apc.LsArchDir) {
+	// ht:// backend lists remote content via manifest or, when explicitly requested, index pages;
+	// otherwise, and also for archived content, can only list locally
+	if bck.IsHTTP() && bck.Props.Extra.HTTP.Manifest == "" && !lsmsg.IsFlagSet(apc.LsHTTPIndex) {
+		lsmsg.SetFlag(apc.LsObjCached)
+	}
+	if lsmsg.IsFlagSet(apc.LsArchDir) {
 		lsmsg.SetFlag(apc.LsObjCached)
 	}
+	// object tags are stored in-cluster; remote backends don't know about them
+	if lsmsg.Tags != "" {
+		lsmsg.SetFlag(apc.LsObjCached)
+	}
+	// only ais buckets can have trash (see cmn.TrashConf)
+	if lsmsg.IsFlagSet(apc.LsDeleted) && !bck.IsAIS() {
+		p.writeErrf(w, r, "%s: cannot list deleted objects in %s (ais buckets only)", p.si, bck)
+		return
+	}
 
 	tsi, listRemote, wantOnlyRemote, err := p.lsoFlowControls(bck, lsmsg, smap)
 	if err != nil {
@@ -1463,7 +1515,7 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request) {
 	}
 	apireq := apiReqAlloc(1, apc.URLPathObjects.L, false /*dpq*/)
 	defer apiReqFree(apireq)
-	if msg.Action == apc.ActRenameObject {
+	if msg.Action == apc.ActRenameObject || msg.Action == apc.ActUndeleteObject {
 		apireq.after = 2
 	}
 	if err := p.parseReq(w, r, apireq); err != nil {
@@ -1491,6 +1543,16 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request) {
 		}
 		p.objMv(w, r, bck, apireq.items[1], msg)
 		return
+	case apc.ActUndeleteObject:
+		if err := p.checkAccess(w, r, bck, apc.AceObjDELETE); err != nil {
+			return
+		}
+		if !bck.IsAIS() || !bck.Props.Trash.Enabled {
+			p.writeErrActf(w, r, msg.Action, "trash is not enabled for bucket %s", bck)
+			return
+		}
+		p.objUndelete(w, r, bck, apireq.items[1], msg)
+		return
 	case apc.ActPromote:
 		if err := p.checkAccess(w, r, bck, apc.AcePromote); err != nil {
 			return
@@ -1911,7 +1973,7 @@ func (p *proxy) listBuckets(w http.ResponseWriter, r *http.Request, qbck *cmn.Qu
 		bmd     = p.owner.bmd.get()
 		present bool
 	)
-	if qbck.IsAIS() || qbck.IsHTTP() || qbck.IsHDFS() {
+	if qbck.IsAIS() || qbck.IsHTTP() || qbck.IsHDFS() || qbck.IsFS() {
 		bcks := bmd.Select(qbck)
 		p.writeJSON(w, r, bcks, "list-buckets")
 		return
@@ -2016,6 +2078,10 @@ func (p *proxy) lsObjsA(bck *cluster.Bck, lsmsg *apc.LsoMsg) (allEntries *cmn.Ls
 	if lsmsg.PageSize == 0 {
 		lsmsg.PageSize = apc.DefaultPageSizeAIS
 	}
+	if lsmsg.Tags != "" {
+		// cached pages are keyed by prefix only
+		lsmsg.Flags &^= apc.UseListObjsCache
+	}
 	pageSize := lsmsg.PageSize
 
 	// TODO: Before checking cache and buffer we should check if there is another
@@ -2181,6 +2247,21 @@ func (p *proxy) objMv(w http.ResponseWriter, r *http.Request, bck *cluster.Bck,
 	p.statsT.Inc(stats.RenameCount)
 }
 
+func (p *proxy) objUndelete(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName string, msg *apc.ActMsg) {
+	started := time.Now()
+	smap := p.owner.smap.get()
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%q %s/%s => %s", msg.Action, bck.Name, objName, si)
+	}
+	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraControl)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+}
+
 func (p *proxy) doListRange(method, bucket string, msg *apc.ActMsg, query url.Values) (xid string, err error) {
 	var (
 		smap   = p.owner.smap.get()
@@ -1,6 +1,6 @@
 // Package ais provides core functionality for the AIStore object storage.
 /*
- * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
  */
 package ais
 
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/s3"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/api/authn"
 	"github.com/NVIDIA/aistore/cluster"
@@ -211,6 +212,10 @@ func (p *proxy) validateToken(hdr http.Header) (*tok.Token, error) {
 	if err != nil {
 		return nil, err
 	}
+	if tok.IsS3AccessKey(token) {
+		glog.Errorf("invalid token: S3 access key cannot be used as a bearer token")
+		return nil, tok.ErrInvalidToken
+	}
 	tk, err := p.authn.validateToken(token)
 	if err != nil {
 		glog.Errorf("invalid token: %v", err)
@@ -249,10 +254,9 @@ func aceErrToCode(err error) (status int) {
 
 func (p *proxy) access(hdr http.Header, bck *cluster.Bck, ace apc.AccessAttrs) error {
 	var (
-		tk     *tok.Token
-		bucket *cmn.Bck
-		err    error
-		cfg    = cmn.GCO.Get()
+		tk  *tok.Token
+		err error
+		cfg = cmn.GCO.Get()
 	)
 	if p.isIntraCall(hdr, false /*from primary*/) == nil {
 		return nil
@@ -266,7 +270,75 @@ func (p *proxy) access(hdr http.Header, bck *cluster.Bck, ace apc.AccessAttrs) e
 			}
 			return err
 		}
-		uid := p.owner.smap.Get().UUID
+	}
+	return p.checkACL(cfg, tk, bck, ace)
+}
+
+// Same as above except that S3 clients authenticate themselves with AWS SigV4
+// (signed header or presigned URL) - see `validateSigV4` below.
+// Without AuthN, only bucket permissions are checked (no exceptions).
+func (p *proxy) accessS3(r *http.Request, bck *cluster.Bck, ace apc.AccessAttrs) error {
+	var (
+		tk  *tok.Token
+		err error
+		cfg = cmn.GCO.Get()
+	)
+	if !cfg.Auth.Enabled {
+		if bck == nil {
+			return nil
+		}
+		return bck.Allow(ace)
+	}
+	if p.isIntraCall(r.Header, false /*from primary*/) == nil {
+		return nil
+	}
+	if s3.IsSigV4(r) {
+		tk, err = p.validateSigV4(r, cfg)
+	} else {
+		tk, err = p.validateToken(r.Header)
+	}
+	if err != nil {
+		return err
+	}
+	return p.checkACL(cfg, tk, bck, ace)
+}
+
+// S3 access key ID is an opaque handle that maps to AuthN-issued token, and the
+// corresponding secret key is derived from the access key ID - see `tok.S3AccessKey`
+// and `tok.S3SecretKey`. The signature is verified only after the token itself
+// is validated (i.e., not expired and not revoked).
+func (p *proxy) validateSigV4(r *http.Request, cfg *cmn.Config) (*tok.Token, error) {
+	sig, err := s3.ParseSigV4(r)
+	if err != nil {
+		return nil, err
+	}
+	token, err := tok.S3Token(sig.AccessKey, cfg.Auth.Secret)
+	if err != nil {
+		glog.Errorf("invalid S3 access key %q: %v", cos.SHead(sig.AccessKey), err)
+		return nil, s3.NewErrInvalidAccessKey(sig.AccessKey)
+	}
+	tk, err := p.authn.validateToken(token)
+	if err != nil {
+		glog.Errorf("invalid S3 access key: %v", err)
+		return nil, err
+	}
+	if err := sig.Verify(r, tok.S3SecretKey(sig.AccessKey, cfg.Auth.Secret), time.Now()); err != nil {
+		glog.Errorf("%s: %v", tk, err)
+		return nil, err
+	}
+	// (the request body, if any, is verified against the signed payload hash as it's being read -
+	// here or by the target the request gets redirected to)
+	r.Body = s3.NewPayloadReader(r)
+	s3.MarkVerified(r)
+	return tk, nil
+}
+
+func (p *proxy) checkACL(cfg *cmn.Config, tk *tok.Token, bck *cluster.Bck, ace apc.AccessAttrs) error {
+	if cfg.Auth.Enabled {
+		var (
+			bucket *cmn.Bck
+			uid    = p.owner.smap.Get().UUID
+		)
 		if bck != nil {
 			bucket = bck.Bucket()
 		}
@@ -158,8 +158,8 @@ func (args *bckInitArgs) _checkRemoteBckPermissions() (err error) {
 		op = "rename/move remote bucket"
 		goto retErr
 	}
-	// HDFS buckets are allowed to be deleted.
-	if args.bck.IsHDFS() {
+	// HDFS and fs:// buckets are allowed to be deleted.
+	if args.bck.IsHDFS() || args.bck.IsFS() {
 		return
 	}
 	// HTTP buckets should fail on PUT and bucket rename operations
@@ -258,9 +258,9 @@ func (args *bckInitArgs) _try() (bck *cluster.Bck, errCode int, err error) {
 		return
 	}
 
-	// if HDFS bucket is not present in the BMD there is no point
+	// if HDFS (or fs://) bucket is not present in the BMD there is no point
 	// in checking if it exists remotely (in re: `ref_directory`)
-	if args.bck.IsHDFS() {
+	if args.bck.IsHDFS() || args.bck.IsFS() {
 		err = cmn.NewErrBckNotFound(args.bck.Bucket())
 		errCode = http.StatusNotFound
 		return
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -21,6 +22,8 @@ import (
 	jsoniter "github.com/json-iterator/go"
 )
 
+const dlSchedulesInterval = 30 * time.Second // (cron resolution is one minute)
+
 // [METHOD] /v1/download
 func (p *proxy) downloadHandler(w http.ResponseWriter, r *http.Request) {
 	if !p.ClusterStarted() {
@@ -28,18 +31,19 @@ func (p *proxy) downloadHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	switch r.Method {
-	case http.MethodGet, http.MethodDelete:
+	case http.MethodGet, http.MethodDelete, http.MethodPut:
 		p.httpdladm(w, r)
 	case http.MethodPost:
 		p.httpdlpost(w, r)
 	default:
-		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodPost)
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodPost, http.MethodPut)
 	}
 }
 
-// httpDownloadAdmin is meant for aborting, removing and getting status updates for downloads.
+// httpDownloadAdmin is meant for aborting, removing, changing limits, and getting status updates for downloads.
 // GET /v1/download?id=...
 // DELETE /v1/download/{abort, remove}?id=...
+// PUT /v1/download/limits?id=...
 func (p *proxy) httpdladm(w http.ResponseWriter, r *http.Request) {
 	if !p.ClusterStarted() {
 		w.WriteHeader(http.StatusServiceUnavailable)
@@ -49,12 +53,13 @@ func (p *proxy) httpdladm(w http.ResponseWriter, r *http.Request) {
 	if err := cmn.ReadJSON(w, r, &msg); err != nil {
 		return
 	}
-	if err := msg.Validate(r.Method == http.MethodDelete); err != nil {
+	if err := msg.Validate(r.Method != http.MethodGet); err != nil {
 		p.writeErr(w, r, err)
 		return
 	}
 
-	if r.Method == http.MethodDelete {
+	switch r.Method {
+	case http.MethodDelete:
 		items, err := cmn.MatchItems(r.URL.Path, 1, false, apc.URLPathDownload.L)
 		if err != nil {
 			p.writeErr(w, r, err)
@@ -65,6 +70,20 @@ func (p *proxy) httpdladm(w http.ResponseWriter, r *http.Request) {
 			p.writeErrAct(w, r, items[0])
 			return
 		}
+	case http.MethodPut:
+		items, err := cmn.MatchItems(r.URL.Path, 1, false, apc.URLPathDownload.L)
+		if err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		if items[0] != apc.Limits {
+			p.writeErrAct(w, r, items[0])
+			return
+		}
+		if msg.Limits == nil {
+			p.writeErrf(w, r, "%s: missing limits of the download job %q", p, msg.ID)
+			return
+		}
 	}
 	if msg.ID != "" && p.ic.redirectToIC(w, r) {
 		return
@@ -110,16 +129,119 @@ func (p *proxy) httpdlpost(w http.ResponseWriter, r *http.Request) {
 		p.writeErrStatusf(w, r, errCode, "Error starting download: %v", err)
 		return
 	}
-	smap := p.owner.smap.get()
-	nl := dload.NewDownloadNL(jobID, string(dlb.Type), &smap.Smap, progressInterval)
-	nl.SetOwner(equalIC)
-	p.ic.registerEqual(regIC{nl: nl, smap: smap})
+	// NOTE: scheduled job does not run upon submission - each run gets registered
+	// by the primary (see dlRunScheduled)
+	if dlBase.Schedule == "" {
+		smap := p.owner.smap.get()
+		nl := dload.NewDownloadNL(jobID, string(dlb.Type), &smap.Smap, progressInterval)
+		nl.SetOwner(equalIC)
+		p.ic.registerEqual(regIC{nl: nl, smap: smap})
+	}
 
 	w.Header().Set(cos.HdrContentType, cos.ContentJSON)
 	b := cos.MustMarshal(dload.DlPostResp{ID: jobID})
 	w.Write(b)
 }
 
+//
+// scheduled jobs
+//
+
+// primary collects scheduled download jobs from all targets and runs those that are due
+func (p *proxy) dlSchedulesHK() time.Duration {
+	if !p.ClusterStarted() || !p.owner.smap.get().IsPrimary(p.si) {
+		return dlSchedulesInterval
+	}
+	if p.dlsched.CAS(false, true) {
+		go func() {
+			p.dlRunSchedules(time.Now())
+			p.dlsched.Store(false)
+		}()
+	}
+	return dlSchedulesInterval
+}
+
+func (p *proxy) dlRunSchedules(now time.Time) {
+	smap := p.owner.smap.get()
+	for _, spec := range p.dlSchedules(smap) {
+		if spec.Due(now) {
+			p.dlRunScheduled(spec, smap)
+		}
+	}
+}
+
+// union of all targets' schedules; for each job, the most recent next run (targets that were
+// down during the previous run may've missed the update)
+func (p *proxy) dlSchedules(smap *smapX) map[string]*dload.ScheduleSpec {
+	var (
+		specs = make(map[string]*dload.ScheduleSpec)
+		args  = allocBcArgs()
+	)
+	args.req = cmn.HreqArgs{
+		Method: http.MethodGet,
+		Path:   apc.URLPathDownload.S,
+		Query:  url.Values{apc.QparamWhat: []string{apc.WhatDlSchedules}},
+	}
+	args.smap = smap
+	args.timeout = cmn.GCO.Get().Timeout.MaxHostBusy.D()
+	args.cresv = cresDS{}
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	for _, res := range results {
+		if res.err != nil {
+			glog.Errorf("%s: failed to get scheduled download jobs from %s: %v", p, res.si, res.err)
+			continue
+		}
+		for _, spec := range *res.v.(*[]*dload.ScheduleSpec) {
+			if prev, ok := specs[spec.ID]; !ok || spec.NextRun.After(prev.NextRun) {
+				specs[spec.ID] = spec
+			}
+		}
+	}
+	freeBcastRes(results)
+	return specs
+}
+
+// run the job cluster-wide under a new xaction ID and register it with IC
+func (p *proxy) dlRunScheduled(spec *dload.ScheduleSpec, smap *smapX) {
+	if nl := p.notifs.entry(spec.ID); nl != nil && !nl.Finished() {
+		glog.Warningf("%s: download job %q: skipping scheduled run - the previous one is still running", p, spec.ID)
+		return
+	}
+	var (
+		xid              = cos.GenUUID()
+		dlBase           = dload.Base{}
+		progressInterval = dload.DownloadProgressInterval
+	)
+	if err := jsoniter.Unmarshal(spec.Body.RawMessage, &dlBase); err == nil && dlBase.ProgressInterval != "" {
+		if ival, err := time.ParseDuration(dlBase.ProgressInterval); err == nil {
+			progressInterval = ival
+		}
+	}
+	query := make(url.Values, 3)
+	query.Set(apc.QparamUUID, xid)
+	query.Set(apc.QparamJobID, spec.ID)
+	query.Set(apc.QparamDlRun, "true")
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodPost, Path: apc.URLPathDownload.S, Body: cos.MustMarshal(spec), Query: query}
+	args.smap = smap
+	args.timeout = cmn.GCO.Get().Timeout.MaxHostBusy.D()
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	for _, res := range results {
+		if res.err != nil {
+			glog.Errorf("%s: failed to run scheduled download job %q on %s: %v", p, spec.ID, res.si, res.err)
+		}
+	}
+	freeBcastRes(results)
+
+	nl := dload.NewDownloadNL(spec.ID, string(spec.Body.Type), &smap.Smap, progressInterval)
+	nl.SetOwner(equalIC)
+	p.ic.registerEqual(regIC{nl: nl, smap: smap})
+	glog.Infof("%s: started scheduled download job %q[%s], next run at %s", p, spec.ID, xid,
+		spec.NextRun.Format(time.RFC3339))
+}
+
 func (p *proxy) dladm(method, path string, msg *dload.AdminBody) ([]byte, int, error) {
 	if msg.ID != "" && method == http.MethodGet && msg.OnlyActive {
 		nl := p.notifs.entry(msg.ID)
@@ -209,6 +331,15 @@ func (p *proxy) dladm(method, path string, msg *dload.AdminBody) ([]byte, int, e
 		}
 		body := cos.MustMarshal(stResp)
 		return body, http.StatusOK, nil
+	case http.MethodPut:
+		// (each target responds whether its part of the job is running)
+		for _, res := range validResponses {
+			var applied bool
+			if err := jsoniter.Unmarshal(res.bytes, &applied); err == nil && applied {
+				return nil, http.StatusOK, nil
+			}
+		}
+		return nil, http.StatusBadRequest, fmt.Errorf("download job %q is not running", msg.ID)
 	case http.MethodDelete:
 		res := validResponses[0]
 		return res.bytes, res.status, res.err
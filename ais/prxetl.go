@@ -5,6 +5,7 @@
 package ais
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -115,6 +116,21 @@ func (p *proxy) handleETLPut(w http.ResponseWriter, r *http.Request) {
 		p.writeErrf(w, r, "%s: etl[%s] already exists", p, initMsg.Name())
 		return
 	}
+	// pipeline stages must exist (and cannot be pipelines themselves)
+	if pmsg, ok := initMsg.(*etl.InitPipelineMsg); ok {
+		for _, stage := range pmsg.Stages {
+			smsg := etlMD.get(stage)
+			if smsg == nil {
+				p.writeErr(w, r, cmn.NewErrNotFound("%s: pipeline %s stage etl[%s]", p, pmsg.Name(), stage))
+				return
+			}
+			if smsg.Type() == etl.Pipeline {
+				p.writeErrf(w, r, "%s: pipeline %s: stage etl[%s] is a pipeline (nesting not supported)",
+					p, pmsg.Name(), stage)
+				return
+			}
+		}
+	}
 
 	// add to cluster MD and start running
 	if err := p.startETL(w, initMsg, true /*add to etlMD*/); err != nil {
@@ -181,6 +197,9 @@ func (p *proxy) handleETLDelete(w http.ResponseWriter, r *http.Request) {
 
 func (p *proxy) _deleteETLPre(ctx *etlMDModifier, clone *etlMD) (err error) {
 	debug.AssertNoErr(k8s.ValidateEtlName(ctx.etlName))
+	if name := clone.usedBy(ctx.etlName); name != "" {
+		return fmt.Errorf("%s: cannot delete etl[%s] - used by pipeline %q", p, ctx.etlName, name)
+	}
 	if exists := clone.del(ctx.etlName); !exists {
 		err = cmn.NewErrNotFound("%s: etl[%s]", p, ctx.etlName)
 	}
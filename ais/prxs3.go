@@ -55,6 +55,9 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 		if len(apiItems) == 0 {
 			// list all buckets; NOTE: compare with `p.easyURLHandler` and see
 			// "list buckets for a given provider" comment there
+			if err := p.checkAccessS3(w, r, nil, apc.AceListBuckets); err != nil {
+				return
+			}
 			p.bckNamesFromBMD(w)
 			return
 		}
@@ -65,10 +68,30 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 			_, cors      = q[s3.QparamCORS]
 			_, acl       = q[s3.QparamACL]
 		)
+		if lifecycle && len(apiItems) == 1 {
+			p.getBckLifecycleS3(w, r, apiItems[0])
+			return
+		}
 		if lifecycle || policy || cors || acl {
 			p.unsupported(w, r, apiItems[0])
 			return
 		}
+		if q.Has(s3.QparamTagging) {
+			if len(apiItems) == 1 {
+				p.unsupported(w, r, apiItems[0]) // bucket tagging
+				return
+			}
+			p.objSubresS3(w, r, apiItems, apc.AceObjHEAD, s3.QparamTagging)
+			return
+		}
+		if q.Has(s3.QparamObjectLock) && len(apiItems) == 1 {
+			p.getBckObjLockS3(w, r, apiItems[0])
+			return
+		}
+		if subres := objLockSubres(q); subres != "" && len(apiItems) > 1 {
+			p.objSubresS3(w, r, apiItems, apc.AceObjHEAD, subres)
+			return
+		}
 		listMultipart := q.Has(s3.QparamMptUploads)
 		if len(apiItems) == 1 && !listMultipart {
 			_, versioning := q[s3.QparamVersioning]
@@ -76,6 +99,10 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.getBckVersioningS3(w, r, apiItems[0])
 				return
 			}
+			if q.Has(s3.QparamVersions) {
+				p.listObjectVersionsS3(w, r, apiItems[0])
+				return
+			}
 			// only bucket name - list objects in the bucket
 			p.listObjectsS3(w, r, apiItems[0])
 			return
@@ -94,9 +121,30 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.putBckVersioningS3(w, r, apiItems[0])
 				return
 			}
+			if q.Has(s3.QparamLifecycle) {
+				p.putBckLifecycleS3(w, r, apiItems[0])
+				return
+			}
+			if q.Has(s3.QparamObjectLock) {
+				p.putBckObjLockS3(w, r, apiItems[0])
+				return
+			}
+			if unsupportedBckSubres(q) {
+				p.unsupported(w, r, apiItems[0]) // must not fall through to create bucket
+				return
+			}
 			p.putBckS3(w, r, apiItems[0])
 			return
 		}
+		q := r.URL.Query()
+		if q.Has(s3.QparamTagging) {
+			p.objSubresS3(w, r, apiItems, apc.AcePUT, s3.QparamTagging)
+			return
+		}
+		if subres := objLockSubres(q); subres != "" {
+			p.objSubresS3(w, r, apiItems, aceBypassGovernance(r, apc.AcePUT), subres)
+			return
+		}
 		p.putObjS3(w, r, apiItems)
 	case http.MethodPost:
 		q := r.URL.Query()
@@ -125,9 +173,21 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.delMultipleObjs(w, r, apiItems[0])
 				return
 			}
+			if q.Has(s3.QparamLifecycle) {
+				p.delBckLifecycleS3(w, r, apiItems[0])
+				return
+			}
+			if unsupportedBckSubres(q) {
+				p.unsupported(w, r, apiItems[0]) // must not fall through to destroy bucket
+				return
+			}
 			p.delBckS3(w, r, apiItems[0])
 			return
 		}
+		if r.URL.Query().Has(s3.QparamTagging) {
+			p.objSubresS3(w, r, apiItems, apc.AcePUT, s3.QparamTagging)
+			return
+		}
 		p.delObjS3(w, r, apiItems)
 	default:
 		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodHead,
@@ -135,6 +195,19 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// when AuthN is enabled, S3 clients authenticate via AWS SigV4 (see `p.accessS3`)
+func (p *proxy) checkAccessS3(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, ace apc.AccessAttrs) (err error) {
+	if err = p.accessS3(r, bck, ace); err == nil {
+		return
+	}
+	errCode := aceErrToCode(err)
+	if e, ok := err.(*s3.ErrSigV4); ok {
+		errCode = e.Status
+	}
+	s3.WriteErr(w, r, err, errCode)
+	return
+}
+
 // GET /s3
 // NOTE: unlike native API, this one is limited to list only those that are currently present in the BMD.
 func (p *proxy) bckNamesFromBMD(w http.ResponseWriter) {
@@ -164,6 +237,13 @@ func (p *proxy) putBckS3(w http.ResponseWriter, r *http.Request, bucket string)
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if err := p.checkAccessS3(w, r, nil, apc.AceCreateBucket); err != nil {
+		return
+	}
+	if cos.IsParseBool(r.Header.Get(cos.S3HdrBckObjLockEnabled)) {
+		bck.Props = defaultBckProps(bckPropsArgs{bck: bck})
+		bck.Props.ObjectLock.Enabled = true
+	}
 	if err := p.createBucket(&msg, bck, nil); err != nil {
 		s3.WriteErr(w, r, err, crerrStatus(err))
 	}
@@ -176,8 +256,7 @@ func (p *proxy) delBckS3(w http.ResponseWriter, r *http.Request, bucket string)
 		s3.WriteErr(w, r, err, errCode)
 		return
 	}
-	if err := bck.Allow(apc.AceDestroyBucket); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.checkAccessS3(w, r, bck, apc.AceDestroyBucket); err != nil {
 		return
 	}
 	msg := apc.ActMsg{Action: apc.ActDestroyBck}
@@ -201,8 +280,7 @@ func (p *proxy) handleMptUpload(w http.ResponseWriter, r *http.Request, parts []
 		s3.WriteErr(w, r, err, errCode)
 		return
 	}
-	if err := bck.Allow(apc.AcePUT); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.checkAccessS3(w, r, bck, apc.AcePUT); err != nil {
 		return
 	}
 	smap := p.owner.smap.get()
@@ -225,13 +303,11 @@ func (p *proxy) delMultipleObjs(w http.ResponseWriter, r *http.Request, bucket s
 		s3.WriteErr(w, r, err, errCode)
 		return
 	}
-	if err := bck.Allow(apc.AceObjDELETE); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.checkAccessS3(w, r, bck, apc.AceObjDELETE); err != nil {
 		return
 	}
-	decoder := xml.NewDecoder(r.Body)
 	objList := &s3.Delete{}
-	if err := decoder.Decode(objList); err != nil {
+	if err := s3.DecodeXML(r.Body, objList); err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
@@ -289,8 +365,7 @@ func (p *proxy) headBckS3(w http.ResponseWriter, r *http.Request, bucket string)
 		s3.WriteErr(w, r, err, errCode)
 		return
 	}
-	if err := bck.Allow(apc.AceBckHEAD); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.checkAccessS3(w, r, bck, apc.AceBckHEAD); err != nil {
 		return
 	}
 	// From https://docs.aws.amazon.com/AmazonS3/latest/API/API_HeadBucket.html:
@@ -314,6 +389,9 @@ func (p *proxy) listObjectsS3(w http.ResponseWriter, r *http.Request, bucket str
 		s3.WriteErr(w, r, err, errCode)
 		return
 	}
+	if err := p.checkAccessS3(w, r, bck, apc.AceObjLIST); err != nil {
+		return
+	}
 	// e.g. <LastModified>2009-10-12T17:50:30.000Z</LastModified>
 	// (compare w/ `t.headObjS3()`
 	lsmsg := &apc.LsoMsg{UUID: cos.GenUUID(), TimeFormat: cos.ISO8601}
@@ -345,6 +423,40 @@ func (p *proxy) listObjectsS3(w http.ResponseWriter, r *http.Request, bucket str
 	sgl.Free()
 }
 
+// GET /s3/<bucket-name>?versions
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListObjectVersions.html
+// (prior versions exist only in ais:// buckets with version history - see `versioning.history`)
+func (p *proxy) listObjectVersionsS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck, err, errCode := cluster.InitByNameOnly(bucket, p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	if err := p.checkAccessS3(w, r, bck, apc.AceObjLIST); err != nil {
+		return
+	}
+	if !bck.IsAIS() {
+		s3.WriteErr(w, r, cmn.NewErrUnsupp("list object versions in", bck.String()), 0)
+		return
+	}
+	lsmsg := &apc.LsoMsg{UUID: cos.GenUUID(), TimeFormat: cos.ISO8601}
+	lsmsg.AddProps(apc.GetPropsSize, apc.GetPropsChecksum, apc.GetPropsAtime, apc.GetPropsVersion)
+	s3.FillMsgFromS3VersionsQuery(r.URL.Query(), lsmsg)
+
+	lst, err := p.lsObjsA(bck, lsmsg)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	resp := s3.NewListVersionsResult(bucket, lsmsg)
+	resp.FillFromAisBckList(lst, lsmsg)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
 // PUT /s3/<bucket-name>/<object-name>
 func (p *proxy) putObjS3(w http.ResponseWriter, r *http.Request, items []string) {
 	if r.Header.Get(cos.S3HdrObjSrc) == "" {
@@ -370,8 +482,7 @@ func (p *proxy) copyObjS3(w http.ResponseWriter, r *http.Request, items []string
 		s3.WriteErr(w, r, err, errCode)
 		return
 	}
-	if err := bckSrc.Allow(apc.AceGET); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.checkAccessS3(w, r, bckSrc, apc.AceGET); err != nil {
 		return
 	}
 	// dst
@@ -384,8 +495,7 @@ func (p *proxy) copyObjS3(w http.ResponseWriter, r *http.Request, items []string
 		si   *cluster.Snode
 		smap = p.owner.smap.get()
 	)
-	if err = bckDst.Allow(apc.AcePUT); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err = p.checkAccessS3(w, r, bckDst, apc.AcePUT); err != nil {
 		return
 	}
 	objName := strings.Trim(parts[1], "/")
@@ -415,8 +525,7 @@ func (p *proxy) directPutObjS3(w http.ResponseWriter, r *http.Request, items []s
 		si   *cluster.Snode
 		smap = p.owner.smap.get()
 	)
-	if err = bck.Allow(apc.AcePUT); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err = p.checkAccessS3(w, r, bck, apc.AcePUT); err != nil {
 		return
 	}
 	if len(items) < 2 {
@@ -449,8 +558,7 @@ func (p *proxy) getObjS3(w http.ResponseWriter, r *http.Request, items []string,
 		si   *cluster.Snode
 		smap = p.owner.smap.get()
 	)
-	if err = bck.Allow(apc.AceGET); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err = p.checkAccessS3(w, r, bck, apc.AceGET); err != nil {
 		return
 	}
 	if listMultipart {
@@ -534,8 +642,7 @@ func (p *proxy) headObjS3(w http.ResponseWriter, r *http.Request, items []string
 		s3.WriteErr(w, r, err, errCode)
 		return
 	}
-	if err := bck.Allow(apc.AceObjHEAD); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.checkAccessS3(w, r, bck, apc.AceObjHEAD); err != nil {
 		return
 	}
 	smap := p.owner.smap.get()
@@ -563,8 +670,7 @@ func (p *proxy) delObjS3(w http.ResponseWriter, r *http.Request, items []string)
 		si   *cluster.Snode
 		smap = p.owner.smap.get()
 	)
-	if err = bck.Allow(apc.AceObjDELETE); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err = p.checkAccessS3(w, r, bck, aceBypassGovernance(r, apc.AceObjDELETE)); err != nil {
 		return
 	}
 	if len(items) < 2 {
@@ -585,6 +691,33 @@ func (p *proxy) delObjS3(w http.ResponseWriter, r *http.Request, items []string)
 	p.s3Redirect(w, r, si, redirectURL, bck.Name)
 }
 
+// [GET|PUT|DELETE] /s3/<bucket-name>/<object-name>?tagging
+// [GET|PUT] /s3/<bucket-name>/<object-name>?retention|legal-hold
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectTagging.html
+func (p *proxy) objSubresS3(w http.ResponseWriter, r *http.Request, items []string, ace apc.AccessAttrs, subres string) {
+	bck, err, errCode := cluster.InitByNameOnly(items[0], p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	if err := p.checkAccessS3(w, r, bck, ace); err != nil {
+		return
+	}
+	objName := s3.ObjName(items)
+	smap := p.owner.smap.get()
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("AISS3: %s %s/%s?%s => %s", r.Method, bck, objName, subres, si)
+	}
+	started := time.Now()
+	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraData)
+	p.s3Redirect(w, r, si, redirectURL, bck.Name)
+}
+
 // GET /s3/<bucket-name>?versioning
 func (p *proxy) getBckVersioningS3(w http.ResponseWriter, r *http.Request, bucket string) {
 	bck, err, errCode := cluster.InitByNameOnly(bucket, p.owner.bmd)
@@ -592,6 +725,9 @@ func (p *proxy) getBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 		s3.WriteErr(w, r, err, errCode)
 		return
 	}
+	if err := p.checkAccessS3(w, r, bck, apc.AceBckHEAD); err != nil {
+		return
+	}
 	resp := s3.NewVersioningConfiguration(bck.Props.Versioning.Enabled)
 	sgl := p.gmm.NewSGL(0)
 	resp.MustMarshal(sgl)
@@ -600,7 +736,12 @@ func (p *proxy) getBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 	sgl.Free()
 }
 
-// GET /s3/<bucket-name>?lifecycle|cors|policy|acl
+// bucket subresources that are not supported (see `p.unsupported`)
+func unsupportedBckSubres(q url.Values) bool {
+	return q.Has(s3.QparamTagging) || q.Has(s3.QparamPolicy) || q.Has(s3.QparamCORS) || q.Has(s3.QparamACL)
+}
+
+// [GET|PUT|DELETE] /s3/<bucket-name>?cors|policy|acl|tagging
 func (p *proxy) unsupported(w http.ResponseWriter, r *http.Request, bucket string) {
 	if _, err, errCode := cluster.InitByNameOnly(bucket, p.owner.bmd); err != nil {
 		s3.WriteErr(w, r, err, errCode)
@@ -620,9 +761,11 @@ func (p *proxy) putBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 		s3.WriteErr(w, r, err, errCode)
 		return
 	}
-	decoder := xml.NewDecoder(r.Body)
+	if err := p.checkAccessS3(w, r, bck, apc.AcePATCH); err != nil {
+		return
+	}
 	vconf := &s3.VersioningConfiguration{}
-	if err := decoder.Decode(vconf); err != nil {
+	if err := s3.DecodeXML(r.Body, vconf); err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
@@ -640,3 +783,165 @@ func (p *proxy) putBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 		s3.WriteErr(w, r, err, 0)
 	}
 }
+
+// GET /s3/<bucket-name>?lifecycle
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketLifecycleConfiguration.html
+func (p *proxy) getBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck, err, errCode := cluster.InitByNameOnly(bucket, p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	if err := p.checkAccessS3(w, r, bck, apc.AceBckHEAD); err != nil {
+		return
+	}
+	if len(bck.Props.Lifecycle.Rules) == 0 {
+		err := cmn.NewErrNotFound("%s: lifecycle configuration for bucket %s", p.si, bck)
+		s3.WriteErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	resp := s3.NewLifecycleConfiguration(&bck.Props.Lifecycle)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>?lifecycle
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketLifecycleConfiguration.html
+func (p *proxy) putBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	lconf := &s3.LifecycleConfiguration{}
+	if err := s3.DecodeXML(r.Body, lconf); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	conf, err := lconf.Conf()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	p.setBckLifecycleS3(w, r, bucket, msg, conf.Rules)
+}
+
+// DELETE /s3/<bucket-name>?lifecycle
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketLifecycle.html
+func (p *proxy) delBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	if p.setBckLifecycleS3(w, r, bucket, msg, []cmn.LifecycleRule{}) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (p *proxy) setBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string, msg *apc.ActMsg,
+	rules []cmn.LifecycleRule) bool {
+	bck, err, errCode := cluster.InitByNameOnly(bucket, p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return false
+	}
+	if err := p.checkAccessS3(w, r, bck, apc.AcePATCH); err != nil {
+		return false
+	}
+	propsToUpdate := cmn.BucketPropsToUpdate{
+		Lifecycle: &cmn.LifecycleConfToUpdate{Rules: &rules},
+	}
+	nprops, err := p.makeNewBckProps(bck, &propsToUpdate)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return false
+	}
+	if _, err := p.setBucketProps(msg, bck, nprops); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return false
+	}
+	return true
+}
+
+// GET /s3/<bucket-name>?object-lock
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectLockConfiguration.html
+func (p *proxy) getBckObjLockS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck, err, errCode := cluster.InitByNameOnly(bucket, p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	if err := p.checkAccessS3(w, r, bck, apc.AceBckHEAD); err != nil {
+		return
+	}
+	if !bck.Props.ObjectLock.Enabled {
+		err := cmn.NewErrNotFound("%s: object lock configuration for bucket %s", p.si, bck)
+		s3.WriteErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	resp := s3.NewObjectLockConfiguration(&bck.Props.ObjectLock)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>?object-lock
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectLockConfiguration.html
+func (p *proxy) putBckObjLockS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck, err, errCode := cluster.InitByNameOnly(bucket, p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	if err := p.checkAccessS3(w, r, bck, apc.AcePATCH); err != nil {
+		return
+	}
+	lconf := &s3.ObjectLockConfiguration{}
+	if err := s3.DecodeXML(r.Body, lconf); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	conf, err := lconf.Conf()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	propsToUpdate := cmn.BucketPropsToUpdate{
+		ObjectLock: &cmn.ObjLockConfToUpdate{Enabled: &conf.Enabled, Mode: &conf.Mode, Retention: &conf.Retention},
+	}
+	nprops, err := p.makeNewBckProps(bck, &propsToUpdate)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if _, err := p.setBucketProps(msg, bck, nprops); err != nil {
+		s3.WriteErr(w, r, err, 0)
+	}
+}
+
+func objLockSubres(q url.Values) string {
+	switch {
+	case q.Has(s3.QparamRetention):
+		return s3.QparamRetention
+	case q.Has(s3.QparamLegalHold):
+		return s3.QparamLegalHold
+	}
+	return ""
+}
+
+// shortening or removing governance-mode retention requires
+// bucket-level (PATCH) permission in addition to the `ace`
+func aceBypassGovernance(r *http.Request, ace apc.AccessAttrs) apc.AccessAttrs {
+	if cos.IsParseBool(r.Header.Get(cos.S3HdrBypassGovernance)) {
+		ace |= apc.AcePATCH
+	}
+	return ace
+}
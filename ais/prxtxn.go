@@ -168,6 +168,8 @@ func (p *proxy) createBucket(msg *apc.ActMsg, bck *cluster.Bck, remoteHdr http.H
 		cloudProps, present := bmd.Get(backend)
 		debug.Assert(present)
 		bprops.Versioning.Enabled = cloudProps.Versioning.Enabled // always takes precedence
+	case bck.IsFS():
+		debug.Assert(bprops != nil && bprops.Extra.FS.RefDirectory != "")
 	case bck.IsRemote(): // can't create cloud buckets (NIE/NSY)
 		if bck.IsCloud() {
 			return cmn.NewErrNotImpl("create", bck.Provider+"(cloud) bucket")
@@ -349,6 +351,8 @@ func (p *proxy) setBucketProps(msg *apc.ActMsg, bck *cluster.Bck, nprops *cmn.Bu
 			bargs.hdr = remoteBckProps
 		}
 		nprops = defaultBckProps(bargs)
+		nprops.ObjectLock = bprops.ObjectLock // (cannot be disabled - see makeNewBckProps)
+		nprops.Encryption = bprops.Encryption // ditto
 	default:
 		return "", fmt.Errorf(fmtErrInvaldAction, msg.Action, []string{apc.ActSetBprops, apc.ActResetBprops})
 	}
@@ -1079,6 +1083,18 @@ func (p *proxy) makeNewBckProps(bck *cluster.Bck, propsToUpdate *cmn.BucketProps
 	} else if bck.IsHDFS() {
 		nprops.Versioning.Enabled = false
 		// TODO: Check if the `RefDirectory` does not overlap with other buckets.
+	} else if bck.IsFS() && nprops.Extra.FS.RefDirectory != bprops.Extra.FS.RefDirectory && bprops.Extra.FS.RefDirectory != "" {
+		err = fmt.Errorf("%s: cannot change reference directory of the existing bucket %s", p.si, bck)
+		return
+	}
+	if bprops.ObjectLock.Enabled && !nprops.ObjectLock.Enabled {
+		err = fmt.Errorf("%s: once enabled, object lock (WORM) cannot be disabled (%s)", p.si, bck)
+		return
+	}
+	if bprops.Encryption.Enabled != nprops.Encryption.Enabled && (len(creating) == 0 || !creating[0]) {
+		err = fmt.Errorf("%s: encryption can be enabled only at bucket creation time and cannot be disabled (%s)",
+			p.si, bck)
+		return
 	}
 	if bprops.EC.Enabled && nprops.EC.Enabled {
 		sameSlices := bprops.EC.DataSlices == nprops.EC.DataSlices && bprops.EC.ParitySlices == nprops.EC.ParitySlices
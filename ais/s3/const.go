@@ -7,11 +7,14 @@ package s3
 const (
 	// AWS URL params
 	QparamVersioning  = "versioning"
+	QparamVersions    = "versions"
+	QparamVersionID   = "versionId"
 	QparamLifecycle   = "lifecycle"
 	QparamCORS        = "cors"
 	QparamPolicy      = "policy"
 	QparamACL         = "acl"
 	QparamMultiDelete = "delete"
+	QparamTagging     = "tagging"
 
 	// multipart
 	QparamMptUploads        = "uploads"
@@ -23,6 +26,8 @@ const (
 	versioningEnabled  = "Enabled"
 	versioningDisabled = "Suspended"
 
+	NullVersionID = "null" // (S3 version ID of an object that was written with versioning disabled)
+
 	// Maximum number of parts per upload
 	// https://docs.aws.amazon.com/AmazonS3/latest/userguide/qfacts.html
 	MaxPartsPerUpload = 10000
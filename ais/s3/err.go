@@ -6,6 +6,7 @@ package s3
 
 import (
 	"encoding/xml"
+	"errors"
 	"net/http"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -34,16 +35,31 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, errCode int) {
 		ok        bool
 		allocated bool
 	)
+	if errCode == 0 && cmn.IsErrObjLocked(err) {
+		errCode = http.StatusForbidden
+	}
+	if errCode == 0 && cmn.IsErrQuotaExceeded(err) {
+		errCode = http.StatusInsufficientStorage
+	}
+	if e := asErrSigV4(err); e != nil && errCode == 0 {
+		errCode = e.Status
+	}
 	if in, ok = err.(*cmn.ErrHTTP); !ok {
 		in = cmn.InitErrHTTP(r, err, errCode)
 		allocated = true
 	}
 	out.Message = in.Message
 	switch {
+	case asErrSigV4(err) != nil:
+		out.Code = asErrSigV4(err).Code
 	case cmn.IsErrBucketAlreadyExists(err):
 		out.Code = "BucketAlreadyExists"
 	case cmn.IsErrBckNotFound(err):
 		out.Code = "NoSuchBucket"
+	case cmn.IsErrObjLocked(err):
+		out.Code = "AccessDenied"
+	case cmn.IsErrQuotaExceeded(err):
+		out.Code = "QuotaExceeded"
 	default:
 		out.Code = in.TypeCode
 	}
@@ -60,3 +76,11 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, errCode int) {
 		cmn.FreeHterr(in)
 	}
 }
+
+func asErrSigV4(err error) *ErrSigV4 {
+	var e *ErrSigV4
+	if errors.As(err, &e) {
+		return e
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Bucket lifecycle configuration <=> `cmn.LifecycleConf`
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketLifecycleConfiguration.html
+// NOTE: supported actions: expiration (in days) and abort-incomplete-multipart-upload;
+// supported filters: name prefix.
+
+const (
+	lifecycleEnabled  = "Enabled"
+	lifecycleDisabled = "Disabled"
+
+	day = 24 * time.Hour
+)
+
+type (
+	LifecycleConfiguration struct {
+		Rules []LifecycleRule `xml:"Rule"`
+	}
+	LifecycleRule struct {
+		Expiration *LifecycleExpiration `xml:"Expiration,omitempty"`
+		AbortMpt   *LifecycleAbortMpt   `xml:"AbortIncompleteMultipartUpload,omitempty"`
+		Filter     *LifecycleFilter     `xml:"Filter,omitempty"`
+		ID         string               `xml:"ID"`
+		Prefix     string               `xml:"Prefix,omitempty"` // deprecated (but still used) alternative to Filter
+		Status     string               `xml:"Status"`
+	}
+	LifecycleFilter struct {
+		Prefix string `xml:"Prefix"`
+		Tag    *Tag   `xml:"Tag,omitempty"` // not supported
+	}
+	LifecycleExpiration struct {
+		Date string `xml:"Date,omitempty"` // not supported
+		Days int    `xml:"Days,omitempty"`
+	}
+	LifecycleAbortMpt struct {
+		DaysAfterInitiation int `xml:"DaysAfterInitiation"`
+	}
+)
+
+func NewLifecycleConfiguration(conf *cmn.LifecycleConf) *LifecycleConfiguration {
+	r := &LifecycleConfiguration{Rules: make([]LifecycleRule, 0, len(conf.Rules))}
+	for i := range conf.Rules {
+		rule := &conf.Rules[i]
+		lr := LifecycleRule{ID: rule.ID, Status: lifecycleDisabled, Filter: &LifecycleFilter{Prefix: rule.Prefix}}
+		if rule.Enabled {
+			lr.Status = lifecycleEnabled
+		}
+		if rule.ExpireAfter > 0 {
+			lr.Expiration = &LifecycleExpiration{Days: toDays(rule.ExpireAfter)}
+		}
+		if rule.AbortMptAfter > 0 {
+			lr.AbortMpt = &LifecycleAbortMpt{DaysAfterInitiation: toDays(rule.AbortMptAfter)}
+		}
+		r.Rules = append(r.Rules, lr)
+	}
+	return r
+}
+
+func (r *LifecycleConfiguration) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// convert and validate
+func (r *LifecycleConfiguration) Conf() (conf cmn.LifecycleConf, err error) {
+	conf.Rules = make([]cmn.LifecycleRule, 0, len(r.Rules))
+	for i := range r.Rules {
+		lr := &r.Rules[i]
+		rule := cmn.LifecycleRule{ID: lr.ID, Prefix: lr.Prefix}
+		switch lr.Status {
+		case lifecycleEnabled:
+			rule.Enabled = true
+		case lifecycleDisabled:
+		default:
+			return conf, fmt.Errorf("lifecycle rule %q: invalid status %q", lr.ID, lr.Status)
+		}
+		if lr.Filter != nil {
+			if lr.Filter.Tag != nil {
+				return conf, fmt.Errorf("lifecycle rule %q: filtering by tags is not supported", lr.ID)
+			}
+			rule.Prefix = lr.Filter.Prefix
+		}
+		if lr.Expiration != nil {
+			if lr.Expiration.Date != "" {
+				return conf, fmt.Errorf("lifecycle rule %q: expiration date is not supported (use days)", lr.ID)
+			}
+			rule.ExpireAfter = cos.Duration(time.Duration(lr.Expiration.Days) * day)
+		}
+		if lr.AbortMpt != nil {
+			rule.AbortMptAfter = cos.Duration(time.Duration(lr.AbortMpt.DaysAfterInitiation) * day)
+		}
+		conf.Rules = append(conf.Rules, rule)
+	}
+	err = conf.ValidateAsProps()
+	return
+}
+
+func toDays(d cos.Duration) int { return int((d.D() + day - 1) / day) }
@@ -0,0 +1,64 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+const lifecycleXML = `<LifecycleConfiguration>
+  <Rule>
+    <ID>expire-tmp</ID>
+    <Filter><Prefix>tmp/</Prefix></Filter>
+    <Status>Enabled</Status>
+    <Expiration><Days>7</Days></Expiration>
+  </Rule>
+  <Rule>
+    <ID>abort-mpt</ID>
+    <Prefix></Prefix>
+    <Status>Disabled</Status>
+    <AbortIncompleteMultipartUpload><DaysAfterInitiation>2</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+  </Rule>
+</LifecycleConfiguration>`
+
+func TestLifecycleConf(t *testing.T) {
+	lc := &LifecycleConfiguration{}
+	if err := xml.Unmarshal([]byte(lifecycleXML), lc); err != nil {
+		t.Fatal(err)
+	}
+	conf, err := lc.Conf()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conf.Rules) != 2 || !conf.Enabled() {
+		t.Fatalf("unexpected rules %+v", conf.Rules)
+	}
+	var (
+		now = time.Now()
+		old = now.Add(-8 * day)
+	)
+	if rule := conf.Expired("tmp/a", old, now); rule == nil || rule.ID != "expire-tmp" {
+		t.Fatalf("expected tmp/a to expire, got %v", rule)
+	}
+	if rule := conf.Expired("tmp/a", now.Add(-6*day), now); rule != nil {
+		t.Fatalf("tmp/a must not expire yet (%s)", rule.ID)
+	}
+	if rule := conf.Expired("data/a", old, now); rule != nil {
+		t.Fatalf("data/a must not expire (%s)", rule.ID)
+	}
+
+	// and back
+	out := NewLifecycleConfiguration(&conf)
+	if len(out.Rules) != 2 || out.Rules[0].Expiration.Days != 7 || out.Rules[1].AbortMpt.DaysAfterInitiation != 2 {
+		t.Fatalf("unexpected %+v", out.Rules)
+	}
+
+	lc.Rules[0].Expiration.Date = "2023-01-01T00:00:00.000Z"
+	if _, err := lc.Conf(); err == nil {
+		t.Fatal("expected expiration date to fail")
+	}
+}
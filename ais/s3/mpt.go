@@ -10,11 +10,13 @@ import (
 	"os"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
 )
 
@@ -27,7 +29,7 @@ type (
 		Num  int64  // part number (*)
 	}
 	mpt struct {
-		bckName string
+		bck     cmn.Bck
 		objName string
 		parts   []*MptPart // by part number
 		ctime   time.Time  // InitUpload time
@@ -43,10 +45,10 @@ var (
 func Init() { ups = make(uploads) }
 
 // Start miltipart upload
-func InitUpload(id, bckName, objName string) {
+func InitUpload(id string, bck *cmn.Bck, objName string) {
 	mu.Lock()
 	ups[id] = &mpt{
-		bckName: bckName,
+		bck:     *bck,
 		objName: objName,
 		parts:   make([]*MptPart, 0, iniCapParts),
 		ctime:   time.Now(),
@@ -142,6 +144,28 @@ func FinishUpload(id, fqn string, aborted bool) (exists bool) {
 	return true
 }
 
+// AbortStaleUploads aborts incomplete uploads that were started more than `maxAge` ago
+// (see bucket lifecycle: `cmn.LifecycleRule.AbortMptAfter`)
+func AbortStaleUploads(bck *cluster.Bck, prefix string, maxAge time.Duration) (n int) {
+	var (
+		ids []string
+		now = time.Now()
+	)
+	mu.RLock()
+	for id, mpt := range ups {
+		if mpt.bck.Equal(bck.Bucket()) && strings.HasPrefix(mpt.objName, prefix) && now.Sub(mpt.ctime) > maxAge {
+			ids = append(ids, id)
+		}
+	}
+	mu.RUnlock()
+	for _, id := range ids {
+		if FinishUpload(id, "", true /*aborted*/) {
+			n++
+		}
+	}
+	return
+}
+
 func ListUploads(bckName, idMarker string, maxUploads int) (result *ListMptUploadsResult) {
 	mu.RLock()
 	results := make([]UploadInfoResult, 0, len(ups))
@@ -183,7 +207,7 @@ func ListParts(id string, lom *cluster.LOM) (parts []*PartInfo, err error, errCo
 			mu.RUnlock()
 			return
 		}
-		mpt.bckName, mpt.objName = lom.Bck().Name, lom.ObjName
+		mpt.bck, mpt.objName = *lom.Bucket(), lom.ObjName
 		mpt.ctime = lom.Atime()
 	}
 	parts = make([]*PartInfo, 0, len(mpt.parts))
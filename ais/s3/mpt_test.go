@@ -0,0 +1,46 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestAbortStaleUploads(t *testing.T) {
+	Init()
+	var (
+		bck     = cmn.Bck{Name: "mpt-bck", Provider: apc.AIS, Ns: cmn.NsGlobal}
+		bckAWS  = cmn.Bck{Name: "mpt-bck", Provider: apc.AWS, Ns: cmn.NsGlobal}
+		bckNs   = cmn.Bck{Name: "mpt-bck", Provider: apc.AIS, Ns: cmn.Ns{Name: "ns"}}
+		started = time.Now().Add(-2 * time.Hour)
+	)
+	InitUpload("ais-stale", &bck, "tmp/obj")
+	InitUpload("ais-other-prefix", &bck, "obj")
+	InitUpload("ais-recent", &bck, "tmp/recent")
+	InitUpload("aws-stale", &bckAWS, "tmp/obj")
+	InitUpload("ns-stale", &bckNs, "tmp/obj")
+	for id, mpt := range ups {
+		if id != "ais-recent" {
+			mpt.ctime = started
+		}
+	}
+
+	if n := AbortStaleUploads(cluster.CloneBck(&bck), "tmp/", time.Hour); n != 1 {
+		t.Fatalf("expected 1 aborted upload, got %d", n)
+	}
+	if _, ok := ups["ais-stale"]; ok {
+		t.Fatal("stale upload was not aborted")
+	}
+	for _, id := range []string{"ais-other-prefix", "ais-recent", "aws-stale", "ns-stale"} {
+		if _, ok := ups[id]; !ok {
+			t.Fatalf("upload %q must not be aborted", id)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Object Lock <=> `cmn.ObjLockConf` (bucket) and object retention & legal hold (LOM custom metadata)
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/object-lock.html
+
+const (
+	QparamObjectLock = "object-lock"
+	QparamRetention  = "retention"
+	QparamLegalHold  = "legal-hold"
+
+	objLockEnabled = "Enabled"
+	legalHoldON    = "ON"
+	legalHoldOFF   = "OFF"
+
+	year = 365 * day
+)
+
+type (
+	ObjectLockConfiguration struct {
+		Rule              *ObjectLockRule `xml:"Rule,omitempty"`
+		ObjectLockEnabled string          `xml:"ObjectLockEnabled"`
+	}
+	ObjectLockRule struct {
+		DefaultRetention DefaultRetention `xml:"DefaultRetention"`
+	}
+	DefaultRetention struct {
+		Mode  string `xml:"Mode"`
+		Days  int    `xml:"Days,omitempty"`
+		Years int    `xml:"Years,omitempty"`
+	}
+	Retention struct {
+		Mode            string `xml:"Mode"`
+		RetainUntilDate string `xml:"RetainUntilDate"`
+	}
+	LegalHold struct {
+		Status string `xml:"Status"`
+	}
+)
+
+// S3 uses upper case (GOVERNANCE | COMPLIANCE)
+func toS3Mode(mode string) string { return strings.ToUpper(mode) }
+
+func fromS3Mode(mode string) (string, error) {
+	m := strings.ToLower(mode)
+	if !cmn.IsValidObjLockMode(m) {
+		return "", fmt.Errorf("invalid object lock mode %q", mode)
+	}
+	return m, nil
+}
+
+/////////////////////////////
+// ObjectLockConfiguration //
+/////////////////////////////
+
+func NewObjectLockConfiguration(conf *cmn.ObjLockConf) *ObjectLockConfiguration {
+	r := &ObjectLockConfiguration{}
+	if !conf.Enabled {
+		return r
+	}
+	r.ObjectLockEnabled = objLockEnabled
+	if conf.Retention > 0 {
+		r.Rule = &ObjectLockRule{
+			DefaultRetention: DefaultRetention{Mode: toS3Mode(conf.Mode), Days: toDays(conf.Retention)},
+		}
+	}
+	return r
+}
+
+func (r *ObjectLockConfiguration) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// convert and validate
+func (r *ObjectLockConfiguration) Conf() (conf cmn.ObjLockConf, err error) {
+	if r.ObjectLockEnabled != objLockEnabled {
+		return conf, fmt.Errorf("invalid ObjectLockEnabled %q (expecting %q)", r.ObjectLockEnabled, objLockEnabled)
+	}
+	conf.Enabled = true
+	if r.Rule == nil {
+		return
+	}
+	dr := &r.Rule.DefaultRetention
+	if conf.Mode, err = fromS3Mode(dr.Mode); err != nil {
+		return
+	}
+	if (dr.Days > 0) == (dr.Years > 0) {
+		return conf, fmt.Errorf("default retention: expecting either Days or Years (positive integer), got %d and %d",
+			dr.Days, dr.Years)
+	}
+	conf.Retention = cos.Duration(time.Duration(dr.Days)*day + time.Duration(dr.Years)*year)
+	err = conf.ValidateAsProps()
+	return
+}
+
+///////////////
+// Retention //
+///////////////
+
+func NewRetention(mode string, until time.Time) *Retention {
+	return &Retention{Mode: toS3Mode(mode), RetainUntilDate: until.UTC().Format(time.RFC3339)}
+}
+
+func (r *Retention) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+func (r *Retention) Parse() (mode string, until time.Time, err error) {
+	if mode, err = fromS3Mode(r.Mode); err != nil {
+		return
+	}
+	until, err = time.Parse(time.RFC3339, r.RetainUntilDate)
+	if err != nil {
+		err = fmt.Errorf("invalid RetainUntilDate %q: %v", r.RetainUntilDate, err)
+	}
+	return
+}
+
+///////////////
+// LegalHold //
+///////////////
+
+func NewLegalHold(on bool) *LegalHold {
+	if on {
+		return &LegalHold{Status: legalHoldON}
+	}
+	return &LegalHold{Status: legalHoldOFF}
+}
+
+func (r *LegalHold) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+func (r *LegalHold) On() (bool, error) { return parseLegalHold(r.Status) }
+
+func parseLegalHold(status string) (bool, error) {
+	switch status {
+	case legalHoldON:
+		return true, nil
+	case legalHoldOFF:
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid legal hold status %q (expecting %q or %q)", status, legalHoldON, legalHoldOFF)
+	}
+}
+
+//
+// PUT(object) headers
+//
+
+func HasObjLockHdrs(hdr http.Header) bool {
+	return hdr.Get(cos.S3HdrObjLockMode) != "" || hdr.Get(cos.S3HdrObjLockLegalHold) != ""
+}
+
+// ParseObjLockHdrs parses optional retention and legal hold headers,
+// and applies them to the object's attributes
+func ParseObjLockHdrs(hdr http.Header, oa *cmn.ObjAttrs) (err error) {
+	if v := hdr.Get(cos.S3HdrObjLockMode); v != "" {
+		var (
+			mode  string
+			until time.Time
+		)
+		if mode, err = fromS3Mode(v); err != nil {
+			return
+		}
+		d := hdr.Get(cos.S3HdrObjLockRetainUntil)
+		if until, err = time.Parse(time.RFC3339, d); err != nil {
+			return fmt.Errorf("invalid %s %q: %v", cos.S3HdrObjLockRetainUntil, d, err)
+		}
+		oa.SetRetention(mode, until)
+	}
+	if v := hdr.Get(cos.S3HdrObjLockLegalHold); v != "" {
+		var on bool
+		if on, err = parseLegalHold(v); err != nil {
+			return
+		}
+		oa.SetLegalHold(on)
+	}
+	return
+}
+
+// SetObjLockHdrs is the reverse of the above (used in HEAD(object))
+func SetObjLockHdrs(hdr http.Header, oa *cmn.ObjAttrs) {
+	if mode, until := oa.Retention(); !until.IsZero() {
+		hdr.Set(cos.S3HdrObjLockMode, toS3Mode(mode))
+		hdr.Set(cos.S3HdrObjLockRetainUntil, until.UTC().Format(time.RFC3339))
+	}
+	if oa.LegalHold() {
+		hdr.Set(cos.S3HdrObjLockLegalHold, legalHoldON)
+	}
+}
@@ -0,0 +1,78 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+func TestObjectLockConfiguration(t *testing.T) {
+	in := &cmn.ObjLockConf{Enabled: true, Mode: cmn.ObjLockCompliance, Retention: cos.Duration(30 * day)}
+	conf, err := NewObjectLockConfiguration(in).Conf()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf != *in {
+		t.Fatalf("in %+v != out %+v", *in, conf)
+	}
+	lconf := &ObjectLockConfiguration{
+		ObjectLockEnabled: objLockEnabled,
+		Rule:              &ObjectLockRule{DefaultRetention{Mode: "GOVERNANCE", Days: 1, Years: 1}},
+	}
+	if _, err := lconf.Conf(); err == nil {
+		t.Fatal("expecting error: both days and years")
+	}
+}
+
+func TestObjLockHdrs(t *testing.T) {
+	var (
+		oa    = &cmn.ObjAttrs{}
+		hdr   = http.Header{}
+		until = time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	)
+	hdr.Set(cos.S3HdrObjLockMode, "GOVERNANCE")
+	hdr.Set(cos.S3HdrObjLockRetainUntil, until.Format(time.RFC3339))
+	hdr.Set(cos.S3HdrObjLockLegalHold, legalHoldON)
+	if err := ParseObjLockHdrs(hdr, oa); err != nil {
+		t.Fatal(err)
+	}
+	if mode, u := oa.Retention(); mode != cmn.ObjLockGovernance || !u.Equal(until) {
+		t.Fatalf("unexpected retention %q, %v", mode, u)
+	}
+	if err := oa.CheckLocked("obj", time.Now()); !cmn.IsErrObjLocked(err) {
+		t.Fatalf("expecting locked object, got %v", err)
+	}
+	oa.SetLegalHold(false)
+	if err := oa.CheckLocked("obj", until.Add(time.Second)); err != nil {
+		t.Fatalf("expecting expired retention, got %v", err)
+	}
+
+	// shorten: only governance with bypass
+	now := time.Now()
+	if err := oa.CheckRetentionUpdate("obj", cmn.ObjLockGovernance, now, false, now); err == nil {
+		t.Fatal("expecting error: shortening retention without bypass")
+	}
+	if err := oa.CheckRetentionUpdate("obj", cmn.ObjLockGovernance, now, true, now); err != nil {
+		t.Fatal(err)
+	}
+	oa.SetRetention(cmn.ObjLockCompliance, until)
+	if err := oa.CheckRetentionUpdate("obj", cmn.ObjLockGovernance, until, true, now); err == nil {
+		t.Fatal("expecting error: weakening compliance mode")
+	}
+	if err := oa.CheckRetentionUpdate("obj", cmn.ObjLockCompliance, until.Add(time.Hour), false, now); err != nil {
+		t.Fatal(err)
+	}
+
+	out := http.Header{}
+	SetObjLockHdrs(out, oa)
+	if out.Get(cos.S3HdrObjLockMode) != "COMPLIANCE" || out.Get(cos.S3HdrObjLockLegalHold) != "" {
+		t.Fatalf("unexpected headers %v", out)
+	}
+}
@@ -0,0 +1,355 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// AWS Signature Version 4: both flavors - signed `Authorization` header and
+// presigned URL (a.k.a. query string authentication).
+// See:
+// - https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+// - https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-query-string-auth.html
+
+const (
+	sigV4Algo    = "AWS4-HMAC-SHA256"
+	sigV4Request = "aws4_request"
+	sigV4Service = "s3"
+	amzDateFmt   = "20060102T150405Z"
+	scopeDateFmt = "20060102"
+
+	// presigned URL query parameters
+	QparamAlgorithm     = "X-Amz-Algorithm"
+	QparamCredential    = "X-Amz-Credential"
+	QparamDate          = "X-Amz-Date"
+	QparamExpires       = "X-Amz-Expires"
+	QparamSignedHeaders = "X-Amz-SignedHeaders"
+	QparamSignature     = "X-Amz-Signature"
+
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+	streamingPayload = "STREAMING-" // e.g., "STREAMING-AWS4-HMAC-SHA256-PAYLOAD" (aws-chunked)
+
+	// added by proxy to the redirect URL upon verifying the signature (see MarkVerified)
+	QparamSigV4Verified = "ais_sigv4"
+
+	// limits
+	maxPresignedExpires = 7 * 24 * time.Hour // same as AWS
+	maxClockSkew        = 15 * time.Minute
+)
+
+type (
+	// parsed SigV4 credentials and signature
+	SigV4 struct {
+		AccessKey     string
+		Region        string
+		amzDate       string
+		scopeDate     string
+		signature     string
+		signedHeaders []string
+		issued        time.Time
+		expires       time.Duration // presigned only
+		presigned     bool
+	}
+	// SigV4 authentication failure; `Code` is the corresponding S3 error code
+	ErrSigV4 struct {
+		Code   string
+		msg    string
+		Status int
+	}
+	// verifies signed payload hash upon EOF
+	payloadReader struct {
+		io.ReadCloser
+		h        hash.Hash
+		expected string
+	}
+)
+
+func newErrSigV4(code string, status int, format string, a ...any) *ErrSigV4 {
+	return &ErrSigV4{Code: code, Status: status, msg: fmt.Sprintf(format, a...)}
+}
+
+func NewErrInvalidAccessKey(accessKey string) *ErrSigV4 {
+	return newErrSigV4("InvalidAccessKeyId", http.StatusForbidden,
+		"the access key %q does not exist in our records", cos.SHead(accessKey))
+}
+
+func (e *ErrSigV4) Error() string { return e.msg }
+
+// IsSigV4 returns true if the request is signed with SigV4 -
+// either via `Authorization` header or presigned.
+func IsSigV4(r *http.Request) bool {
+	if r.URL.Query().Has(QparamSignature) {
+		return true
+	}
+	return strings.HasPrefix(r.Header.Get(apc.HdrAuthorization), sigV4Algo)
+}
+
+// ParseSigV4 parses SigV4 `Authorization` header or, if presigned, query parameters.
+func ParseSigV4(r *http.Request) (*SigV4, error) {
+	var (
+		sig   = &SigV4{}
+		q     = r.URL.Query()
+		creds string
+		err   error
+	)
+	if q.Has(QparamSignature) {
+		sig.presigned = true
+		if algo := q.Get(QparamAlgorithm); algo != sigV4Algo {
+			return nil, newErrSigV4("AuthorizationQueryParametersError", http.StatusBadRequest,
+				"unsupported %s %q", QparamAlgorithm, algo)
+		}
+		creds = q.Get(QparamCredential)
+		sig.amzDate = q.Get(QparamDate)
+		sig.signature = q.Get(QparamSignature)
+		sig.signedHeaders = strings.Split(q.Get(QparamSignedHeaders), ";")
+		secs, err := strconv.ParseInt(q.Get(QparamExpires), 10, 64)
+		if err != nil || secs <= 0 || time.Duration(secs)*time.Second > maxPresignedExpires {
+			return nil, newErrSigV4("AuthorizationQueryParametersError", http.StatusBadRequest,
+				"invalid %s %q (expecting positive number of seconds not exceeding %v)",
+				QparamExpires, q.Get(QparamExpires), maxPresignedExpires)
+		}
+		sig.expires = time.Duration(secs) * time.Second
+	} else {
+		// e.g.: "AWS4-HMAC-SHA256 Credential=AKID/20230101/us-east-1/s3/aws4_request,
+		//        SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=abcd..."
+		hdr := r.Header.Get(apc.HdrAuthorization)
+		if !strings.HasPrefix(hdr, sigV4Algo+" ") {
+			return nil, newErrSigV4("AuthorizationHeaderMalformed", http.StatusBadRequest,
+				"unsupported authorization algorithm (expecting %s)", sigV4Algo)
+		}
+		for _, kv := range strings.Split(hdr[len(sigV4Algo)+1:], ",") {
+			k, v, _ := strings.Cut(strings.TrimSpace(kv), "=")
+			switch k {
+			case "Credential":
+				creds = v
+			case "SignedHeaders":
+				sig.signedHeaders = strings.Split(v, ";")
+			case "Signature":
+				sig.signature = v
+			}
+		}
+		sig.amzDate = r.Header.Get(QparamDate)
+	}
+	if sig.signature == "" || len(sig.signedHeaders) == 0 || sig.signedHeaders[0] == "" {
+		return nil, sig.errMalformed("missing signature or signed headers")
+	}
+	if err = sig.parseCreds(creds); err != nil {
+		return nil, err
+	}
+	if sig.issued, err = time.Parse(amzDateFmt, sig.amzDate); err != nil {
+		return nil, sig.errMalformed("invalid %s %q", QparamDate, sig.amzDate)
+	}
+	if sig.issued.Format(scopeDateFmt) != sig.scopeDate {
+		return nil, sig.errMalformed("credential scope date %q does not match %s %q",
+			sig.scopeDate, QparamDate, sig.amzDate)
+	}
+	return sig, nil
+}
+
+// <access-key>/<date>/<region>/s3/aws4_request
+func (sig *SigV4) parseCreds(creds string) error {
+	parts := strings.Split(creds, "/")
+	if len(parts) != 5 || parts[0] == "" || parts[3] != sigV4Service || parts[4] != sigV4Request {
+		return sig.errMalformed("invalid credential %q", creds)
+	}
+	sig.AccessKey, sig.scopeDate, sig.Region = parts[0], parts[1], parts[2]
+	return nil
+}
+
+func (sig *SigV4) errMalformed(format string, a ...any) error {
+	code := "AuthorizationHeaderMalformed"
+	if sig.presigned {
+		code = "AuthorizationQueryParametersError"
+	}
+	return newErrSigV4(code, http.StatusBadRequest, format, a...)
+}
+
+// Verify checks validity period and then recomputes the signature
+// using the provided secret key.
+func (sig *SigV4) Verify(r *http.Request, secretKey string, now time.Time) error {
+	if sig.presigned {
+		if now.Before(sig.issued.Add(-maxClockSkew)) || now.After(sig.issued.Add(sig.expires)) {
+			return newErrSigV4("AccessDenied", http.StatusForbidden, "request has expired")
+		}
+	} else if d := now.Sub(sig.issued); d > maxClockSkew || d < -maxClockSkew {
+		return newErrSigV4("RequestTimeTooSkewed", http.StatusForbidden,
+			"the difference between the request time and the current time is too large (%v)", d)
+	}
+	signature := sig.sign(r, secretKey)
+	if !hmac.Equal([]byte(signature), []byte(sig.signature)) {
+		return newErrSigV4("SignatureDoesNotMatch", http.StatusForbidden,
+			"the request signature does not match (access key %q)", cos.SHead(sig.AccessKey))
+	}
+	return nil
+}
+
+func (sig *SigV4) scope() string {
+	return sig.scopeDate + "/" + sig.Region + "/" + sigV4Service + "/" + sigV4Request
+}
+
+func (sig *SigV4) sign(r *http.Request, secretKey string) string {
+	creq := sig.canonicalRequest(r)
+	hash := sha256.Sum256([]byte(creq))
+	toSign := sigV4Algo + "\n" + sig.amzDate + "\n" + sig.scope() + "\n" + hex.EncodeToString(hash[:])
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), sig.scopeDate)
+	key = hmacSHA256(key, sig.Region)
+	key = hmacSHA256(key, sigV4Service)
+	key = hmacSHA256(key, sigV4Request)
+	return hex.EncodeToString(hmacSHA256(key, toSign))
+}
+
+// Method\nURI\nQuery\nHeaders\n\nSignedHeaders\nPayloadHash
+func (sig *SigV4) canonicalRequest(r *http.Request) string {
+	var sb strings.Builder
+	sb.WriteString(r.Method)
+	sb.WriteByte('\n')
+	sb.WriteString(uriEncode(r.URL.Path, false /*encode slash*/))
+	sb.WriteByte('\n')
+	sb.WriteString(sig.canonicalQuery(r.URL.Query()))
+	sb.WriteByte('\n')
+	for _, name := range sig.signedHeaders {
+		var val string
+		if name == "host" {
+			val = r.Host
+		} else {
+			val = strings.Join(r.Header.Values(name), ",")
+		}
+		sb.WriteString(name)
+		sb.WriteByte(':')
+		sb.WriteString(strings.Join(strings.Fields(val), " "))
+		sb.WriteByte('\n')
+	}
+	sb.WriteByte('\n')
+	sb.WriteString(strings.Join(sig.signedHeaders, ";"))
+	sb.WriteByte('\n')
+	sb.WriteString(sig.payloadHash(r))
+	return sb.String()
+}
+
+func (sig *SigV4) canonicalQuery(q url.Values) string {
+	if sig.presigned {
+		q.Del(QparamSignature)
+	}
+	pairs := make([]string, 0, len(q))
+	for k, vals := range q {
+		ek := uriEncode(k, true)
+		for _, v := range vals {
+			pairs = append(pairs, ek+"="+uriEncode(v, true))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// NOTE: the payload hash is taken at face value - the body is not read (and hashed) here;
+// instead, the body gets verified against the (signed) hash while being read - see `NewPayloadReader`
+func (sig *SigV4) payloadHash(r *http.Request) string {
+	if sig.presigned {
+		return unsignedPayload
+	}
+	if v := r.Header.Get(cos.S3HdrContentSHA256); v != "" {
+		return v
+	}
+	hash := sha256.Sum256(nil)
+	return hex.EncodeToString(hash[:])
+}
+
+// NewPayloadReader wraps request body to verify it against the signed payload hash
+// (`x-amz-content-sha256`) - the check is performed upon reaching EOF, so that
+// the caller must read the body in its entirety and fail the request on error.
+// Unsigned, streaming (aws-chunked, signed per chunk), and presigned payloads are passed through as is.
+func NewPayloadReader(r *http.Request) io.ReadCloser {
+	v := r.Header.Get(cos.S3HdrContentSHA256)
+	if v == "" || v == unsignedPayload || strings.HasPrefix(v, streamingPayload) ||
+		r.Body == nil || r.Body == http.NoBody || r.URL.Query().Has(QparamSignature) {
+		return r.Body
+	}
+	return &payloadReader{ReadCloser: r.Body, h: sha256.New(), expected: v}
+}
+
+// (proxy) the request's signature is verified - the target to verify the payload
+func MarkVerified(r *http.Request) {
+	if r.URL.RawQuery != "" {
+		r.URL.RawQuery += "&"
+	}
+	r.URL.RawQuery += QparamSigV4Verified + "=true"
+}
+
+// (target) same as NewPayloadReader but only for the requests that were SigV4-verified
+// by the proxy (see MarkVerified)
+func NewRedirectedPayloadReader(r *http.Request) io.ReadCloser {
+	if !r.URL.Query().Has(QparamSigV4Verified) {
+		return r.Body
+	}
+	return NewPayloadReader(r)
+}
+
+func (pr *payloadReader) Read(b []byte) (n int, err error) {
+	n, err = pr.ReadCloser.Read(b)
+	pr.h.Write(b[:n])
+	if err == io.EOF {
+		if actual := hex.EncodeToString(pr.h.Sum(nil)); actual != pr.expected {
+			err = newErrSigV4("XAmzContentSHA256Mismatch", http.StatusBadRequest,
+				"the provided %s header does not match what was computed (%q vs %q)",
+				cos.S3HdrContentSHA256, pr.expected, actual)
+		}
+	}
+	return
+}
+
+// DecodeXML reads request body in its entirety - to make sure that `payloadReader`
+// (if any) reaches EOF - and unmarshals the result
+func DecodeXML(body io.Reader, v any) error {
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	return xml.Unmarshal(b, v)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// URI-encode every byte except unreserved characters: 'A'-'Z', 'a'-'z', '0'-'9', '-', '.', '_', and '~'
+func uriEncode(s string, encodeSlash bool) string {
+	const hexUpper = "0123456789ABCDEF"
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9'),
+			c == '-', c == '.', c == '_', c == '~':
+			sb.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte('%')
+			sb.WriteByte(hexUpper[c>>4])
+			sb.WriteByte(hexUpper[c&0xf])
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,94 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// object tagging limits
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/object-tagging.html
+const (
+	MaxTagsPerObject = 10
+	maxTagKeyLen     = 128
+	maxTagValueLen   = 256
+)
+
+type (
+	// PutObjectTagging request and GetObjectTagging response
+	Tagging struct {
+		TagSet []Tag `xml:"TagSet>Tag"`
+	}
+	Tag struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	}
+)
+
+func NewTagging(tags cos.StrKVs) *Tagging {
+	keys := tags.Keys()
+	sort.Strings(keys)
+	r := &Tagging{TagSet: make([]Tag, 0, len(keys))}
+	for _, k := range keys {
+		r.TagSet = append(r.TagSet, Tag{Key: k, Value: tags[k]})
+	}
+	return r
+}
+
+func (r *Tagging) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// validate and convert to key-value map
+func (r *Tagging) KVs() (tags cos.StrKVs, err error) {
+	tags = make(cos.StrKVs, len(r.TagSet))
+	for _, tag := range r.TagSet {
+		if _, ok := tags[tag.Key]; ok {
+			return nil, fmt.Errorf("duplicate tag key %q", tag.Key)
+		}
+		tags[tag.Key] = tag.Value
+	}
+	return tags, validateTags(tags)
+}
+
+// ParseTaggingHdr parses `x-amz-tagging` header: URL-encoded query, e.g. "k1=v1&k2=v2"
+func ParseTaggingHdr(hdr string) (tags cos.StrKVs, err error) {
+	q, err := url.ParseQuery(hdr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s header %q: %v", cos.S3HdrTagging, hdr, err)
+	}
+	tags = make(cos.StrKVs, len(q))
+	for k, vs := range q {
+		if len(vs) > 1 {
+			return nil, fmt.Errorf("duplicate tag key %q", k)
+		}
+		tags[k] = vs[0]
+	}
+	return tags, validateTags(tags)
+}
+
+func validateTags(tags cos.StrKVs) error {
+	if len(tags) > MaxTagsPerObject {
+		return fmt.Errorf("number of tags (%d) exceeds the maximum (%d)", len(tags), MaxTagsPerObject)
+	}
+	for k, v := range tags {
+		if k == "" || len(k) > maxTagKeyLen {
+			return fmt.Errorf("invalid tag key %q (length must be between 1 and %d)", k, maxTagKeyLen)
+		}
+		if len(v) > maxTagValueLen {
+			return fmt.Errorf("tag %q: value is too long (max %d)", k, maxTagValueLen)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+func TestTaggingRoundTrip(t *testing.T) {
+	in := cos.StrKVs{"project": "imagenet", "stage": "train", "empty": ""}
+	b, err := xml.Marshal(NewTagging(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := &Tagging{}
+	if err := xml.Unmarshal(b, out); err != nil {
+		t.Fatal(err)
+	}
+	tags, err := out.KVs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !in.Compare(tags) {
+		t.Fatalf("in %v != out %v", in, tags)
+	}
+}
+
+func TestParseTaggingHdr(t *testing.T) {
+	tags, err := ParseTaggingHdr("project=imagenet&stage=train%2Fval")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags["project"] != "imagenet" || tags["stage"] != "train/val" {
+		t.Fatalf("unexpected tags %v", tags)
+	}
+	if _, err := ParseTaggingHdr("k=v1&k=v2"); err == nil {
+		t.Fatal("expected duplicate key error")
+	}
+	var hdr string
+	for i := 0; i <= MaxTagsPerObject; i++ {
+		hdr += fmt.Sprintf("k%d=v&", i)
+	}
+	if _, err := ParseTaggingHdr(hdr); err == nil {
+		t.Fatal("expected too-many-tags error")
+	}
+}
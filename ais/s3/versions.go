@@ -0,0 +1,91 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListObjectVersions.html
+// NOTE: prior versions of ais objects are never split across pages, which is why
+// version-id-marker is ignored (and never returned)
+
+type (
+	ListVersionsResult struct {
+		Name          string         `xml:"Name"`
+		Ns            string         `xml:"xmlns,attr"`
+		Prefix        string         `xml:"Prefix"`
+		KeyMarker     string         `xml:"KeyMarker"`
+		NextKeyMarker string         `xml:"NextKeyMarker,omitempty"`
+		MaxKeys       int            `xml:"MaxKeys"`
+		IsTruncated   bool           `xml:"IsTruncated"`
+		Versions      []*VersionInfo `xml:"Version"`
+	}
+	VersionInfo struct {
+		Key          string `xml:"Key"`
+		VersionID    string `xml:"VersionId"`
+		IsLatest     bool   `xml:"IsLatest"`
+		LastModified string `xml:"LastModified"`
+		ETag         string `xml:"ETag"`
+		Size         int64  `xml:"Size"`
+		Class        string `xml:"StorageClass"`
+	}
+)
+
+func FillMsgFromS3VersionsQuery(query url.Values, msg *apc.LsoMsg) {
+	mxStr := query.Get("max-keys")
+	if pageSize, err := strconv.Atoi(mxStr); err == nil && pageSize > 0 {
+		msg.PageSize = uint(pageSize)
+	}
+	if prefix := query.Get("prefix"); prefix != "" {
+		msg.Prefix = prefix
+	}
+	if marker := query.Get("key-marker"); marker != "" {
+		msg.ContinuationToken = marker
+	}
+	msg.SetFlag(apc.LsVersions)
+}
+
+func NewListVersionsResult(bucket string, lsmsg *apc.LsoMsg) *ListVersionsResult {
+	return &ListVersionsResult{
+		Name:      bucket,
+		Ns:        s3Namespace,
+		Prefix:    lsmsg.Prefix,
+		KeyMarker: lsmsg.ContinuationToken,
+		MaxKeys:   1000,
+		Versions:  make([]*VersionInfo, 0),
+	}
+}
+
+func (r *ListVersionsResult) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+func (r *ListVersionsResult) FillFromAisBckList(bckList *cmn.LsoResult, lsmsg *apc.LsoMsg) {
+	r.IsTruncated = bckList.ContinuationToken != ""
+	r.NextKeyMarker = bckList.ContinuationToken
+	for _, e := range bckList.Entries {
+		objInfo := entryToS3(e, lsmsg)
+		v := &VersionInfo{
+			Key:          objInfo.Key,
+			VersionID:    cos.Either(e.Version, NullVersionID),
+			IsLatest:     !e.IsVersion(),
+			LastModified: objInfo.LastModified,
+			ETag:         objInfo.ETag,
+			Size:         objInfo.Size,
+		}
+		r.Versions = append(r.Versions, v)
+	}
+}
@@ -38,6 +38,7 @@ import (
 	"github.com/NVIDIA/aistore/ext/etl"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/fs/health"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/reb"
@@ -68,6 +69,7 @@ type (
 		res          *res.Res
 		transactions transactions
 		regstate     regstate // the state of being registered with the primary, can be (en/dis)abled via API
+		quotas       quotas   // bucket and namespace usage (see tgtquota.go)
 	}
 )
 
@@ -87,6 +89,7 @@ func (t *target) initBackends() {
 	aisBackend := backend.NewAIS(t)
 	t.backend[apc.AIS] = aisBackend                  // always present
 	t.backend[apc.HTTP] = backend.NewHTTP(t, config) // ditto
+	t.backend[apc.FS] = backend.NewFS(t)             // ditto
 
 	if aisConf := config.Backend.Get(apc.AIS); aisConf != nil {
 		if err := aisBackend.Apply(aisConf, "init", &config.ClusterConfig); err != nil {
@@ -123,7 +126,7 @@ func (t *target) _initBuiltin() error {
 			add, err = backend.NewAzure(t)
 		case apc.HDFS:
 			add, err = backend.NewHDFS(t)
-		case apc.AIS, apc.HTTP:
+		case apc.AIS, apc.HTTP, apc.FS:
 			continue
 		default:
 			return fmt.Errorf(cmn.FmtErrUnknown, t, "backend provider", provider)
@@ -292,13 +295,25 @@ func (t *target) Run() error {
 		glog.Errorln("")
 	}
 
-	// register object type and workfile type
+	// register object, workfile, object version, trash, and write-back types
 	if err := fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}); err != nil {
 		cos.ExitLogf("%v", err)
 	}
 	if err := fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}); err != nil {
 		cos.ExitLogf("%v", err)
 	}
+	if err := fs.CSM.Reg(fs.ObjVersionType, &fs.ObjVersionContentResolver{}); err != nil {
+		cos.ExitLogf("%v", err)
+	}
+	if err := fs.CSM.Reg(fs.TrashType, &fs.TrashContentResolver{}); err != nil {
+		cos.ExitLogf("%v", err)
+	}
+	if err := fs.CSM.Reg(fs.WriteBackType, &fs.WriteBackContentResolver{}); err != nil {
+		cos.ExitLogf("%v", err)
+	}
+	if err := fs.CSM.Reg(fs.PartialDlType, &fs.PartialDlContentResolver{}); err != nil {
+		cos.ExitLogf("%v", err)
+	}
 
 	// Init meta-owners and load local instances
 	t.owner.bmd.init()
@@ -377,6 +392,11 @@ func (t *target) Run() error {
 	mirror.Init()
 
 	xreg.RegWithHK()
+	hk.Reg(apc.ActLifecycle+hk.NameSuffix, t.lifecycleHK, lifecycleInterval)
+	hk.Reg(apc.ActTiering+hk.NameSuffix, t.tieringHK, tieringInterval)
+	hk.Reg("purge-trash"+hk.NameSuffix, t.trashHK, trashInterval)
+	hk.Reg(apc.ActWriteBack+hk.NameSuffix, t.writeBackHK, writeBackInterval)
+	hk.Reg("resume-downloads"+hk.NameSuffix, t.resumeDownloadsHK, resumeDownloadsInterval)
 
 	marked := xreg.GetResilverMarked()
 	if marked.Interrupted || daemon.resilver.required {
@@ -643,6 +663,7 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 			mime:     dpq.archmime, // query.Get(apc.QparamArchmime)
 		}
 		goi.isGFN = cos.IsParseBool(dpq.isGFN) // query.Get(apc.QparamIsGFNRequest)
+		goi.version = dpq.version              // query.Get(apc.QparamVersion)
 		// goi.chunked = cmn.GCO.Get().Net.HTTP.Chunked NOTE: disabled - no need
 	}
 	if bck.IsHTTP() {
@@ -720,6 +741,14 @@ func (t *target) httpobjput(w http.ResponseWriter, r *http.Request) {
 		t.writeErr(w, r, errdb)
 		return
 	}
+	if err := chkLockedDst(lom, !skipVC /*loaded*/); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	if err := t.checkQuotaPut(lom, r, apireq.dpq); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
 
 	// do
 	var (
@@ -799,7 +828,7 @@ func (t *target) httpobjpost(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if msg.Action != apc.ActRenameObject {
+	if msg.Action != apc.ActRenameObject && msg.Action != apc.ActUndeleteObject {
 		t.writeErrAct(w, r, msg.Action)
 		return
 	}
@@ -812,6 +841,10 @@ func (t *target) httpobjpost(w http.ResponseWriter, r *http.Request) {
 		t.writeErrf(w, r, "%s: %s-%s(obj) is expected to be redirected", t.si, r.Method, msg.Action)
 		return
 	}
+	if msg.Action == apc.ActUndeleteObject {
+		t.undelete(w, r, apireq.bck, apireq.items[1])
+		return
+	}
 
 	lom := cluster.AllocLOM(apireq.items[1])
 	err = lom.InitBck(apireq.bck.Bucket())
@@ -1015,6 +1048,22 @@ func (t *target) httpobjpatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	delOldSetNew := cos.IsParseBool(apireq.query.Get(apc.QparamNewCustom))
+	if lom.Bprops().ObjectLock.Enabled {
+		// retention and legal hold cannot be modified via custom props
+		for key := range custom {
+			if cmn.IsObjLockMD(key) {
+				t.writeErrf(w, r, "%s: cannot set %q - object lock metadata is read-only", lom, key)
+				return
+			}
+		}
+		if delOldSetNew {
+			for key, val := range lom.GetCustomMD() {
+				if cmn.IsObjLockMD(key) {
+					custom[key] = val
+				}
+			}
+		}
+	}
 	if delOldSetNew {
 		lom.SetCustomMD(custom)
 	} else {
@@ -1223,6 +1272,18 @@ func (t *target) putMirror(lom *cluster.LOM) {
 	xputlrep.Repl(lom)
 }
 
+// tiered storage: move accessed object back into the hot tier (asynchronously)
+func (t *target) promoteTier(lom *cluster.LOM) {
+	rns := xreg.RenewTierPromote(t, lom)
+	if rns.Err != nil {
+		glog.Errorf("%s: %s %v", t, lom, rns.Err)
+		return
+	}
+	xctn := rns.Entry.Get()
+	xpromote := xctn.(*mirror.XactTierPromote)
+	xpromote.Promote(lom)
+}
+
 func (t *target) DeleteObject(lom *cluster.LOM, evict bool) (code int, err error) {
 	var isback bool
 	lom.Lock(true)
@@ -1252,9 +1313,20 @@ func (t *target) delobj(lom *cluster.LOM, evict bool) (int, error, bool) {
 		aisErr, backendErr         error
 		aisErrCode, backendErrCode int
 		delFromAIS, delFromBackend bool
+		wbPending                  bool
 	)
 	delFromBackend = lom.Bck().IsRemote() && !evict
 	if err := lom.Load(false /*cache it*/, true /*locked*/); err == nil {
+		if err := lom.CheckLocked(); err != nil {
+			return http.StatusForbidden, err, false
+		}
+		if lom.IsWriteBackPending() {
+			if evict {
+				return http.StatusConflict, fmt.Errorf("%s: cannot evict %s - not yet written to %s",
+					t, lom, lom.Bck().Provider), false
+			}
+			wbPending = true
+		}
 		delFromAIS = true
 	} else if !cmn.IsObjNotExist(err) {
 		return 0, err, false
@@ -1267,10 +1339,23 @@ func (t *target) delobj(lom *cluster.LOM, evict bool) (int, error, bool) {
 
 	if delFromBackend {
 		backendErrCode, backendErr = t.Backend(lom.Bck()).DeleteObj(lom)
+		if wbPending {
+			// (may have never been written)
+			if backendErr != nil && backendErrCode == http.StatusNotFound {
+				backendErrCode, backendErr = 0, nil
+			}
+			if err := cos.RemoveFile(lom.WriteBackFQN()); err != nil {
+				glog.Errorf("%s: %v", t, err)
+			}
+		}
 	}
 	if delFromAIS {
-		size := lom.SizeBytes()
-		aisErr = lom.Remove()
+		size, dsize := lom.SizeBytes(), lom.SizeOnDisk()
+		if !evict && lom.TrashEnabled() {
+			aisErr = lom.MoveToTrash()
+		} else {
+			aisErr = lom.Remove()
+		}
 		if aisErr != nil {
 			if !os.IsNotExist(aisErr) {
 				if backendErr != nil {
@@ -1280,12 +1365,17 @@ func (t *target) delobj(lom *cluster.LOM, evict bool) (int, error, bool) {
 				}
 				return 0, aisErr, false
 			}
-		} else if evict {
-			debug.Assert(lom.Bck().IsRemote())
-			t.statsT.AddMany(
-				cos.NamedVal64{Name: stats.LruEvictCount, Value: 1},
-				cos.NamedVal64{Name: stats.LruEvictSize, Value: size},
-			)
+		} else {
+			if u := t.quotas.get(lom.Bck()); u != nil {
+				u.del(dsize)
+			}
+			if evict {
+				debug.Assert(lom.Bck().IsRemote())
+				t.statsT.AddMany(
+					cos.NamedVal64{Name: stats.LruEvictCount, Value: 1},
+					cos.NamedVal64{Name: stats.LruEvictSize, Value: size},
+				)
+			}
 		}
 	}
 	if backendErr != nil {
@@ -1294,6 +1384,41 @@ func (t *target) delobj(lom *cluster.LOM, evict bool) (int, error, bool) {
 	return aisErrCode, aisErr, false
 }
 
+// restore deleted obj from the trash (see cmn.TrashConf)
+func (t *target) undelete(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName string) {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	if !lom.TrashEnabled() {
+		t.writeErrf(w, r, "%s: cannot undelete %s - trash is disabled", t.si, lom)
+		return
+	}
+	lom.Lock(true)
+	err := lom.Load(false /*cache it*/, true /*locked*/)
+	if err == nil {
+		lom.Unlock(true)
+		t.writeErrStatusf(w, r, http.StatusConflict, "%s: cannot undelete %s - object exists", t.si, lom)
+		return
+	}
+	if cmn.IsObjNotExist(err) {
+		buf, slab := t.gmm.Alloc()
+		err = lom.Undelete(buf)
+		slab.Free(buf)
+	}
+	lom.Unlock(true)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	if u := t.quotas.get(lom.Bck()); u != nil {
+		u.add(-1, lom.SizeOnDisk())
+	}
+	t.putMirror(lom)
+}
+
 // rename obj
 func (t *target) objMv(lom *cluster.LOM, msg *apc.ActMsg) error {
 	if lom.Bck().IsRemote() {
@@ -1305,6 +1430,11 @@ func (t *target) objMv(lom *cluster.LOM, msg *apc.ActMsg) error {
 	if msg.Name == lom.ObjName {
 		return fmt.Errorf("%s: cannot rename/move object %s onto itself", t.si, lom)
 	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err == nil {
+		if err := lom.CheckLocked(); err != nil {
+			return err
+		}
+	}
 
 	buf, slab := t.gmm.Alloc()
 	coi := allocCopyObjInfo()
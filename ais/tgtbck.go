@@ -139,7 +139,7 @@ func (t *target) listBuckets(w http.ResponseWriter, r *http.Request, qbck *cmn.Q
 		code   int
 	)
 	if qbck.Provider != "" {
-		if qbck.IsAIS() || qbck.IsHTTP() { // built-in providers
+		if qbck.IsAIS() || qbck.IsHTTP() || qbck.IsFS() { // built-in providers
 			bcks = bmd.Select(qbck)
 		} else {
 			bcks, code, err = t.blist(qbck, config, bmd)
@@ -155,7 +155,7 @@ func (t *target) listBuckets(w http.ResponseWriter, r *http.Request, qbck *cmn.Q
 		for provider := range apc.Providers {
 			var buckets cmn.Bcks
 			qbck.Provider = provider
-			if qbck.IsAIS() || qbck.IsHTTP() {
+			if qbck.IsAIS() || qbck.IsHTTP() || qbck.IsFS() {
 				buckets = bmd.Select(qbck)
 			} else {
 				buckets, code, err = t.blist(qbck, config, bmd)
@@ -346,12 +346,17 @@ func (t *target) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 	switch msg.Action {
 	case apc.ActEvictRemoteBck:
 		keepMD := cos.IsParseBool(apireq.query.Get(apc.QparamKeepRemote))
-		// HDFS buckets will always keep metadata so they can re-register later
-		if apireq.bck.IsHDFS() || keepMD {
+		// HDFS and fs:// buckets will always keep metadata so they can re-register later
+		if apireq.bck.IsHDFS() || apireq.bck.IsFS() || keepMD {
 			nlp := apireq.bck.GetNameLockPair()
 			nlp.Lock()
 			defer nlp.Unlock()
 
+			if err := chkLockedBck(apireq.bck); err != nil {
+				t.writeErr(w, r, err, http.StatusForbidden)
+				return
+			}
+
 			err := fs.DestroyBucket(msg.Action, apireq.bck.Bucket(), apireq.bck.Props.BID)
 			if err != nil {
 				t.writeErr(w, r, err)
@@ -433,6 +438,24 @@ func (t *target) httpbckpost(w http.ResponseWriter, r *http.Request) {
 		rns := xreg.RenewPrefetch(msg.UUID, t, apireq.bck, lrMsg)
 		xctn := rns.Entry.Get()
 		go xctn.Run(nil)
+	case apc.ActSyncBck:
+		syncMsg := &apc.SyncBckMsg{}
+		if !apireq.bck.IsRemote() {
+			t.writeErrf(w, r, "%s: expecting remote bucket, got %s, action=%s",
+				t.si, apireq.bck, msg.Action)
+			return
+		}
+		if err := cos.MorphMarshal(msg.Value, syncMsg); err != nil {
+			t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, msg.Value, err)
+			return
+		}
+		rns := xreg.RenewSyncBck(t, msg.UUID, apireq.bck, &xreg.SyncBckArgs{Msg: syncMsg, StatsT: t.statsT})
+		if rns.Err != nil {
+			t.writeErr(w, r, rns.Err)
+			return
+		}
+		xctn := rns.Entry.Get()
+		go xctn.Run(nil)
 	default:
 		t.writeErrAct(w, r, msg.Action)
 	}
@@ -476,6 +499,11 @@ func (t *target) httpbckhead(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	if !inBMD && apireq.bck.Provider == apc.AWS {
+		if endpoint := apireq.query.Get(apc.QparamS3Endpoint); endpoint != "" {
+			ctx = context.WithValue(ctx, cos.CtxS3Endpoint, endpoint)
+		}
+	}
 	// + cloud
 	bucketProps, code, err = t.Backend(apireq.bck).HeadBucket(ctx, apireq.bck)
 	if err != nil {
@@ -0,0 +1,300 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+	"golang.org/x/sync/errgroup"
+)
+
+// Parallel cold GET (see cmn.ExtraPropsColdGet)
+// - objects larger than the configured chunk size get downloaded via concurrent range reads
+//   (cluster.RangeReader), each writing its chunk directly into the (preallocated) workfile;
+// - all range reads are conditional on the object's version (or ETag) as per the prior HEAD;
+// - the assembled workfile gets checksummed and, if configured (`ValidateColdGet`), validated
+//   against the checksum provided by the remote backend - and then finalized, same as PUT;
+// - encrypted and compressed buckets: the assembled workfile is written again via the regular
+//   (single-stream) PUT path.
+
+const coldGetChunkRetries = 2
+
+type coldGetArgs struct {
+	t     *target
+	ctx   context.Context
+	lom   *cluster.LOM
+	oa    *cmn.ObjAttrs
+	rr    cluster.RangeReader
+	size  int64
+	chunk int64
+	code  atomic.Int32 // first error's HTTP status
+}
+
+// returns handled = false when the object must be cold-GET the regular way: bucket not
+// configured, backend not supporting range reads, or the object's size not exceeding
+// a single chunk
+func (t *target) getColdChunked(ctx context.Context, lom *cluster.LOM, owt cmn.OWT) (handled bool, errCode int,
+	err error) {
+	var (
+		conf    = &lom.Bprops().Extra.ColdGet
+		backend = t.Backend(lom.Bck())
+		oa      *cmn.ObjAttrs
+	)
+	if !conf.IsEnabled() {
+		return
+	}
+	rr, ok := backend.(cluster.RangeReader)
+	if !ok {
+		return
+	}
+	if oa, errCode, err = backend.HeadObj(ctx, lom); err != nil {
+		return true, errCode, err
+	}
+	if oa.Size <= int64(conf.ChunkSize) {
+		return
+	}
+
+	var (
+		workFQN = fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileColdget)
+		args    = &coldGetArgs{t: t, ctx: ctx, lom: lom, oa: oa, rr: rr, size: oa.Size, chunk: int64(conf.ChunkSize)}
+		started = mono.NanoTime()
+	)
+	handled = true
+	if err = args.download(workFQN, conf.Workers()); err != nil {
+		if errRm := cos.RemoveFile(workFQN); errRm != nil {
+			glog.Errorf(fmtNested, t, err, "remove", workFQN, errRm)
+		}
+		errCode = int(args.code.Load())
+		if errCode == 0 {
+			errCode = http.StatusInternalServerError
+		}
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: downloaded %s in %d chunks (%v)", t, lom, (oa.Size+args.chunk-1)/args.chunk,
+			mono.Since(started))
+	}
+
+	// object metadata (compare with backend GetObjReader)
+	lom.SetCustomMD(oa.GetCustomMD())
+	lom.ObjAttrs().DelCustomKeys(cos.HdrContentType) // (not stored - see backend HeadObj)
+	if oa.Ver != "" {
+		lom.SetVersion(oa.Ver)
+	}
+	if lom.IsEncrypted() || lom.ToCompress(oa.Size) {
+		err = t._putAssembled(lom, workFQN, owt, expectedCksum(oa))
+		if errRm := cos.RemoveFile(workFQN); errRm != nil && !os.IsNotExist(errRm) {
+			glog.Errorf("%s: failed to remove %q: %v", t, workFQN, errRm)
+		}
+		if err != nil {
+			errCode = http.StatusInternalServerError
+		}
+		return
+	}
+	if err = t._cksumAssembled(lom, workFQN, oa.Size, owt, expectedCksum(oa)); err != nil {
+		if errRm := cos.RemoveFile(workFQN); errRm != nil {
+			glog.Errorf(fmtNested, t, err, "remove", workFQN, errRm)
+		}
+		return handled, http.StatusInternalServerError, err
+	}
+	lom.SetSize(oa.Size)
+	lom.SetCompressed(0)
+	poi := allocPutObjInfo()
+	{
+		poi.atime = time.Now()
+		poi.t = t
+		poi.lom = lom
+		poi.workFQN = workFQN
+		poi.owt = owt
+	}
+	errCode, err = poi.finalize()
+	freePutObjInfo(poi)
+	return
+}
+
+// checksum provided by the remote backend (with MD5 preferred)
+func expectedCksum(oa *cmn.ObjAttrs) *cos.Cksum {
+	if v, ok := oa.GetCustomKey(cmn.MD5ObjMD); ok && v != "" {
+		return cos.NewCksum(cos.ChecksumMD5, v)
+	}
+	if v, ok := oa.GetCustomKey(cmn.CRC32CObjMD); ok && v != "" {
+		return cos.NewCksum(cos.ChecksumCRC32C, v)
+	}
+	return nil
+}
+
+// compute (and store with LOM) the checksum of the assembled workfile and, if requested,
+// validate the one provided by the remote backend (compare with poi.write)
+func (t *target) _cksumAssembled(lom *cluster.LOM, workFQN string, size int64, owt cmn.OWT, expct *cos.Cksum) error {
+	var (
+		ckconf  = lom.CksumConf()
+		store   *cos.CksumHash
+		given   *cos.CksumHash
+		writers = make([]io.Writer, 0, 2)
+	)
+	if ckconf.Type == cos.ChecksumNone {
+		lom.SetCksum(cos.NoneCksum)
+		return nil
+	}
+	validate := !expct.IsEmpty() && ckconf.ValidateColdGet && owt != cmn.OwtGetPrefetchLock
+	if !expct.IsEmpty() && !validate {
+		lom.SetCksum(expct) // same as poi.write
+		return nil
+	}
+	store = cos.NewCksumHash(ckconf.Type)
+	writers = append(writers, store.H)
+	if validate {
+		given = cos.NewCksumHash(expct.Type())
+		writers = append(writers, given.H)
+	}
+	fh, err := os.Open(workFQN)
+	if err != nil {
+		return err
+	}
+	buf, slab := t.gmm.Alloc()
+	_, err = io.CopyBuffer(cos.NewWriterMulti(writers...), fh, buf)
+	slab.Free(buf)
+	cos.Close(fh)
+	if err != nil {
+		return err
+	}
+	if given != nil {
+		given.Finalize()
+		if !given.Equal(expct) {
+			t.statsT.AddMany(
+				cos.NamedVal64{Name: stats.ErrCksumCount, Value: 1},
+				cos.NamedVal64{Name: stats.ErrCksumSize, Value: size},
+			)
+			return cos.NewBadDataCksumError(expct, &given.Cksum, lom.String())
+		}
+	}
+	store.Finalize()
+	lom.SetCksum(&store.Cksum)
+	return nil
+}
+
+// encrypted or compressed: use the assembled workfile as a reader
+func (t *target) _putAssembled(lom *cluster.LOM, workFQN string, owt cmn.OWT, expct *cos.Cksum) error {
+	fh, err := os.Open(workFQN)
+	if err != nil {
+		return err
+	}
+	params := cluster.AllocPutObjParams()
+	{
+		params.WorkTag = fs.WorkfileColdget
+		params.Reader = fh
+		params.OWT = owt
+		params.Cksum = expct
+		params.Atime = time.Now()
+	}
+	err = t.PutObject(lom, params)
+	cluster.FreePutObjParams(params)
+	return err
+}
+
+/////////////////
+// coldGetArgs //
+/////////////////
+
+func (a *coldGetArgs) download(workFQN string, workers int) error {
+	fh, err := a.lom.CreateFile(workFQN)
+	if err != nil {
+		return err
+	}
+	if err = fh.Truncate(a.size); err != nil {
+		cos.Close(fh)
+		return err
+	}
+	var (
+		nchunks     = (a.size + a.chunk - 1) / a.chunk
+		next        atomic.Int64
+		group, gctx = errgroup.WithContext(a.ctx)
+	)
+	if int64(workers) > nchunks {
+		workers = int(nchunks)
+	}
+	for i := 0; i < workers; i++ {
+		group.Go(func() error {
+			buf, slab := a.t.gmm.AllocSize(a.chunk)
+			defer slab.Free(buf)
+			for {
+				idx := next.Inc() - 1
+				if idx >= nchunks {
+					return nil
+				}
+				off := idx * a.chunk
+				if err := a.getChunk(gctx, fh, off, cos.MinI64(a.chunk, a.size-off), buf); err != nil {
+					return err
+				}
+			}
+		})
+	}
+	err = group.Wait()
+	if errC := fh.Close(); err == nil {
+		err = errC
+	}
+	return err
+}
+
+func (a *coldGetArgs) getChunk(ctx context.Context, fh *os.File, off, length int64, buf []byte) (err error) {
+	var errCode int
+	for i := 0; i <= coldGetChunkRetries; i++ {
+		var r io.ReadCloser
+		if r, errCode, err = a.rr.GetObjRange(ctx, a.lom, a.oa, off, length); err == nil {
+			err = writeChunk(fh, r, off, length, buf)
+			cos.Close(r)
+			if err == nil {
+				return nil
+			}
+		} else if errCode == http.StatusNotFound || errCode == http.StatusPreconditionFailed {
+			a.code.CAS(0, int32(errCode)) // removed or changed in the meantime
+			return err
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		glog.Warningf("%s: failed to get [%d, %d) of %s (attempt %d): %v", a.t, off, off+length, a.lom, i+1, err)
+	}
+	if errCode != 0 {
+		a.code.CAS(0, int32(errCode))
+	}
+	return err
+}
+
+func writeChunk(fh *os.File, r io.Reader, off, length int64, buf []byte) error {
+	var written int64
+	for written < length {
+		n, err := r.Read(buf[:cos.MinI64(int64(len(buf)), length-written)])
+		if n > 0 {
+			if _, errW := fh.WriteAt(buf[:n], off+written); errW != nil {
+				return errW
+			}
+			written += int64(n)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if written != length {
+		return fmt.Errorf("range [%d, %d): expected %d bytes, got %d", off, off+length, length, written)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+// remote backend that only supports HEAD and range reads
+type rangeBP struct {
+	cluster.BackendProvider // (any other call panics)
+	data                    []byte
+	md5                     string
+	mu                      sync.Mutex
+	ranges                  int
+}
+
+func (*rangeBP) Provider() string { return apc.AWS }
+
+func (bp *rangeBP) HeadObj(context.Context, *cluster.LOM) (*cmn.ObjAttrs, int, error) {
+	oa := &cmn.ObjAttrs{Size: int64(len(bp.data)), Ver: "v1"}
+	oa.SetCustomKey(cmn.SourceObjMD, apc.AWS)
+	oa.SetCustomKey(cmn.MD5ObjMD, bp.md5)
+	return oa, 0, nil
+}
+
+func (bp *rangeBP) GetObjRange(_ context.Context, _ *cluster.LOM, _ *cmn.ObjAttrs, off, length int64) (io.ReadCloser,
+	int, error) {
+	bp.mu.Lock()
+	bp.ranges++
+	bp.mu.Unlock()
+	if off+length > int64(len(bp.data)) {
+		return nil, http.StatusRequestedRangeNotSatisfiable, io.ErrUnexpectedEOF
+	}
+	return io.NopCloser(bytes.NewReader(bp.data[off : off+length])), 0, nil
+}
+
+// (the test target - see TestMain)
+func testTarget() *target { return t }
+
+func TestColdGetChunked(t *testing.T) {
+	const (
+		bckName = "cold-get-chunked"
+		size    = 5*cos.MiB + 123
+	)
+	data := make([]byte, size)
+	cos.NowRand().Read(data)
+	sum := md5.Sum(data)
+	var (
+		tgt = testTarget()
+		bp  = &rangeBP{data: data, md5: hex.EncodeToString(sum[:])}
+	)
+
+	oldConfig := cmn.GCO.Get()
+	config := cmn.GCO.BeginUpdate()
+	config.Backend.Providers = map[string]cmn.Ns{apc.AWS: cmn.NsGlobal}
+	cmn.GCO.CommitUpdate(config)
+	tgt.backend[apc.AWS] = bp
+	defer func() {
+		delete(tgt.backend, apc.AWS)
+		cmn.GCO.BeginUpdate()
+		cmn.GCO.CommitUpdate(oldConfig)
+	}()
+
+	bck := cluster.NewBck(bckName, apc.AWS, cmn.NsGlobal)
+	bmd := tgt.owner.bmd.get().clone()
+	bmd.add(bck, &cmn.BucketProps{
+		Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash, ValidateColdGet: true},
+		Extra: cmn.ExtraProps{ColdGet: cmn.ExtraPropsColdGet{ChunkSize: cos.MiB, Concurrency: 3}},
+	})
+	tgt.owner.bmd.putPersist(bmd, nil)
+	fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)
+	defer func() {
+		for _, mi := range fs.GetAvail() {
+			os.RemoveAll(mi.MakePathBck(bck.Bucket()))
+		}
+	}()
+
+	coldGet := func(objName string) (*cluster.LOM, error) {
+		lom := cluster.AllocLOM(objName)
+		tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+		_, err := tgt.GetCold(context.Background(), lom, cmn.OwtGetLock)
+		return lom, err
+	}
+
+	// ok
+	lom, err := coldGet("ok")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bp.ranges == 6, "expected 6 range reads, got %d", bp.ranges)
+	tassert.CheckFatal(t, lom.Load(false /*cache it*/, false /*locked*/))
+	tassert.Errorf(t, lom.SizeBytes() == size, "expected size %d, got %d", size, lom.SizeBytes())
+	tassert.Errorf(t, lom.Version() == "v1", "expected version v1, got %q", lom.Version())
+	tassert.Errorf(t, lom.Checksum().Type() == cos.ChecksumXXHash, "unexpected checksum %s", lom.Checksum())
+	b, err := os.ReadFile(lom.FQN)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bytes.Equal(b, data), "assembled object differs from the original")
+	cluster.FreeLOM(lom)
+
+	// corrupted
+	bp.md5 = hex.EncodeToString(make([]byte, md5.Size))
+	lom, err = coldGet("corrupted")
+	tassert.Errorf(t, err != nil && cos.IsErrBadCksum(err), "expected bad checksum, got %v", err)
+	tassert.Errorf(t, lom.Load(false /*cache it*/, false /*locked*/) != nil, "corrupted object must not exist")
+	cluster.FreeLOM(lom)
+}
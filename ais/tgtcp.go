@@ -650,6 +650,7 @@ func (t *target) _postBMD(tag string, rmbcks []*cluster.Bck) {
 	// evict LOM cache
 	if len(rmbcks) > 0 {
 		xreg.AbortAllBuckets(errors.New("post-bmd"), rmbcks...)
+		t.quotas.remove(rmbcks...)
 		go func(bcks ...*cluster.Bck) {
 			for _, b := range bcks {
 				cluster.EvictLomCache(b)
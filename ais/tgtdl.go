@@ -17,11 +17,14 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/ext/dload"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/xact/xreg"
 	jsoniter "github.com/json-iterator/go"
 )
 
+const resumeDownloadsInterval = 10 * time.Second
+
 // [METHOD] /v1/download
 func (t *target) downloadHandler(w http.ResponseWriter, r *http.Request) {
 	var (
@@ -39,68 +42,34 @@ func (t *target) downloadHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		var (
-			query            = r.URL.Query()
-			xid              = query.Get(apc.QparamUUID)
-			jobID            = query.Get(apc.QparamJobID)
-			dlb              = dload.Body{}
-			progressInterval = dload.DownloadProgressInterval
+			query = r.URL.Query()
+			xid   = query.Get(apc.QparamUUID)
+			jobID = query.Get(apc.QparamJobID)
+			dlb   = dload.Body{}
 		)
 		debug.Assertf(cos.IsValidUUID(xid) && cos.IsValidUUID(jobID), "%q, %q", xid, jobID)
-		if err := cmn.ReadJSON(w, r, &dlb); err != nil {
-			return
-		}
-
-		dlBodyBase := dload.Base{}
-		if err := jsoniter.Unmarshal(dlb.RawMessage, &dlBodyBase); err != nil {
-			err = fmt.Errorf(cmn.FmtErrUnmarshal, t, "download message", cos.BHead(dlb.RawMessage), err)
-			t.writeErr(w, r, err)
-			return
-		}
-
-		if dlBodyBase.ProgressInterval != "" {
-			dur, err := time.ParseDuration(dlBodyBase.ProgressInterval)
-			if err != nil {
-				t.writeErrf(w, r, "%s: invalid progress interval %q: %v", t, dlBodyBase.ProgressInterval, err)
+		if cos.IsParseBool(query.Get(apc.QparamDlRun)) {
+			spec := &dload.ScheduleSpec{}
+			if err := cmn.ReadJSON(w, r, spec); err != nil {
 				return
 			}
-			progressInterval = dur
-		}
-
-		bck := cluster.CloneBck(&dlBodyBase.Bck)
-		if err := bck.Init(t.Bowner()); err != nil {
-			t.writeErr(w, r, err)
-			return
-		}
-
-		xdl, err := t.renewdl(xid)
-		if err != nil {
-			t.writeErr(w, r, err, http.StatusInternalServerError)
-			return
+			debug.Assert(spec.ID == jobID)
+			response, statusCode, respErr = t.runScheduled(xid, spec)
+			break
 		}
-		dljob, err := dload.ParseStartRequest(t, bck, jobID, dlb, xdl)
-		if err != nil {
-			t.writeErr(w, r, err)
+		if err := cmn.ReadJSON(w, r, &dlb); err != nil {
 			return
 		}
-		if glog.FastV(4, glog.SmoduleAIS) {
-			glog.Infof("Downloading: %s", dljob.ID())
-		}
-
-		dljob.AddNotif(&dload.NotifDownload{
-			Base: nl.Base{
-				When:     cluster.UponProgress,
-				Interval: progressInterval,
-				Dsts:     []string{equalIC},
-				F:        t.callerNotifyFin,
-				P:        t.callerNotifyProgress,
-			},
-		}, dljob)
-		response, statusCode, respErr = xdl.Download(dljob)
+		response, statusCode, respErr = t.startDownload(xid, jobID, dlb, false /*run*/)
 
 	case http.MethodGet:
 		if _, err := t.apiItems(w, r, 0, false, apc.URLPathDownload.L); err != nil {
 			return
 		}
+		if r.URL.Query().Get(apc.QparamWhat) == apc.WhatDlSchedules {
+			response, statusCode = dload.ListSchedules(), http.StatusOK
+			break
+		}
 		msg := &dload.AdminBody{}
 		if err := cmn.ReadJSON(w, r, msg); err != nil {
 			return
@@ -166,8 +135,36 @@ func (t *target) downloadHandler(w http.ResponseWriter, r *http.Request) {
 		} else { // apc.Remove
 			response, statusCode, respErr = xdl.RemoveJob(payload.ID)
 		}
+
+	case http.MethodPut:
+		items, err := t.apiItems(w, r, 1, false, apc.URLPathDownload.L)
+		if err != nil {
+			return
+		}
+		if items[0] != apc.Limits {
+			t.writeErrAct(w, r, items[0])
+			return
+		}
+		payload := &dload.AdminBody{}
+		if err := cmn.ReadJSON(w, r, payload); err != nil {
+			return
+		}
+		if err := payload.Validate(true /*requireID*/); err != nil {
+			debug.Assert(false)
+			t.writeErr(w, r, err)
+			return
+		}
+		debug.Assert(payload.Limits != nil)
+		xid := r.URL.Query().Get(apc.QparamUUID)
+		debug.Assertf(cos.IsValidUUID(xid), "%q", xid)
+		xdl, err := t.renewdl(xid)
+		if err != nil {
+			t.writeErr(w, r, err, http.StatusInternalServerError)
+			return
+		}
+		response, statusCode, respErr = xdl.SetLimits(payload.ID, payload.Limits)
 	default:
-		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodPost)
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodPost, http.MethodPut)
 		return
 	}
 
@@ -180,6 +177,97 @@ func (t *target) downloadHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// (also used to restart download jobs upon reboot and to run scheduled ones - with `run` set)
+func (t *target) startDownload(xid, jobID string, dlb dload.Body, run bool) (any, int, error) {
+	progressInterval := dload.DownloadProgressInterval
+	dlBodyBase := dload.Base{}
+	if err := jsoniter.Unmarshal(dlb.RawMessage, &dlBodyBase); err != nil {
+		err = fmt.Errorf(cmn.FmtErrUnmarshal, t, "download message", cos.BHead(dlb.RawMessage), err)
+		return nil, http.StatusBadRequest, err
+	}
+	if dlBodyBase.ProgressInterval != "" {
+		dur, err := time.ParseDuration(dlBodyBase.ProgressInterval)
+		if err != nil {
+			err = fmt.Errorf("%s: invalid progress interval %q: %v", t, dlBodyBase.ProgressInterval, err)
+			return nil, http.StatusBadRequest, err
+		}
+		progressInterval = dur
+	}
+
+	bck := cluster.CloneBck(&dlBodyBase.Bck)
+	if err := bck.Init(t.Bowner()); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if dlBodyBase.Schedule != "" && !run {
+		return dload.Schedule(jobID, dlb)
+	}
+
+	xdl, err := t.renewdl(xid)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	dljob, err := dload.ParseStartRequest(t, bck, jobID, dlb, xdl)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("Downloading: %s", dljob.ID())
+	}
+
+	dljob.AddNotif(&dload.NotifDownload{
+		Base: nl.Base{
+			When:     cluster.UponProgress,
+			Interval: progressInterval,
+			Dsts:     []string{equalIC},
+			F:        t.callerNotifyFin,
+			P:        t.callerNotifyProgress,
+		},
+	}, dljob)
+	return xdl.Download(dljob, dlb)
+}
+
+// restart download jobs interrupted by shutdown (see dload.JobSpec)
+func (t *target) resumeDownloadsHK() time.Duration {
+	if !t.ClusterStarted() {
+		return resumeDownloadsInterval
+	}
+	go t.resumeDownloads()
+	return hk.UnregInterval
+}
+
+func (t *target) resumeDownloads() {
+	specs, err := dload.PendingJobs()
+	if err != nil {
+		glog.Errorf("%s: failed to load download jobs: %v", t, err)
+		return
+	}
+	for _, spec := range specs {
+		_, statusCode, err := t.startDownload(spec.XactID, spec.ID, spec.Body, true /*run*/)
+		if statusCode >= http.StatusBadRequest {
+			glog.Errorf("%s: failed to restart download job %q: %v(%d)", t, spec.ID, err, statusCode)
+			dload.DropPendingJob(spec.ID)
+			continue
+		}
+		glog.Infof("%s: restarted download job %q", t, spec.ID)
+	}
+}
+
+// run scheduled download job on behalf of the primary (see prxdl.go)
+func (t *target) runScheduled(xid string, spec *dload.ScheduleSpec) (any, int, error) {
+	run, err := dload.PrepRun(spec)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if !run {
+		return spec.ID, http.StatusOK, nil
+	}
+	resp, statusCode, err := t.startDownload(xid, spec.ID, spec.Body, true /*run*/)
+	if statusCode < http.StatusBadRequest {
+		glog.Infof("%s: started scheduled download job %q (next run at %s)", t, spec.ID, spec.NextRun.Format(time.RFC3339))
+	}
+	return resp, statusCode, err
+}
+
 func (t *target) renewdl(xid string) (*dload.Xact, error) {
 	rns := xreg.RenewDownloader(t, t.statsT, xid)
 	if rns.Err != nil {
@@ -0,0 +1,371 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/dload"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// returns the bucket to download into and the cleanup that removes it and restores
+// the target's prior Smap (other unit tests in the package rely on it - see TestMain)
+func initDlTest(tgt *target, bckName string) (*cluster.Bck, func()) {
+	xreg.Init()
+	dload.Xreg()
+	hk.TestInit()
+	dload.SetDB(mock.NewDBDriver())
+	_ = fs.CSM.Reg(fs.PartialDlType, &fs.PartialDlContentResolver{})
+	prev, smap := tgt.owner.smap.get(), newSmap()
+	smap.Tmap[tgt.si.ID()] = tgt.si
+	tgt.owner.smap.put(smap)
+
+	bck := cluster.NewBck(bckName, apc.AIS, cmn.NsGlobal)
+	bmd := tgt.owner.bmd.get().clone()
+	bmd.add(bck, &cmn.BucketProps{Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash}})
+	tgt.owner.bmd.putPersist(bmd, nil)
+	fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)
+	return bck, func() {
+		for _, mi := range fs.GetAvail() {
+			os.RemoveAll(mi.MakePathBck(bck.Bucket()))
+		}
+		if prev == nil {
+			prev = newSmap()
+		}
+		tgt.owner.smap.put(prev)
+	}
+}
+
+func waitDlJob(t *testing.T, tgt *target, xid, jobID string) (resp *dload.StatusResp) {
+	xdl, err := tgt.renewdl(xid)
+	tassert.CheckFatal(t, err)
+	for i := 0; i < 300; i++ {
+		v, _, err := xdl.JobStatus(jobID, false /*onlyActive*/)
+		tassert.CheckFatal(t, err)
+		if resp = v.(*dload.StatusResp); resp.JobFinished() {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	tassert.Fatalf(t, resp.JobFinished(), "timed out waiting for %q", jobID)
+	return resp
+}
+
+// the first GET breaks the connection past the first checkpoint; the retry must resume via range request
+func TestDownloadResume(t *testing.T) {
+	const (
+		bckName = "dl-resume"
+		size    = 65 * cos.MiB
+		etag    = `"v1"`
+	)
+	var (
+		tgt    = testTarget()
+		data   = make([]byte, size)
+		mu     sync.Mutex
+		ranges []string
+	)
+	_, err := rand.Read(data)
+	tassert.CheckFatal(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ranges = append(ranges, r.Header.Get(cos.HdrRange))
+		first := len(ranges) == 1
+		mu.Unlock()
+		w.Header().Set(cos.HdrETag, etag)
+		if first {
+			w.Header().Set(cos.HdrAcceptRanges, "bytes")
+			w.Header().Set(cos.HdrContentLength, strconv.Itoa(size))
+			w.WriteHeader(http.StatusOK)
+			w.Write(data[:size-cos.MiB/2]) // (short write: connection gets closed)
+			return
+		}
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+	}))
+	defer srv.Close()
+
+	bck, cleanup := initDlTest(tgt, bckName)
+	defer cleanup()
+
+	var (
+		xid   = cos.GenUUID()
+		jobID = cos.GenUUID()
+		body  = &dload.SingleBody{
+			Base:      dload.Base{Bck: bck.Clone(), Timeout: "1m"},
+			SingleObj: dload.SingleObj{ObjName: "obj", Link: srv.URL + "/obj"},
+		}
+		dlb = dload.Body{Type: dload.TypeSingle, RawMessage: cos.MustMarshal(body)}
+	)
+	_, statusCode, err := tgt.startDownload(xid, jobID, dlb, false /*run*/)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, statusCode == http.StatusOK, "status %d", statusCode)
+
+	resp := waitDlJob(t, tgt, xid, jobID)
+	tassert.Fatalf(t, resp.ErrorCnt == 0, "download failed: %v", resp.Errs)
+
+	mu.Lock()
+	tassert.Fatalf(t, len(ranges) == 2 && ranges[0] == "", "expected 2 requests, got %q", ranges)
+	tassert.Errorf(t, ranges[1] == "bytes="+strconv.Itoa(size-cos.MiB/2)+"-", "expected to resume, got %q", ranges[1])
+	mu.Unlock()
+
+	lom := cluster.AllocLOM("obj")
+	defer cluster.FreeLOM(lom)
+	tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+	tassert.CheckFatal(t, lom.Load(false /*cache it*/, false /*locked*/))
+	tassert.Errorf(t, cos.Stat(fs.CSM.Gen(lom, fs.PartialDlType, jobID)) != nil, "partial workfile must be removed")
+	fh, err := lom.Open()
+	tassert.CheckFatal(t, err)
+	b, err := io.ReadAll(fh)
+	cos.Close(fh)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bytes.Equal(b, data), "downloaded content differs (size %d)", len(b))
+
+	specs, err := dload.PendingJobs()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(specs) == 0, "finished job must not be restarted: %+v", specs)
+}
+
+// objects listed in the manifest get verified; the corrupted one is re-downloaded, removed, and reported
+func TestDownloadVerify(t *testing.T) {
+	const bckName = "dl-verify"
+	var (
+		tgt     = testTarget()
+		good    = []byte("good content")
+		bad     = []byte("corrupted content")
+		mu      sync.Mutex
+		badGets int
+	)
+	goodSum, badSum := sha256.Sum256(good), sha256.Sum256([]byte("original content"))
+	manifest := fmt.Sprintf("# checksums\n%x  good\n%x *./bad\n", goodSum, badSum)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/SHA256SUMS":
+			w.Write([]byte(manifest))
+		case "/good":
+			w.Write(good)
+		case "/bad":
+			mu.Lock()
+			badGets++
+			mu.Unlock()
+			w.Write(bad)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	bck, cleanup := initDlTest(tgt, bckName)
+	defer cleanup()
+
+	var (
+		xid   = cos.GenUUID()
+		jobID = cos.GenUUID()
+		body  = &dload.MultiBody{
+			Base: dload.Base{
+				Bck:           bck.Clone(),
+				Timeout:       "1m",
+				Manifest:      srv.URL + "/SHA256SUMS",
+				VerifyRetries: 1,
+			},
+			ObjectsPayload: []string{srv.URL + "/good", srv.URL + "/bad"},
+		}
+		dlb = dload.Body{Type: dload.TypeMulti, RawMessage: cos.MustMarshal(body)}
+	)
+	_, statusCode, err := tgt.startDownload(xid, jobID, dlb, false /*run*/)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, statusCode == http.StatusOK, "status %d", statusCode)
+
+	resp := waitDlJob(t, tgt, xid, jobID)
+	tassert.Fatalf(t, resp.ErrorCnt == 1 && len(resp.Errs) == 1, "expected 1 error, got %v", resp.Errs)
+	tassert.Errorf(t, resp.Errs[0].Name == "bad" && strings.Contains(strings.ToLower(resp.Errs[0].Err), "checksum"),
+		"unexpected error %+v", resp.Errs[0])
+	tassert.Errorf(t, resp.FinishedCnt == 1, "expected 1 finished, got %d", resp.FinishedCnt)
+	mu.Lock()
+	tassert.Errorf(t, badGets == 2, "expected 1 retry, got %d GETs", badGets)
+	mu.Unlock()
+
+	for objName, exists := range map[string]bool{"good": true, "bad": false} {
+		lom := cluster.AllocLOM(objName)
+		tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+		err := lom.Load(false /*cache it*/, false /*locked*/)
+		tassert.Errorf(t, (err == nil) == exists, "%s: exists %t, got %v", objName, exists, err)
+		cluster.FreeLOM(lom)
+	}
+
+	// invalid manifest fails the request upfront
+	body.Manifest = srv.URL + "/none"
+	dlb.RawMessage = cos.MustMarshal(body)
+	_, statusCode, err = tgt.startDownload(xid, cos.GenUUID(), dlb, false /*run*/)
+	tassert.Fatalf(t, err != nil && statusCode == http.StatusBadRequest, "expected bad request, got %d (%v)", statusCode, err)
+}
+
+// scheduled job does not run upon submission; each run downloads only new or changed objects
+func TestDownloadSchedule(t *testing.T) {
+	const bckName = "dl-schedule"
+	var (
+		tgt     = testTarget()
+		data    = []byte("scheduled content")
+		mu      sync.Mutex
+		getsCnt int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			mu.Lock()
+			getsCnt++
+			mu.Unlock()
+		}
+		w.Header().Set(cos.HdrETag, `"v1"`)
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+	}))
+	defer srv.Close()
+
+	bck, cleanup := initDlTest(tgt, bckName)
+	defer cleanup()
+
+	var (
+		xid   = cos.GenUUID()
+		jobID = cos.GenUUID()
+		body  = &dload.SingleBody{
+			Base:      dload.Base{Bck: bck.Clone(), Timeout: "1m", Schedule: "1h"},
+			SingleObj: dload.SingleObj{ObjName: "obj", Link: srv.URL + "/obj"},
+		}
+		dlb  = dload.Body{Type: dload.TypeSingle, RawMessage: cos.MustMarshal(body)}
+		gets = func() int { mu.Lock(); defer mu.Unlock(); return getsCnt }
+	)
+	_, statusCode, err := tgt.startDownload(xid, jobID, dlb, false /*run*/)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, statusCode == http.StatusOK, "status %d", statusCode)
+
+	v, _, err := dload.ListJobs(nil, false /*onlyActive*/)
+	tassert.CheckFatal(t, err)
+	job, ok := v.(map[string]dload.Job)[jobID]
+	tassert.Fatalf(t, ok, "scheduled job %q not listed", jobID)
+	tassert.Errorf(t, job.Schedule == "1h" && job.JobFinished(), "unexpected %+v", job)
+	tassert.Errorf(t, time.Until(job.NextRun) > 59*time.Minute, "unexpected next run %s", job.NextRun)
+	tassert.Fatalf(t, len(dueSchedules(time.Now())) == 0, "not expecting the job to be due")
+	tassert.Fatalf(t, gets() == 0, "not expecting the job to run upon submission")
+
+	// 1st run (the primary advances the next run and the target persists it)
+	specs := dueSchedules(time.Now().Add(2 * time.Hour))
+	tassert.Fatalf(t, len(specs) == 1 && specs[0].ID == jobID, "expecting %q to be due, got %+v", jobID, specs)
+	_, statusCode, err = tgt.runScheduled(xid, specs[0])
+	tassert.Fatalf(t, err == nil && statusCode == http.StatusOK, "status %d (%v)", statusCode, err)
+	resp := waitDlJob(t, tgt, xid, jobID)
+	tassert.Fatalf(t, resp.ErrorCnt == 0 && resp.FinishedCnt == 1, "unexpected %+v", resp.Job)
+	tassert.Errorf(t, gets() == 1, "expected 1 GET, got %d", gets())
+	tassert.Fatalf(t, len(dueSchedules(time.Now().Add(2*time.Hour))) == 0, "not expecting the job to be due again")
+
+	// 2nd run: unchanged source
+	xid = cos.GenUUID()
+	specs = dueSchedules(time.Now().Add(4 * time.Hour))
+	tassert.Fatalf(t, len(specs) == 1, "expecting %q to be due, got %+v", jobID, specs)
+	_, statusCode, err = tgt.runScheduled(xid, specs[0])
+	tassert.Fatalf(t, err == nil && statusCode == http.StatusOK, "status %d (%v)", statusCode, err)
+	resp = waitDlJob(t, tgt, xid, jobID)
+	tassert.Fatalf(t, resp.ErrorCnt == 0 && resp.SkippedCnt == 1, "expected unchanged object to be skipped: %+v", resp.Job)
+	tassert.Errorf(t, gets() == 1, "expected no new GETs, got %d", gets())
+
+	// abort cancels the schedule
+	xdl, err := tgt.renewdl(xid)
+	tassert.CheckFatal(t, err)
+	_, _, err = xdl.AbortJob(jobID)
+	tassert.CheckFatal(t, err)
+	specs = dload.ListSchedules()
+	tassert.Errorf(t, len(specs) == 0, "aborted job must not run: %+v", specs)
+}
+
+// (as the primary does - see dlRunSchedules)
+func dueSchedules(now time.Time) (specs []*dload.ScheduleSpec) {
+	for _, spec := range dload.ListSchedules() {
+		if spec.Due(now) {
+			specs = append(specs, spec)
+		}
+	}
+	return
+}
+
+// bandwidth limit of the running job gets lifted midway
+func TestDownloadLimits(t *testing.T) {
+	const (
+		bckName = "dl-limits"
+		size    = 4 * cos.MiB
+		bps     = 256 * cos.KiB
+	)
+	var (
+		tgt     = testTarget()
+		content = make([]byte, size)
+	)
+	_, err := rand.Read(content)
+	tassert.CheckFatal(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(cos.HdrContentLength, strconv.Itoa(size))
+		w.Write(content)
+	}))
+	defer srv.Close()
+
+	bck, cleanup := initDlTest(tgt, bckName)
+	defer cleanup()
+
+	var (
+		xid   = cos.GenUUID()
+		jobID = cos.GenUUID()
+		body  = &dload.SingleBody{
+			Base:      dload.Base{Bck: bck.Clone(), Timeout: "1m", Limits: dload.Limits{BytesPerSec: bps}},
+			SingleObj: dload.SingleObj{ObjName: "obj", Link: srv.URL + "/obj"},
+		}
+		dlb = dload.Body{Type: dload.TypeSingle, RawMessage: cos.MustMarshal(body)}
+	)
+	started := time.Now()
+	_, statusCode, err := tgt.startDownload(xid, jobID, dlb, false /*run*/)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, statusCode == http.StatusOK, "status %d", statusCode)
+
+	xdl, err := tgt.renewdl(xid)
+	tassert.CheckFatal(t, err)
+	time.Sleep(time.Second)
+	v, _, err := xdl.JobStatus(jobID, true /*onlyActive*/)
+	tassert.CheckFatal(t, err)
+	resp := v.(*dload.StatusResp)
+	tassert.Fatalf(t, len(resp.CurrentTasks) == 1, "expecting the download to be in progress: %+v", resp)
+	tassert.Errorf(t, resp.CurrentTasks[0].Downloaded < 4*bps, "expecting the download to be throttled, got %d bytes",
+		resp.CurrentTasks[0].Downloaded)
+
+	// lift the limit
+	var unlimited int64
+	v, statusCode, err = xdl.SetLimits(jobID, &dload.LimitsToUpdate{BytesPerSec: &unlimited})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, statusCode == http.StatusOK && v.(bool), "expecting the limits to be applied (%d, %v)", statusCode, v)
+
+	resp = waitDlJob(t, tgt, xid, jobID)
+	tassert.Fatalf(t, resp.ErrorCnt == 0 && resp.FinishedCnt == 1, "unexpected %+v", resp.Job)
+	elapsed := time.Since(started)
+	tassert.Errorf(t, elapsed < size/bps*time.Second/2, "expecting the job to speed up, took %v", elapsed)
+
+	// not running anymore
+	limit := int64(bps)
+	v, _, err = xdl.SetLimits(jobID, &dload.LimitsToUpdate{BytesPerSec: &limit})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, !v.(bool), "not expecting the limits to be applied to finished job")
+}
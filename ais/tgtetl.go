@@ -14,16 +14,11 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
-	"github.com/NVIDIA/aistore/cmn/k8s"
 	"github.com/NVIDIA/aistore/ext/etl"
 )
 
 // [METHOD] /v1/etl
 func (t *target) etlHandler(w http.ResponseWriter, r *http.Request) {
-	if err := k8s.Detect(); err != nil {
-		t.writeErrSilent(w, r, err)
-		return
-	}
 	switch {
 	case r.Method == http.MethodPut:
 		t.handleETLPut(w, r)
@@ -59,11 +54,22 @@ func (t *target) handleETLPut(w http.ResponseWriter, r *http.Request) {
 	}
 	xid := r.URL.Query().Get(apc.QparamUUID)
 
+	if ty := initMsg.Type(); ty != etl.Builtin && ty != etl.Pipeline {
+		// (in-process transformers and pipelines run anywhere)
+		if err := etl.CheckDeployment(); err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+	}
 	switch msg := initMsg.(type) {
 	case *etl.InitSpecMsg:
 		err = etl.InitSpec(t, msg, xid, etl.StartOpts{})
 	case *etl.InitCodeMsg:
 		err = etl.InitCode(t, msg, xid)
+	case *etl.InitBuiltinMsg:
+		err = etl.InitBuiltin(t, msg, xid)
+	case *etl.InitPipelineMsg:
+		err = etl.InitPipeline(t, msg, xid)
 	default:
 		debug.Assert(false, initMsg.String())
 	}
@@ -237,6 +243,9 @@ func (t *target) getObjectETL(w http.ResponseWriter, r *http.Request) {
 		t.writeErr(w, r, err)
 		return
 	}
+	if etl.ServeStaged(w, r, objName) {
+		return // output of the previous pipeline stage
+	}
 	dpq := dpqAlloc()
 	if err := dpq.fromRawQ(r.URL.RawQuery); err != nil {
 		dpqFree(dpq)
@@ -263,6 +272,9 @@ func (t *target) headObjectETL(w http.ResponseWriter, r *http.Request) {
 		t.writeErr(w, r, err)
 		return
 	}
+	if etl.ServeStaged(w, r, objName) {
+		return // output of the previous pipeline stage
+	}
 
 	lom := cluster.AllocLOM(objName)
 	t.headObject(w, r, r.URL.Query(), bck, lom)
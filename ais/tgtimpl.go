@@ -70,6 +70,7 @@ func (t *target) PutObject(lom *cluster.LOM, params *cluster.PutObjectParams) er
 		poi.xctn = params.Xact
 		poi.owt = params.OWT
 		poi.skipEC = params.SkipEncode
+		poi.raw = params.Raw
 	}
 	if poi.owt != cmn.OwtPut {
 		poi.cksumToUse = params.Cksum
@@ -80,6 +81,7 @@ func (t *target) PutObject(lom *cluster.LOM, params *cluster.PutObjectParams) er
 }
 
 func (t *target) FinalizeObj(lom *cluster.LOM, workFQN string, xctn cluster.Xact) (errCode int, err error) {
+	lom.SetCompressed(0) // (workfiles are never compressed)
 	poi := allocPutObjInfo()
 	{
 		poi.t = t
@@ -127,6 +129,11 @@ func (t *target) CopyObject(lom *cluster.LOM, params *cluster.CopyObjectParams,
 	if params.ObjNameTo != "" {
 		objNameTo = params.ObjNameTo
 	}
+	if mdp, ok := params.DP.(cluster.MultiDP); ok && mdp.Multi() {
+		size, err = coi.copyMulti(lom, objNameTo, mdp) // (counts each produced object)
+		freeCopyObjInfo(coi)
+		return
+	}
 	if params.DP != nil { // NOTE: w/ transformation
 		size, err = coi.copyReader(lom, objNameTo)
 	} else {
@@ -168,8 +175,12 @@ func (t *target) GetCold(ctx context.Context, lom *cluster.LOM, owt cmn.OWT) (er
 		return
 	}
 
-	// 2. get from remote
-	if errCode, err = t.Backend(lom.Bck()).GetObj(ctx, lom, owt); err != nil {
+	// 2. get from remote (in parallel chunks, if configured - see tgtcoldget.go)
+	var handled bool
+	if handled, errCode, err = t.getColdChunked(ctx, lom, owt); !handled {
+		errCode, err = t.Backend(lom.Bck()).GetObj(ctx, lom, owt)
+	}
+	if err != nil {
 		if owt != cmn.OwtGetPrefetchLock {
 			lom.Unlock(true)
 		}
@@ -247,6 +258,11 @@ func (t *target) _promLocal(params *cluster.PromoteParams, lom *cluster.LOM) (fi
 	if err = lom.Load(true /*cache it*/, false /*locked*/); err == nil && !params.OverwriteDst {
 		return
 	}
+	if fi, errN := os.Stat(params.SrcFQN); errN == nil {
+		if err = t.checkQuota(lom, fi.Size()); err != nil {
+			return
+		}
+	}
 	if params.DeleteSrc {
 		// To use `params.SrcFQN` as `workFQN`, make sure both are
 		// located on the same filesystem. About "filesystem sharing" see also:
@@ -6,6 +6,7 @@ package ais
 
 import (
 	"archive/tar"
+	"bufio"
 	"context"
 	"encoding"
 	"encoding/base64"
@@ -14,6 +15,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -23,10 +25,12 @@ import (
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/compr"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/feat"
 	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/sse"
 	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
@@ -42,6 +46,9 @@ import (
 
 const fmtNested = "%s: nested (%v): failed to %s %q: %v"
 
+// max size of the object produced by `cluster.MultiDP` to buffer in memory (see coi.copyMulti)
+const maxPartSGL = 4 * cos.MiB
+
 type (
 	putObjInfo struct {
 		atime time.Time
@@ -60,7 +67,10 @@ type (
 		restful bool    // being invoked via RESTful API
 		t2t     bool    // by another target
 		skipEC  bool    // do not erasure-encode when finalizing
+		raw     bool    // on-disk (encrypted or compressed) content (see cluster.PutObjectParams)
 		skipVC  bool    // skip loading existing Version and skip comparing Checksums (skip VC)
+		// size not known in advance (e.g., chunked transfer encoding) - enforce quota upon receiving
+		chkQuota bool
 	}
 
 	getObjInfo struct {
@@ -72,6 +82,7 @@ type (
 
 		archive archiveQuery // archive query
 		ranges  byteRanges   // range read (see https://www.rfc-editor.org/rfc/rfc7233#section-2.1)
+		version string       // prior version (apc.QparamVersion)
 
 		atime      int64
 		latency    int64 // nanoseconds
@@ -118,6 +129,14 @@ type (
 		owt       cmn.OWT
 	}
 
+	// one of the multiple objects produced by `cluster.MultiDP` (see coi.copyMulti)
+	partDP struct {
+		sgl  *memsys.SGL // buffered in memory (up to `maxPartSGL`)
+		fqn  string      // or else, spilled to workfile
+		oah  cmn.ObjAttrs
+		once sync.Once
+	}
+
 	appendArchObjInfo struct {
 		started  time.Time     // started time of receiving - used to calculate the recv duration
 		r        io.ReadCloser // reader with the content of the object
@@ -160,10 +179,14 @@ func (poi *putObjInfo) do(resphdr http.Header, r *http.Request, dpq *dpq) (int,
 			poi.size = size
 		}
 	}
+	poi.chkQuota = r.ContentLength < 0
 	return poi.putObject()
 }
 
 func (poi *putObjInfo) putObject() (errCode int, err error) {
+	if poi.owt == cmn.OwtPut && !poi.t2t {
+		poi.lom.ObjAttrs().SetDefaultRetention(&poi.lom.Bprops().ObjectLock, poi.atime)
+	}
 	// PUT is a no-op if the checksums do match
 	if !poi.skipVC && !poi.cksumToUse.IsEmpty() {
 		if poi.lom.EqCksum(poi.cksumToUse) {
@@ -212,6 +235,55 @@ rerr:
 	return
 }
 
+// (object lock) PUT, APPEND, and copy cannot overwrite existing object
+// that is retained or under legal hold
+func chkLockedDst(lom *cluster.LOM, loaded bool) error {
+	if !lom.Bprops().ObjectLock.Enabled {
+		return nil
+	}
+	if loaded {
+		return lom.CheckLocked()
+	}
+	dst := cluster.AllocLOM(lom.ObjName)
+	defer cluster.FreeLOM(dst)
+	if err := dst.InitBck(lom.Bucket()); err != nil {
+		return err
+	}
+	if err := dst.Load(false /*cache it*/, false /*locked*/); err != nil {
+		return nil // (does not exist or cannot be loaded - nothing to check)
+	}
+	return dst.CheckLocked()
+}
+
+// (object lock) cannot destroy or evict bucket that holds at least one object
+// that is retained or under legal hold - in either mode, governance or compliance
+func chkLockedBck(bck *cluster.Bck) (err error) {
+	if !bck.Props.ObjectLock.Enabled {
+		return nil
+	}
+	for _, mi := range fs.GetAvail() {
+		opts := &fs.WalkOpts{Mi: mi, Bck: *bck.Bucket(), CTs: []string{fs.ObjectType}}
+		opts.Callback = func(fqn string, de fs.DirEntry) error {
+			if de.IsDir() {
+				return nil
+			}
+			lom := cluster.AllocLOM("")
+			defer cluster.FreeLOM(lom)
+			if lom.InitFQN(fqn, bck.Bucket()) != nil || lom.Load(false /*cache it*/, false /*locked*/) != nil {
+				return nil
+			}
+			return lom.CheckLocked()
+		}
+		if err = fs.Walk(opts); err != nil {
+			if cmn.IsErrObjLocked(err) {
+				return fmt.Errorf("cannot destroy (or evict) %s: %w", bck, err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
 func (poi *putObjInfo) loghdr() string {
 	s := poi.owt.String() + ", " + poi.lom.String()
 	if poi.xctn != nil { // may not be showing remote xaction (see doPut)
@@ -258,9 +330,22 @@ func (poi *putObjInfo) fini() (errCode int, err error) {
 	var (
 		lom = poi.lom
 		bck = lom.Bck()
+		wb  = poi.writeBack()
 	)
+	if poi.chkQuota {
+		if finfo, errN := os.Stat(poi.workFQN); errN == nil {
+			if err = poi.t.checkQuota(lom, finfo.Size()); err != nil {
+				return
+			}
+		}
+	}
 	// put remote
-	if bck.IsRemote() && (poi.owt == cmn.OwtPut || poi.owt == cmn.OwtFinalize || poi.owt == cmn.OwtPromote) {
+	if wb {
+		if poi.owt == cmn.OwtPut && !bck.IsRemoteAIS() {
+			// (see putRemote)
+			lom.ObjAttrs().DelCustomKeys(cmn.SourceObjMD, cmn.CRC32CObjMD, cmn.ETag, cmn.MD5ObjMD, cmn.VersionObjMD)
+		}
+	} else if bck.IsRemote() && (poi.owt == cmn.OwtPut || poi.owt == cmn.OwtFinalize || poi.owt == cmn.OwtPromote) {
 		errCode, err = poi.putRemote()
 		if err != nil {
 			loghdr := poi.loghdr()
@@ -297,9 +382,23 @@ func (poi *putObjInfo) fini() (errCode int, err error) {
 		lom.SetAtimeUnix(poi.atime.UnixNano())
 	}
 
+	// quota usage (see tgtquota.go)
+	var (
+		u     = poi.t.quotas.get(bck)
+		prior int64
+	)
+	if u != nil {
+		prior = priorSize(lom)
+	}
+
 	// ais versioning
 	if bck.IsAIS() && lom.VersionConf().Enabled {
 		if poi.owt == cmn.OwtPut || poi.owt == cmn.OwtFinalize || poi.owt == cmn.OwtPromote {
+			if !poi.skipVC && lom.KeepsHistory() {
+				if errv := lom.ArchiveVersion(); errv != nil {
+					glog.Errorf("PUT (%s): failed to retain prior version: %v", poi.loghdr(), errv)
+				}
+			}
 			if poi.skipVC {
 				err = lom.IncVersion()
 				debug.Assert(err == nil)
@@ -311,10 +410,20 @@ func (poi *putObjInfo) fini() (errCode int, err error) {
 		}
 	}
 
+	// write-back: mark prior to making the new content visible
+	if wb {
+		if err = lom.MarkWriteBack(); err != nil {
+			return
+		}
+	}
+
 	// done
 	if err = lom.RenameFile(poi.workFQN); err != nil {
 		return
 	}
+	if u != nil {
+		u.add(prior, lom.SizeOnDisk())
+	}
 	if lom.HasCopies() {
 		if errdc := lom.DelAllCopies(); errdc != nil {
 			glog.Errorf("PUT (%s): failed to delete old copies [%v], proceeding to PUT anyway...", poi.loghdr(), errdc)
@@ -323,17 +432,37 @@ func (poi *putObjInfo) fini() (errCode int, err error) {
 	if lom.AtimeUnix() == 0 { // (is set when migrating within cluster; prefetch special case)
 		lom.SetAtimeUnix(poi.atime.UnixNano())
 	}
-	err = lom.PersistMain()
+	if err = lom.PersistMain(); err == nil && wb {
+		poi.t.writeBack(lom)
+	}
 	return
 }
 
+// write-back: instead of calling backend.PutObj() synchronously, queue new content for upload;
+// that includes objects migrating within the cluster prior to their upload (those have no source)
+func (poi *putObjInfo) writeBack() bool {
+	lom := poi.lom
+	if !lom.IsWriteBack() {
+		return false
+	}
+	switch poi.owt {
+	case cmn.OwtPut, cmn.OwtFinalize, cmn.OwtPromote:
+		return true
+	case cmn.OwtMigrate:
+		src, ok := lom.GetCustomKey(cmn.SourceObjMD)
+		return (!ok || src == "") && !lom.Bck().IsRemoteAIS()
+	default:
+		return false
+	}
+}
+
 // via backend.PutObj()
 func (poi *putObjInfo) putRemote() (errCode int, err error) {
 	var (
 		lom     = poi.lom
 		backend = poi.t.Backend(lom.Bck())
 	)
-	lmfh, err := cos.NewFileHandle(poi.workFQN)
+	lmfh, err := lom.OpenFQN(poi.workFQN) // (decrypting if need be)
 	if err != nil {
 		err = cmn.NewErrFailedTo(poi.t, "open", poi.workFQN, err)
 		return
@@ -357,7 +486,13 @@ func (poi *putObjInfo) write() (err error) {
 		buf     []byte
 		slab    *memsys.Slab
 		lmfh    *os.File
+		dsize   int64 // iff compressed
 		writer  io.Writer
+		reader  io.Reader        = poi.r
+		ew      *sse.Writer      // encrypting
+		dw      *sse.DecWriter   // decrypting
+		cw      *compr.Writer    // compressing
+		cd      *compr.DecWriter // decompressing
 		writers = make([]io.Writer, 0, 4)
 		cksums  = struct {
 			store *cos.CksumHash // store with LOM
@@ -401,11 +536,55 @@ func (poi *putObjInfo) write() (err error) {
 		writers = append(writers, cksums.given.H)
 	}
 write:
-	if len(writers) == 0 {
-		written, err = io.CopyBuffer(writer, poi.r /*reader*/, buf)
-	} else {
+	switch {
+	case poi.raw && poi.lom.IsEncrypted():
+		// store as is while decrypting - to authenticate the content
+		// and compute plaintext size and checksum(s), if any
+		dw = sse.NewDecWriter(poi.plainWriter(writers))
+		writers = append(writers[:0], writer, dw)
+	case poi.raw:
+		// ditto, if compressed
+		br := bufio.NewReaderSize(poi.r, compr.HeaderSize)
+		if b, _ := br.Peek(compr.HeaderSize); compr.IsHeader(b) {
+			cd = compr.NewDecWriter(poi.plainWriter(writers))
+			writers = append(writers[:0], writer, cd)
+		} else {
+			writers = append(writers, writer)
+		}
+		reader = br
+	case poi.lom.IsEncrypted():
+		if ew, err = poi.lom.NewEncWriter(writer); err != nil {
+			return
+		}
+		writers = append(writers, ew)
+	case poi.lom.ToCompress(poi.size):
+		if cw, err = poi.lom.NewComprWriter(writer); err != nil {
+			return
+		}
+		writers = append(writers, cw)
+	default:
 		writers = append(writers, writer)
-		written, err = io.CopyBuffer(cos.NewWriterMulti(writers...), poi.r /*reader*/, buf)
+	}
+	if len(writers) == 1 {
+		written, err = io.CopyBuffer(writers[0], reader, buf)
+	} else {
+		written, err = io.CopyBuffer(cos.NewWriterMulti(writers...), reader, buf)
+	}
+	if err != nil {
+		return
+	}
+	switch {
+	case ew != nil:
+		err = ew.Close()
+	case dw != nil:
+		err = dw.Close()
+		written = dw.Size()
+	case cw != nil:
+		err = cw.Close()
+		dsize = cw.Size()
+	case cd != nil:
+		err = cd.Close()
+		written, dsize = cd.Size(), written
 	}
 	if err != nil {
 		return
@@ -431,6 +610,7 @@ write:
 	cos.Close(lmfh)
 	lmfh = nil
 	poi.lom.SetSize(written) // TODO: compare with non-zero lom.SizeBytes() that may have been set via oa.FromHeader()
+	poi.lom.SetCompressed(dsize)
 	if cksums.store != nil {
 		cksums.store.Finalize()
 		poi.lom.SetCksum(&cksums.store.Cksum)
@@ -438,6 +618,14 @@ write:
 	return
 }
 
+// when storing on-disk content as is: the writer to compute the original's checksum(s), if any
+func (*putObjInfo) plainWriter(writers []io.Writer) io.Writer {
+	if len(writers) == 0 {
+		return io.Discard
+	}
+	return cos.NewWriterMulti(writers...)
+}
+
 // post-write close & cleanup
 func (poi *putObjInfo) _cleanup(buf []byte, slab *memsys.Slab, lmfh *os.File, err error) {
 	if buf != nil {
@@ -478,7 +666,11 @@ func (poi *putObjInfo) validateCksum(c *cmn.CksumConf) (v bool) {
 func (goi *getObjInfo) getObject() (errCode int, err error) {
 	debug.Assert(!goi.unlocked)
 	goi.lom.Lock(false)
-	errCode, err = goi.get()
+	if goi.version != "" {
+		errCode, err = goi.getVersion()
+	} else {
+		errCode, err = goi.get()
+	}
 	if !goi.unlocked {
 		goi.lom.Unlock(false)
 	}
@@ -594,6 +786,35 @@ fin:
 	return
 }
 
+// GET prior version of the object (is under rlock)
+func (goi *getObjInfo) getVersion() (errCode int, err error) {
+	lom := goi.lom
+	if err = lom.Load(true /*cache it*/, true /*locked*/); err != nil {
+		if cmn.IsObjNotExist(err) {
+			errCode = http.StatusNotFound
+		}
+		return
+	}
+	if lom.Version() == goi.version {
+		_, errCode, err = goi.finalize(false /*cold GET*/)
+		return
+	}
+	vlom, err := lom.LoadVersion(goi.version)
+	if err != nil {
+		if cmn.IsErrNotFound(err) {
+			errCode = http.StatusNotFound
+		}
+		return
+	}
+	// NOTE: prior versions are read as is - no load balancing, no atime, no tiering
+	// (which is also why "cold GET" below)
+	goi.lom = vlom
+	_, errCode, err = goi.finalize(true /*cold GET*/)
+	goi.lom = lom
+	cluster.FreeLOM(vlom)
+	return
+}
+
 // - validate checksums
 // - if corrupted and IsAIS, try to recover from redundant replicas or EC slices
 // - otherwise, rely on the remote backend for recovery (tradeoff; TODO: make it configurable)
@@ -817,14 +1038,14 @@ func (goi *getObjInfo) getFromNeighbor(lom *cluster.LOM, tsi *cluster.Snode) boo
 
 func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err error) {
 	var (
-		lmfh *os.File
+		lmfh cos.LomReader
 		hrng *htrange
 		fqn  = goi.lom.FQN
 	)
 	if !coldGet && !goi.isGFN {
 		fqn = goi.lom.LBGet() // best-effort GET load balancing (see also mirror.findLeastUtilized())
 	}
-	lmfh, err = os.Open(fqn)
+	lmfh, err = goi.lom.OpenFQN(fqn) // (decrypting if need be)
 	if err != nil {
 		if os.IsNotExist(err) {
 			errCode = http.StatusNotFound
@@ -861,7 +1082,7 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, errCode int, err erro
 }
 
 // in particular, setup reader and writer and set headers
-func (goi *getObjInfo) fini(fqn string, lmfh *os.File, hdr http.Header, hrng *htrange, coldGet bool) (errCode int, err error) {
+func (goi *getObjInfo) fini(fqn string, lmfh cos.LomReader, hdr http.Header, hrng *htrange, coldGet bool) (errCode int, err error) {
 	var (
 		slab   *memsys.Slab
 		buf    []byte
@@ -952,6 +1173,9 @@ func (goi *getObjInfo) transmit(r io.Reader, buf []byte, fqn string, coldGet boo
 		}
 		goi.lom.SetAtimeUnix(goi.atime)
 		goi.lom.Recache() // GFN and cold GETs have already done this
+		if goi.lom.ToPromote() {
+			goi.t.promoteTier(goi.lom)
+		}
 	}
 	// Update objects which were sent during GFN. Thanks to this we will not
 	// have to resend them in rebalance. In case of a race between rebalance
@@ -1136,11 +1360,17 @@ func (coi *copyObjInfo) objsAdd(size int64, err error) {
 	}
 }
 
+func (coi *copyObjInfo) encDiffers(lom *cluster.LOM) bool {
+	props := coi.BckTo.Props
+	return props != nil && lom.IsEncrypted() != props.Encryption.Enabled
+}
+
 func (coi *copyObjInfo) copyObject(lom *cluster.LOM, objNameTo string) (size int64, err error) {
 	debug.Assert(coi.DP == nil)
 
 	// remote to remote: no need to create local copies - use copyReader
-	if lom.Bck().IsRemote() || coi.BckTo.IsRemote() {
+	// (ditto when encryption differs between the source and the destination)
+	if lom.Bck().IsRemote() || coi.BckTo.IsRemote() || coi.encDiffers(lom) {
 		coi.DP = &cluster.LDP{}
 		return coi.copyReader(lom, objNameTo)
 	}
@@ -1190,13 +1420,29 @@ func (coi *copyObjInfo) copyObject(lom *cluster.LOM, objNameTo string) (size int
 			if lom.EqCksum(dst.Checksum()) {
 				return
 			}
+			if err = dst.CheckLocked(); err != nil {
+				return
+			}
 		} else if cmn.IsErrBucketNought(err) {
 			return
 		}
+		if err = coi.t.checkQuota(dst, lom.SizeOnDisk()); err != nil {
+			return
+		}
+	}
+	var (
+		u     = coi.t.quotas.get(dst.Bck())
+		prior int64
+	)
+	if u != nil {
+		prior = priorSize(dst)
 	}
 	dst2, err2 := lom.Copy2FQN(dst.FQN, coi.Buf)
 	if err2 == nil {
 		size = lom.SizeBytes()
+		if u != nil && lom.Uname() != dst.Uname() {
+			u.add(prior, dst2.SizeOnDisk())
+		}
 		if coi.finalize {
 			coi.t.putMirror(dst2)
 		}
@@ -1212,6 +1458,110 @@ func (coi *copyObjInfo) copyObject(lom *cluster.LOM, objNameTo string) (size int
 // COPY READER //
 /////////////////
 
+// one-to-many: each (named) object produced by the data provider gets buffered (in memory or,
+// if large, in a workfile) and then copied as `<destination name without extension>/<name>`, e.g.:
+// "videos/a.mp4" => "videos/a/frame-0001.jpg", "videos/a/frame-0002.jpg", ...
+// Upon failure, the objects produced so far get removed - except those sent to other targets
+// (the error reports how many).
+func (coi *copyObjInfo) copyMulti(lom *cluster.LOM, objNameTo string, mdp cluster.MultiDP) (size int64, err error) {
+	var (
+		local  []string
+		remote int
+		prefix = strings.TrimSuffix(objNameTo, filepath.Ext(objNameTo)) + "/"
+	)
+	err = mdp.Walk(lom, func(name string, r io.Reader, psize int64) error {
+		pdp, err := coi.newPartDP(lom, r, psize)
+		if err != nil {
+			return err
+		}
+		tsi, err := cluster.HrwTarget(coi.BckTo.MakeUname(prefix+name), coi.t.owner.smap.Get())
+		if err != nil {
+			pdp.free()
+			return err
+		}
+		coi.DP = pdp
+		if _, err := coi.copyReader(lom, prefix+name); err != nil {
+			pdp.free() // (in case it hasn't been read)
+			return err
+		}
+		if tsi.ID() == coi.t.si.ID() {
+			local = append(local, prefix+name)
+		} else {
+			remote++
+		}
+		coi.objsAdd(pdp.oah.Size, nil)
+		size += pdp.oah.Size
+		return nil
+	})
+	coi.DP = mdp
+	if err == nil || coi.dryRun || len(local)+remote == 0 {
+		return
+	}
+	for _, name := range local {
+		dst := cluster.AllocLOM(name)
+		if errV := dst.InitBck(coi.BckTo.Bucket()); errV == nil {
+			if _, errV = coi.t.DeleteObject(dst, false /*evict*/); errV != nil && !cmn.IsObjNotExist(errV) {
+				glog.Errorf("%s: failed to clean up %s: %v", coi.t, dst, errV)
+			}
+		}
+		cluster.FreeLOM(dst)
+	}
+	if remote > 0 {
+		err = fmt.Errorf("%w (%d object%s copied to other targets under %s/%s not removed)",
+			err, remote, cos.Plural(remote), coi.BckTo, prefix)
+	}
+	return
+}
+
+func (coi *copyObjInfo) newPartDP(lom *cluster.LOM, r io.Reader, size int64) (pdp *partDP, err error) {
+	pdp = &partDP{}
+	if size <= maxPartSGL {
+		var n int64
+		pdp.sgl = coi.t.gmm.NewSGL(cos.MaxI64(size, 0))
+		if n, err = io.CopyBuffer(pdp.sgl, io.LimitReader(r, maxPartSGL+1), coi.Buf); err != nil {
+			pdp.sgl.Free()
+			return nil, err
+		}
+		if n <= maxPartSGL {
+			pdp.oah = cmn.ObjAttrs{Size: n, Cksum: cos.NoneCksum, Atime: lom.AtimeUnix()}
+			return pdp, nil
+		}
+	}
+	// spill to disk
+	var (
+		wfh *os.File
+		n   int64
+	)
+	pdp.fqn = fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileCopy)
+	if wfh, err = cos.CreateFile(pdp.fqn); err != nil {
+		if pdp.sgl != nil {
+			pdp.sgl.Free()
+		}
+		return nil, err
+	}
+	if pdp.sgl != nil {
+		n, err = io.CopyBuffer(wfh, pdp.sgl, coi.Buf)
+		pdp.sgl.Free()
+		pdp.sgl = nil
+	}
+	if err == nil {
+		var m int64
+		m, err = io.CopyBuffer(wfh, r, coi.Buf)
+		n += m
+	}
+	if errC := wfh.Close(); err == nil {
+		err = errC
+	}
+	if err != nil {
+		if errRm := cos.RemoveFile(pdp.fqn); errRm != nil {
+			glog.Errorf(fmtNested, coi.t, err, "remove", pdp.fqn, errRm)
+		}
+		return nil, err
+	}
+	pdp.oah = cmn.ObjAttrs{Size: n, Cksum: cos.NoneCksum, Atime: lom.AtimeUnix()}
+	return pdp, nil
+}
+
 // copyReader puts a new object to a cluster, according to a reader taken from coi.DP.Reader(lom) The reader returned
 // from coi.DP is responsible for any locking or source LOM, if necessary. If the reader doesn't take any locks, it has
 // to consider object content changing in the middle of copying.
@@ -1277,6 +1627,37 @@ func (coi *copyObjInfo) copyReader(lom *cluster.LOM, objNameTo string) (size int
 	return
 }
 
+////////////
+// partDP //
+////////////
+
+func (pdp *partDP) Reader(*cluster.LOM) (cos.ReadOpenCloser, cmn.ObjAttrsHolder, error) {
+	if pdp.sgl != nil {
+		r := cos.NewReaderWithArgs(cos.ReaderArgs{R: memsys.NewReader(pdp.sgl), Size: pdp.sgl.Size(), DeferCb: pdp.free})
+		return cos.NopOpener(r), &pdp.oah, nil
+	}
+	fh, err := os.Open(pdp.fqn)
+	if err != nil {
+		pdp.free()
+		return nil, nil, err
+	}
+	r := cos.NewReaderWithArgs(cos.ReaderArgs{R: fh, Size: pdp.oah.Size, DeferCb: func() {
+		fh.Close()
+		pdp.free()
+	}})
+	return cos.NopOpener(r), &pdp.oah, nil
+}
+
+func (pdp *partDP) free() {
+	pdp.once.Do(func() {
+		if pdp.sgl != nil {
+			pdp.sgl.Free()
+		} else if err := cos.RemoveFile(pdp.fqn); err != nil {
+			glog.Errorln(err)
+		}
+	})
+}
+
 func (coi *copyObjInfo) dryRunCopyReader(lom *cluster.LOM) (size int64, err error) {
 	var reader io.ReadCloser
 	if reader, _, err = coi.DP.Reader(lom); err != nil {
@@ -1507,12 +1888,26 @@ func (aaoi *appendArchObjInfo) appendObject() (errCode int, err error) {
 	if aaoi.mime != cos.ExtTar {
 		return http.StatusBadRequest, fmt.Errorf("append is supported only for %s archives", cos.ExtTar)
 	}
+	// ditto, in place
+	if aaoi.lom.IsEncrypted() || aaoi.lom.IsCompressed() {
+		return http.StatusBadRequest, fmt.Errorf("%s: append is not supported for encrypted or compressed archives", aaoi.lom)
+	}
+	var (
+		u     = aaoi.t.quotas.get(aaoi.lom.Bck())
+		prior int64
+	)
+	if u != nil {
+		prior = priorSize(aaoi.lom)
+	}
 	workFQN, err := aaoi.begin()
 	if err != nil {
 		return http.StatusInternalServerError, err
 	}
 	if err = aaoi.appendToArch(workFQN); err == nil {
 		if err = aaoi.finalize(workFQN); err == nil {
+			if u != nil {
+				u.add(prior, aaoi.lom.SizeOnDisk())
+			}
 			return 0, nil
 		}
 	}
@@ -5,11 +5,15 @@
 package ais
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -20,6 +24,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/tools/readers"
+	"github.com/NVIDIA/aistore/tools/tassert"
 )
 
 const (
@@ -38,6 +43,11 @@ type (
 	discardRW struct {
 		w io.Writer
 	}
+	// produces objects of the given sizes, and then fails (optionally)
+	multiDP struct {
+		sizes []int64
+		err   error
+	}
 )
 
 func newDiscardRW() *discardRW {
@@ -76,6 +86,10 @@ func TestMain(m *testing.M) {
 	t.statsT = mock.NewStatsTracker()
 	cluster.Init(t)
 
+	smap := newSmap()
+	smap.addTarget(t.si)
+	t.owner.smap.put(smap)
+
 	bck := cluster.NewBck(testBucket, apc.AIS, cmn.NsGlobal)
 	bmd := newBucketMD()
 	bmd.add(bck, &cmn.BucketProps{
@@ -261,3 +275,117 @@ func BenchmarkObjGetDiscard(b *testing.B) {
 		})
 	}
 }
+
+func (*multiDP) Reader(*cluster.LOM) (cos.ReadOpenCloser, cmn.ObjAttrsHolder, error) {
+	return nil, nil, errors.New("not implemented")
+}
+func (*multiDP) Multi() bool { return true }
+
+func (dp *multiDP) Walk(_ *cluster.LOM, cb func(name string, r io.Reader, size int64) error) error {
+	for i, size := range dp.sizes {
+		r, err := readers.NewRandReader(size, cos.ChecksumNone)
+		if err != nil {
+			return err
+		}
+		if err := cb(fmt.Sprintf("part-%d", i), r, -1 /*unknown*/); err != nil {
+			return err
+		}
+	}
+	return dp.err
+}
+
+// one-to-many copy: small and large (spilled to disk) objects; cleanup upon failure
+func TestCopyMulti(tst *testing.T) {
+	var (
+		bck   = cluster.NewBck(testBucket, apc.AIS, cmn.NsGlobal)
+		sizes = []int64{cos.KiB, maxPartSGL + cos.MiB, 0}
+		total int64
+	)
+	tassert.CheckFatal(tst, bck.Init(t.owner.bmd))
+	lom := cluster.AllocLOM("src.tar")
+	defer cluster.FreeLOM(lom)
+	tassert.CheckFatal(tst, lom.InitBck(bck.Bucket()))
+	r, err := readers.NewRandReader(cos.KiB, cos.ChecksumNone)
+	tassert.CheckFatal(tst, err)
+	poi := &putObjInfo{atime: time.Now(), t: t, lom: lom, r: r, workFQN: path.Join(testMountpath, "src.work")}
+	_, err = poi.putObject()
+	tassert.CheckFatal(tst, err)
+	defer os.Remove(lom.FQN)
+
+	exists := func(objName string) bool {
+		dst := cluster.AllocLOM(objName)
+		defer cluster.FreeLOM(dst)
+		tassert.CheckFatal(tst, dst.InitBck(bck.Bucket()))
+		return dst.Load(false /*cache it*/, false /*locked*/) == nil
+	}
+	for _, size := range sizes {
+		total += size
+	}
+
+	// success
+	params := &cluster.CopyObjectParams{DP: &multiDP{sizes: sizes}, BckTo: bck, ObjNameTo: "dst.tar", Buf: make([]byte, cos.KiB*32)}
+	size, err := t.CopyObject(lom, params, false /*dry-run*/)
+	tassert.CheckFatal(tst, err)
+	tassert.Errorf(tst, size == total, "expected size %d, got %d", total, size)
+	for i, size := range sizes {
+		dst := cluster.AllocLOM(fmt.Sprintf("dst/part-%d", i))
+		tassert.CheckFatal(tst, dst.InitBck(bck.Bucket()))
+		tassert.CheckFatal(tst, dst.Load(false, false))
+		tassert.Errorf(tst, dst.SizeBytes() == size, "%s: expected size %d, got %d", dst, size, dst.SizeBytes())
+		tassert.CheckError(tst, dst.Remove())
+		cluster.FreeLOM(dst)
+	}
+
+	// failure midway: the objects produced so far get removed
+	params.DP, params.ObjNameTo = &multiDP{sizes: sizes, err: errors.New("walk failed")}, "failed.tar"
+	_, err = t.CopyObject(lom, params, false /*dry-run*/)
+	tassert.Fatalf(tst, err != nil, "expecting error")
+	for i := range sizes {
+		tassert.Errorf(tst, !exists(fmt.Sprintf("failed/part-%d", i)), "part-%d must be removed", i)
+	}
+
+	// no leftover workfiles
+	entries, _ := os.ReadDir(filepath.Dir(fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileCopy)))
+	for _, e := range entries {
+		tassert.Errorf(tst, !strings.HasPrefix(e.Name(), fs.WorkfileCopy+"."+lom.ObjName), "leftover workfile %q", e.Name())
+	}
+}
+
+// (object lock) bucket can be destroyed once there are no locked objects - in compliance mode as well
+func TestChkLockedBck(tst *testing.T) {
+	bck := cluster.NewBck("compliance", apc.AIS, cmn.NsGlobal)
+	bmd := t.owner.bmd.get().clone()
+	bmd.add(bck, &cmn.BucketProps{
+		Cksum:      cmn.CksumConf{Type: cos.ChecksumNone},
+		ObjectLock: cmn.ObjLockConf{Enabled: true, Mode: cmn.ObjLockCompliance, Retention: cos.Duration(time.Hour)},
+	})
+	tassert.CheckFatal(tst, t.owner.bmd.putPersist(bmd, nil))
+	fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)
+	tassert.CheckFatal(tst, bck.Init(t.owner.bmd))
+
+	putRetained := func(objName string, until time.Time) {
+		lom := cluster.AllocLOM(objName)
+		defer cluster.FreeLOM(lom)
+		tassert.CheckFatal(tst, lom.InitBck(bck.Bucket()))
+		tassert.CheckFatal(tst, os.WriteFile(lom.FQN, []byte("retained"), cos.PermRWR))
+		lom.SetSize(int64(len("retained")))
+		lom.SetAtimeUnix(time.Now().UnixNano())
+		lom.ObjAttrs().SetRetention(cmn.ObjLockCompliance, until)
+		tassert.CheckFatal(tst, lom.Persist())
+	}
+
+	// empty
+	tassert.CheckError(tst, chkLockedBck(bck))
+
+	// retention expired
+	putRetained("expired", time.Now().Add(-time.Minute))
+	tassert.CheckError(tst, chkLockedBck(bck))
+
+	// retained
+	putRetained("retained", time.Now().Add(time.Hour))
+	var (
+		err  = chkLockedBck(bck)
+		errL *cmn.ErrObjLocked
+	)
+	tassert.Errorf(tst, errors.As(err, &errL), "expecting object locked, got %v", err)
+}
@@ -0,0 +1,350 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Quotas (see cmn.QuotaConf)
+// - each target enforces its own share of the configured limits: limit divided by the number
+//   of active targets (which is how HRW distributes objects);
+// - the target's usage of a given bucket (number of objects and their size on disk) is kept
+//   incrementally upon PUT (and everything else that goes through poi.fini), APPEND, local copy,
+//   and DELETE;
+// - usage gets counted (by walking the bucket) upon the first access after startup and re-synced
+//   upon mountpath events (see `quotas.invalidate`); the count is applied as a delta, so that
+//   concurrent updates are not lost; until the initial count completes, writes wait for it
+//   (up to `quotaSyncWait`) and then get checked against the usage counted so far;
+// - namespace usage is the sum over all its buckets - kept incrementally as well (see `nsUsage`);
+// - PUT with unknown size (chunked) is checked upon receiving the content (see poi.chkQuota),
+//   while APPEND is charged additively (and the final flush - as an overwrite).
+
+// max time to wait for the initial count
+const quotaSyncWait = 2 * time.Second
+
+type (
+	bckUsage struct {
+		ns      *nsUsage
+		ready   chan struct{} // closed upon the initial count
+		objs    atomic.Int64
+		size    atomic.Int64
+		synced  atomic.Int64 // mono-time of the last completed re-sync (zero - not yet)
+		syncing atomic.Bool
+		stale   atomic.Bool // needs to be re-synced (mountpath event)
+		soft    atomic.Bool // soft limit exceeded (and logged)
+		bid     uint64
+	}
+	nsUsage struct {
+		objs    atomic.Int64
+		size    atomic.Int64
+		ranging atomic.Bool
+		ranged  atomic.Bool // all buckets in the namespace are accounted for
+		soft    atomic.Bool // (see bckUsage.soft)
+	}
+	quotas struct {
+		m  sync.Map // bucket uname => *bckUsage
+		ns sync.Map // namespace uname => *nsUsage
+	}
+)
+
+// returns nil if the bucket is not subject to quotas
+func (q *quotas) get(bck *cluster.Bck) *bckUsage {
+	if !bck.Props.Quota.IsSet() && cmn.GCO.Get().Quota.Get(bck.Ns) == nil {
+		return nil
+	}
+	uname := bck.MakeUname("")
+	v, ok := q.m.Load(uname)
+	if !ok {
+		v, _ = q.m.LoadOrStore(uname, q.newUsage(bck))
+	}
+	u := v.(*bckUsage)
+	if u.bid != bck.Props.BID { // same name, different bucket (destroyed and recreated)
+		u.ns.sub(u)
+		u = q.newUsage(bck)
+		q.m.Store(uname, u)
+	}
+	if (u.synced.Load() == 0 || u.stale.Load()) && u.syncing.CAS(false, true) {
+		go u.resync(cluster.CloneBck(bck.Bucket()))
+	}
+	return u
+}
+
+func (q *quotas) newUsage(bck *cluster.Bck) *bckUsage {
+	return &bckUsage{ns: q.nsGet(bck.Ns), ready: make(chan struct{}), bid: bck.Props.BID}
+}
+
+// upon destroying buckets (see _postBMD)
+func (q *quotas) remove(bcks ...*cluster.Bck) {
+	for _, bck := range bcks {
+		if v, ok := q.m.LoadAndDelete(bck.MakeUname("")); ok {
+			u := v.(*bckUsage)
+			u.ns.sub(u)
+		}
+	}
+}
+
+// upon mountpath events: usage of all buckets gets re-synced upon next access
+func (q *quotas) invalidate() {
+	q.m.Range(func(_, v any) bool {
+		v.(*bckUsage).stale.Store(true)
+		return true
+	})
+}
+
+func (q *quotas) nsGet(ns cmn.Ns) *nsUsage {
+	uname := ns.Uname()
+	if v, ok := q.ns.Load(uname); ok {
+		return v.(*nsUsage)
+	}
+	v, _ := q.ns.LoadOrStore(uname, &nsUsage{})
+	return v.(*nsUsage)
+}
+
+// namespace usage; the first call (with namespace quota configured) makes sure
+// that all the namespace's buckets are accounted for
+func (q *quotas) nsUsage(bmd *bucketMD, ns cmn.Ns) *nsUsage {
+	n := q.nsGet(ns)
+	if !n.ranged.Load() && n.ranging.CAS(false, true) {
+		bmd.Range(nil, &ns, func(bck *cluster.Bck) bool {
+			q.get(bck)
+			return false
+		})
+		n.ranged.Store(true)
+	}
+	return n
+}
+
+/////////////
+// nsUsage //
+/////////////
+
+func (n *nsUsage) sub(u *bckUsage) {
+	n.objs.Sub(u.objs.Load())
+	n.size.Sub(u.size.Load())
+}
+
+//////////////
+// bckUsage //
+//////////////
+
+// prior < 0: new object
+func (u *bckUsage) add(prior, size int64) {
+	if prior < 0 {
+		u.objs.Inc()
+		u.ns.objs.Inc()
+	} else {
+		size -= prior
+	}
+	u.size.Add(size)
+	u.ns.size.Add(size)
+}
+
+func (u *bckUsage) del(size int64) {
+	u.objs.Dec()
+	u.size.Sub(size)
+	u.ns.objs.Dec()
+	u.ns.size.Sub(size)
+}
+
+// waits (bounded) for the initial count
+func (u *bckUsage) wait() {
+	if u.synced.Load() != 0 {
+		return
+	}
+	timer := time.NewTimer(quotaSyncWait)
+	select {
+	case <-u.ready:
+	case <-timer.C:
+	}
+	timer.Stop()
+}
+
+func (u *bckUsage) resync(bck *cluster.Bck) {
+	var (
+		objs, size     atomic.Int64
+		wg             = &sync.WaitGroup{}
+		availablePaths = fs.GetAvail()
+		started        = mono.NanoTime()
+		objs0, size0   = u.objs.Load(), u.size.Load()
+	)
+	u.stale.Store(false)
+	for _, mi := range availablePaths {
+		wg.Add(1)
+		go func(mi *fs.Mountpath) {
+			defer wg.Done()
+			opts := &fs.WalkOpts{
+				Mi:  mi,
+				Bck: *bck.Bucket(),
+				CTs: []string{fs.ObjectType},
+				Callback: func(fqn string, de fs.DirEntry) error {
+					if de.IsDir() {
+						return nil
+					}
+					lom := cluster.AllocLOM("")
+					if lom.InitFQN(fqn, bck.Bucket()) == nil && lom.IsHRW() { // (skipping copies)
+						if finfo, err := os.Stat(fqn); err == nil {
+							objs.Inc()
+							size.Add(finfo.Size())
+						}
+					}
+					cluster.FreeLOM(lom)
+					return nil
+				},
+			}
+			if err := fs.Walk(opts); err != nil && !cmn.IsErrBucketNought(err) && !os.IsNotExist(err) {
+				glog.Errorf("%s: failed to count quota usage: %v", bck, err)
+			}
+		}(mi)
+	}
+	wg.Wait()
+	// reconcile: add the difference between the count and the usage at the start of the walk
+	// (rather than overwriting the usage that may have been updated in the meantime)
+	dobjs, dsize := objs.Load()-objs0, size.Load()-size0
+	u.objs.Add(dobjs)
+	u.size.Add(dsize)
+	u.ns.objs.Add(dobjs)
+	u.ns.size.Add(dsize)
+	if u.synced.Swap(mono.NanoTime()) == 0 {
+		close(u.ready)
+	}
+	u.syncing.Store(false)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: quota usage %d objects, %s (%v)", bck, objs.Load(), cos.ToSizeIEC(size.Load(), 2),
+			mono.Since(started))
+	}
+}
+
+////////////
+// target //
+////////////
+
+// checkQuota is called prior to writing (or overwriting) the object with `size` bytes
+// (negative when unknown); returns cmn.ErrQuotaExceeded if the write would exceed
+// (this target's share of) the bucket's or the namespace's hard limit
+func (t *target) checkQuota(lom *cluster.LOM, size int64) error {
+	return t._checkQuota(lom, size, false /*append*/)
+}
+
+// same as above except that APPEND adds `size` bytes to the object
+func (t *target) checkQuotaAppend(lom *cluster.LOM, size int64) error {
+	return t._checkQuota(lom, size, true /*append*/)
+}
+
+func (t *target) _checkQuota(lom *cluster.LOM, size int64, apnd bool) error {
+	var (
+		bck    = lom.Bck()
+		nsconf = cmn.GCO.Get().Quota.Get(bck.Ns)
+		u      = t.quotas.get(bck)
+	)
+	if u == nil {
+		return nil
+	}
+	// prior to the initial count, the usage counted so far (i.e., a lower bound) is enforced
+	u.wait()
+
+	dobjs, dsize := int64(1), cos.MaxI64(size, 0)
+	if prior := priorSize(lom); prior >= 0 {
+		dobjs = 0
+		if !apnd {
+			dsize -= prior
+		}
+	}
+	ntargets := int64(cos.Max(t.owner.smap.get().CountActiveTs(), 1))
+	if conf := &bck.Props.Quota; conf.IsSet() {
+		objs, size := u.objs.Load()+dobjs, u.size.Load()+dsize
+		if err := chkQuota(conf, bck.String(), objs, size, ntargets, &u.soft); err != nil {
+			return err
+		}
+	}
+	if nsconf != nil {
+		n := t.quotas.nsUsage(t.owner.bmd.get(), bck.Ns)
+		objs, size := n.objs.Load()+dobjs, n.size.Load()+dsize
+		return chkQuota(nsconf, "namespace "+bck.Ns.String(), objs, size, ntargets, &n.soft)
+	}
+	return nil
+}
+
+// PUT and APPEND via RESTful API: APPEND (including APPEND to archive) is charged additively,
+// while flush - as an overwrite, upon promoting the appended content (see _promLocal)
+func (t *target) checkQuotaPut(lom *cluster.LOM, r *http.Request, dpq *dpq) error {
+	size := r.ContentLength
+	switch {
+	case dpq.archpath != "": // apc.QparamArchpath
+		return t.checkQuotaAppend(lom, size)
+	case dpq.appendTy == apc.AppendOp:
+		return t.checkQuotaAppend(lom, appendedSize(dpq.appendHdl)+cos.MaxI64(size, 0))
+	case dpq.appendTy != "":
+		debug.Assert(dpq.appendTy == apc.FlushOp)
+		return nil
+	default:
+		return t.checkQuota(lom, size) // (when size < 0, see also poi.chkQuota)
+	}
+}
+
+func chkQuota(conf *cmn.QuotaConf, scope string, objs, size, ntargets int64, soft *atomic.Bool) error {
+	if limit := quotaShare(conf.Hard.Objects, ntargets); limit > 0 && objs > limit {
+		return cmn.NewErrQuotaExceeded(scope, cmn.QuotaObjects, objs, limit)
+	}
+	if limit := quotaShare(int64(conf.Hard.Size), ntargets); limit > 0 && size > limit {
+		return cmn.NewErrQuotaExceeded(scope, cmn.QuotaSize, size, limit)
+	}
+	var (
+		olimit = quotaShare(conf.Soft.Objects, ntargets)
+		slimit = quotaShare(int64(conf.Soft.Size), ntargets)
+	)
+	if (olimit > 0 && objs > olimit) || (slimit > 0 && size > slimit) {
+		if !soft.Swap(true) {
+			glog.Warningf("%s: soft quota exceeded: %d objects, %s (%s)", scope, objs, cos.ToSizeIEC(size, 2),
+				conf.Soft.String())
+		}
+	} else {
+		soft.Store(false)
+	}
+	return nil
+}
+
+func quotaShare(limit, ntargets int64) int64 {
+	if limit <= 0 {
+		return 0
+	}
+	return (limit + ntargets - 1) / ntargets
+}
+
+// returns the size on disk of the object that is about to be overwritten,
+// or -1 if it does not exist
+func priorSize(lom *cluster.LOM) int64 {
+	finfo, err := os.Stat(lom.FQN)
+	if err != nil {
+		return -1
+	}
+	return finfo.Size()
+}
+
+// APPEND: size of the content appended so far (see appendObjInfo)
+func appendedSize(handle string) int64 {
+	hi, err := parseAppendHandle(handle)
+	if err != nil || hi.filePath == "" {
+		return 0
+	}
+	finfo, err := os.Stat(hi.filePath)
+	if err != nil {
+		return 0
+	}
+	return finfo.Size()
+}
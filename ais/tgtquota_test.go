@@ -0,0 +1,181 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/readers"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func quotaBck(tst *testing.T, name string) *cluster.Bck {
+	return addQuotaBck(tst, name, cmn.NsGlobal, cmn.QuotaConf{Hard: cmn.QuotaLimits{Objects: 3, Size: 10 * cos.KiB}})
+}
+
+func addQuotaBck(tst *testing.T, name string, ns cmn.Ns, quota cmn.QuotaConf) *cluster.Bck {
+	bck := cluster.NewBck(name, apc.AIS, ns)
+	if bck.Init(t.owner.bmd) == nil {
+		return bck
+	}
+	bmd := t.owner.bmd.get().clone()
+	bmd.add(bck, &cmn.BucketProps{Cksum: cmn.CksumConf{Type: cos.ChecksumNone}, Quota: quota})
+	tassert.CheckFatal(tst, t.owner.bmd.putPersist(bmd, nil))
+	fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)
+	tassert.CheckFatal(tst, bck.Init(t.owner.bmd))
+	return bck
+}
+
+// returns bucket usage upon completing the (re)count
+func quotaUsage(tst *testing.T, bck *cluster.Bck) *bckUsage {
+	u := t.quotas.get(bck)
+	tassert.Fatalf(tst, u != nil, "%s: expecting quota usage", bck)
+	for i := 0; u.synced.Load() == 0 || u.stale.Load() || u.syncing.Load(); i++ {
+		tassert.Fatalf(tst, i < 100, "%s: quota usage not synced", bck)
+		time.Sleep(50 * time.Millisecond)
+	}
+	return u
+}
+
+func quotaPut(tst *testing.T, bck *cluster.Bck, objName string, size int64, chunked bool) error {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	tassert.CheckFatal(tst, lom.InitBck(bck.Bucket()))
+	r, err := readers.NewRandReader(size, cos.ChecksumNone)
+	tassert.CheckFatal(tst, err)
+	poi := &putObjInfo{
+		atime:    time.Now(),
+		t:        t,
+		lom:      lom,
+		r:        r,
+		size:     size,
+		workFQN:  path.Join(testMountpath, objName+".work"),
+		chkQuota: chunked,
+	}
+	if !chunked {
+		if err := t.checkQuota(lom, size); err != nil {
+			return err
+		}
+	}
+	_, err = poi.putObject()
+	return err
+}
+
+func TestQuotaEnforcement(tst *testing.T) {
+	bck := quotaBck(tst, "quota-enforce")
+	u := quotaUsage(tst, bck)
+
+	tassert.CheckFatal(tst, quotaPut(tst, bck, "obj1", 4*cos.KiB, false))
+	tassert.Errorf(tst, u.objs.Load() == 1 && u.size.Load() == 4*cos.KiB, "unexpected usage %d, %d",
+		u.objs.Load(), u.size.Load())
+
+	// size: 4KiB + 8KiB > 10KiB
+	err := quotaPut(tst, bck, "obj2", 8*cos.KiB, false)
+	tassert.Fatalf(tst, cmn.IsErrQuotaExceeded(err), "expecting quota exceeded, got %v", err)
+
+	// chunked (size not known in advance) - enforced upon receiving
+	err = quotaPut(tst, bck, "obj2", 8*cos.KiB, true /*chunked*/)
+	tassert.Fatalf(tst, cmn.IsErrQuotaExceeded(err), "expecting quota exceeded, got %v", err)
+	lom := cluster.AllocLOM("obj2")
+	defer cluster.FreeLOM(lom)
+	tassert.CheckFatal(tst, lom.InitBck(bck.Bucket()))
+	tassert.Errorf(tst, lom.Load(false, false) != nil, "%s must not exist", lom)
+	tassert.Errorf(tst, u.objs.Load() == 1 && u.size.Load() == 4*cos.KiB, "unexpected usage %d, %d",
+		u.objs.Load(), u.size.Load())
+
+	// overwrite: 8KiB replaces 4KiB
+	obj1 := cluster.AllocLOM("obj1")
+	defer cluster.FreeLOM(obj1)
+	tassert.CheckFatal(tst, obj1.InitBck(bck.Bucket()))
+	tassert.CheckError(tst, t.checkQuota(obj1, 8*cos.KiB))
+
+	// append: 4KiB + 8KiB
+	err = t.checkQuotaAppend(obj1, 8*cos.KiB)
+	tassert.Errorf(tst, cmn.IsErrQuotaExceeded(err), "expecting quota exceeded, got %v", err)
+	tassert.CheckError(tst, t.checkQuotaAppend(obj1, 4*cos.KiB))
+
+	// number of objects
+	tassert.CheckFatal(tst, quotaPut(tst, bck, "obj2", cos.KiB, false))
+	tassert.CheckFatal(tst, quotaPut(tst, bck, "obj3", cos.KiB, true /*chunked*/))
+	err = quotaPut(tst, bck, "obj4", cos.KiB, false)
+	tassert.Errorf(tst, cmn.IsErrQuotaExceeded(err), "expecting quota exceeded, got %v", err)
+}
+
+func TestQuotaResync(tst *testing.T) {
+	bck := quotaBck(tst, "quota-resync")
+	u := quotaUsage(tst, bck)
+
+	tassert.CheckFatal(tst, quotaPut(tst, bck, "obj-resync", 2*cos.KiB, false))
+	objs, size := u.objs.Load(), u.size.Load()
+
+	// usage that got out of sync (e.g., removed bypassing the accounting) gets reconciled
+	// upon mountpath event
+	u.add(-1, cos.KiB)
+	t.quotas.invalidate()
+	u = quotaUsage(tst, bck)
+	tassert.Errorf(tst, u.objs.Load() == objs && u.size.Load() == size, "expecting usage %d, %d, got %d, %d",
+		objs, size, u.objs.Load(), u.size.Load())
+}
+
+// prior to the initial count, the usage counted so far gets enforced
+func TestQuotaNotSynced(tst *testing.T) {
+	bck := quotaBck(tst, "quota-not-synced")
+	uname := bck.MakeUname("")
+	u := t.quotas.newUsage(bck)
+	u.syncing.Store(true) // (counting in progress)
+	t.quotas.m.Store(uname, u)
+	defer t.quotas.m.Delete(uname)
+
+	lom := cluster.AllocLOM("obj-not-synced")
+	defer cluster.FreeLOM(lom)
+	tassert.CheckFatal(tst, lom.InitBck(bck.Bucket()))
+	tassert.CheckError(tst, t.checkQuota(lom, cos.KiB))
+
+	u.add(-1, 10*cos.KiB)
+	started := time.Now()
+	err := t.checkQuota(lom, cos.KiB)
+	tassert.Errorf(tst, cmn.IsErrQuotaExceeded(err), "expecting quota exceeded, got %v", err)
+	tassert.Errorf(tst, time.Since(started) >= quotaSyncWait, "expecting to wait for the initial count")
+}
+
+func TestQuotaNamespace(tst *testing.T) {
+	var (
+		ns   = cmn.Ns{Name: "quota-ns"}
+		bck1 = addQuotaBck(tst, "quota-ns-1", ns, cmn.QuotaConf{})
+		bck2 = addQuotaBck(tst, "quota-ns-2", ns, cmn.QuotaConf{})
+	)
+	config := cmn.GCO.BeginUpdate()
+	config.Quota.Namespaces = map[string]cmn.QuotaConf{ns.String(): {Hard: cmn.QuotaLimits{Objects: 3}}}
+	cmn.GCO.CommitUpdate(config)
+	defer func() {
+		config := cmn.GCO.BeginUpdate()
+		config.Quota.Namespaces = nil
+		cmn.GCO.CommitUpdate(config)
+	}()
+	quotaUsage(tst, bck1)
+	quotaUsage(tst, bck2)
+
+	tassert.CheckFatal(tst, quotaPut(tst, bck1, "obj1", cos.KiB, false))
+	tassert.CheckFatal(tst, quotaPut(tst, bck1, "obj2", cos.KiB, false))
+	tassert.CheckFatal(tst, quotaPut(tst, bck2, "obj1", cos.KiB, false))
+	n := t.quotas.nsUsage(t.owner.bmd.get(), ns)
+	tassert.Errorf(tst, n.objs.Load() == 3 && n.size.Load() == 3*cos.KiB, "unexpected namespace usage %d, %d",
+		n.objs.Load(), n.size.Load())
+
+	err := quotaPut(tst, bck2, "obj2", cos.KiB, false)
+	tassert.Errorf(tst, cmn.IsErrQuotaExceeded(err), "expecting quota exceeded, got %v", err)
+
+	// destroyed bucket no longer counts
+	t.quotas.remove(bck1)
+	tassert.Errorf(tst, n.objs.Load() == 1, "unexpected namespace usage %d", n.objs.Load())
+	tassert.CheckError(tst, quotaPut(tst, bck2, "obj2", cos.KiB, false))
+}
@@ -34,15 +34,20 @@ func (t *target) s3Handler(w http.ResponseWriter, r *http.Request) {
 	case http.MethodGet:
 		t.getObjS3(w, r, apiItems)
 	case http.MethodPut:
+		r.Body = s3.NewRedirectedPayloadReader(r)
 		t.putCopyMpt(w, r, apiItems)
 	case http.MethodDelete:
 		q := r.URL.Query()
-		if q.Has(s3.QparamMptUploadID) {
+		switch {
+		case q.Has(s3.QparamMptUploadID):
 			t.abortMptUpload(w, r, apiItems, q)
-		} else {
+		case q.Has(s3.QparamTagging):
+			t.delObjTaggingS3(w, r, apiItems)
+		default:
 			t.delObjS3(w, r, apiItems)
 		}
 	case http.MethodPost:
+		r.Body = s3.NewRedirectedPayloadReader(r)
 		t.postObjS3(w, r, apiItems)
 	default:
 		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPost)
@@ -63,6 +68,12 @@ func (t *target) putCopyMpt(w http.ResponseWriter, r *http.Request, items []stri
 	}
 	q := r.URL.Query()
 	switch {
+	case q.Has(s3.QparamTagging):
+		t.putObjTaggingS3(w, r, items, bck)
+	case q.Has(s3.QparamRetention):
+		t.putObjRetentionS3(w, r, items, bck)
+	case q.Has(s3.QparamLegalHold):
+		t.putObjLegalHoldS3(w, r, items, bck)
 	case q.Has(s3.QparamMptPartNo) && q.Has(s3.QparamMptUploadID):
 		if r.Header.Get(cos.S3HdrObjSrc) != "" {
 			t.putMptCopy(w, r, items)
@@ -175,6 +186,34 @@ func (t *target) putObjS3(w http.ResponseWriter, r *http.Request, items []string
 
 	// TODO: dual checksumming, e.g. lom.SetCustom(apc.AWS, ...)
 
+	if hdr := r.Header.Get(cos.S3HdrTagging); hdr != "" {
+		tags, err := s3.ParseTaggingHdr(hdr)
+		if err != nil {
+			s3.WriteErr(w, r, err, 0)
+			return
+		}
+		lom.ObjAttrs().SetTags(tags)
+	}
+	if err := chkLockedDst(lom, false /*loaded*/); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if err := t.checkQuota(lom, r.ContentLength); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if s3.HasObjLockHdrs(r.Header) {
+		if !bck.Props.ObjectLock.Enabled {
+			err := fmt.Errorf("%s: bucket %s is not object lock enabled", t.si, bck)
+			s3.WriteErr(w, r, err, 0)
+			return
+		}
+		if err := s3.ParseObjLockHdrs(r.Header, lom.ObjAttrs()); err != nil {
+			s3.WriteErr(w, r, err, 0)
+			return
+		}
+	}
+
 	dpq := dpqAlloc()
 	defer dpqFree(dpq)
 	if err := dpq.fromRawQ(r.URL.RawQuery); err != nil {
@@ -217,6 +256,14 @@ func (t *target) getObjS3(w http.ResponseWriter, r *http.Request, items []string
 		return
 	}
 	objName := s3.ObjName(items)
+	if q.Has(s3.QparamTagging) {
+		t.getObjTaggingS3(w, r, bck, objName)
+		return
+	}
+	if subres := objLockSubres(q); subres != "" {
+		t.getObjLockS3(w, r, bck, objName, subres)
+		return
+	}
 	if q.Has(s3.QparamMptPartNo) {
 		t.getMptPart(w, r, bck, objName, q)
 		return
@@ -299,6 +346,10 @@ func (t *target) headObjS3(w http.ResponseWriter, r *http.Request, items []strin
 	if v, ok := custom[cos.HdrContentType]; ok {
 		hdr.Set(cos.HdrContentType, v)
 	}
+	if tags := op.GetTags(); len(tags) > 0 {
+		hdr.Set(cos.S3HdrTaggingCount, strconv.Itoa(len(tags)))
+	}
+	s3.SetObjLockHdrs(hdr, &op.ObjAttrs)
 	// e.g. https://docs.aws.amazon.com/AmazonS3/latest/API/API_HeadObject.html#API_HeadObject_Examples
 	// (compare w/ `p.listObjectsS3()`
 	lastModified := cos.FormatNanoTime(op.Atime, cos.RFC1123GMT)
@@ -326,6 +377,12 @@ func (t *target) delObjS3(w http.ResponseWriter, r *http.Request, items []string
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if cos.IsParseBool(r.Header.Get(cos.S3HdrBypassGovernance)) {
+		if errCode, err := t.bypassGovernance(lom); err != nil {
+			s3.WriteErr(w, r, err, errCode)
+			return
+		}
+	}
 	errCode, err = t.DeleteObject(lom, false)
 	if err != nil {
 		name := lom.FullName()
@@ -0,0 +1,152 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// S3 object lock: retention and legal hold are stored as LOM custom metadata (see `cmn.ObjLockMDPrefix`)
+
+// GET /s3/<bucket-name>/<object-name>?retention|legal-hold
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectRetention.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectLegalHold.html
+func (t *target) getObjLockS3(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName, subres string) {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if errCode, err := t.loadTaggedLOM(lom, bck, false /*locked*/); err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	var (
+		oa  = lom.ObjAttrs()
+		sgl = t.gmm.NewSGL(0)
+	)
+	if subres == s3.QparamLegalHold {
+		s3.NewLegalHold(oa.LegalHold()).MustMarshal(sgl)
+	} else {
+		mode, until := oa.Retention()
+		if until.IsZero() {
+			sgl.Free()
+			err := cmn.NewErrNotFound("%s: object %s has no retention configured", t.si, lom.FullName())
+			s3.WriteErr(w, r, err, http.StatusNotFound)
+			return
+		}
+		s3.NewRetention(mode, until).MustMarshal(sgl)
+	}
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>/<object-name>?retention
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectRetention.html
+func (t *target) putObjRetentionS3(w http.ResponseWriter, r *http.Request, items []string, bck *cluster.Bck) {
+	if len(items) < 2 {
+		s3.WriteErr(w, r, errS3Obj, 0)
+		return
+	}
+	retention := &s3.Retention{}
+	if err := s3.DecodeXML(r.Body, retention); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	mode, until, err := retention.Parse()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	bypass := cos.IsParseBool(r.Header.Get(cos.S3HdrBypassGovernance))
+	errCode, err := t.updateObjLock(s3.ObjName(items), bck, func(lom *cluster.LOM) error {
+		oa := lom.ObjAttrs()
+		if err := oa.CheckRetentionUpdate(lom.String(), mode, until, bypass, time.Now()); err != nil {
+			return err
+		}
+		oa.SetRetention(mode, until)
+		return nil
+	})
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+	}
+}
+
+// PUT /s3/<bucket-name>/<object-name>?legal-hold
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectLegalHold.html
+func (t *target) putObjLegalHoldS3(w http.ResponseWriter, r *http.Request, items []string, bck *cluster.Bck) {
+	if len(items) < 2 {
+		s3.WriteErr(w, r, errS3Obj, 0)
+		return
+	}
+	legalHold := &s3.LegalHold{}
+	if err := s3.DecodeXML(r.Body, legalHold); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	on, err := legalHold.On()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	errCode, err := t.updateObjLock(s3.ObjName(items), bck, func(lom *cluster.LOM) error {
+		lom.ObjAttrs().SetLegalHold(on)
+		return nil
+	})
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+	}
+}
+
+// load (under write lock), update, and persist
+func (t *target) updateObjLock(objName string, bck *cluster.Bck, update func(lom *cluster.LOM) error) (int, error) {
+	if !bck.Props.ObjectLock.Enabled {
+		return http.StatusBadRequest, fmt.Errorf("%s: bucket %s is not object lock enabled", t.si, bck)
+	}
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if errCode, err := t.loadTaggedLOM(lom, bck, true /*locked*/); err != nil {
+		return errCode, err
+	}
+	if err := update(lom); err != nil {
+		return http.StatusForbidden, err
+	}
+	if err := lom.Persist(); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return 0, nil
+}
+
+// remove governance-mode retention prior to deleting the object
+// (is called only with explicit governance bypass)
+func (t *target) bypassGovernance(lom *cluster.LOM) (int, error) {
+	if !lom.Bprops().ObjectLock.Enabled {
+		return 0, nil
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return 0, nil // (delete will handle it)
+	}
+	oa := lom.ObjAttrs()
+	if err := oa.CheckRetentionUpdate(lom.String(), "", time.Time{}, true /*bypass*/, time.Now()); err != nil {
+		return http.StatusForbidden, err
+	}
+	if _, until := oa.Retention(); until.IsZero() {
+		return 0, nil
+	}
+	oa.SetRetention("", time.Time{})
+	if err := lom.Persist(); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return 0, nil
+}
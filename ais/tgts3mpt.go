@@ -5,7 +5,6 @@
 package ais
 
 import (
-	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
@@ -101,14 +100,25 @@ func (t *target) putMptPart(w http.ResponseWriter, r *http.Request, items []stri
 		cksumSHA  *cos.CksumHash
 		partSHA   string
 		mwriter   io.Writer
+		fw        io.WriteCloser = fh
 	)
+	if lom.IsEncrypted() {
+		if fw, err = lom.NewEncWriter(fh); err != nil {
+			cos.Close(fh)
+			s3.WriteErr(w, r, err, 0)
+			return
+		}
+	}
 	if partSHA = r.Header.Get(cos.S3HdrContentSHA256); partSHA != "" {
 		cksumSHA = cos.NewCksumHash(cos.ChecksumSHA256)
-		mwriter = io.MultiWriter(cksumMD5.H, cksumSHA.H, fh)
+		mwriter = io.MultiWriter(cksumMD5.H, cksumSHA.H, fw)
 	} else {
-		mwriter = io.MultiWriter(cksumMD5.H, fh)
+		mwriter = io.MultiWriter(cksumMD5.H, fw)
 	}
 	size, err := io.CopyBuffer(mwriter, r.Body, buf)
+	if err == nil && lom.IsEncrypted() {
+		err = fw.Close()
+	}
 	cos.Close(fh)
 	slab.Free(buf)
 	if err != nil {
@@ -156,7 +166,7 @@ func (t *target) startMpt(w http.ResponseWriter, r *http.Request, items []string
 	}
 
 	uploadID := cos.GenUUID()
-	s3.InitUpload(uploadID, bck.Name, objName)
+	s3.InitUpload(uploadID, bck.Bucket(), objName)
 	result := &s3.InitiateMptUploadResult{Bucket: bck.Name, Key: objName, UploadID: uploadID}
 
 	sgl := t.gmm.NewSGL(0)
@@ -178,9 +188,8 @@ func (t *target) completeMpt(w http.ResponseWriter, r *http.Request, items []str
 		s3.WriteErr(w, r, errors.New("empty uploadId"), 0)
 		return
 	}
-	decoder := xml.NewDecoder(r.Body)
 	partList := &s3.CompleteMptUpload{}
-	if err := decoder.Decode(partList); err != nil {
+	if err := s3.DecodeXML(r.Body, partList); err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
@@ -194,9 +203,13 @@ func (t *target) completeMpt(w http.ResponseWriter, r *http.Request, items []str
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
-
+	if err := chkLockedDst(lom, false /*loaded*/); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
 	// steps 1-...
 	var (
+		fh          *os.File
 		obj         io.WriteCloser
 		objWorkfile string
 		mwriter     io.Writer
@@ -213,36 +226,59 @@ func (t *target) completeMpt(w http.ResponseWriter, r *http.Request, items []str
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	var total int64
+	for _, part := range nparts {
+		total += part.Size
+	}
+	if err := t.checkQuota(lom, total); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
 	// 3. append all parts and, separately, their respective MD5s
 	buf, slab := t.gmm.Alloc()
 	defer slab.Free(buf)
 
 	prefix := fmt.Sprintf("%s.%s", uploadID, "complete") // <upload-id>.complete.<obj-name>
 	objWorkfile = fs.CSM.Gen(lom, fs.WorkfileType, prefix)
-	obj, err = os.Create(objWorkfile)
+	fh, err = os.Create(objWorkfile)
 	if err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	obj = fh
+	if lom.IsEncrypted() {
+		if obj, err = lom.NewEncWriter(fh); err != nil {
+			cos.Close(fh)
+			s3.WriteErr(w, r, err, 0)
+			return
+		}
+	}
 	mwriter = io.MultiWriter(actualMD5.H, obj)
 
 	for _, partInfo := range nparts {
 		concatMD5 += partInfo.MD5
-		nextPart, err := os.Open(partInfo.FQN)
+		nextPart, err := lom.OpenFQN(partInfo.FQN) // (decrypting if need be)
 		if err != nil {
-			cos.Close(obj)
+			cos.Close(fh)
 			s3.WriteErr(w, r, err, 0)
 			return
 		}
 		if _, err := io.CopyBuffer(mwriter, nextPart, buf); err != nil {
-			cos.Close(obj)
+			cos.Close(fh)
 			cos.Close(nextPart)
 			s3.WriteErr(w, r, err, 0)
 			return
 		}
 		cos.Close(nextPart)
 	}
-	cos.Close(obj)
+	if lom.IsEncrypted() {
+		if err := obj.Close(); err != nil {
+			cos.Close(fh)
+			s3.WriteErr(w, r, err, 0)
+			return
+		}
+	}
+	cos.Close(fh)
 
 	// 4. resulting ETag and MD5
 	resMD5 := cos.NewCksumHash(cos.ChecksumMD5)
@@ -376,7 +412,7 @@ func (t *target) getMptPart(w http.ResponseWriter, r *http.Request, bck *cluster
 	if err != nil {
 		s3.WriteErr(w, r, err, status)
 	}
-	fh, err := os.Open(lom.FQN)
+	fh, err := lom.Open()
 	if err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
@@ -0,0 +1,103 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// S3 object tagging: tags are stored as LOM custom metadata (see `cmn.TagObjMDPrefix`)
+
+// GET /s3/<bucket-name>/<object-name>?tagging
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectTagging.html
+func (t *target) getObjTaggingS3(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName string) {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if errCode, err := t.loadTaggedLOM(lom, bck, false /*locked*/); err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	resp := s3.NewTagging(lom.ObjAttrs().GetTags())
+	sgl := t.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>/<object-name>?tagging
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectTagging.html
+func (t *target) putObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string, bck *cluster.Bck) {
+	if len(items) < 2 {
+		s3.WriteErr(w, r, errS3Obj, 0)
+		return
+	}
+	tagging := &s3.Tagging{}
+	if err := s3.DecodeXML(r.Body, tagging); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	tags, err := tagging.KVs()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if errCode, err := t.setObjTags(s3.ObjName(items), bck, tags); err != nil {
+		s3.WriteErr(w, r, err, errCode)
+	}
+}
+
+// DELETE /s3/<bucket-name>/<object-name>?tagging
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteObjectTagging.html
+func (t *target) delObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	bck, err, errCode := cluster.InitByNameOnly(items[0], t.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	if len(items) < 2 {
+		s3.WriteErr(w, r, errS3Obj, 0)
+		return
+	}
+	if errCode, err := t.setObjTags(s3.ObjName(items), bck, nil); err != nil {
+		s3.WriteErr(w, r, err, errCode)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// replace all existing tags (nil `tags` to delete)
+func (t *target) setObjTags(objName string, bck *cluster.Bck, tags cos.StrKVs) (int, error) {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if errCode, err := t.loadTaggedLOM(lom, bck, true /*locked*/); err != nil {
+		return errCode, err
+	}
+	lom.ObjAttrs().SetTags(tags)
+	if err := lom.Persist(); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return 0, nil
+}
+
+func (t *target) loadTaggedLOM(lom *cluster.LOM, bck *cluster.Bck, locked bool) (int, error) {
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		return 0, err
+	}
+	if err := lom.Load(!locked /*cache it*/, locked); err != nil {
+		if cmn.IsObjNotExist(err) {
+			return http.StatusNotFound, cmn.NewErrNotFound("%s: object %s", t.si, lom.FullName())
+		}
+		return 0, err
+	}
+	return 0, nil
+}
@@ -6,8 +6,10 @@ package ais
 
 import (
 	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/s3"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -17,10 +19,17 @@ import (
 	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/space"
+	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/xact"
 	"github.com/NVIDIA/aistore/xact/xreg"
 )
 
+const (
+	lifecycleInterval = time.Hour
+	tieringInterval   = time.Hour
+	trashInterval     = time.Hour
+)
+
 // triggers by an out-of-space condition or a suspicion of thereof
 func (t *target) OOS(csRefreshed *fs.CapStatus) (cs fs.CapStatus) {
 	var err error
@@ -83,6 +92,115 @@ func (t *target) runLRU(id string, wg *sync.WaitGroup, force bool, bcks ...cmn.B
 	space.RunLRU(&ini)
 }
 
+// periodically, via housekeeper (see also: cmn.LifecycleConf)
+func (t *target) lifecycleHK() time.Duration {
+	if t.ClusterStarted() && !t.regstate.disabled.Load() && t.hasLifecycleRules() {
+		go t.runLifecycle("" /*uuid*/, nil /*wg*/)
+	}
+	return lifecycleInterval
+}
+
+func (t *target) hasLifecycleRules() (yes bool) {
+	t.owner.bmd.get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		yes = bck.Props.Lifecycle.Enabled()
+		return yes
+	})
+	return
+}
+
+// expire (under w-lock) - remote buckets: evict the cached copy only
+func (t *target) delExpired(lom *cluster.LOM) error {
+	_, err, _ := t.delobj(lom, lom.Bck().IsRemote() /*evict*/)
+	if err == nil {
+		t.statsT.Inc(stats.DeleteCount)
+	}
+	return err
+}
+
+func (t *target) runLifecycle(id string, wg *sync.WaitGroup, bcks ...cmn.Bck) {
+	regToIC := id == ""
+	if regToIC {
+		id = cos.GenUUID()
+	}
+	rns := xreg.RenewLifecycle(id)
+	if rns.Err != nil || rns.IsRunning() {
+		debug.Assert(rns.Err == nil || cmn.IsErrUsePrevXaction(rns.Err))
+		if wg != nil {
+			wg.Done()
+		}
+		return
+	}
+	xlife := rns.Entry.Get()
+	if regToIC && xlife.ID() == id {
+		// pre-existing UUID: notify IC members
+		regMsg := xactRegMsg{UUID: id, Kind: apc.ActLifecycle, Srcs: []string{t.si.ID()}}
+		msg := t.newAmsgActVal(apc.ActRegGlobalXaction, regMsg)
+		t.bcastAsyncIC(msg)
+	}
+	ini := space.IniLife{
+		T:        t,
+		Xaction:  xlife.(*space.XactLife),
+		Buckets:  bcks,
+		WG:       wg,
+		AbortMpt: s3.AbortStaleUploads,
+		DelObj:   t.delExpired,
+	}
+	xlife.AddNotif(&xact.NotifXact{
+		Base: nl.Base{When: cluster.UponTerm, Dsts: []string{equalIC}, F: t.callerNotifyFin},
+		Xact: xlife,
+	})
+	space.RunLifecycle(&ini)
+}
+
+// periodically, via housekeeper (see also: cmn.TieringConf)
+func (t *target) tieringHK() time.Duration {
+	if t.ClusterStarted() && !t.regstate.disabled.Load() && fs.NumTiers() > 1 {
+		go t.runTiering("" /*uuid*/, nil /*wg*/)
+	}
+	return tieringInterval
+}
+
+func (t *target) runTiering(id string, wg *sync.WaitGroup, bcks ...cmn.Bck) {
+	regToIC := id == ""
+	if regToIC {
+		id = cos.GenUUID()
+	}
+	rns := xreg.RenewTiering(id)
+	if rns.Err != nil || rns.IsRunning() {
+		debug.Assert(rns.Err == nil || cmn.IsErrUsePrevXaction(rns.Err))
+		if wg != nil {
+			wg.Done()
+		}
+		return
+	}
+	xtier := rns.Entry.Get()
+	if regToIC && xtier.ID() == id {
+		// pre-existing UUID: notify IC members
+		regMsg := xactRegMsg{UUID: id, Kind: apc.ActTiering, Srcs: []string{t.si.ID()}}
+		msg := t.newAmsgActVal(apc.ActRegGlobalXaction, regMsg)
+		t.bcastAsyncIC(msg)
+	}
+	ini := space.IniTier{
+		T:       t,
+		Xaction: xtier.(*space.XactTier),
+		Buckets: bcks,
+		WG:      wg,
+	}
+	xtier.AddNotif(&xact.NotifXact{
+		Base: nl.Base{When: cluster.UponTerm, Dsts: []string{equalIC}, F: t.callerNotifyFin},
+		Xact: xtier,
+	})
+	space.RunTiering(&ini)
+}
+
+// periodically, via housekeeper (see also: cmn.TrashConf)
+func (t *target) trashHK() time.Duration {
+	if t.ClusterStarted() && !t.regstate.disabled.Load() {
+		go space.PurgeTrash(t)
+	}
+	return trashInterval
+}
+
 func (t *target) runStoreCleanup(id string, wg *sync.WaitGroup, bcks ...cmn.Bck) fs.CapStatus {
 	regToIC := id == ""
 	if regToIC {
@@ -0,0 +1,184 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/NVIDIA/aistore/xact/xs"
+)
+
+// remote backend that lists and serves a fixed set of versioned objects (and supports PUT)
+type syncBP struct {
+	putBP
+	tgt  *target
+	vers map[string]string // object name => version
+}
+
+func (bp *syncBP) ListObjects(_ *cluster.Bck, _ *apc.LsoMsg, lst *cmn.LsoResult) (int, error) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	lst.Entries = lst.Entries[:0]
+	for name, b := range bp.objs {
+		lst.Entries = append(lst.Entries, &cmn.LsoEntry{Name: name, Size: int64(len(b)), Version: bp.vers[name]})
+	}
+	return 0, nil
+}
+
+func (bp *syncBP) GetObj(_ context.Context, lom *cluster.LOM, owt cmn.OWT) (int, error) {
+	b, _ := bp.get(lom.ObjName)
+	bp.mu.Lock()
+	ver := bp.vers[lom.ObjName]
+	bp.mu.Unlock()
+	return 0, syncPut(bp.tgt, lom, b, ver, owt)
+}
+
+// as if cold-GET from the remote backend
+func syncPut(tgt *target, lom *cluster.LOM, b []byte, ver string, owt cmn.OWT) error {
+	lom.SetCustomKey(cmn.SourceObjMD, apc.AWS)
+	lom.SetVersion(ver)
+	params := cluster.AllocPutObjParams()
+	{
+		params.WorkTag = fs.WorkfileColdget
+		params.Reader = io.NopCloser(bytes.NewReader(b))
+		params.OWT = owt
+		params.Atime = time.Now()
+	}
+	err := tgt.PutObject(lom, params)
+	cluster.FreePutObjParams(params)
+	return err
+}
+
+func TestSyncBck(t *testing.T) {
+	const bckName = "sync-bck"
+	var (
+		tgt = testTarget()
+		bp  = &syncBP{
+			putBP: putBP{gate: make(chan struct{}), objs: make(map[string][]byte)},
+			tgt:   tgt,
+			vers:  make(map[string]string),
+		}
+	)
+	close(bp.gate)
+	xreg.Init()
+	xs.Xreg()
+	hk.TestInit()
+	_ = fs.CSM.Reg(fs.WriteBackType, &fs.WriteBackContentResolver{})
+
+	oldConfig := cmn.GCO.Get()
+	config := cmn.GCO.BeginUpdate()
+	config.Backend.Providers = map[string]cmn.Ns{apc.AWS: cmn.NsGlobal}
+	cmn.GCO.CommitUpdate(config)
+	tgt.backend[apc.AWS] = bp
+	smap := newSmap()
+	smap.Tmap[tgt.si.ID()] = tgt.si
+	tgt.owner.smap.put(smap)
+	defer func() {
+		tgt.owner.smap.put(newSmap())
+		delete(tgt.backend, apc.AWS)
+		cmn.GCO.BeginUpdate()
+		cmn.GCO.CommitUpdate(oldConfig)
+	}()
+
+	bck := cluster.NewBck(bckName, apc.AWS, cmn.NsGlobal)
+	bmd := tgt.owner.bmd.get().clone()
+	bmd.add(bck, &cmn.BucketProps{Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash}})
+	tgt.owner.bmd.putPersist(bmd, nil)
+	fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)
+	defer func() {
+		for _, mi := range fs.GetAvail() {
+			os.RemoveAll(mi.MakePathBck(bck.Bucket()))
+		}
+	}()
+
+	newLOM := func(objName string) *cluster.LOM {
+		lom := cluster.AllocLOM(objName)
+		tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+		return lom
+	}
+	exists := func(objName string) bool {
+		lom := newLOM(objName)
+		defer cluster.FreeLOM(lom)
+		return lom.Load(false /*cache it*/, false /*locked*/) == nil
+	}
+	version := func(objName string) string {
+		lom := newLOM(objName)
+		defer cluster.FreeLOM(lom)
+		tassert.CheckFatal(t, lom.Load(false /*cache it*/, false /*locked*/))
+		return lom.Version()
+	}
+
+	// remote: "same", "changed", and "not-cached"
+	data := []byte("sync-bck content")
+	for _, name := range []string{"same", "changed", "not-cached"} {
+		bp.objs[name] = data
+		bp.vers[name] = "v1"
+	}
+	// cached: "same", "changed", and "deleted" (out-of-band); and "local" (never stored remotely)
+	for _, name := range []string{"same", "changed", "deleted", "local"} {
+		lom := newLOM(name)
+		if name == "local" {
+			lom.SetCustomMD(nil)
+			params := cluster.AllocPutObjParams()
+			{
+				params.WorkTag = fs.WorkfilePut
+				params.Reader = io.NopCloser(bytes.NewReader(data))
+				params.OWT = cmn.OwtGetPrefetchLock
+				params.Atime = time.Now()
+			}
+			tassert.CheckFatal(t, tgt.PutObject(lom, params))
+			cluster.FreePutObjParams(params)
+		} else {
+			tassert.CheckFatal(t, syncPut(tgt, lom, data, "v1", cmn.OwtGetPrefetchLock))
+		}
+		cluster.FreeLOM(lom)
+	}
+	bp.vers["changed"] = "v2"
+	time.Sleep(10 * time.Millisecond)
+
+	run := func(msg *apc.SyncBckMsg) *xs.ExtSyncBckStats {
+		rns := xreg.RenewSyncBck(tgt, cos.GenUUID(), bck, &xreg.SyncBckArgs{Msg: msg, StatsT: tgt.statsT})
+		tassert.CheckFatal(t, rns.Err)
+		xctn := rns.Entry.Get()
+		xctn.Run(nil)
+		snap := xctn.Snap()
+		tassert.Fatalf(t, !snap.AbortedX, "%s aborted", xctn)
+		return snap.Ext.(*xs.ExtSyncBckStats)
+	}
+	check := func(ext *xs.ExtSyncBckStats) {
+		tassert.Errorf(t, ext.Changed == 1 && ext.Deleted == 1 && ext.LocalOnly == 1 && ext.Errors == 0,
+			"unexpected diff %+v", ext)
+	}
+
+	// dry-run: report only
+	check(run(&apc.SyncBckMsg{DryRun: true}))
+	tassert.Errorf(t, version("changed") == "v1", "dry-run must not refresh")
+	tassert.Errorf(t, exists("deleted"), "dry-run must not evict")
+
+	// sync
+	check(run(&apc.SyncBckMsg{PushLocal: true}))
+	tassert.Errorf(t, version("same") == "v1", "expected v1, got %q", version("same"))
+	tassert.Errorf(t, version("changed") == "v2", "expected v2, got %q", version("changed"))
+	tassert.Errorf(t, !exists("deleted"), "expected deleted object to be evicted")
+	tassert.Errorf(t, !exists("not-cached"), "sync must not prefetch")
+	lom := newLOM("local")
+	defer cluster.FreeLOM(lom)
+	tassert.Fatalf(t, waitUnmarked(lom), "timed out waiting for %s to upload", lom)
+	b, ok := bp.get("local")
+	tassert.Errorf(t, ok && bytes.Equal(b, data), "local-only object must be uploaded")
+}
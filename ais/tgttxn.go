@@ -20,7 +20,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/feat"
-	"github.com/NVIDIA/aistore/cmn/k8s"
+	"github.com/NVIDIA/aistore/cmn/sse"
 	"github.com/NVIDIA/aistore/ext/etl"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/mirror"
@@ -384,6 +384,10 @@ func (t *target) validateNprops(bck *cluster.Bck, msg *aisMsg) (nprops *cmn.Buck
 	if nprops.EC.Enabled && !bck.Props.EC.Enabled {
 		err = cs.Err
 	}
+	if err == nil && nprops.Encryption.Enabled {
+		// the key must be available (note: new objects only - existing ones may reference older keys)
+		_, err = sse.Key(nprops.Encryption.Provider, nprops.Encryption.KeyID)
+	}
 	return
 }
 
@@ -484,9 +488,6 @@ func (t *target) validateBckRenTxn(bckFrom, bckTo *cluster.Bck, msg *aisMsg) err
 }
 
 func (t *target) etlDP(msg *apc.TCBMsg) (cluster.DP, error) {
-	if err := k8s.Detect(); err != nil {
-		return nil, err
-	}
 	if err := msg.Validate(true); err != nil {
 		return nil, err
 	}
@@ -862,6 +863,12 @@ func (t *target) destroyBucket(c *txnServerCtx) error {
 		if !nlp.TryLock(c.timeout.netw / 2) {
 			return cmn.NewErrBckIsBusy(c.bck.Bucket())
 		}
+		if c.bck.Init(t.owner.bmd) == nil {
+			if err := chkLockedBck(c.bck); err != nil {
+				nlp.Unlock()
+				return err
+			}
+		}
 		txn := newTxnBckBase(c.bck)
 		txn.fillFromCtx(c)
 		if err := t.transactions.begin(txn); err != nil {
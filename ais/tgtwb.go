@@ -0,0 +1,130 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/NVIDIA/aistore/xact/xs"
+)
+
+// Write-back (see apc.WriteBack)
+// PUT marks the object (fs.WriteBackType) and queues it for asynchronous upload (xs.XactWriteBack);
+// in addition, marked objects get periodically (re)queued - those that failed to upload, did not fit
+// into the queue, or were marked prior to restart.
+
+const writeBackInterval = time.Minute
+
+var (
+	wbScanning atomic.Bool
+	errWbFull  = errors.New("write-back queue is full")
+)
+
+type wbScanJ struct {
+	t   *target
+	bck *cluster.Bck
+	n   int
+}
+
+// queue for upload; returns false if already queued or the queue is full
+func (t *target) writeBack(lom *cluster.LOM) (xwb *xs.XactWriteBack, ok bool) {
+	rns := xreg.RenewWriteBack(t, t.statsT, lom.Bck())
+	if rns.Err != nil {
+		glog.Errorf("%s: %s %v", t, lom, rns.Err)
+		return
+	}
+	xwb = rns.Entry.Get().(*xs.XactWriteBack)
+	ok = xwb.Upload(lom)
+	return
+}
+
+// periodically, via housekeeper
+func (t *target) writeBackHK() time.Duration {
+	if t.ClusterStarted() && !t.regstate.disabled.Load() {
+		go t.scanWriteBack()
+	}
+	return writeBackInterval
+}
+
+func (t *target) scanWriteBack() {
+	if !wbScanning.CAS(false, true) {
+		return
+	}
+	defer wbScanning.Store(false)
+	// (including buckets that are no longer write-back but may still have objects pending upload)
+	var bcks []*cluster.Bck
+	t.owner.bmd.get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		if bck.IsRemote() && !bck.IsHTTP() {
+			bcks = append(bcks, bck)
+		}
+		return false
+	})
+	for _, bck := range bcks {
+		j := &wbScanJ{t: t, bck: bck}
+		for _, mi := range fs.GetAvail() {
+			opts := &fs.WalkOpts{
+				Mi:       mi,
+				Bck:      *bck.Bucket(),
+				CTs:      []string{fs.WriteBackType},
+				Callback: j.walk,
+			}
+			if err := fs.Walk(opts); err != nil {
+				if err == errWbFull {
+					break
+				}
+				if !cmn.IsErrBucketNought(err) && !cmn.IsErrObjNought(err) && !os.IsNotExist(err) {
+					glog.Errorf("%s: failed to scan %s: %v", t, bck, err)
+				}
+			}
+		}
+		if j.n > 0 {
+			glog.Infof("%s: %s - queued %d object%s for upload", t, bck, j.n, cos.Plural(j.n))
+		}
+	}
+}
+
+func (j *wbScanJ) walk(fqn string, de fs.DirEntry) error {
+	if de.IsDir() {
+		return nil
+	}
+	parsedFQN, err := fs.ParseFQN(fqn)
+	if err != nil {
+		return nil
+	}
+	lom := cluster.AllocLOM(parsedFQN.ObjName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(j.bck.Bucket()); err != nil {
+		return err
+	}
+	// (object moved to another mountpath - e.g., resilvered)
+	if mfqn := lom.WriteBackFQN(); mfqn != fqn {
+		if cos.Stat(lom.FQN) != nil {
+			return nil // (see space cleanup)
+		}
+		if err := lom.MarkWriteBack(); err != nil {
+			glog.Errorf("%s: %v", j.t, err)
+			return nil
+		}
+		if err := cos.RemoveFile(fqn); err != nil {
+			glog.Errorf("%s: %v", j.t, err)
+		}
+	}
+	xwb, ok := j.t.writeBack(lom)
+	if ok {
+		j.n++
+	} else if xwb != nil && xwb.Full() {
+		return errWbFull // (will continue next time)
+	}
+	return nil
+}
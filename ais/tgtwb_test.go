@@ -0,0 +1,142 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/NVIDIA/aistore/xact/xs"
+)
+
+// remote backend that only supports PUT (and blocks it until the gate opens)
+type putBP struct {
+	cluster.BackendProvider // (any other call panics)
+	gate                    chan struct{}
+	mu                      sync.Mutex
+	objs                    map[string][]byte
+}
+
+func (*putBP) Provider() string { return apc.AWS }
+
+func (bp *putBP) PutObj(r io.ReadCloser, lom *cluster.LOM) (int, error) {
+	defer r.Close()
+	<-bp.gate
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	bp.mu.Lock()
+	bp.objs[lom.ObjName] = b
+	bp.mu.Unlock()
+	lom.SetCustomKey(cmn.ETag, "etag")
+	return 0, nil
+}
+
+func (bp *putBP) get(objName string) (b []byte, ok bool) {
+	bp.mu.Lock()
+	b, ok = bp.objs[objName]
+	bp.mu.Unlock()
+	return
+}
+
+func waitUnmarked(lom *cluster.LOM) bool {
+	for i := 0; i < 100; i++ {
+		if cos.Stat(lom.WriteBackFQN()) != nil {
+			return true
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return false
+}
+
+func TestWriteBack(t *testing.T) {
+	const bckName = "write-back"
+	var (
+		tgt  = testTarget()
+		bp   = &putBP{gate: make(chan struct{}), objs: make(map[string][]byte)}
+		data = []byte("write-back content")
+	)
+	xreg.Init()
+	xs.Xreg()
+	hk.TestInit()
+	_ = fs.CSM.Reg(fs.WriteBackType, &fs.WriteBackContentResolver{})
+
+	oldConfig := cmn.GCO.Get()
+	config := cmn.GCO.BeginUpdate()
+	config.Backend.Providers = map[string]cmn.Ns{apc.AWS: cmn.NsGlobal}
+	cmn.GCO.CommitUpdate(config)
+	tgt.backend[apc.AWS] = bp
+	defer func() {
+		delete(tgt.backend, apc.AWS)
+		cmn.GCO.BeginUpdate()
+		cmn.GCO.CommitUpdate(oldConfig)
+	}()
+
+	bck := cluster.NewBck(bckName, apc.AWS, cmn.NsGlobal)
+	bmd := tgt.owner.bmd.get().clone()
+	bmd.add(bck, &cmn.BucketProps{
+		Cksum:       cmn.CksumConf{Type: cos.ChecksumXXHash},
+		WritePolicy: cmn.WritePolicyConf{Data: apc.WriteBack},
+	})
+	tgt.owner.bmd.putPersist(bmd, nil)
+	fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)
+	defer func() {
+		for _, mi := range fs.GetAvail() {
+			os.RemoveAll(mi.MakePathBck(bck.Bucket()))
+		}
+	}()
+
+	lom := cluster.AllocLOM("obj")
+	defer cluster.FreeLOM(lom)
+	tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+	params := cluster.AllocPutObjParams()
+	{
+		params.WorkTag = fs.WorkfilePut
+		params.Reader = io.NopCloser(bytes.NewReader(data))
+		params.OWT = cmn.OwtPut
+		params.Atime = time.Now()
+	}
+	err := tgt.PutObject(lom, params)
+	cluster.FreePutObjParams(params)
+	tassert.CheckFatal(t, err)
+
+	// PUT completes locally while the upload is blocked
+	tassert.CheckFatal(t, lom.Load(false /*cache it*/, false /*locked*/))
+	tassert.Errorf(t, lom.IsWriteBackPending(), "expected %s to be pending upload", lom)
+	_, ok := bp.get(lom.ObjName)
+	tassert.Errorf(t, !ok, "%s must not be uploaded yet", lom)
+
+	close(bp.gate)
+	tassert.Fatalf(t, waitUnmarked(lom), "timed out waiting for %s to upload", lom)
+	b, ok := bp.get(lom.ObjName)
+	tassert.Errorf(t, ok && bytes.Equal(b, data), "uploaded content differs: %q", b)
+	tassert.CheckFatal(t, lom.Load(false /*cache it*/, false /*locked*/))
+	src, _ := lom.GetCustomKey(cmn.SourceObjMD)
+	etag, _ := lom.GetCustomKey(cmn.ETag)
+	tassert.Errorf(t, src == apc.AWS && etag == "etag", "unexpected custom metadata %v", lom.GetCustomMD())
+
+	// marked (e.g., prior to restart) gets uploaded via periodic scan
+	bp.mu.Lock()
+	delete(bp.objs, lom.ObjName)
+	bp.mu.Unlock()
+	tassert.CheckFatal(t, lom.MarkWriteBack())
+	tgt.scanWriteBack()
+	tassert.Fatalf(t, waitUnmarked(lom), "timed out waiting for %s to upload", lom)
+	_, ok = bp.get(lom.ObjName)
+	tassert.Errorf(t, ok, "%s must be uploaded", lom)
+}
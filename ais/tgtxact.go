@@ -176,6 +176,16 @@ func (t *target) xstart(r *http.Request, args *xact.ArgsMsg, bck *cluster.Bck) e
 		wg.Add(1)
 		go t.runStoreCleanup(args.ID, wg, args.Buckets...)
 		wg.Wait()
+	case apc.ActLifecycle:
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		go t.runLifecycle(args.ID, wg, args.Buckets...)
+		wg.Wait()
+	case apc.ActTiering:
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		go t.runTiering(args.ID, wg, args.Buckets...)
+		wg.Wait()
 	case apc.ActResilver:
 		if bck != nil {
 			glog.Errorf(erfmb, args.Kind, bck)
@@ -218,7 +228,8 @@ func (t *target) xstart(r *http.Request, args *xact.ArgsMsg, bck *cluster.Bck) e
 	// 3. cannot start
 	case apc.ActPutCopies:
 		return fmt.Errorf("cannot start %q (is driven by PUTs into a mirrored bucket)", args)
-	case apc.ActDownload, apc.ActEvictObjects, apc.ActDeleteObjects, apc.ActMakeNCopies, apc.ActECEncode:
+	case apc.ActDownload, apc.ActEvictObjects, apc.ActDeleteObjects, apc.ActMakeNCopies, apc.ActECEncode,
+		apc.ActSyncBck:
 		return fmt.Errorf("initiating %q must be done via a separate documented API", args)
 	// 4. unknown
 	case "":
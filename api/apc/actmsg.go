@@ -30,6 +30,7 @@ const (
 	ActEvictRemoteBck = "evict-remote-bck" // evict remote bucket's data
 	ActInvalListCache = "inval-listobj-cache"
 	ActLRU            = "lru"
+	ActLifecycle      = "lifecycle" // apply bucket lifecycle rules (see cmn.LifecycleConf)
 	ActList           = "list"
 	ActLoadLomCache   = "load-lom-cache"
 	ActMakeNCopies    = "make-n-copies"
@@ -39,6 +40,7 @@ const (
 	ActPutCopies      = "put-copies"
 	ActRebalance      = "rebalance"
 	ActRenameObject   = "rename-obj"
+	ActUndeleteObject = "undelete-obj" // restore deleted object from the trash (see cmn.TrashConf)
 	ActResetStats     = "reset-stats"
 	ActResetBprops    = "reset-bprops"
 	ActResetConfig    = "reset-config"
@@ -49,6 +51,10 @@ const (
 	ActShutdown       = "shutdown"
 	ActStartGFN       = "start-gfn"
 	ActStoreCleanup   = "cleanup-store"
+	ActSyncBck        = "sync-bck"     // reconcile cached remote bucket with its backend (see SyncBckMsg)
+	ActTiering        = "tiering"      // demote cold objects to slower storage tiers (see cmn.TieringConf)
+	ActTierPromote    = "tier-promote" // promote demoted objects back into the hot tier upon access
+	ActWriteBack      = "write-back"   // upload objects to remote backend asynchronously (see WriteBack)
 
 	// multi-object (via `SelectObjsMsg`)
 	ActCopyObjects     = "copy-listrange"
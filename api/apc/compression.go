@@ -21,6 +21,11 @@ const (
 // (alternative to lz4 compressions upon popular request)
 const LZ4Compression = "lz4"
 
+// on-disk (bucket) compression algorithms
+const ZstdCompression = "zstd"
+
 var SupportedCompression = []string{CompressNever, CompressAlways}
 
+var SupportedDiskCompression = []string{LZ4Compression, ZstdCompression}
+
 func IsValidCompression(c string) bool { return c == "" || cos.StringInSlice(c, SupportedCompression) }
@@ -13,6 +13,10 @@ import (
 const (
 	LocationPropSepa = ":"
 	LsPropsSepa      = ","
+
+	// LsoMsg.Tags, e.g. "project=imagenet,stage" (where "stage" matches any value)
+	LsTagsSepa  = ","
+	LsTagKVSepa = "="
 )
 
 // LsoMsg flags
@@ -22,7 +26,7 @@ const (
 	LsObjCached = 1 << iota
 
 	LsAll      // include misplaced objects and replicas
-	LsDeleted  // include obj-s marked for deletion (TODO)
+	LsDeleted  // list deleted obj-s that can be undeleted (ais buckets with trash, see `cmn.TrashConf`)
 	LsArchDir  // expand archives as directories
 	LsNameOnly // return only object names and statuses (for faster listing)
 	LsNameSize // same as above plus size
@@ -73,6 +77,17 @@ const (
 	// simply forwards it to the associated remote backend and delivers the results as is to the
 	// requesting proxy and, subsequently, to client.
 	LsWantOnlyRemoteProps
+
+	// ais:// buckets with version history (see `versioning.history`): include retained
+	// prior versions of the listed objects - each listed right after the object itself
+	// under the same name (and flagged EntryIsVersion); prior versions do not count
+	// toward the page size
+	LsVersions
+
+	// ht:// buckets: list remote content by crawling the origin's HTML index pages;
+	// absent this flag (and absent manifest - see `extra.http.manifest`), ht:// buckets
+	// are listed cached-only
+	LsHTTPIndex
 )
 
 // List objects default page size
@@ -90,8 +105,9 @@ const (
 	LocIsCopyMissingObj
 
 	// Flags
-	EntryIsCached = 1 << (EntryStatusBits + 1)
-	EntryInArch   = 1 << (EntryStatusBits + 2)
+	EntryIsCached  = 1 << (EntryStatusBits + 1)
+	EntryInArch    = 1 << (EntryStatusBits + 2)
+	EntryIsVersion = 1 << (EntryStatusBits + 3) // prior version (see LsVersions)
 )
 
 // ObjEntry.Flags field
@@ -136,6 +152,7 @@ type LsoMsg struct {
 	SID               string `json:"target"`             // selected target to solely execute backend.list-objects
 	Flags             uint64 `json:"flags,string"`       // enum {LsObjCached, ...} - see above
 	PageSize          uint   `json:"pagesize"`           // max entries returned by list objects call
+	Tags              string `json:"tags,omitempty"`     // filter by object tags (see LsTagsSepa)
 }
 
 ////////////
@@ -198,6 +215,27 @@ func (lsmsg *LsoMsg) PropsSet() (s cos.StrSet) {
 	return s
 }
 
+// TagFilter parses `lsmsg.Tags` (empty value matches any value of the tag)
+func (lsmsg *LsoMsg) TagFilter() (filter cos.StrKVs) {
+	if lsmsg.Tags == "" {
+		return
+	}
+	tags := strings.Split(lsmsg.Tags, LsTagsSepa)
+	filter = make(cos.StrKVs, len(tags))
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag == "" {
+			continue
+		}
+		kv := strings.SplitN(tag, LsTagKVSepa, 2)
+		if len(kv) == 1 {
+			filter[kv[0]] = ""
+		} else {
+			filter[kv[0]] = kv[1]
+		}
+	}
+	return
+}
+
 func (lsmsg *LsoMsg) SetFlag(flag uint64)         { lsmsg.Flags |= flag }
 func (lsmsg *LsoMsg) IsFlagSet(flags uint64) bool { return lsmsg.Flags&flags == flags }
 
@@ -17,8 +17,9 @@ const (
 	GCP   = "gcp"
 	HDFS  = "hdfs"
 	HTTP  = "ht"
+	FS    = "fs" // POSIX (e.g., NFS-mounted) directory tree
 
-	AllProviders = "ais, aws (s3://), gcp (gs://), azure (az://), hdfs://, ht://" // NOTE: must include all
+	AllProviders = "ais, aws (s3://), gcp (gs://), azure (az://), hdfs://, ht://, fs://" // NOTE: must include all
 
 	NsUUIDPrefix = '@' // BEWARE: used by on-disk layout
 	NsNamePrefix = '#' // BEWARE: used by on-disk layout
@@ -34,7 +35,7 @@ const (
 	AISScheme     = "ais"
 )
 
-var Providers = cos.NewStrSet(AIS, GCP, AWS, Azure, HDFS, HTTP)
+var Providers = cos.NewStrSet(AIS, GCP, AWS, Azure, HDFS, HTTP, FS)
 
 func IsProvider(p string) bool { return Providers.Contains(p) }
 
@@ -43,7 +44,7 @@ func IsCloudProvider(p string) bool {
 }
 
 func IsRemoteProvider(p string) bool {
-	return IsCloudProvider(p) || p == HDFS || p == HTTP
+	return IsCloudProvider(p) || p == HDFS || p == HTTP || p == FS
 }
 
 func ToScheme(p string) string {
@@ -52,6 +52,9 @@ const (
 	// HTTP bucket support.
 	QparamOrigURL = "original_url"
 
+	// S3 endpoint (URL or alias) to look up s3:// bucket that is not yet in BMD.
+	QparamS3Endpoint = "s3_endpoint"
+
 	// Log severity
 	QparamLogSev = "severity" // see { LogInfo, ...} enum
 	QparamLogOff = "offset"
@@ -60,6 +63,9 @@ const (
 	QparamArchpath = "archpath"
 	QparamArchmime = "archmime"
 
+	// GET a given prior version of the object (ais:// buckets with version history - see `versioning.history`)
+	QparamVersion = "version"
+
 	// Skip loading existing object's metadata, in part to
 	// compare its Checksum and update its existing Version (if exists).
 	// Can be used to reduce PUT latency when:
@@ -150,6 +156,9 @@ const (
 
 	// Notification target's node ID (usually, the node that initiates the operation).
 	QparamNotifyMe = "nft"
+
+	// Primary => targets: run scheduled download job (see dload.ScheduleSpec).
+	QparamDlRun = "dl_run"
 )
 
 // QparamWhat enum.
@@ -180,8 +189,9 @@ const (
 	WhatQueryXactStats  = "qryxstats"   // stats: all matching xactions
 	WhatAllRunningXacts = "running_all" // e.g. e.g.: put-copies[D-ViE6HEL_j] list[H96Y7bhR2s] ...
 	// internal
-	WhatSnode    = "snode"
-	WhatICBundle = "ic_bundle"
+	WhatSnode       = "snode"
+	WhatICBundle    = "ic_bundle"
+	WhatDlSchedules = "dl_schedules" // scheduled download jobs (see dload.ScheduleSpec)
 )
 
 // QparamLogSev enum.
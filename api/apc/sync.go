@@ -0,0 +1,12 @@
+// Package apc: API constant and control messages
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+// sync (cached) remote bucket with its backend (see ActSyncBck)
+type SyncBckMsg struct {
+	Prefix    string `json:"prefix"`     // sync only the objects with names starting with the prefix
+	DryRun    bool   `json:"dry_run"`    // compute and report the diff without making any changes
+	PushLocal bool   `json:"push_local"` // upload objects that exist only in the cluster (see WriteBack)
+}
@@ -63,6 +63,7 @@ const (
 	FinishedAck = "finished_ack"
 	List        = "list"
 	Remove      = "remove"
+	Limits      = "limits"
 	Next        = "next"
 	Peek        = "peek"
 	Discard     = "discard"
@@ -141,6 +142,7 @@ var (
 	URLPathDownload       = urlpath(Version, Download)
 	URLPathDownloadAbort  = urlpath(Version, Download, Abort)
 	URLPathDownloadRemove = urlpath(Version, Download, Remove)
+	URLPathDownloadLimits = urlpath(Version, Download, Limits)
 
 	URLPathETL       = urlpath(Version, ETL)
 	URLPathETLObject = urlpath(Version, ETL, ETLObject)
@@ -15,6 +15,10 @@ const (
 	WriteDelayed   = WritePolicy("delayed")   // cache and flush when not accessed for a while (lom_cache_hk.go)
 	WriteNever     = WritePolicy("never")     // transient - in-memory only
 
+	// data only: PUT completes once the object is stored locally; remote backend
+	// gets updated asynchronously (see xs.XactWriteBack)
+	WriteBack = WritePolicy("write_back")
+
 	WriteDefault = WritePolicy("") // same as `WriteImmediate` - see IsImmediate() below
 )
 
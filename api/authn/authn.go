@@ -81,6 +81,30 @@ func LoginUser(bp api.BaseParams, userID, pass, clusterID string, expire *time.D
 	return token, nil
 }
 
+// Authorize a user and return S3 credentials - access key and secret key -
+// that can be used with any S3 client to sign requests (and presign URLs)
+// sent to the cluster's S3 API endpoint. The credentials expire the same
+// way (and at the same time) as the user token.
+func IssueS3Key(bp api.BaseParams, userID, pass, clusterID string, expire *time.Duration) (key *S3KeyMsg, err error) {
+	bp.Method = http.MethodPost
+	rec := LoginMsg{Password: pass, ExpiresIn: expire, ClusterID: clusterID}
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathUsers.Join(userID, S3Key)
+		reqParams.Body = cos.MustMarshal(rec)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	if _, err = reqParams.DoReqAny(&key); err != nil {
+		return nil, err
+	}
+	if key.AccessKey == "" || key.SecretKey == "" {
+		return nil, errors.New("failed to issue S3 key: empty response from AuthN server")
+	}
+	return key, nil
+}
+
 func RegisterCluster(bp api.BaseParams, cluSpec CluACL) error {
 	msg := cos.MustMarshal(cluSpec)
 	bp.Method = http.MethodPost
@@ -14,6 +14,9 @@ import (
 
 const (
 	AdminRole = "Admin"
+
+	// POST /v1/users/<user-id>/s3key (issue S3 access key)
+	S3Key = "s3key"
 )
 
 type (
@@ -37,6 +40,11 @@ type (
 	TokenMsg struct {
 		Token string `json:"token"`
 	}
+	// S3 credentials (to sign S3 requests and presign URLs with AWS SigV4)
+	S3KeyMsg struct {
+		AccessKey string `json:"access_key"`
+		SecretKey string `json:"secret_key"`
+	}
 	LoginMsg struct {
 		Password  string         `json:"password"`
 		ExpiresIn *time.Duration `json:"expires_in"`
@@ -503,6 +503,25 @@ func ListObjectsInvalidateCache(bp BaseParams, bck cmn.Bck) error {
 	return err
 }
 
+// SyncBucket starts an extended action (xaction) to reconcile the cached content of a given
+// remote bucket with its backend: evict objects deleted out-of-band, refresh the changed ones,
+// and (optionally) upload local-only objects - see apc.SyncBckMsg.
+// Returns xaction ID if successful, an error otherwise.
+func SyncBucket(bp BaseParams, bck cmn.Bck, msg *apc.SyncBckMsg) (xid string, err error) {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBuckets.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActSyncBck, Value: msg})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.AddToQuery(nil)
+	}
+	_, err = reqParams.doReqStr(&xid)
+	FreeRp(reqParams)
+	return
+}
+
 // MakeNCopies starts an extended action (xaction) to bring a given bucket to a
 // certain redundancy level (num copies).
 // Returns xaction ID if successful, an error otherwise.
@@ -140,6 +140,23 @@ func RemoveDownload(bp BaseParams, id string) error {
 	return err
 }
 
+// change bandwidth limit and/or weight of the running download job (see dload.LimitsToUpdate);
+// limits that are not specified (nil) remain unchanged
+func SetDownloadLimits(bp BaseParams, id string, limits *dload.LimitsToUpdate) error {
+	dlBody := dload.AdminBody{ID: id, Limits: limits}
+	bp.Method = http.MethodPut
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathDownloadLimits.S
+		reqParams.Body = cos.MustMarshal(dlBody)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // TODO: simplify `dload.DlPostResp` => string
 func (reqParams *ReqParams) doDlDownloadRequest() (string, error) {
 	var resp dload.DlPostResp
@@ -37,9 +37,10 @@ type (
 		// (in other words, with no writer the object that is being read will be discarded)
 		Writer io.Writer
 
-		// Currently, the Query field can optionally carry 2 (two) distinct values:
+		// Currently, the Query field can optionally carry 3 (three) distinct values:
 		// 1. `apc.QparamETLName`: named ETL to transform the object (i.e., perform "inline transformation")
 		// 2. `apc.QparamOrigURL`: GET from a vanilla http(s) location (`ht://` bucket with the corresponding `OrigURLBck`)
+		// 3. `apc.QparamVersion`: GET a given prior version of the object (see `versioning.history`)
 		Query url.Values
 
 		// The field is exclusively used to facilitate Range Read.
@@ -521,6 +522,23 @@ func RenameObject(bp BaseParams, bck cmn.Bck, oldName, newName string) error {
 	return err
 }
 
+// UndeleteObject restores the most recently deleted instance of the object from the
+// bucket's trash (ais buckets with trash enabled - see cmn.TrashConf)
+func UndeleteObject(bp BaseParams, bck cmn.Bck, objName string) error {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, objName)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActUndeleteObject})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.AddToQuery(nil)
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // promote files and directories to ais objects
 func Promote(args *PromoteArgs) (xid string, err error) {
 	actMsg := apc.ActMsg{Action: apc.ActPromote, Name: args.SrcFQN}
@@ -30,3 +30,10 @@ type BackendProvider interface {
 	GetObj(ctx context.Context, lom *LOM, owt cmn.OWT) (errCode int, err error)
 	GetObjReader(ctx context.Context, lom *LOM) (r io.ReadCloser, expectedCksum *cos.Cksum, errCode int, err error)
 }
+
+// optional: backends that support range reads (see parallel cold GET and cmn.ExtraPropsColdGet);
+// `oa` is the result of the prior HeadObj - all ranges must belong to the same version of the object
+type RangeReader interface {
+	GetObjRange(ctx context.Context, lom *LOM, oa *cmn.ObjAttrs, offset, length int64) (r io.ReadCloser, errCode int,
+		err error)
+}
@@ -79,6 +79,7 @@ func (b *Bck) IsAIS() bool                        { return (*cmn.Bck)(b).IsAIS()
 func (b *Bck) HasProvider() bool                  { return (*cmn.Bck)(b).HasProvider() }
 func (b *Bck) IsHTTP() bool                       { return (*cmn.Bck)(b).IsHTTP() }
 func (b *Bck) IsHDFS() bool                       { return (*cmn.Bck)(b).IsHDFS() }
+func (b *Bck) IsFS() bool                         { return (*cmn.Bck)(b).IsFS() }
 func (b *Bck) IsCloud() bool                      { return (*cmn.Bck)(b).IsCloud() }
 func (b *Bck) IsRemote() bool                     { return (*cmn.Bck)(b).IsRemote() }
 func (b *Bck) IsRemoteAIS() bool                  { return (*cmn.Bck)(b).IsRemoteAIS() }
@@ -200,6 +201,7 @@ func (b *Bck) init(bmd *BMD) error {
 	} else if apc.IsRemoteProvider(b.Provider) {
 		present := bmd.initBck(b)
 		debug.Assert(!b.IsHDFS() || !present || b.Props.Extra.HDFS.RefDirectory != "")
+		debug.Assert(!b.IsFS() || !present || b.Props.Extra.FS.RefDirectory != "")
 	} else {
 		b.Props, _ = bmd.Get(b)
 	}
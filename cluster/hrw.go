@@ -10,6 +10,7 @@ import (
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/xoshiro256"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/OneOfOne/xxhash"
@@ -154,6 +155,16 @@ func HrwTargetTask(uuid string, smap *Smap) (si *Snode, err error) {
 }
 
 func HrwMpath(uname string) (mi *fs.Mountpath, digest uint64, err error) {
+	return _hrwMpath(uname, -1)
+}
+
+// same as above with the selection limited to a given storage tier (see fs.Tiers)
+func HrwMpathTier(uname string, tier int) (mi *fs.Mountpath, digest uint64, err error) {
+	debug.Assert(tier >= 0)
+	return _hrwMpath(uname, tier)
+}
+
+func _hrwMpath(uname string, tier int) (mi *fs.Mountpath, digest uint64, err error) {
 	var (
 		max            uint64
 		availablePaths = fs.GetAvail()
@@ -163,6 +174,9 @@ func HrwMpath(uname string) (mi *fs.Mountpath, digest uint64, err error) {
 		if mpathInfo.IsAnySet(fs.FlagWaitingDD) {
 			continue
 		}
+		if tier >= 0 && mpathInfo.Tier != tier {
+			continue
+		}
 		cs := xoshiro256.Hash(mpathInfo.PathDigest ^ digest)
 		if cs >= max {
 			max = cs
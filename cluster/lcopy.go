@@ -283,7 +283,8 @@ func (lom *LOM) copy2fqn(dst *LOM, buf []byte) (err error) {
 		srcCksum  = lom.Checksum()
 		cksumType = cos.ChecksumNone
 	)
-	if !srcCksum.IsEmpty() {
+	// (encrypted or compressed: copying as is, keeping the original checksum)
+	if !srcCksum.IsEmpty() && !lom.IsEncrypted() && !lom.IsCompressed() {
 		cksumType = srcCksum.Ty()
 	}
 	if dst.isMirror(lom) && lom.md.copies != nil {
@@ -297,6 +298,10 @@ func (lom *LOM) copy2fqn(dst *LOM, buf []byte) (err error) {
 		dst.SetVersion(lomInitialVersion)
 	}
 
+	if lom.IsEncrypted() != dst.IsEncrypted() {
+		return fmt.Errorf("cannot copy %s => %s as is: encrypted vs plaintext", lom, dst)
+	}
+
 	workFQN := fs.CSM.Gen(dst, fs.WorkfileType, fs.WorkfileCopy)
 	_, dstCksum, err = cos.CopyFile(lom.FQN, workFQN, buf, cksumType)
 	if err != nil {
@@ -412,7 +417,7 @@ func (lom *LOM) haveMpath(mpath string) bool {
 func (lom *LOM) ToMpath() (mi *fs.Mountpath, isHrw bool) {
 	var (
 		availablePaths = fs.GetAvail()
-		hrwMi, _, err  = HrwMpath(lom.md.uname)
+		hrwMi, err     = lom.hrwMpath()
 	)
 	if err != nil {
 		glog.Error(err)
@@ -60,6 +60,9 @@ func (lom *LOM) InitFQN(fqn string, expbck *cmn.Bck) (err error) {
 		return
 	}
 	lom.md.uname = lom.bck.MakeUname(lom.ObjName)
+	if lom.IsTiered() {
+		lom.HrwFQN = lom.hrwFQN() // (within the object's storage tier)
+	}
 	return nil
 }
 
@@ -81,7 +84,11 @@ func (lom *LOM) InitBck(bck *cmn.Bck) (err error) {
 		return
 	}
 	lom.md.uname = lom.bck.MakeUname(lom.ObjName)
-	lom.mi, lom.mpathDigest, err = HrwMpath(lom.md.uname)
+	if lom.IsTiered() {
+		lom.mi, lom.mpathDigest, err = HrwMpathTier(lom.md.uname, fs.HotTier())
+	} else {
+		lom.mi, lom.mpathDigest, err = HrwMpath(lom.md.uname)
+	}
 	if err != nil {
 		return
 	}
@@ -43,6 +43,7 @@ type (
 		cmn.ObjAttrs
 		atimefs uint64 // NOTE: high bit is reserved for `dirty`
 		bckID   uint64
+		dsize   int64 // size on disk iff stored compressed (zero otherwise)
 	}
 	LOM struct {
 		bck         Bck
@@ -134,6 +135,15 @@ func (lom *LOM) SetCustomMD(md cos.StrKVs) { lom.md.SetCustomMD(md) }
 func (lom *LOM) GetCustomKey(key string) (string, bool) { return lom.md.GetCustomKey(key) }
 func (lom *LOM) SetCustomKey(key, value string)         { lom.md.SetCustomKey(key, value) }
 
+// CheckLocked returns cmn.ErrObjLocked if the (loaded) object belongs to
+// a lock-enabled bucket and is retained or under legal hold
+func (lom *LOM) CheckLocked() error {
+	if !lom.Bprops().ObjectLock.Enabled {
+		return nil
+	}
+	return lom.md.CheckLocked(lom.String(), time.Now())
+}
+
 // lom <= transport.ObjHdr (NOTE: caller must call freeLOM)
 func AllocLomFromHdr(hdr *transport.ObjHdr) (lom *LOM, err error) {
 	lom = AllocLOM(hdr.ObjName)
@@ -179,6 +189,27 @@ func (lom *LOM) WritePolicy() (p apc.WritePolicy) {
 	return
 }
 
+// data write policy: update remote backend asynchronously (see apc.WriteBack)
+func (lom *LOM) IsWriteBack() bool {
+	bprops := lom.Bprops()
+	return bprops != nil && bprops.WritePolicy.Data == apc.WriteBack && lom.Bck().IsRemote()
+}
+
+// write-back: object pending upload is marked via (zero-length) fs.WriteBackType file
+func (lom *LOM) WriteBackFQN() string { return fs.CSM.Gen(lom, fs.WriteBackType, "") }
+
+func (lom *LOM) IsWriteBackPending() bool {
+	return lom.IsWriteBack() && cos.Stat(lom.WriteBackFQN()) == nil
+}
+
+func (lom *LOM) MarkWriteBack() error {
+	fh, err := cos.CreateFile(lom.WriteBackFQN())
+	if err != nil {
+		return err
+	}
+	return fh.Close()
+}
+
 func (lom *LOM) loaded() bool { return lom.md.bckID != 0 }
 
 func (lom *LOM) HrwTarget(smap *Smap) (tsi *Snode, local bool, err error) {
@@ -332,11 +363,11 @@ func (lom *LOM) ComputeSetCksum() (*cos.Cksum, error) {
 }
 
 func (lom *LOM) ComputeCksum(cksumType string) (cksum *cos.CksumHash, err error) {
-	var file *os.File
+	var file cos.LomReader
 	if cksumType == cos.ChecksumNone {
 		return
 	}
-	if file, err = os.Open(lom.FQN); err != nil {
+	if file, err = lom.Open(); err != nil {
 		return
 	}
 	// No need to allocate `buf` as `io.Discard` has efficient `io.ReaderFrom` implementation.
@@ -367,7 +398,19 @@ func (lom *LOM) Load(cacheit, locked bool) (err error) {
 	}
 	err = lom.FromFS()
 	if err != nil {
-		return
+		if !os.IsNotExist(err) || !lom.IsHRW() || fs.NumTiers() < 2 {
+			return
+		}
+		// tiered storage: look up the other tiers
+		var lmd2 *lmeta
+		if lcache, lmd2 = lom.locate(); lcache == nil {
+			return
+		}
+		err = nil
+		if lmd2 != nil {
+			lom.md = *lmd2
+			return lom._checkBucket(bmd)
+		}
 	}
 	bid := lom.Bprops().BID
 	debug.Assert(bid != 0, lom.FullName())
@@ -467,7 +510,7 @@ func (lom *LOM) FromFS() error {
 		return err
 	}
 	// fstat & atime
-	if lom.md.Size != finfo.Size() { // corruption or tampering
+	if lom.SizeOnDisk() != finfo.Size() { // corruption or tampering
 		return cmn.NewErrLmetaCorrupted(lom.whingeSize(finfo.Size()))
 	}
 	lom.md.Atime = atimefs
@@ -476,7 +519,7 @@ func (lom *LOM) FromFS() error {
 }
 
 func (lom *LOM) whingeSize(size int64) error {
-	return fmt.Errorf("errsize (%d != %d)", lom.md.Size, size)
+	return fmt.Errorf("errsize (%d != %d)", lom.SizeOnDisk(), size)
 }
 
 func (lom *LOM) Remove(force ...bool) (err error) {
@@ -601,6 +644,10 @@ func (lom *LOM) CreateFile(fqn string) (fh *os.File, err error) {
 
 // (compare with cos.Rename)
 func (lom *LOM) RenameFile(workfqn string) error {
+	tiered := fs.NumTiers() > 1
+	if tiered {
+		lom.toWorkMpath(workfqn)
+	}
 	bdir := lom.mi.MakePathBck(lom.Bucket())
 	if err := cos.Stat(bdir); err != nil {
 		return fmt.Errorf("%s(bdir: %s): %w", lom, bdir, err)
@@ -608,6 +655,9 @@ func (lom *LOM) RenameFile(workfqn string) error {
 	if err := cos.Rename(workfqn, lom.FQN); err != nil {
 		return cmn.NewErrFailedTo(T, "rename", lom, err)
 	}
+	if tiered {
+		lom.delOtherTiers()
+	}
 	return nil
 }
 
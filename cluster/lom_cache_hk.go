@@ -9,7 +9,9 @@ import (
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/memsys"
 )
@@ -70,42 +72,48 @@ func (lchk *lcHK) mp() (d time.Duration, tag string) {
 
 func (lchk *lcHK) evictAll(d time.Duration) {
 	var (
-		caches               = lomCaches()
+		availablePaths       = fs.GetAvail()
 		now                  = time.Now()
 		evictedCnt, totalCnt int
 	)
 	defer lchk.running.Store(false)
 
 	// one cache at a time (TODO: throttle via mountpath.IsIdle())
-	for _, cache := range caches {
-		f := func(hkey, value any) bool {
-			md := value.(*lmeta)
-			mdTime := md.Atime
-			if mdTime < 0 {
-				mdTime = -mdTime // special case: prefetched but not yet accessed
-			}
-			totalCnt++
-			atime := time.Unix(0, mdTime)
-			if now.Sub(atime) < d {
-				return true
-			}
-			atimefs := md.atimefs & ^lomDirtyMask
-			if md.Atime > 0 && atimefs != uint64(md.Atime) {
-				debug.Assert(isValidAtime(md.Atime))
-				lif := LIF{Uname: md.uname, BID: md.bckID}
-				lom, err := lif.LOM()
-				if err == nil {
-					lom.Lock(true)
-					lom.flushCold(md, atime)
-					lom.Unlock(true)
-					FreeLOM(lom)
+	for _, mi := range availablePaths {
+		for idx := 0; idx < cos.MultiSyncMapCount; idx++ {
+			cache := mi.LomCache(idx)
+			f := func(hkey, value any) bool {
+				md := value.(*lmeta)
+				mdTime := md.Atime
+				if mdTime < 0 {
+					mdTime = -mdTime // special case: prefetched but not yet accessed
 				}
+				totalCnt++
+				atime := time.Unix(0, mdTime)
+				if now.Sub(atime) < d {
+					return true
+				}
+				atimefs := md.atimefs & ^lomDirtyMask
+				if md.Atime > 0 && atimefs != uint64(md.Atime) {
+					debug.Assert(isValidAtime(md.Atime))
+					lif := LIF{Uname: md.uname, BID: md.bckID}
+					lom, err := lif.LOM()
+					if err == nil {
+						if lom.mi.Path != mi.Path {
+							lom.setMpath(mi) // (tiered storage)
+						}
+						lom.Lock(true)
+						lom.flushCold(md, atime)
+						lom.Unlock(true)
+						FreeLOM(lom)
+					}
+				}
+				cache.Delete(hkey)
+				evictedCnt++
+				return true
 			}
-			cache.Delete(hkey)
-			evictedCnt++
-			return true
+			cache.Range(f)
 		}
-		cache.Range(f)
 	}
 	if _, tag := lchk.mp(); tag != "" {
 		glog.Infof("memory pressure %q, total %d, evicted %d", tag, totalCnt, evictedCnt)
@@ -0,0 +1,114 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"io"
+	"os"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/compr"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/sse"
+)
+
+// On-disk representation: an object may be stored encrypted (see lom_sse.go)
+// or compressed (below) - either way, object metadata (size, checksum) refers to
+// the original content.
+//
+// Local (raw) copies - mirroring, erasure-coded slices and replicas - are made
+// of the on-disk content; everything else reads objects via `lom.Open`.
+
+// objects smaller than that are never compressed
+const minComprSize = 4 * cos.KiB
+
+type comprFile struct {
+	*compr.Reader
+	fh  *os.File
+	fqn string
+}
+
+// interface guard
+var _ cos.LomReader = (*comprFile)(nil)
+
+// SizeOnDisk returns the size of the object's file
+func (lom *LOM) SizeOnDisk() int64 {
+	switch {
+	case lom.md.dsize != 0:
+		return lom.md.dsize
+	case lom.IsEncrypted():
+		return sse.DiskSize(lom.md.Size)
+	default:
+		return lom.md.Size
+	}
+}
+
+// Open opens the object for reading and, if need be, decrypting or decompressing
+func (lom *LOM) Open() (cos.LomReader, error) { return lom.OpenFQN(lom.FQN) }
+
+// same as above for a given (e.g., mirrored) copy
+func (lom *LOM) OpenFQN(fqn string) (cos.LomReader, error) {
+	switch {
+	case lom.md.dsize != 0:
+		return openCompr(fqn)
+	case lom.IsEncrypted():
+		return openEnc(fqn)
+	default:
+		return cos.NewFileHandle(fqn)
+	}
+}
+
+//
+// compression
+//
+
+func (lom *LOM) CompressionConf() *cmn.CompressionConf { return &lom.Bprops().Compression }
+
+// IsCompressed returns true if the object is stored compressed
+// (regardless of the bucket's current configuration)
+func (lom *LOM) IsCompressed() bool { return lom.md.dsize != 0 }
+
+// SetCompressed sets the size of the compressed content or, if zero, marks the object
+// as stored uncompressed
+func (lom *LOM) SetCompressed(dsize int64) { lom.md.dsize = dsize }
+
+// ToCompress returns true if a new object of the given size (if known) should be stored compressed
+func (lom *LOM) ToCompress(size int64) bool {
+	if !lom.CompressionConf().Enabled || lom.IsEncrypted() {
+		return false
+	}
+	if size > 0 && size < minComprSize {
+		return false
+	}
+	return compr.Compressible(lom.ObjName)
+}
+
+// NewComprWriter returns compressing writer (that must be closed - see compr.Writer)
+func (lom *LOM) NewComprWriter(w io.Writer) (*compr.Writer, error) {
+	return compr.NewWriter(w, lom.CompressionConf().Algo)
+}
+
+///////////////
+// comprFile //
+///////////////
+
+func openCompr(fqn string) (*comprFile, error) {
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return nil, err
+	}
+	finfo, err := fh.Stat()
+	if err == nil {
+		var r *compr.Reader
+		if r, err = compr.NewReader(fh, finfo.Size()); err == nil {
+			return &comprFile{Reader: r, fh: fh, fqn: fqn}, nil
+		}
+	}
+	fh.Close()
+	return nil, err
+}
+
+func (cf *comprFile) Close() error                      { return cf.fh.Close() }
+func (cf *comprFile) Open() (cos.ReadOpenCloser, error) { return openCompr(cf.fqn) }
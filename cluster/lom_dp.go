@@ -6,6 +6,7 @@ package cluster
 
 import (
 	"context"
+	"io"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -20,6 +21,15 @@ type (
 	DP interface {
 		Reader(lom *LOM) (reader cos.ReadOpenCloser, objMeta cmn.ObjAttrsHolder, err error)
 	}
+	// optionally, data provider that produces multiple (named) objects per source object
+	// (e.g., ETL that splits archives or extracts video frames)
+	MultiDP interface {
+		DP
+		Multi() bool
+		// calls back for each produced object, in order; `name` is relative to the destination
+		// object name, `size` is -1 when unknown, and `r` is valid only for the duration of the call
+		Walk(lom *LOM, cb func(name string, r io.Reader, size int64) error) error
+	}
 
 	LDP struct{}
 
@@ -41,7 +51,7 @@ func (r *deferROC) Close() (err error) {
 
 // is called under rlock; unlocks on fail
 func (lom *LOM) NewDeferROC() (cos.ReadOpenCloser, error) {
-	fh, err := cos.NewFileHandle(lom.FQN)
+	fh, err := lom.Open()
 	if err == nil {
 		return &deferROC{fh, lom.LIF()}, nil
 	}
@@ -0,0 +1,62 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"io"
+	"os"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/sse"
+)
+
+// Server-side encryption (SSE): all objects in an encrypted bucket are stored
+// encrypted (see cmn/sse for the format). Object metadata (size, checksum) always
+// refers to the plaintext content.
+//
+// See also lom_disk.go.
+
+type encFile struct {
+	*sse.Reader
+	fh  *os.File
+	fqn string
+}
+
+// interface guard
+var _ cos.LomReader = (*encFile)(nil)
+
+func (lom *LOM) IsEncrypted() bool { return lom.Bprops().Encryption.Enabled }
+
+func (lom *LOM) EncryptionConf() *cmn.EncryptionConf { return &lom.Bprops().Encryption }
+
+// NewEncWriter returns encrypting writer (that must be closed - see sse.Writer)
+func (lom *LOM) NewEncWriter(w io.Writer) (*sse.Writer, error) {
+	conf := lom.EncryptionConf()
+	return sse.NewWriter(w, conf.Provider, conf.KeyID)
+}
+
+/////////////
+// encFile //
+/////////////
+
+func openEnc(fqn string) (*encFile, error) {
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return nil, err
+	}
+	finfo, err := fh.Stat()
+	if err == nil {
+		var r *sse.Reader
+		if r, err = sse.NewReader(fh, finfo.Size()); err == nil {
+			return &encFile{Reader: r, fh: fh, fqn: fqn}, nil
+		}
+	}
+	fh.Close()
+	return nil, err
+}
+
+func (ef *encFile) Close() error                      { return ef.fh.Close() }
+func (ef *encFile) Open() (cos.ReadOpenCloser, error) { return openEnc(ef.fqn) }
@@ -0,0 +1,235 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Tiered storage (see fs/tier.go and cmn.TieringConf):
+// - objects of a tiered bucket are placed (HRW) within a given storage tier: new objects - within
+//   the hot tier, demoted objects - within the slower one;
+// - when not found at its default location, an object is looked up in all the other tiers
+//   (this is also what makes it possible to enable and disable tiering at any time);
+// - new content always stays on the filesystem of its workfile (see RenameFile) - other versions
+//   of the same object (if any) get removed.
+
+var errHasCopies = errors.New("mirrored objects stay in place")
+
+func (lom *LOM) TieringConf() *cmn.TieringConf { return &lom.Bprops().Tiering }
+
+// IsTiered returns true if the bucket is tiered and the target has more than one storage tier
+func (lom *LOM) IsTiered() bool { return lom.TieringConf().Enabled && fs.NumTiers() > 1 }
+
+// Tier returns the storage tier of the object's (main replica) mountpath
+func (lom *LOM) Tier() int { return lom.mi.Tier }
+
+// ToDemote returns true if the (loaded) object is not accessed for longer
+// than configured and is not in the slowest tier
+func (lom *LOM) ToDemote(now time.Time) (tier int, ok bool) {
+	if lom.HasCopies() {
+		return
+	}
+	if now.Sub(lom.atime()) < lom.TieringConf().DemoteAfter.D() {
+		return
+	}
+	return fs.NextTier(lom.mi.Tier)
+}
+
+// ToPromote returns true if the (loaded) object belongs to a tiered bucket
+// but does not reside in the hot tier
+func (lom *LOM) ToPromote() bool {
+	return lom.IsTiered() && !lom.HasCopies() && lom.mi.Tier != fs.HotTier()
+}
+
+// MoveToTier relocates the object to its HRW location in a given tier; is called
+// under w-lock with the object loaded (and updates the lom accordingly)
+func (lom *LOM) MoveToTier(tier int, buf []byte) error {
+	if lom.HasCopies() {
+		return errHasCopies
+	}
+	mi, _, err := HrwMpathTier(lom.md.uname, tier)
+	if err != nil {
+		return err
+	}
+	if mi.Path == lom.mi.Path {
+		return nil
+	}
+	var (
+		srcFQN  = lom.FQN
+		srcMi   = lom.mi
+		dstFQN  = mi.MakePathFQN(lom.Bucket(), fs.ObjectType, lom.ObjName)
+		workFQN = mi.MakePathFQN(lom.Bucket(), fs.WorkfileType, fs.WorkfileCopy+"."+lom.ObjName)
+	)
+	finfo, err := os.Stat(srcFQN)
+	if err != nil {
+		return err
+	}
+	if _, _, err = cos.CopyFile(srcFQN, workFQN, buf, cos.ChecksumNone); err != nil {
+		return err
+	}
+	if err = cos.Rename(workFQN, dstFQN); err != nil {
+		if errRemove := cos.RemoveFile(workFQN); errRemove != nil {
+			T.FSHC(errRemove, workFQN)
+		}
+		return err
+	}
+	lom.Uncache(true /*delDirty*/)
+	lom.setMpath(mi)
+	buf2, mm := lom.marshal()
+	err = fs.SetXattr(dstFQN, XattrLOM, buf2)
+	mm.Free(buf2)
+	if err == nil {
+		// demotion and promotion both preserve access and modification times
+		err = os.Chtimes(dstFQN, lom.atime(), finfo.ModTime())
+	}
+	if err != nil {
+		if errRemove := cos.RemoveFile(dstFQN); errRemove != nil && !os.IsNotExist(errRemove) {
+			T.FSHC(errRemove, dstFQN)
+		}
+		lom.setMpath(srcMi)
+		return err
+	}
+	lom.md.clearDirty()
+	lom.Recache()
+	if err = cos.RemoveFile(srcFQN); err != nil && !os.IsNotExist(err) {
+		T.FSHC(err, srcFQN)
+	}
+	return nil
+}
+
+// (prefetched and not yet accessed objects have negative atime)
+func (lom *LOM) atime() time.Time {
+	atime := lom.md.Atime
+	if atime < 0 {
+		atime = -atime
+	}
+	return time.Unix(0, atime)
+}
+
+// point lom at a given mountpath
+func (lom *LOM) setMpath(mi *fs.Mountpath) {
+	lom.mi = mi
+	lom.FQN = mi.MakePathFQN(lom.Bucket(), fs.ObjectType, lom.ObjName)
+	lom.HrwFQN = lom.hrwFQN()
+	lom.info = ""
+}
+
+func (lom *LOM) hrwFQN() string {
+	mi, err := lom.hrwMpath()
+	if err != nil {
+		return lom.HrwFQN
+	}
+	return mi.MakePathFQN(lom.Bucket(), fs.ObjectType, lom.ObjName)
+}
+
+// HRW mountpath: within the object's storage tier (tiered buckets) or across all mountpaths
+func (lom *LOM) hrwMpath() (mi *fs.Mountpath, err error) {
+	if !lom.IsTiered() {
+		mi, _, err = HrwMpath(lom.md.uname)
+		return
+	}
+	if mi, _, err = HrwMpathTier(lom.md.uname, lom.mi.Tier); err != nil {
+		mi, _, err = HrwMpathTier(lom.md.uname, fs.HotTier()) // (the tier is gone)
+	}
+	return
+}
+
+// mountpaths where the object may reside: HRW in each tier, and HRW across all tiers
+func (lom *LOM) tierMpaths() (mpaths []*fs.Mountpath) {
+	var (
+		tiers      = fs.Tiers()
+		mi, _, err = HrwMpath(lom.md.uname)
+	)
+	mpaths = make([]*fs.Mountpath, 0, len(tiers)+1)
+	for _, tier := range tiers {
+		if tmi, _, err := HrwMpathTier(lom.md.uname, tier); err == nil {
+			mpaths = append(mpaths, tmi)
+		}
+	}
+	if err == nil && !lom.haveTierMpath(mpaths, mi) {
+		mpaths = append(mpaths, mi)
+	}
+	return
+}
+
+func (*LOM) haveTierMpath(mpaths []*fs.Mountpath, mi *fs.Mountpath) bool {
+	for _, tmi := range mpaths {
+		if tmi.Path == mi.Path {
+			return true
+		}
+	}
+	return false
+}
+
+// locate looks up the object in all the other storage tiers; if found,
+// the lom is moved to the respective location (and its metadata gets loaded)
+func (lom *LOM) locate() (lcache *sync.Map, lmd *lmeta) {
+	var (
+		mi, fqn, hrwFQN = lom.mi, lom.FQN, lom.HrwFQN
+		md              = lom.md
+	)
+	for _, tmi := range lom.tierMpaths() {
+		if tmi.Path == mi.Path {
+			continue
+		}
+		lom.setMpath(tmi)
+		if lcache, lmd = lom.fromCache(); lmd != nil {
+			return
+		}
+		if err := lom.FromFS(); err == nil {
+			return lcache, nil
+		}
+		lom.md = md
+	}
+	lom.mi, lom.FQN, lom.HrwFQN, lom.info = mi, fqn, hrwFQN, ""
+	return nil, nil
+}
+
+// new content must stay on the filesystem of its workfile (see RenameFile)
+func (lom *LOM) toWorkMpath(workfqn string) {
+	if strings.HasPrefix(workfqn, lom.mi.Path+string(filepath.Separator)) {
+		return
+	}
+	availablePaths := fs.GetAvail()
+	for _, mi := range availablePaths {
+		if strings.HasPrefix(workfqn, mi.Path+string(filepath.Separator)) {
+			lom.Uncache(true /*delDirty*/)
+			lom.setMpath(mi)
+			return
+		}
+	}
+}
+
+// remove other versions of the object (if any) from all the other tiers; is called
+// under w-lock upon writing new content
+func (lom *LOM) delOtherTiers() {
+	for _, mi := range lom.tierMpaths() {
+		if mi.Path == lom.mi.Path {
+			continue
+		}
+		fqn := mi.MakePathFQN(lom.Bucket(), fs.ObjectType, lom.ObjName)
+		if err := cos.Stat(fqn); err != nil {
+			continue
+		}
+		other := AllocLOM(lom.ObjName)
+		if other.InitFQN(fqn, lom.Bucket()) == nil {
+			other.Uncache(true /*delDirty*/)
+		}
+		FreeLOM(other)
+		if err := cos.RemoveFile(fqn); err != nil && !os.IsNotExist(err) {
+			T.FSHC(err, fqn)
+		}
+	}
+}
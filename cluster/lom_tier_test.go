@@ -0,0 +1,167 @@
+// Package cluster_test provides tests for cluster package
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster_test
+
+import (
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LOM tiering", func() {
+	const (
+		tmpDir    = "/tmp/lom_tier_test"
+		hotMpath  = tmpDir + "/hot"
+		coldMpath = tmpDir + "/cold"
+
+		bucketTiered = "LOM_TEST_Tiered"
+		coldTier     = 1
+	)
+
+	var (
+		tieredBck = cmn.Bck{Name: bucketTiered, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		bmdMock   = mock.NewBaseBownerMock(
+			cluster.NewBck(
+				bucketTiered, apc.AIS, cmn.NsGlobal,
+				&cmn.BucketProps{
+					Cksum:   cmn.CksumConf{Type: cos.ChecksumXXHash},
+					Tiering: cmn.TieringConf{Enabled: true, DemoteAfter: cos.Duration(time.Hour)},
+					BID:     301,
+				},
+			),
+		)
+		buf = make([]byte, 32*cos.KiB)
+	)
+
+	BeforeEach(func() {
+		_ = cos.CreateDir(hotMpath)
+		_ = cos.CreateDir(coldMpath)
+
+		config := cmn.GCO.BeginUpdate()
+		config.FSP.Tiers = map[string]int{coldMpath: coldTier}
+		cmn.GCO.CommitUpdate(config)
+
+		fs.TestDisableValidation()
+		_, _ = fs.Add(hotMpath, "daeID")
+		_, _ = fs.Add(coldMpath, "daeID")
+
+		_ = mock.NewTarget(bmdMock)
+	})
+
+	AfterEach(func() {
+		_, _ = fs.Remove(hotMpath)
+		_, _ = fs.Remove(coldMpath)
+		_ = os.RemoveAll(tmpDir)
+
+		config := cmn.GCO.BeginUpdate()
+		config.FSP.Tiers = nil
+		cmn.GCO.CommitUpdate(config)
+	})
+
+	newLom := func(objName string) *cluster.LOM {
+		lom := &cluster.LOM{ObjName: objName}
+		Expect(lom.InitBck(&tieredBck)).NotTo(HaveOccurred())
+		return lom
+	}
+	putObj := func(objName string, size int) *cluster.LOM {
+		lom := newLom(objName)
+		createTestFile(lom.FQN, size)
+		lom.SetSize(int64(size))
+		Expect(lom.IncVersion()).NotTo(HaveOccurred())
+		Expect(persist(lom)).NotTo(HaveOccurred())
+		return lom
+	}
+	demote := func(lom *cluster.LOM) {
+		lom.Lock(true)
+		err := lom.MoveToTier(coldTier, buf)
+		lom.Unlock(true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lom.Tier()).To(Equal(coldTier))
+		Expect(lom.FQN).To(BeARegularFile())
+		Expect(lom.IsHRW()).To(BeTrue())
+	}
+
+	It("should place new objects into the hot tier", func() {
+		Expect(fs.NumTiers()).To(Equal(2))
+		lom := newLom("tiered/new-obj")
+		Expect(lom.IsTiered()).To(BeTrue())
+		Expect(lom.Tier()).To(Equal(fs.HotTier()))
+	})
+
+	It("should demote and then locate demoted object", func() {
+		const objName = "tiered/demoted-obj"
+		lom := putObj(objName, 1024)
+		hotFQN, atime := lom.FQN, lom.AtimeUnix()
+
+		demote(lom)
+		Expect(hotFQN).NotTo(BeAnExistingFile())
+		lom.Uncache(true /*delDirty*/)
+
+		lom2 := newLom(objName)
+		Expect(lom2.FQN).To(Equal(hotFQN))
+		Expect(lom2.Load(false /*cache it*/, false /*locked*/)).NotTo(HaveOccurred())
+		Expect(lom2.FQN).To(Equal(lom.FQN))
+		Expect(lom2.SizeBytes()).To(BeEquivalentTo(1024))
+		Expect(lom2.Version()).To(Equal(lom.Version()))
+		Expect(lom2.AtimeUnix()).To(BeNumerically("~", atime, int64(time.Millisecond)))
+		Expect(lom2.ToPromote()).To(BeTrue())
+	})
+
+	It("should promote demoted object", func() {
+		const objName = "tiered/promoted-obj"
+		lom := putObj(objName, 4096)
+		hotFQN := lom.FQN
+		demote(lom)
+		coldFQN := lom.FQN
+
+		lom.Lock(true)
+		err := lom.MoveToTier(fs.HotTier(), buf)
+		lom.Unlock(true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lom.FQN).To(Equal(hotFQN))
+		Expect(coldFQN).NotTo(BeAnExistingFile())
+
+		lom2 := newLom(objName)
+		Expect(lom2.Load(false /*cache it*/, false /*locked*/)).NotTo(HaveOccurred())
+		Expect(lom2.FQN).To(Equal(hotFQN))
+		Expect(lom2.ToPromote()).To(BeFalse())
+	})
+
+	It("should not demote recently accessed objects", func() {
+		lom := putObj("tiered/hot-obj", 128)
+		_, ok := lom.ToDemote(time.Now())
+		Expect(ok).To(BeFalse())
+		tier, ok := lom.ToDemote(time.Now().Add(2 * time.Hour))
+		Expect(ok).To(BeTrue())
+		Expect(tier).To(Equal(coldTier))
+	})
+
+	It("should remove demoted version upon writing new content", func() {
+		const objName = "tiered/overwritten-obj"
+		lom := putObj(objName, 1024)
+		demote(lom)
+		coldFQN := lom.FQN
+		lom.Uncache(true /*delDirty*/)
+
+		lom2 := newLom(objName)
+		workFQN := fs.CSM.Gen(lom2, fs.WorkfileType, "test")
+		createTestFile(workFQN, 512)
+		lom2.Lock(true)
+		err := lom2.RenameFile(workFQN)
+		lom2.Unlock(true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lom2.Tier()).To(Equal(fs.HotTier()))
+		Expect(lom2.FQN).To(BeARegularFile())
+		Expect(coldFQN).NotTo(BeAnExistingFile())
+	})
+})
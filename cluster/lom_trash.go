@@ -0,0 +1,146 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Trash (ais:// buckets only; see cmn.TrashConf):
+// - upon deletion, the object (along with its metadata) gets renamed into the trash
+//   on the same mountpath - content type fs.TrashType, same name;
+// - the trashed file's mtime is the time of deletion;
+// - undelete renames (or, if the object's mountpath has changed, copies) the object back
+//   into its default location.
+
+// TrashEnabled returns true if deleting the object must move it into the trash
+func (lom *LOM) TrashEnabled() bool {
+	return lom.Bck().IsAIS() && lom.Bprops().Trash.Enabled
+}
+
+// MoveToTrash is called under w-lock with the object loaded; replaces the previously
+// trashed instance, if any; removes the object's copies, if any
+func (lom *LOM) MoveToTrash() (err error) {
+	if lom.HasCopies() {
+		if err = lom.DelAllCopies(); err != nil {
+			return
+		}
+	}
+	// metadata goes with the content (including delayed-write policies)
+	buf, mm := lom.marshal()
+	err = fs.SetXattr(lom.FQN, XattrLOM, buf)
+	mm.Free(buf)
+	if err != nil {
+		T.FSHC(err, lom.FQN)
+		return
+	}
+	tfqn := lom.trashFQN(lom.mi)
+	if err = cos.Rename(lom.FQN, tfqn); err != nil {
+		return
+	}
+	if err = os.Chtimes(tfqn, lom.Atime(), time.Now()); err != nil {
+		glog.Errorf("%s: failed to set deletion time: %v", lom, err)
+		err = nil
+	}
+	lom.Uncache(true /*delDirty*/)
+	lom.md.bckID = 0
+	return
+}
+
+// Undelete restores the most recently deleted instance of the object;
+// is called under w-lock when the object does not exist
+func (lom *LOM) Undelete(buf []byte) (err error) {
+	tlom := AllocLOM(lom.ObjName)
+	defer FreeLOM(tlom)
+	if err = tlom.InitBck(lom.Bucket()); err != nil {
+		return
+	}
+	if !tlom.findTrashed(lom.mi) {
+		return cmn.NewErrNotFound("%s in trash", lom)
+	}
+	if finfo, err := os.Stat(tlom.FQN); err != nil || !lom.IsTrashRetained(finfo.ModTime(), time.Now()) {
+		return cmn.NewErrNotFound("%s in trash (expired)", lom)
+	}
+	if tlom.mi.Path == lom.mi.Path {
+		err = cos.Rename(tlom.FQN, lom.FQN)
+	} else {
+		workFQN := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileCopy)
+		if _, _, err = cos.CopyFile(tlom.FQN, workFQN, buf, cos.ChecksumNone); err != nil {
+			return
+		}
+		if err = cos.Rename(workFQN, lom.FQN); err != nil {
+			if errRemove := cos.RemoveFile(workFQN); errRemove != nil {
+				glog.Errorf(fmtNestedErr, errRemove)
+			}
+			return
+		}
+		if errRemove := cos.RemoveFile(tlom.FQN); errRemove != nil {
+			glog.Errorf(fmtNestedErr, errRemove)
+		}
+	}
+	if err != nil {
+		return
+	}
+	lom.md = tlom.md
+	lom.SetAtimeUnix(time.Now().UnixNano())
+	return lom.Persist()
+}
+
+// LoadTrashed initializes the LOM from the FQN of its trashed instance and loads
+// the corresponding metadata; the LOM must not be used to access the object itself
+func (lom *LOM) LoadTrashed(fqn string) error {
+	parsedFQN, err := fs.ParseFQN(fqn)
+	if err != nil {
+		return err
+	}
+	if parsedFQN.ContentType != fs.TrashType {
+		return fmt.Errorf("%s: unexpected content type %q (expecting %q)", fqn, parsedFQN.ContentType, fs.TrashType)
+	}
+	lom.ObjName = parsedFQN.ObjName
+	if err := lom.InitBck(&parsedFQN.Bck); err != nil {
+		return err
+	}
+	lom.mi, lom.FQN = parsedFQN.Mountpath, fqn
+	return lom.FromFS()
+}
+
+// IsTrashRetained returns true if the trashed object (deleted at a given time)
+// can still be undeleted
+func (lom *LOM) IsTrashRetained(deleted, now time.Time) bool {
+	if !lom.TrashEnabled() {
+		return false
+	}
+	return now.Sub(deleted) <= lom.Bprops().Trash.Retention.D()
+}
+
+// the object's mountpath first
+func (lom *LOM) findTrashed(mi *fs.Mountpath) bool {
+	if lom.fromTrash(mi) == nil {
+		return true
+	}
+	availablePaths := fs.GetAvail()
+	for _, mpi := range availablePaths {
+		if mpi.Path != mi.Path && lom.fromTrash(mpi) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (lom *LOM) fromTrash(mi *fs.Mountpath) error {
+	lom.mi, lom.FQN = mi, lom.trashFQN(mi)
+	return lom.FromFS()
+}
+
+func (lom *LOM) trashFQN(mi *fs.Mountpath) string {
+	return mi.MakePathFQN(lom.Bucket(), fs.TrashType, lom.ObjName)
+}
@@ -0,0 +1,128 @@
+// Package cluster_test provides tests for cluster package
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster_test
+
+import (
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LOM trash", func() {
+	const (
+		tmpDir = "/tmp/lom_trash_test"
+		mpath  = tmpDir + "/mpath"
+
+		bucketTrash = "LOM_TEST_Trash"
+		retention   = time.Hour
+	)
+
+	var (
+		trashBck = cmn.Bck{Name: bucketTrash, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		bmdMock  = mock.NewBaseBownerMock(
+			cluster.NewBck(
+				bucketTrash, apc.AIS, cmn.NsGlobal,
+				&cmn.BucketProps{
+					Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash},
+					Trash: cmn.TrashConf{Enabled: true, Retention: cos.Duration(retention)},
+					BID:   303,
+				},
+			),
+		)
+		buf = make([]byte, 32*cos.KiB)
+	)
+
+	BeforeEach(func() {
+		_ = cos.CreateDir(mpath)
+		fs.TestDisableValidation()
+		_, _ = fs.Add(mpath, "daeID")
+		_ = fs.CSM.Reg(fs.TrashType, &fs.TrashContentResolver{})
+		_ = mock.NewTarget(bmdMock)
+	})
+
+	AfterEach(func() {
+		availablePaths := fs.GetAvail()
+		for _, mi := range availablePaths {
+			_ = os.RemoveAll(mi.MakePathBck(&trashBck))
+		}
+		_, _ = fs.Remove(mpath)
+		_ = os.RemoveAll(tmpDir)
+	})
+
+	newLom := func(objName string) *cluster.LOM {
+		lom := &cluster.LOM{ObjName: objName}
+		Expect(lom.InitBck(&trashBck)).NotTo(HaveOccurred())
+		return lom
+	}
+	putObj := func(objName string, size int) *cluster.LOM {
+		lom := newLom(objName)
+		createTestFile(lom.FQN, size)
+		lom.SetSize(int64(size))
+		Expect(lom.IncVersion()).NotTo(HaveOccurred())
+		Expect(persist(lom)).NotTo(HaveOccurred())
+		return lom
+	}
+	// DELETE (compare with ais/target.go delobj)
+	del := func(lom *cluster.LOM) {
+		lom.Lock(true)
+		defer lom.Unlock(true)
+		Expect(lom.Load(false /*cache it*/, true /*locked*/)).NotTo(HaveOccurred())
+		Expect(lom.TrashEnabled()).To(BeTrue())
+		Expect(lom.MoveToTrash()).NotTo(HaveOccurred())
+	}
+
+	It("should move deleted object into the trash and then undelete it", func() {
+		const objName = "trash/obj"
+		lom := putObj(objName, 1024)
+		fqn := lom.FQN
+		del(lom)
+		Expect(fqn).NotTo(BeAnExistingFile())
+
+		tfqn := lom.Mountpath().MakePathFQN(&trashBck, fs.TrashType, objName)
+		Expect(tfqn).To(BeARegularFile())
+		tlom := cluster.AllocLOM("")
+		Expect(tlom.LoadTrashed(tfqn)).NotTo(HaveOccurred())
+		Expect(tlom.ObjName).To(Equal(objName))
+		Expect(tlom.SizeBytes()).To(BeEquivalentTo(1024))
+		cluster.FreeLOM(tlom)
+
+		lom2 := newLom(objName)
+		Expect(cmn.IsObjNotExist(lom2.Load(false /*cache it*/, false /*locked*/))).To(BeTrue())
+		lom2.Lock(true)
+		err := lom2.Undelete(buf)
+		lom2.Unlock(true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tfqn).NotTo(BeAnExistingFile())
+
+		lom3 := newLom(objName)
+		Expect(lom3.Load(false /*cache it*/, false /*locked*/)).NotTo(HaveOccurred())
+		Expect(lom3.SizeBytes()).To(BeEquivalentTo(1024))
+		Expect(lom3.Version()).To(Equal(lom.Version()))
+	})
+
+	It("should fail to undelete object that is not in the trash", func() {
+		lom := newLom("trash/none")
+		lom.Lock(true)
+		err := lom.Undelete(buf)
+		lom.Unlock(true)
+		Expect(cmn.IsErrNotFound(err)).To(BeTrue())
+	})
+
+	It("should tell when trashed object expires", func() {
+		lom := newLom("trash/expiring")
+		now := time.Now()
+		Expect(lom.IsTrashRetained(now, now)).To(BeTrue())
+		Expect(lom.IsTrashRetained(now, now.Add(retention/2))).To(BeTrue())
+		Expect(lom.IsTrashRetained(now, now.Add(2*retention))).To(BeFalse())
+	})
+})
@@ -0,0 +1,149 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Version history (ais:// buckets only; see cmn.VersionConf):
+// - upon overwrite, the current content of the object gets renamed (along with its metadata)
+//   as its prior version - content type fs.ObjVersionType, same mountpath;
+// - prior versions are retained up to the configured count (`History`) and/or for at most
+//   `HistoryTTL` - whatever is not retained gets removed by space cleanup;
+// - prior versions stay where they were created: rebalance, resilver, and tiering
+//   do not move them.
+
+// KeepsHistory returns true if prior versions of the object are to be retained upon overwrite
+func (lom *LOM) KeepsHistory() bool {
+	if !lom.Bck().IsAIS() {
+		return false
+	}
+	vconf := lom.VersionConf()
+	return vconf.Enabled && vconf.KeepsHistory()
+}
+
+// ArchiveVersion retains the current content of the object (that is about to be overwritten)
+// as its prior version; is called under w-lock with the object loaded
+func (lom *LOM) ArchiveVersion() error {
+	ver := lom.Version(true /*special*/)
+	if ver == "" {
+		return nil
+	}
+	vfqn := lom.versionFQN(lom.mi, ver)
+	if err := cos.Rename(lom.FQN, vfqn); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	// prune (locally) the oldest retained version, if any - space cleanup takes care of the rest
+	if n := lom.VersionConf().History; n > 0 {
+		if v, err := strconv.Atoi(ver); err == nil && v > n {
+			fqn := lom.versionFQN(lom.mi, strconv.Itoa(v-n))
+			if err := cos.RemoveFile(fqn); err != nil {
+				T.FSHC(err, fqn)
+			}
+		}
+	}
+	return nil
+}
+
+// LoadVersion returns a given prior version of the object with its metadata loaded from
+// the respective file; the caller must free the returned LOM
+func (lom *LOM) LoadVersion(ver string) (*LOM, error) {
+	vlom := AllocLOM(lom.ObjName)
+	if err := vlom.InitBck(lom.Bucket()); err != nil {
+		FreeLOM(vlom)
+		return nil, err
+	}
+	// the object's mountpath first
+	if vlom.fromVersion(lom.mi, ver) == nil {
+		return vlom, nil
+	}
+	availablePaths := fs.GetAvail()
+	for _, mi := range availablePaths {
+		if mi.Path == lom.mi.Path {
+			continue
+		}
+		if vlom.fromVersion(mi, ver) == nil {
+			return vlom, nil
+		}
+	}
+	FreeLOM(vlom)
+	return nil, cmn.NewErrNotFound("%s version %q", lom, ver)
+}
+
+func (lom *LOM) fromVersion(mi *fs.Mountpath, ver string) (err error) {
+	lom.mi, lom.FQN, lom.info = mi, lom.versionFQN(mi, ver), ""
+	if err = lom.FromFS(); err == nil && lom.md.Ver != ver {
+		err = cmn.NewErrLmetaCorrupted(fmt.Errorf("%s: version %q vs %q", lom.FQN, lom.md.Ver, ver))
+	}
+	return
+}
+
+// PriorVersions visits retained prior versions of the (loaded) object, latest first;
+// the callback must not retain the visited LOM
+func (lom *LOM) PriorVersions(cb func(vlom *LOM) error) error {
+	cur, err := strconv.Atoi(lom.Version())
+	if err != nil {
+		return nil
+	}
+	var (
+		n   = lom.VersionConf().History
+		low = 1
+	)
+	if n > 0 {
+		low = cos.Max(cur-n, 1)
+	}
+	for v := cur - 1; v >= low; v-- {
+		vlom, err := lom.LoadVersion(strconv.Itoa(v))
+		if err != nil {
+			if n == 0 {
+				break // (versions are contiguous unless pruned)
+			}
+			continue
+		}
+		err = cb(vlom)
+		FreeLOM(vlom)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsVersionRetained returns true if a given prior version (`ver` and its modification time)
+// is to be retained; is called by space cleanup with the object loaded
+func (lom *LOM) IsVersionRetained(ver string, mtime, now time.Time) bool {
+	if !lom.KeepsHistory() {
+		return false
+	}
+	vconf := lom.VersionConf()
+	if vconf.HistoryTTL > 0 && now.Sub(mtime) > vconf.HistoryTTL.D() {
+		return false
+	}
+	v, err := strconv.Atoi(ver)
+	if err != nil {
+		return false
+	}
+	cur, err := strconv.Atoi(lom.Version())
+	if err != nil || v >= cur { // e.g., left over by a deleted (and then re-created) object
+		return false
+	}
+	return vconf.History == 0 || v >= cur-vconf.History
+}
+
+func (lom *LOM) versionFQN(mi *fs.Mountpath, ver string) string {
+	base := fs.CSM.Resolver(fs.ObjVersionType).GenUniqueFQN(lom.ObjName, ver)
+	return mi.MakePathFQN(lom.Bucket(), fs.ObjVersionType, base)
+}
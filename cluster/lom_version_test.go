@@ -0,0 +1,128 @@
+// Package cluster_test provides tests for cluster package
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster_test
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LOM version history", func() {
+	const (
+		tmpDir = "/tmp/lom_version_test"
+		mpath  = tmpDir + "/mpath"
+
+		bucketHistory = "LOM_TEST_History"
+		history       = 2
+	)
+
+	var (
+		historyBck = cmn.Bck{Name: bucketHistory, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		bmdMock    = mock.NewBaseBownerMock(
+			cluster.NewBck(
+				bucketHistory, apc.AIS, cmn.NsGlobal,
+				&cmn.BucketProps{
+					Cksum:      cmn.CksumConf{Type: cos.ChecksumXXHash},
+					Versioning: cmn.VersionConf{Enabled: true, History: history},
+					BID:        302,
+				},
+			),
+		)
+	)
+
+	BeforeEach(func() {
+		_ = cos.CreateDir(mpath)
+		fs.TestDisableValidation()
+		_, _ = fs.Add(mpath, "daeID")
+		_ = fs.CSM.Reg(fs.ObjVersionType, &fs.ObjVersionContentResolver{})
+		_ = mock.NewTarget(bmdMock)
+	})
+
+	AfterEach(func() {
+		availablePaths := fs.GetAvail()
+		for _, mi := range availablePaths {
+			_ = os.RemoveAll(mi.MakePathBck(&historyBck))
+		}
+		_, _ = fs.Remove(mpath)
+		_ = os.RemoveAll(tmpDir)
+	})
+
+	// PUT (compare with ais/tgtobj.go poi.fini)
+	put := func(objName string, size int) *cluster.LOM {
+		lom := &cluster.LOM{ObjName: objName}
+		Expect(lom.InitBck(&historyBck)).NotTo(HaveOccurred())
+		_ = lom.Load(false /*cache it*/, false /*locked*/)
+		lom.Lock(true)
+		defer lom.Unlock(true)
+		Expect(lom.KeepsHistory()).To(BeTrue())
+		Expect(lom.ArchiveVersion()).NotTo(HaveOccurred())
+		Expect(lom.IncVersion()).NotTo(HaveOccurred())
+		createTestFile(lom.FQN, size)
+		lom.SetSize(int64(size))
+		Expect(persist(lom)).NotTo(HaveOccurred())
+		return lom
+	}
+	priorVersions := func(lom *cluster.LOM) (vers []string) {
+		err := lom.PriorVersions(func(vlom *cluster.LOM) error {
+			vers = append(vers, vlom.Version())
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return
+	}
+
+	It("should retain prior versions upon overwrite", func() {
+		const objName = "history/obj"
+		for i := 1; i <= 3; i++ {
+			put(objName, i*100)
+		}
+		lom := put(objName, 400)
+		Expect(lom.Version()).To(Equal("4"))
+		Expect(priorVersions(lom)).To(Equal([]string{"3", "2"}))
+
+		vlom, err := lom.LoadVersion("2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vlom.SizeBytes()).To(BeEquivalentTo(200))
+		Expect(vlom.ObjName).To(Equal(objName))
+		cluster.FreeLOM(vlom)
+
+		// pruned upon overwrite
+		_, err = lom.LoadVersion("1")
+		Expect(cmn.IsErrNotFound(err)).To(BeTrue())
+	})
+
+	It("should tell which prior versions to retain", func() {
+		const objName = "history/retained"
+		var lom *cluster.LOM
+		for i := 1; i <= 5; i++ {
+			lom = put(objName, 10)
+		}
+		Expect(lom.Version()).To(Equal("5"))
+		now := time.Now()
+		for v := 1; v <= 5; v++ {
+			retained := lom.IsVersionRetained(strconv.Itoa(v), now, now)
+			Expect(retained).To(Equal(v == 3 || v == 4), "version %d", v)
+		}
+	})
+
+	It("should parse prior version names", func() {
+		orig, ver, ok := fs.ParseObjVersion("a/b/c.v12")
+		Expect(ok).To(BeTrue())
+		Expect(orig).To(Equal("a/b/c"))
+		Expect(ver).To(Equal("12"))
+		_, _, ok = fs.ParseObjVersion("a/b/c.vx")
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -58,6 +58,7 @@ const (
 	lomObjSize
 	lomObjCopies
 	lomCustomMD
+	lomDiskSize
 )
 
 // packing format separators
@@ -301,6 +302,7 @@ func (md *lmeta) unmarshal(buf []byte) error {
 		cksumType, cksumValue             string
 		haveSize, haveVersion, haveCopies bool
 		haveCksumType, haveCksumValue     bool
+		haveDiskSize                      bool
 		last                              bool
 	)
 	if len(buf) < prefLen {
@@ -391,6 +393,12 @@ func (md *lmeta) unmarshal(buf []byte) error {
 				custom[entries[i]] = entries[i+1]
 			}
 			md.SetCustomMD(custom)
+		case lomDiskSize:
+			if haveDiskSize {
+				return errors.New(invalid + " #9")
+			}
+			md.dsize = int64(binary.BigEndian.Uint64([]byte(val)))
+			haveDiskSize = true
 		default:
 			return errors.New(invalid + " #6")
 		}
@@ -421,6 +429,11 @@ func (md *lmeta) marshal(mm *memsys.MMSA, mdSize int64) (buf []byte) {
 	}
 	binary.BigEndian.PutUint64(b8[:], uint64(md.Size))
 	buf = _marshRecord(mm, buf, lomObjSize, string(b8[:]), false)
+	if md.dsize != 0 {
+		buf = mm.Append(buf, recordSepa)
+		binary.BigEndian.PutUint64(b8[:], uint64(md.dsize))
+		buf = _marshRecord(mm, buf, lomDiskSize, string(b8[:]), false)
+	}
 	if len(md.copies) > 0 {
 		buf = mm.Append(buf, recordSepa)
 		buf = _marshRecord(mm, buf, lomObjCopies, "", false)
@@ -139,6 +139,22 @@ var _ = Describe("LOM Xattributes", func() {
 				Expect(lom.GetCustomMD()).To(BeEquivalentTo(newLom.GetCustomMD()))
 			})
 
+			It("should save size on disk of a compressed object", func() {
+				lom := filePut(localFQN, testFileSize)
+				lom.Lock(true)
+				defer lom.Unlock(true)
+				lom.SetSize(int64(testFileSize) * 3)
+				lom.SetCompressed(int64(testFileSize))
+				Expect(persist(lom)).NotTo(HaveOccurred())
+
+				lom.Uncache(true)
+				newLom := NewBasicLom(localFQN)
+				Expect(newLom.Load(false, true)).NotTo(HaveOccurred())
+				Expect(newLom.IsCompressed()).To(BeTrue())
+				Expect(newLom.SizeBytes()).To(BeEquivalentTo(testFileSize * 3))
+				Expect(newLom.SizeOnDisk()).To(BeEquivalentTo(testFileSize))
+			})
+
 			It("should _not_ save meta to disk", func() {
 				lom := filePut(cachedFQN, testFileSize)
 				Expect(lom.IsHRW()).To(BeTrue())
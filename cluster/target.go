@@ -118,6 +118,7 @@ type (
 		WorkTag    string // (=> work fqn)
 		OWT        cmn.OWT
 		SkipEncode bool // don't run erasure-code when finalizing
+		Raw        bool // reader delivers on-disk (encrypted or compressed) content, to store as is
 	}
 	CopyObjectParams struct {
 		DM        DataMover
@@ -297,6 +297,16 @@ func (h *hserv) userLogin(w http.ResponseWriter, r *http.Request) {
 		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
 		return
 	}
+	if len(apiItems) > 1 && apiItems[1] == authn.S3Key {
+		accessKey, err := tok.S3AccessKey(tokenString, Conf.Secret())
+		if err != nil {
+			cmn.WriteErr(w, r, err, http.StatusInternalServerError)
+			return
+		}
+		key := &authn.S3KeyMsg{AccessKey: accessKey, SecretKey: tok.S3SecretKey(accessKey, Conf.Secret())}
+		writeJSON(w, key, "auth-s3key")
+		return
+	}
 
 	repl := fmt.Sprintf(`{"token": %q}`, tokenString)
 	writeBytes(w, []byte(repl), "auth")
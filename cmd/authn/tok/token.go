@@ -6,6 +6,13 @@
 package tok
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
@@ -91,6 +98,73 @@ func DecryptToken(tokenStr, secret string) (*Token, error) {
 	return tk, nil
 }
 
+// S3 access keys: access key ID is an opaque handle that AIS gateways (and only
+// AuthN and AIS gateways - the holders of the shared secret) can map back to the
+// AuthN-issued token: the token sealed with a key derived from the secret.
+// The corresponding secret access key is derived from the access key ID and the
+// same secret. This way, AIS gateways can verify SigV4 signatures without storing
+// (or receiving) any S3 secrets, while the token itself never travels in
+// `Credential=` (or presigned `X-Amz-Credential`) and cannot be extracted
+// (and used as a bearer token) by anyone holding the access key.
+
+const s3KeyPrefix = "AKAIS"
+
+func s3KeyAEAD(secret string) (cipher.AEAD, error) {
+	key := sha256.Sum256([]byte("s3-access-key:" + secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// IsS3AccessKey returns true if the string looks like an S3 access key ID (and not a token)
+func IsS3AccessKey(s string) bool { return strings.HasPrefix(s, s3KeyPrefix) }
+
+// S3AccessKey seals the token into an opaque S3 access key ID
+func S3AccessKey(token, secret string) (string, error) {
+	aead, err := s3KeyAEAD(secret)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(token)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(token), []byte(s3KeyPrefix))
+	return s3KeyPrefix + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// S3Token maps S3 access key ID back to the token
+func S3Token(accessKey, secret string) (string, error) {
+	if !IsS3AccessKey(accessKey) {
+		return "", ErrInvalidToken
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(accessKey[len(s3KeyPrefix):])
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	aead, err := s3KeyAEAD(secret)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", ErrInvalidToken
+	}
+	token, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(s3KeyPrefix))
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	return string(token), nil
+}
+
+func S3SecretKey(accessKey, secret string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte("s3:"))
+	h.Write([]byte(accessKey))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 ///////////
 // Token //
 ///////////
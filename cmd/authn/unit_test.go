@@ -5,6 +5,7 @@
 package main
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -194,6 +195,31 @@ func TestToken(t *testing.T) {
 	}
 }
 
+func TestS3Key(t *testing.T) {
+	var (
+		secret = Conf.Server.Secret
+		token  = "header.claims.signature"
+	)
+	accessKey, err := tok.S3AccessKey(token, secret)
+	tassert.CheckFatal(t, err)
+	if !tok.IsS3AccessKey(accessKey) || strings.Contains(accessKey, token) || strings.Contains(accessKey, "/") {
+		t.Fatalf("invalid S3 access key %q", accessKey)
+	}
+	s, err := tok.S3Token(accessKey, secret)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, s == token, "expecting %q, got %q", token, s)
+
+	if _, err := tok.S3Token(accessKey, secret+"x"); err == nil {
+		t.Error("expecting error (wrong secret)")
+	}
+	if _, err := tok.S3Token(accessKey[:len(accessKey)-1]+"A", secret); err == nil {
+		t.Error("expecting error (tampered with)")
+	}
+	if _, err := tok.S3Token(token, secret); err == nil {
+		t.Error("expecting error (token is not an access key)")
+	}
+}
+
 func TestMergeCluACLS(t *testing.T) {
 	tests := []struct {
 		title    string
@@ -430,6 +430,12 @@ func listObjects(c *cli.Context, bck cmn.Bck, prefix string, listArch bool) erro
 	if listArch {
 		msg.SetFlag(apc.LsArchDir)
 	}
+	if flagIsSet(c, listDeletedFlag) {
+		msg.SetFlag(apc.LsDeleted)
+	}
+	if flagIsSet(c, listHTTPIndexFlag) {
+		msg.SetFlag(apc.LsHTTPIndex)
+	}
 	if flagIsSet(c, allObjsOrBcksFlag) {
 		msg.SetFlag(apc.LsAll)
 	}
@@ -28,6 +28,15 @@ var examplesBckSetProps = `
 Usage examples:
 - ais bucket props set [BUCKET] checksum.type=xxhash
 - ais bucket props set ais://nnn checksum.type=md5 checksum.validate_warm_get=true
+- ais bucket props set ais://nnn object_lock.enabled=true object_lock.mode=governance object_lock.retention=720h
+- ais bucket props set ais://nnn compression.enabled=true compression.algo=zstd
+- ais bucket props set ais://nnn tiering.enabled=true tiering.demote_after=72h
+- ais bucket props set ais://nnn versioning.history=3 versioning.history_ttl=168h
+- ais bucket props set ais://nnn trash.enabled=true trash.retention=24h
+- ais bucket props set ais://nnn quota.hard.size=10GiB quota.hard.objects=1000000
+- ais bucket props set ht://nnn extra.http.manifest=https://example.com/dataset/manifest.txt
+- ais bucket props set s3://nnn extra.cold_get.chunk_size=64MiB extra.cold_get.concurrency=8
+- ais bucket props set s3://nnn write_policy.data=write_back
 - ais bucket props [BUCKET] checksum
   (see docs/cli for details)
 `
@@ -90,6 +99,8 @@ var (
 			listObjCachedFlag,
 			listAnonymousFlag,
 			listArchFlag,
+			listDeletedFlag,
+			listHTTPIndexFlag,
 			nameOnlyFlag,
 			unitsFlag,
 			bckSummaryFlag,
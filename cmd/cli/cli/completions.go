@@ -574,6 +574,7 @@ func rebalanceCompletions(c *cli.Context) {
 //
 
 func downloadIDFinishedCompletions(c *cli.Context) { suggestDownloadID(c, (*dload.Job).JobFinished, 0) }
+func downloadIDRunningCompletions(c *cli.Context)  { suggestDownloadID(c, (*dload.Job).JobRunning, 0) }
 
 func suggestDownloadID(c *cli.Context, filter func(*dload.Job) bool, shift int) {
 	if c.NArg() > shift {
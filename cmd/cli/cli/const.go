@@ -63,6 +63,7 @@ const (
 	commandPut       = "put"
 	commandRemove    = "rm"
 	commandRename    = "mv"
+	commandUndelete  = "undelete"
 	commandSet       = "set"
 	commandStart     = apc.ActXactStart
 	commandStop      = apc.ActXactStop
@@ -163,10 +164,12 @@ const (
 	cmdK8sCluster = commandCluster
 
 	// ETL subcommands
-	cmdInit = "init"
-	cmdSpec = "spec"
-	cmdCode = "code"
-	cmdSrc  = "source"
+	cmdInit     = "init"
+	cmdSpec     = "spec"
+	cmdCode     = "code"
+	cmdBuiltin  = "builtin"
+	cmdPipeline = "pipeline"
+	cmdSrc      = "source"
 
 	// config subcommands
 	cmdCLI        = "cli"
@@ -254,6 +257,7 @@ const (
 	objectArgument          = "BUCKET/OBJECT_NAME"
 	optionalObjectsArgument = "BUCKET[/OBJECT_NAME]..."
 	renameObjectArgument    = "BUCKET/OBJECT_NAME NEW_OBJECT_NAME"
+	objectsArgument         = "BUCKET/OBJECT_NAME [BUCKET/OBJECT_NAME...]"
 	appendToArchArgument    = "FILE BUCKET[/OBJECT_NAME]"
 
 	setCustomArgument = objectArgument + " " + jsonKeyValueArgument + " | " + keyValuePairsArgument + ", e.g.:\n" +
@@ -507,11 +511,26 @@ var (
 		Usage: "maximum download speed, as in: maximum size per target (node) per hour (see '--units'), e.g.:\n" +
 			indent4 + "\t--limit-bph 1MiB or, same, --limit-bph 1048576",
 	}
+	limitBytesPerSecFlag = cli.StringFlag{
+		Name: "limit-bps",
+		Usage: "maximum download speed (bytes per second) of the job, cluster-wide (see '--units'), e.g.:\n" +
+			indent4 + "\t--limit-bps 100MiB or, same, --limit-bps 104857600",
+	}
+	dloadWeightFlag = cli.IntFlag{
+		Name: "weight",
+		Usage: "job's share of the target's bandwidth (see 'downloader.max_bandwidth' config) and download queues,\n" +
+			indent4 + "\trelative to other concurrently running download jobs (default: 1)",
+	}
 	objectsListFlag = cli.StringFlag{
 		Name:  "object-list,from",
 		Usage: "path to file containing JSON array of object names to download",
 	}
-	syncFlag = cli.BoolFlag{Name: "sync", Usage: "sync bucket with Cloud"}
+	syncFlag          = cli.BoolFlag{Name: "sync", Usage: "sync bucket with Cloud"}
+	dloadScheduleFlag = cli.StringFlag{
+		Name: "schedule",
+		Usage: "run the download periodically (each run downloads only new or changed objects):\n" +
+			indent4 + "\tinterval, e.g.: '--schedule 24h', or cron expression (UTC), e.g.: '--schedule \"0 2 * * *\"'",
+	}
 
 	// dSort
 	dsortFsizeFlag  = cli.StringFlag{Name: "fsize", Value: "1024", Usage: "size of the files in a shard"}
@@ -625,6 +644,14 @@ var (
 		Name:  "anonymous",
 		Usage: "list public-access Cloud buckets that may disallow certain operations (e.g., 'HEAD(bucket)')",
 	}
+	listHTTPIndexFlag = cli.BoolFlag{
+		Name:  "index",
+		Usage: "list ht:// bucket by crawling index pages of the origin web server (bucket without 'extra.http.manifest')",
+	}
+	listDeletedFlag = cli.BoolFlag{
+		Name:  "deleted",
+		Usage: "list deleted objects that can be undeleted (ais:// buckets with trash enabled, see 'ais object undelete --help')",
+	}
 
 	enableFlag  = cli.BoolFlag{Name: "enable", Usage: "enable"}
 	disableFlag = cli.BoolFlag{Name: "disable", Usage: "disable"}
@@ -747,6 +774,33 @@ var (
 		Name:  "comm-type",
 		Usage: "communication type which should be used when running the provided code",
 	}
+	wasmFileFlag = cli.StringFlag{
+		Name:  "wasm-file",
+		Usage: "path to the WebAssembly module (required with builtin 'wasm'); requires WebAssembly runtime registered with aisnode",
+	}
+	etlArgsFlag = cli.StringFlag{
+		Name:  "args",
+		Usage: "transformer-specific arguments (e.g., compression level for builtin 'gzip')",
+	}
+	etlMemLimitFlag = cli.StringFlag{
+		Name:  "mem-limit",
+		Usage: "max size of the transformed object when buffered in memory, i.e., offline (and, for WebAssembly, the module's memory), e.g. 64MiB",
+	}
+	etlObjTimeoutFlag = DurationFlag{
+		Name: "obj-timeout",
+		Usage: "max time to transform a single object;\n" +
+			indent4 + "\tvalid time units: " + timeUnits,
+	}
+	etlOutputFlag = cli.StringFlag{
+		Name: "output",
+		Usage: "transformer returns multiple objects per object: 'multipart' (MIME) or 'tar'\n" +
+			indent4 + "\t(offline transformation stores each of them as <DST_OBJECT_NAME without extension>/<NAME>)",
+	}
+	etlLocalFlag = cli.StringFlag{
+		Name: "local",
+		Usage: "when not deployed on Kubernetes: run ETL as a local 'process' or 'container'\n" +
+			indent4 + "\t(requires feature flag 'Local-ETL'; default: 'process' for spec, 'container' for code)",
+	}
 	funcTransformFlag = cli.StringFlag{
 		Name:  "transform",
 		Value: "transform", // NOTE: default name of the transform() function
@@ -382,6 +382,9 @@ func printDownloadStatus(c *cli.Context, d *dload.StatusResp, verbose bool) {
 		return
 	}
 
+	if d.Schedule != "" {
+		fmt.Fprintf(w, "Scheduled (%s), next run at %s\n", d.Schedule, d.NextRun.Format(time.RFC3339))
+	}
 	if d.JobFinished() {
 		var skipped, errs string
 		if d.SkippedCnt > 0 {
@@ -40,12 +40,28 @@ var (
 			unitsFlag,
 			waitPodReadyTimeoutFlag,
 			etlNameFlag,
+			etlLocalFlag,
+			etlOutputFlag,
 		},
 		cmdSpec: {
 			fromFileFlag,
 			commTypeFlag,
 			etlNameFlag,
 			waitPodReadyTimeoutFlag,
+			etlLocalFlag,
+			etlOutputFlag,
+		},
+		cmdBuiltin: {
+			etlNameFlag,
+			etlArgsFlag,
+			wasmFileFlag,
+			etlMemLimitFlag,
+			etlObjTimeoutFlag,
+			etlOutputFlag,
+		},
+		cmdPipeline: {
+			etlNameFlag,
+			etlOutputFlag,
 		},
 		cmdStop: {
 			allRunningJobsFlag,
@@ -107,6 +123,21 @@ var (
 				Flags:  etlSubFlags[cmdCode],
 				Action: etlInitCodeHandler,
 			},
+			{
+				Name:      cmdBuiltin,
+				Usage:     "start ETL job with in-process transformer: Go function compiled into aisnode or WebAssembly module",
+				ArgsUsage: "TRANSFORMER",
+				Flags:     etlSubFlags[cmdBuiltin],
+				Action:    etlInitBuiltinHandler,
+			},
+			{
+				Name:         cmdPipeline,
+				Usage:        "start ETL pipeline that chains (two or more) existing ETLs",
+				ArgsUsage:    etlNameListArgument,
+				Flags:        etlSubFlags[cmdPipeline],
+				Action:       etlInitPipelineHandler,
+				BashComplete: etlIDCompletions,
+			},
 		},
 	}
 	objCmdETL = cli.Command{
@@ -202,6 +233,8 @@ func etlInitSpecHandler(c *cli.Context) (err error) {
 	{
 		msg.IDX = parseStrFlag(c, etlNameFlag)
 		msg.CommTypeX = parseStrFlag(c, commTypeFlag)
+		msg.Local = parseStrFlag(c, etlLocalFlag)
+		msg.Output = parseStrFlag(c, etlOutputFlag)
 		msg.Spec = spec
 	}
 	if err = msg.Validate(); err != nil {
@@ -252,6 +285,8 @@ func etlInitCodeHandler(c *cli.Context) (err error) {
 
 	msg.Runtime = parseStrFlag(c, runtimeFlag)
 	msg.CommTypeX = parseStrFlag(c, commTypeFlag)
+	msg.Local = parseStrFlag(c, etlLocalFlag)
+	msg.Output = parseStrFlag(c, etlOutputFlag)
 
 	if flagIsSet(c, chunkSizeFlag) {
 		msg.ChunkSize, err = parseSizeFlag(c, chunkSizeFlag)
@@ -289,6 +324,70 @@ func etlInitCodeHandler(c *cli.Context) (err error) {
 	return nil
 }
 
+func etlInitBuiltinHandler(c *cli.Context) (err error) {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	msg := &etl.InitBuiltinMsg{}
+	{
+		msg.IDX = parseStrFlag(c, etlNameFlag)
+		msg.Builtin = c.Args().Get(0)
+		msg.Args = parseStrFlag(c, etlArgsFlag)
+		msg.Output = parseStrFlag(c, etlOutputFlag)
+		msg.ObjTimeout = cos.Duration(parseDurationFlag(c, etlObjTimeoutFlag))
+	}
+	if flagIsSet(c, etlMemLimitFlag) {
+		limit, err := parseSizeFlag(c, etlMemLimitFlag)
+		if err != nil {
+			return err
+		}
+		msg.MemLimit = cos.SizeIEC(limit)
+	}
+	if wasmFile := parseStrFlag(c, wasmFileFlag); wasmFile != "" {
+		if msg.Wasm, err = os.ReadFile(wasmFile); err != nil {
+			return fmt.Errorf("failed to read %q: %v", wasmFile, err)
+		}
+	}
+	if err = msg.Validate(); err != nil {
+		return err
+	}
+	if err = etlAlreadyExists(msg.Name()); err != nil {
+		return
+	}
+
+	xid, err := api.ETLInit(apiBP, msg)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "ETL[%s]: job %q\n", msg.Name(), xid)
+	return nil
+}
+
+func etlInitPipelineHandler(c *cli.Context) (err error) {
+	if c.NArg() < 2 {
+		return missingArgumentsError(c, "at least two "+etlNameArgument+"s")
+	}
+	msg := &etl.InitPipelineMsg{}
+	{
+		msg.IDX = parseStrFlag(c, etlNameFlag)
+		msg.Stages = c.Args()
+		msg.Output = parseStrFlag(c, etlOutputFlag)
+	}
+	if err = msg.Validate(); err != nil {
+		return err
+	}
+	if err = etlAlreadyExists(msg.Name()); err != nil {
+		return
+	}
+
+	xid, err := api.ETLInit(apiBP, msg)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.App.Writer, "ETL[%s]: job %q\n", msg.Name(), xid)
+	return nil
+}
+
 func etlListHandler(c *cli.Context) (err error) {
 	_, err = etlList(c, false)
 	return
@@ -342,6 +441,19 @@ func etlPrintInitMsg(c *cli.Context, id string) error {
 		fmt.Fprintln(c.App.Writer, string(initMsg.Spec))
 		return nil
 	}
+	if initMsg, ok := msg.(*etl.InitBuiltinMsg); ok {
+		if initMsg.Builtin == etl.BuiltinWasm {
+			fmt.Fprintf(c.App.Writer, "%s: WebAssembly module (%s)\n",
+				initMsg.Builtin, cos.ToSizeIEC(int64(len(initMsg.Wasm)), 2))
+		} else {
+			fmt.Fprintf(c.App.Writer, "%s %s\n", initMsg.Builtin, initMsg.Args)
+		}
+		return nil
+	}
+	if initMsg, ok := msg.(*etl.InitPipelineMsg); ok {
+		fmt.Fprintln(c.App.Writer, strings.Join(initMsg.Stages, " -> "))
+		return nil
+	}
 	err = fmt.Errorf("invalid response [%+v, %T]", msg, msg)
 	debug.AssertNoErr(err)
 	return err
@@ -42,6 +42,7 @@ var (
 		jobStopSub,
 		jobWaitSub,
 		jobRemoveSub,
+		jobSetSub,
 		makeAlias(showCmdJob, "", true, commandShow), // alias for `ais show`
 	}
 )
@@ -63,7 +64,10 @@ var (
 			waitFlag,
 			waitJobXactFinishedFlag,
 			limitBytesPerHourFlag,
+			limitBytesPerSecFlag,
+			dloadWeightFlag,
 			syncFlag,
+			dloadScheduleFlag,
 			unitsFlag,
 		},
 		cmdDsort: {
@@ -213,6 +217,28 @@ var (
 	}
 )
 
+// ais job set
+var (
+	jobSetSub = cli.Command{
+		Name:  commandSet,
+		Usage: "change limits of a running job",
+		Subcommands: []cli.Command{
+			{
+				Name: cmdDownload,
+				Usage: "change bandwidth limit and/or weight of a running download job, e.g.:\n" +
+					indent1 + "\t- 'ais job set download JOB_ID --limit-bps 100MiB'\t- limit the job to 100MiB/s (cluster-wide);\n" +
+					indent1 + "\t- 'ais job set download JOB_ID --weight 4'\t- prioritize the job vs. other download jobs;\n" +
+					indent1 + "\t- 'ais job set download JOB_ID --limit-bps 0'\t- remove the limit.\n" +
+					indent1 + "(limits that are not specified remain unchanged)",
+				ArgsUsage:    jobIDArgument,
+				Flags:        []cli.Flag{limitBytesPerHourFlag, limitBytesPerSecFlag, dloadWeightFlag, unitsFlag},
+				Action:       setDownloadLimitsHandler,
+				BashComplete: downloadIDRunningCompletions,
+			},
+		},
+	}
+)
+
 func appendJobSub(jobcmd *cli.Command) {
 	debug.Assert(jobcmd.Subcommands[0].Name == commandStart)
 
@@ -367,10 +393,11 @@ func startDownloadHandler(c *cli.Context) error {
 		return err
 	}
 
-	limitBPH, err := parseSizeFlag(c, limitBytesPerHourFlag)
+	limits, err := parseDownloadLimits(c)
 	if err != nil {
 		return err
 	}
+	limits.Connections = parseIntFlag(c, limitConnectionsFlag)
 
 	if _, err := time.ParseDuration(progressInterval); err != nil {
 		return err
@@ -381,10 +408,8 @@ func startDownloadHandler(c *cli.Context) error {
 		Timeout:          timeout,
 		Description:      description,
 		ProgressInterval: progressInterval,
-		Limits: dload.Limits{
-			Connections:  parseIntFlag(c, limitConnectionsFlag),
-			BytesPerHour: int(limitBPH),
-		},
+		Schedule:         parseStrFlag(c, dloadScheduleFlag),
+		Limits:           limits,
 	}
 
 	if basePayload.Bck.Props, err = api.HeadBucket(apiBP, basePayload.Bck, true /* don't add */); err != nil {
@@ -491,6 +516,10 @@ func startDownloadHandler(c *cli.Context) error {
 		return err
 	}
 
+	if basePayload.Schedule != "" {
+		fmt.Fprintf(c.App.Writer, "Scheduled download job %s (%s)\n", id, basePayload.Schedule)
+		return nil
+	}
 	fmt.Fprintf(c.App.Writer, "Started download job %s\n", id)
 
 	if flagIsSet(c, progressFlag) {
@@ -504,6 +533,20 @@ func startDownloadHandler(c *cli.Context) error {
 	return bgDownload(c, id)
 }
 
+func parseDownloadLimits(c *cli.Context) (limits dload.Limits, err error) {
+	var limitBPH, limitBPS int64
+	if limitBPH, err = parseSizeFlag(c, limitBytesPerHourFlag); err != nil {
+		return
+	}
+	if limitBPS, err = parseSizeFlag(c, limitBytesPerSecFlag); err != nil {
+		return
+	}
+	limits.BytesPerHour = int(limitBPH)
+	limits.BytesPerSec = limitBPS
+	limits.Weight = parseIntFlag(c, dloadWeightFlag)
+	return
+}
+
 func pbDownload(c *cli.Context, id string) (err error) {
 	refreshRate := _refreshRate(c)
 	downloadingResult, err := newDownloaderPB(apiBP, id, refreshRate).run()
@@ -1177,6 +1220,40 @@ func waitDsortHandler(c *cli.Context, id string) error {
 	return nil
 }
 
+//
+// job set
+//
+
+// change only the limits that are explicitly specified (see dload.LimitsToUpdate)
+func setDownloadLimitsHandler(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return missingArgumentsError(c, jobIDArgument)
+	}
+	if !flagIsSet(c, limitBytesPerHourFlag) && !flagIsSet(c, limitBytesPerSecFlag) && !flagIsSet(c, dloadWeightFlag) {
+		return missingArgumentsError(c, qflprn(limitBytesPerSecFlag)+" and/or "+qflprn(dloadWeightFlag))
+	}
+	parsed, err := parseDownloadLimits(c)
+	if err != nil {
+		return err
+	}
+	limits := &dload.LimitsToUpdate{}
+	if flagIsSet(c, limitBytesPerHourFlag) {
+		limits.BytesPerHour = &parsed.BytesPerHour
+	}
+	if flagIsSet(c, limitBytesPerSecFlag) {
+		limits.BytesPerSec = &parsed.BytesPerSec
+	}
+	if flagIsSet(c, dloadWeightFlag) {
+		limits.Weight = &parsed.Weight
+	}
+	id := c.Args().First()
+	if err := api.SetDownloadLimits(apiBP, id, limits); err != nil {
+		return err
+	}
+	actionDone(c, fmt.Sprintf("Changed limits of download job %q", id))
+	return nil
+}
+
 //
 // job remove
 //
@@ -1209,7 +1286,7 @@ func removeDownloadRegex(c *cli.Context, regex string) error {
 
 	var cnt int
 	for _, dl := range dlList {
-		if !dl.JobFinished() {
+		if !dl.JobFinished() || dl.Schedule != "" { // (scheduled jobs are removed explicitly, by ID)
 			continue
 		}
 		err = api.RemoveDownload(apiBP, dl.ID)
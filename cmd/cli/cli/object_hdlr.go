@@ -7,6 +7,7 @@ package cli
 
 import (
 	"fmt"
+	"net/http"
 	"path/filepath"
 	"time"
 
@@ -26,7 +27,8 @@ var (
 			verboseFlag,
 			yesFlag,
 		),
-		commandRename: {},
+		commandRename:   {},
+		commandUndelete: {},
 		commandGet: {
 			offsetFlag,
 			lengthFlag,
@@ -152,6 +154,15 @@ var (
 				Action:       removeObjectHandler,
 				BashComplete: bucketCompletions(bcmplop{multiple: true, separator: true}),
 			},
+			{
+				Name: commandUndelete,
+				Usage: "restore the most recently deleted instance of the object(s) from the bucket's trash\n" +
+					indent4 + "\t(ais:// buckets with 'trash.enabled', within 'trash.retention'; see also 'ais ls --deleted')",
+				ArgsUsage:    objectsArgument,
+				Flags:        objectCmdsFlags[commandUndelete],
+				Action:       undeleteObjectHandler,
+				BashComplete: bucketCompletions(bcmplop{multiple: true, separator: true}),
+			},
 			{
 				Name:         commandPromote,
 				Usage:        "promote files and directories (i.e., replicate files and convert them to objects)",
@@ -226,6 +237,29 @@ func mvObjectHandler(c *cli.Context) (err error) {
 	return
 }
 
+func undeleteObjectHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	for _, uri := range c.Args() {
+		bck, objName, err := parseBckObjectURI(c, uri)
+		if err != nil {
+			return err
+		}
+		if !bck.IsAIS() {
+			return incorrectUsageMsg(c, "%s: undelete is supported only for ais:// buckets", bck)
+		}
+		if err := api.UndeleteObject(apiBP, bck, objName); err != nil {
+			if herr, ok := err.(*cmn.ErrHTTP); ok && herr.Status == http.StatusNotFound {
+				return fmt.Errorf("%s/%s not found in trash (not deleted, purged, or trash is disabled)", bck, objName)
+			}
+			return err
+		}
+		fmt.Fprintf(c.App.Writer, "%q undeleted in %s\n", objName, bck.DisplayName())
+	}
+	return nil
+}
+
 func removeObjectHandler(c *cli.Context) (err error) {
 	if c.NArg() == 0 {
 		return missingArgumentsError(c, c.Command.ArgsUsage)
@@ -102,7 +102,8 @@ const (
 	downloadListBody = "{{$value.ID}}\t " +
 		"{{$value.XactID}}\t " +
 		"{{if $value.Aborted}}Aborted" +
-		"{{else}}{{if $value.JobFinished}}Finished{{else}}{{$value.PendingCnt}} pending{{end}}" +
+		"{{else}}{{if $value.JobFinished}}{{if $value.Schedule}}Scheduled ({{$value.Schedule}}){{else}}Finished{{end}}" +
+		"{{else}}{{$value.PendingCnt}} pending{{end}}" +
 		"{{end}}\t {{$value.ErrorCnt}}\t {{$value.Description}}\n"
 	DownloadListNoHdrTmpl = "{{ range $key, $value := . }}" + downloadListBody + "{{end}}"
 	DownloadListTmpl      = downloadListHdr + DownloadListNoHdrTmpl
@@ -8,6 +8,7 @@ package cmn
 import (
 	"fmt"
 	"math"
+	"net/url"
 	"path/filepath"
 	"reflect"
 	"sort"
@@ -45,17 +46,29 @@ type (
 		BID         uint64          `json:"bid,string" list:"omit"`         // unique ID
 		Created     int64           `json:"created,string" list:"readonly"` // creation timestamp
 		Versioning  VersionConf     `json:"versioning"`                     // versioning (see "inherit" here and elsewhere)
+		Lifecycle   LifecycleConf   `json:"lifecycle" list:"omitempty"`     // expiration rules (bucket-only, not inherited)
+		ObjectLock  ObjLockConf     `json:"object_lock"`                    // object lock (WORM) and default retention
+		Encryption  EncryptionConf  `json:"encryption"`                     // server-side encryption at rest
+		Compression CompressionConf `json:"compression"`                    // transparent (on-disk) compression
+		Tiering     TieringConf     `json:"tiering"`                        // demotion and promotion between storage tiers
+		Trash       TrashConf       `json:"trash"`                          // soft delete and undelete
+		Quota       QuotaConf       `json:"quota"`                          // soft and hard limits: number of objects and total size
 	}
 
 	ExtraProps struct {
 		AWS  ExtraPropsAWS  `json:"aws,omitempty" list:"omitempty"`
 		HTTP ExtraPropsHTTP `json:"http,omitempty" list:"omitempty"`
 		HDFS ExtraPropsHDFS `json:"hdfs,omitempty" list:"omitempty"`
+		FS   ExtraPropsFS   `json:"fs,omitempty" list:"omitempty"`
+		// provider-agnostic
+		ColdGet ExtraPropsColdGet `json:"cold_get,omitempty" list:"omitempty"`
 	}
 	ExtraToUpdate struct { // ref. bpropsFilterExtra
-		AWS  *ExtraPropsAWSToUpdate  `json:"aws"`
-		HTTP *ExtraPropsHTTPToUpdate `json:"http"`
-		HDFS *ExtraPropsHDFSToUpdate `json:"hdfs"`
+		AWS     *ExtraPropsAWSToUpdate     `json:"aws"`
+		HTTP    *ExtraPropsHTTPToUpdate    `json:"http"`
+		HDFS    *ExtraPropsHDFSToUpdate    `json:"hdfs"`
+		FS      *ExtraPropsFSToUpdate      `json:"fs"`
+		ColdGet *ExtraPropsColdGetToUpdate `json:"cold_get"`
 	}
 
 	ExtraPropsAWS struct {
@@ -64,6 +77,7 @@ type (
 		// from https://github.com/aws/aws-sdk-go/blob/main/aws/config.go:
 		//   "An optional endpoint URL (hostname only or fully qualified URI)
 		//    that overrides the default generated endpoint."
+		// Alternatively, alias of one of the named S3 endpoints (see BackendConfAWS).
 		Endpoint string `json:"endpoint,omitempty"`
 	}
 	ExtraPropsAWSToUpdate struct {
@@ -74,9 +88,12 @@ type (
 	ExtraPropsHTTP struct {
 		// Original URL prior to hashing.
 		OrigURLBck string `json:"original_url,omitempty" list:"readonly"`
+		// URL of the list of objects (optional) - see ais/backend/http.go ListObjects.
+		Manifest string `json:"manifest,omitempty" list:"omitempty"`
 	}
 	ExtraPropsHTTPToUpdate struct {
 		OrigURLBck *string `json:"original_url"`
+		Manifest   *string `json:"manifest"`
 	}
 
 	ExtraPropsHDFS struct {
@@ -87,6 +104,27 @@ type (
 		RefDirectory *string `json:"ref_directory"`
 	}
 
+	ExtraPropsFS struct {
+		// Reference directory: absolute local path (e.g., NFS mount) that must be
+		// accessible by all targets.
+		RefDirectory string `json:"ref_directory,omitempty"`
+	}
+	ExtraPropsFSToUpdate struct {
+		RefDirectory *string `json:"ref_directory"`
+	}
+
+	// Parallel cold GET: objects larger than `ChunkSize` get downloaded from the remote
+	// backend in chunks, via up to `Concurrency` concurrent range reads (see ais/tgtcoldget.go).
+	// Applies to backends that support range reads: aws, gcp, and azure.
+	ExtraPropsColdGet struct {
+		ChunkSize   cos.SizeIEC `json:"chunk_size,omitempty"`  // zero: single-stream cold GET (default)
+		Concurrency int         `json:"concurrency,omitempty"` // zero: ColdGetDfltConcurrency
+	}
+	ExtraPropsColdGetToUpdate struct {
+		ChunkSize   *cos.SizeIEC `json:"chunk_size"`
+		Concurrency *int         `json:"concurrency"`
+	}
+
 	// Once validated, BucketPropsToUpdate are copied to BucketProps.
 	// The struct may have extra fields that do not exist in BucketProps.
 	// Add tag 'copy:"skip"' to ignore those fields when copying values.
@@ -100,6 +138,13 @@ type (
 		Access      *apc.AccessAttrs         `json:"access,string,omitempty"`
 		WritePolicy *WritePolicyConfToUpdate `json:"write_policy,omitempty"`
 		Extra       *ExtraToUpdate           `json:"extra,omitempty"`
+		Lifecycle   *LifecycleConfToUpdate   `json:"lifecycle,omitempty"`
+		ObjectLock  *ObjLockConfToUpdate     `json:"object_lock,omitempty"`
+		Encryption  *EncryptionConfToUpdate  `json:"encryption,omitempty"`
+		Compression *CompressionConfToUpdate `json:"compression,omitempty"`
+		Tiering     *TieringConfToUpdate     `json:"tiering,omitempty"`
+		Trash       *TrashConfToUpdate       `json:"trash,omitempty"`
+		Quota       *QuotaConfToUpdate       `json:"quota,omitempty"`
 		Force       bool                     `json:"force,omitempty" copy:"skip" list:"omit"`
 	}
 
@@ -255,7 +300,11 @@ func (bp *BucketProps) Validate(targetCnt int) error {
 		}
 	}
 	var softErr error
-	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy} {
+	validators := []PropsValidator{
+		&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy, &bp.Lifecycle, &bp.ObjectLock, &bp.Encryption,
+		&bp.Compression, &bp.Tiering, &bp.Versioning, &bp.Trash, &bp.Quota,
+	}
+	for _, pv := range validators {
 		var err error
 		if pv == &bp.EC {
 			err = bp.EC.ValidateAsProps(targetCnt)
@@ -274,6 +323,22 @@ func (bp *BucketProps) Validate(targetCnt int) error {
 	if bp.Mirror.Enabled && bp.EC.Enabled {
 		return fmt.Errorf("cannot enable mirroring and ec at the same time for the same bucket")
 	}
+	if bp.Trash.Enabled && bp.EC.Enabled {
+		return fmt.Errorf("cannot enable trash and ec at the same time for the same bucket")
+	}
+	if bp.Compression.Enabled && bp.Encryption.Enabled {
+		return fmt.Errorf("cannot enable compression and encryption at the same time for the same bucket")
+	}
+	if bp.WritePolicy.Data == apc.WriteBack {
+		provider := bp.Provider
+		if !bp.BackendBck.IsEmpty() {
+			provider = bp.BackendBck.Provider
+		}
+		if provider == apc.AIS || provider == apc.HTTP {
+			return fmt.Errorf("write policy %q requires a remote bucket or a bucket with remote backend"+
+				" (other than %q)", apc.WriteBack, apc.HTTP)
+		}
+	}
 	return softErr
 }
 
@@ -308,15 +373,57 @@ func NewBucketPropsToUpdate(nvs cos.StrKVs) (props *BucketPropsToUpdate, err err
 func (c *ExtraProps) ValidateAsProps(arg ...any) error {
 	provider, ok := arg[0].(string)
 	debug.Assert(ok)
+	if err := c.ColdGet.validate(); err != nil {
+		return err
+	}
 	switch provider {
 	case apc.HDFS:
 		if c.HDFS.RefDirectory == "" {
 			return fmt.Errorf("reference directory must be set for a bucket with HDFS provider")
 		}
+	case apc.FS:
+		if c.FS.RefDirectory == "" {
+			return fmt.Errorf("reference directory must be set for a bucket with %s provider", apc.FS)
+		}
+		if !filepath.IsAbs(c.FS.RefDirectory) {
+			return fmt.Errorf("reference directory %q must be an absolute path", c.FS.RefDirectory)
+		}
 	case apc.HTTP:
 		if c.HTTP.OrigURLBck == "" {
 			return fmt.Errorf("original bucket URL must be set for a bucket with HTTP provider")
 		}
+		if c.HTTP.Manifest != "" {
+			if u, err := url.Parse(c.HTTP.Manifest); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				return fmt.Errorf("invalid manifest URL %q (expecting http(s)://...)", c.HTTP.Manifest)
+			}
+		}
+	}
+	return nil
+}
+
+const (
+	ColdGetMinChunkSize    = cos.MiB
+	ColdGetMaxConcurrency  = 64
+	ColdGetDfltConcurrency = 4
+)
+
+func (c *ExtraPropsColdGet) IsEnabled() bool { return c.ChunkSize > 0 }
+
+func (c *ExtraPropsColdGet) Workers() int {
+	if c.Concurrency == 0 {
+		return ColdGetDfltConcurrency
+	}
+	return c.Concurrency
+}
+
+func (c *ExtraPropsColdGet) validate() error {
+	if c.ChunkSize != 0 && c.ChunkSize < ColdGetMinChunkSize {
+		return fmt.Errorf("invalid cold_get.chunk_size %s (expecting zero (disabled) or >= %s)",
+			c.ChunkSize, cos.SizeIEC(ColdGetMinChunkSize))
+	}
+	if c.Concurrency < 0 || c.Concurrency > ColdGetMaxConcurrency {
+		return fmt.Errorf("invalid cold_get.concurrency %d (expecting 0 (default) to %d)",
+			c.Concurrency, ColdGetMaxConcurrency)
 	}
 	return nil
 }
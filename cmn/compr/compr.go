@@ -0,0 +1,163 @@
+// Package compr provides transparent (on-disk) object compression: chunked lz4 or zstd
+// format that supports reading arbitrary ranges.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package compr
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v3"
+)
+
+// On-disk format:
+//
+//	header | chunk 0 | ... | chunk N-1 | end-of-chunks | index | trailer
+//
+// where:
+// - header (HeaderSize bytes): magic, version, algorithm, and chunk size;
+// - each chunk: 4-byte (big-endian) length of the stored data, followed by the data itself -
+//   (up to) ChunkSize bytes of the original content, compressed or, if incompressible, stored as is
+//   (in which case the high bit of the length is set);
+// - end-of-chunks: zero length;
+// - index: N 8-byte offsets of the respective chunks;
+// - trailer (trailerSize bytes): original size, N, and magic.
+//
+// Chunk headers make it possible to decompress the content as a stream (see DecWriter),
+// while the index makes for random access (see Reader).
+
+const (
+	ChunkSize  = 256 * 1024
+	HeaderSize = 16
+
+	magic       = "AISZ"
+	version     = 1
+	trailerSize = 16
+	lenSize     = 4
+	flagRaw     = 1 << 31
+
+	algoLZ4  = 1
+	algoZstd = 2
+)
+
+var errCorrupted = errors.New("compr: truncated or corrupted content")
+
+var (
+	zenc *zstd.Encoder
+	zdec *zstd.Decoder
+)
+
+func init() {
+	zenc, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithEncoderConcurrency(1))
+	zdec, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+}
+
+func ValidateAlgo(algo string) error {
+	if algo == "" || algo == apc.LZ4Compression || algo == apc.ZstdCompression {
+		return nil
+	}
+	return fmt.Errorf("invalid compression algorithm %q (expecting one of: %v)", algo, apc.SupportedDiskCompression)
+}
+
+func algo2id(algo string) byte {
+	if algo == apc.ZstdCompression {
+		return algoZstd
+	}
+	return algoLZ4
+}
+
+// Compressible returns false for the objects that are most likely already compressed
+// (judging by their names) - storing those compressed would only waste CPU.
+func Compressible(objName string) bool {
+	ext := strings.ToLower(filepath.Ext(objName))
+	return ext == "" || !cos.StringInSlice(ext[1:], incompressible)
+}
+
+var incompressible = []string{
+	"gz", "tgz", "bz2", "xz", "txz", "lz4", "zst", "zstd", "zip", "7z", "rar", "br", "snappy", "sz",
+	"jpg", "jpeg", "png", "gif", "webp", "heic", "avif", "jp2",
+	"mp3", "aac", "ogg", "opus", "flac", "m4a",
+	"mp4", "m4v", "mkv", "webm", "mov", "avi",
+	"parquet", "orc", "avro",
+}
+
+// IsHeader returns true if `b` starts with a valid header
+func IsHeader(b []byte) bool {
+	if len(b) < HeaderSize || string(b[:4]) != magic || b[4] != version {
+		return false
+	}
+	if b[5] != algoLZ4 && b[5] != algoZstd {
+		return false
+	}
+	return binary.BigEndian.Uint32(b[8:]) == ChunkSize
+}
+
+func newHeader(algo string) (h [HeaderSize]byte) {
+	copy(h[:], magic)
+	h[4], h[5] = version, algo2id(algo)
+	binary.BigEndian.PutUint32(h[8:], ChunkSize)
+	return
+}
+
+func numChunks(size int64) int64 { return (size + ChunkSize - 1) / ChunkSize }
+
+//
+// chunks
+//
+
+// compress `plain` into `dst` (that must have enough capacity); return the chunk including its length
+func compress(dst, plain []byte, id byte, tryCompress bool) []byte {
+	var n int
+	dst = dst[:lenSize]
+	if tryCompress {
+		// must save at least 1/8 to be worth it
+		limit := len(plain) - len(plain)/8
+		switch id {
+		case algoZstd:
+			if out := zenc.EncodeAll(plain, dst); len(out)-lenSize < limit {
+				dst, n = out, len(out)-lenSize
+			}
+		default:
+			n, _ = lz4.CompressBlock(plain, dst[lenSize:lenSize+limit], nil)
+			dst = dst[:lenSize+n]
+		}
+	}
+	if n == 0 { // incompressible or not trying
+		dst = append(dst[:lenSize], plain...)
+		binary.BigEndian.PutUint32(dst, uint32(len(plain))|flagRaw)
+	} else {
+		binary.BigEndian.PutUint32(dst, uint32(n))
+	}
+	return dst
+}
+
+// decompress chunk's stored `data` given the length (with flags) that precedes it
+func decompress(dst, data []byte, l uint32, id byte) ([]byte, error) {
+	if l&flagRaw != 0 {
+		return append(dst[:0], data...), nil
+	}
+	switch id {
+	case algoZstd:
+		out, err := zdec.DecodeAll(data, dst[:0])
+		if err != nil || len(out) > ChunkSize {
+			return nil, errCorrupted
+		}
+		return out, nil
+	default:
+		n, err := lz4.UncompressBlock(data, dst[:ChunkSize])
+		if err != nil {
+			return nil, errCorrupted
+		}
+		return dst[:n], nil
+	}
+}
+
+func storedLen(l uint32) int64 { return int64(l &^ flagRaw) }
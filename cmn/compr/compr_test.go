@@ -0,0 +1,142 @@
+// Package compr provides transparent (on-disk) object compression: chunked lz4 or zstd
+// format that supports reading arbitrary ranges.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package compr
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+)
+
+func compressAll(t *testing.T, plain []byte, algo string) []byte {
+	var (
+		out     bytes.Buffer
+		cw, err = NewWriter(&out, algo)
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// write in odd-sized pieces
+	for p := plain; len(p) > 0; {
+		n := len(p)
+		if n > 1000 {
+			n = 1000
+		}
+		if _, err := cw.Write(p[:n]); err != nil {
+			t.Fatal(err)
+		}
+		p = p[n:]
+	}
+	if err := cw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if cw.Size() != int64(out.Len()) {
+		t.Fatalf("written %d, reported %d", out.Len(), cw.Size())
+	}
+	return out.Bytes()
+}
+
+func genContent(size int, random bool) []byte {
+	if random {
+		b := make([]byte, size)
+		rand.Read(b)
+		return b
+	}
+	s := strings.Repeat("the quick brown fox jumps over the lazy dog; ", size/45+1)
+	return []byte(s[:size])
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, algo := range apc.SupportedDiskCompression {
+		for _, random := range []bool{false, true} {
+			for _, size := range []int{0, 1, ChunkSize - 1, ChunkSize, ChunkSize + 1, 3*ChunkSize + 17} {
+				plain := genContent(size, random)
+				stored := compressAll(t, plain, algo)
+				if !IsHeader(stored) {
+					t.Fatalf("%s, size %d: invalid header", algo, size)
+				}
+				if !random && size >= ChunkSize && len(stored) > size/4 {
+					t.Errorf("%s, size %d: poor compression (%d)", algo, size, len(stored))
+				}
+
+				// read all
+				r, err := NewReader(bytes.NewReader(stored), int64(len(stored)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if r.Size() != int64(size) {
+					t.Fatalf("%s, size %d: reader size %d", algo, size, r.Size())
+				}
+				got, err := io.ReadAll(r)
+				if err != nil || !bytes.Equal(got, plain) {
+					t.Fatalf("%s, size %d: read-all mismatch (%v)", algo, size, err)
+				}
+
+				// range
+				if size > 10 {
+					off, l := int64(size/2-5), 10
+					rng := make([]byte, l)
+					if _, err := r.ReadAt(rng, off); err != nil || !bytes.Equal(rng, plain[off:off+int64(l)]) {
+						t.Fatalf("%s, size %d: range mismatch (%v)", algo, size, err)
+					}
+				}
+
+				// stream
+				var out bytes.Buffer
+				dw := NewDecWriter(&out)
+				if _, err := io.CopyBuffer(dw, bytes.NewReader(stored), make([]byte, 777)); err != nil {
+					t.Fatal(err)
+				}
+				if err := dw.Close(); err != nil {
+					t.Fatalf("%s, size %d: %v", algo, size, err)
+				}
+				if dw.Size() != int64(size) || !bytes.Equal(out.Bytes(), plain) {
+					t.Fatalf("%s, size %d: dec-writer mismatch", algo, size)
+				}
+			}
+		}
+	}
+}
+
+func TestTruncated(t *testing.T) {
+	stored := compressAll(t, genContent(2*ChunkSize+100, false), apc.LZ4Compression)
+	for _, l := range []int{HeaderSize, len(stored) / 2, len(stored) - 1} {
+		if _, err := NewReader(bytes.NewReader(stored[:l]), int64(l)); err == nil {
+			t.Errorf("expected error reading truncated (%d) content", l)
+		}
+		dw := NewDecWriter(io.Discard)
+		_, err := dw.Write(stored[:l])
+		if err == nil {
+			err = dw.Close()
+		}
+		if err == nil {
+			t.Errorf("expected error decompressing truncated (%d) content", l)
+		}
+	}
+}
+
+func TestCompressible(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"a/b/c.txt", true},
+		{"shard-0001.tar", true},
+		{"noext", true},
+		{"img.JPG", false},
+		{"archive.tar.gz", false},
+		{"data.parquet", false},
+	}
+	for _, test := range tests {
+		if got := Compressible(test.name); got != test.expected {
+			t.Errorf("%q: expected %t, got %t", test.name, test.expected, got)
+		}
+	}
+}
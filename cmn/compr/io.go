@@ -0,0 +1,389 @@
+// Package compr provides transparent (on-disk) object compression: chunked lz4 or zstd
+// format that supports reading arbitrary ranges.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package compr
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+type (
+	// Writer compresses into the underlying writer;
+	// Close must be called to write the remaining content (it does not close the underlying writer).
+	Writer struct {
+		w     io.Writer
+		buf   []byte // original
+		out   []byte // stored
+		index []byte
+		size  int64 // original
+		dsize int64 // stored
+		err   error
+		id    byte
+		nraw  int // number of incompressible chunks
+	}
+	// Reader decompresses (any part of) compressed content
+	Reader struct {
+		ra      io.ReaderAt
+		index   []int64 // chunk offsets, plus the offset of the end-of-chunks
+		buf     []byte  // stored
+		plain   []byte  // decompressed chunk `cur`
+		size    int64   // original
+		nchunks int64
+		off     int64
+		cur     int64
+		id      byte
+	}
+	// DecWriter consumes compressed content (e.g., restored by erasure decoding)
+	// and writes the original into the underlying writer, thus validating the former
+	// and making it possible to checksum the latter
+	DecWriter struct {
+		w     io.Writer
+		hdr   []byte
+		buf   []byte // current chunk's length and stored data, or the tail (index and trailer)
+		plain []byte
+		size  int64
+		err   error
+		need  int64 // bytes to complete the current chunk
+		nchk  int64
+		id    byte
+		tail  bool
+	}
+)
+
+// interface guard
+var (
+	_ io.WriteCloser = (*Writer)(nil)
+	_ io.ReaderAt    = (*Reader)(nil)
+	_ io.ReadSeeker  = (*Reader)(nil)
+	_ io.WriteCloser = (*DecWriter)(nil)
+)
+
+////////////
+// Writer //
+////////////
+
+func NewWriter(w io.Writer, algo string) (*Writer, error) {
+	if err := ValidateAlgo(algo); err != nil {
+		return nil, err
+	}
+	h := newHeader(algo)
+	if _, err := w.Write(h[:]); err != nil {
+		return nil, err
+	}
+	return &Writer{
+		w:     w,
+		id:    h[5],
+		buf:   make([]byte, 0, ChunkSize),
+		out:   make([]byte, 0, lenSize+ChunkSize),
+		dsize: HeaderSize,
+	}, nil
+}
+
+// total number of bytes written into the underlying writer - valid only after Close
+func (cw *Writer) Size() int64 { return cw.dsize }
+
+func (cw *Writer) Write(p []byte) (n int, err error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	for len(p) > 0 {
+		m := copy(cw.buf[len(cw.buf):ChunkSize], p)
+		cw.buf = cw.buf[:len(cw.buf)+m]
+		n += m
+		p = p[m:]
+		if len(cw.buf) == ChunkSize {
+			if cw.err = cw.flush(); cw.err != nil {
+				return n, cw.err
+			}
+		}
+	}
+	return n, nil
+}
+
+func (cw *Writer) flush() error {
+	// heuristics: stop trying when (the first) half of the chunks turn out to be incompressible
+	var (
+		nchk = len(cw.index) / 8
+		try  = cw.nraw < 2 || cw.nraw*2 < nchk
+	)
+	cw.out = compress(cw.out, cw.buf, cw.id, try)
+	if binary.BigEndian.Uint32(cw.out)&flagRaw != 0 {
+		cw.nraw++
+	}
+	cw.index = binary.BigEndian.AppendUint64(cw.index, uint64(cw.dsize))
+	cw.size += int64(len(cw.buf))
+	cw.dsize += int64(len(cw.out))
+	cw.buf = cw.buf[:0]
+	_, err := cw.w.Write(cw.out)
+	return err
+}
+
+func (cw *Writer) Close() error {
+	if cw.err != nil {
+		return cw.err
+	}
+	if len(cw.buf) > 0 {
+		if cw.err = cw.flush(); cw.err != nil {
+			return cw.err
+		}
+	}
+	var (
+		b    = cw.out[:0]
+		nchk = len(cw.index) / 8
+	)
+	b = binary.BigEndian.AppendUint32(b, 0) // end-of-chunks
+	b = append(b, cw.index...)
+	b = binary.BigEndian.AppendUint64(b, uint64(cw.size))
+	b = binary.BigEndian.AppendUint32(b, uint32(nchk))
+	b = append(b, magic...)
+	if _, cw.err = cw.w.Write(b); cw.err != nil {
+		return cw.err
+	}
+	cw.dsize += int64(len(b))
+	cw.err = errors.New("compr: writer closed")
+	return nil
+}
+
+////////////
+// Reader //
+////////////
+
+// read and validate the header, the trailer, and the index
+func NewReader(ra io.ReaderAt, diskSize int64) (*Reader, error) {
+	var h [HeaderSize]byte
+	if diskSize < HeaderSize+lenSize+trailerSize {
+		return nil, errCorrupted
+	}
+	if _, err := ra.ReadAt(h[:], 0); err != nil {
+		return nil, err
+	}
+	if !IsHeader(h[:]) {
+		return nil, errors.New("compr: not compressed or invalid header")
+	}
+	var t [trailerSize]byte
+	if _, err := ra.ReadAt(t[:], diskSize-trailerSize); err != nil {
+		return nil, err
+	}
+	if string(t[12:]) != magic {
+		return nil, errCorrupted
+	}
+	var (
+		size    = int64(binary.BigEndian.Uint64(t[:]))
+		nchunks = int64(binary.BigEndian.Uint32(t[8:]))
+		offIdx  = diskSize - trailerSize - nchunks*8
+	)
+	if size < 0 || nchunks != numChunks(size) || offIdx < HeaderSize+lenSize {
+		return nil, errCorrupted
+	}
+	b := make([]byte, nchunks*8)
+	if _, err := ra.ReadAt(b, offIdx); err != nil {
+		return nil, err
+	}
+	r := &Reader{ra: ra, size: size, nchunks: nchunks, cur: -1, id: h[5]}
+	r.index = make([]int64, nchunks+1)
+	for i := int64(0); i < nchunks; i++ {
+		r.index[i] = int64(binary.BigEndian.Uint64(b[i*8:]))
+		if r.index[i] < HeaderSize || (i > 0 && r.index[i] <= r.index[i-1]) {
+			return nil, errCorrupted
+		}
+	}
+	r.index[nchunks] = offIdx - lenSize // end-of-chunks
+	if nchunks > 0 && r.index[nchunks] <= r.index[nchunks-1] {
+		return nil, errCorrupted
+	}
+	return r, nil
+}
+
+func (r *Reader) Size() int64 { return r.size }
+
+func (r *Reader) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.New("compr: negative offset")
+	}
+	for len(p) > 0 {
+		if off >= r.size {
+			return n, io.EOF
+		}
+		idx := off / ChunkSize
+		if err = r.load(idx); err != nil {
+			return n, err
+		}
+		m := copy(p, r.plain[off-idx*ChunkSize:])
+		n += m
+		off += int64(m)
+		p = p[m:]
+	}
+	return n, nil
+}
+
+func (r *Reader) load(idx int64) error {
+	if idx == r.cur {
+		return nil
+	}
+	var (
+		stored = r.index[idx+1] - r.index[idx]
+		plain  = int64(ChunkSize)
+	)
+	if idx == r.nchunks-1 {
+		plain = r.size - idx*ChunkSize
+	}
+	if stored <= lenSize || stored > lenSize+ChunkSize {
+		return errCorrupted
+	}
+	if r.buf == nil {
+		r.buf = make([]byte, lenSize+ChunkSize)
+		r.plain = make([]byte, 0, ChunkSize)
+	}
+	b := r.buf[:stored]
+	if _, err := r.ra.ReadAt(b, r.index[idx]); err != nil {
+		if err == io.EOF {
+			err = errCorrupted
+		}
+		return err
+	}
+	l := binary.BigEndian.Uint32(b)
+	if storedLen(l) != stored-lenSize {
+		return errCorrupted
+	}
+	out, err := decompress(r.plain, b[lenSize:], l, r.id)
+	if err != nil || int64(len(out)) != plain {
+		r.cur = -1
+		return errCorrupted
+	}
+	r.plain, r.cur = out, idx
+	return nil
+}
+
+func (r *Reader) Read(p []byte) (n int, err error) {
+	n, err = r.ReadAt(p, r.off)
+	r.off += int64(n)
+	return
+}
+
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.off
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.New("compr: invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("compr: negative position")
+	}
+	r.off = offset
+	return offset, nil
+}
+
+///////////////
+// DecWriter //
+///////////////
+
+func NewDecWriter(w io.Writer) *DecWriter {
+	return &DecWriter{
+		w:     w,
+		hdr:   make([]byte, 0, HeaderSize),
+		buf:   make([]byte, 0, lenSize+ChunkSize),
+		plain: make([]byte, 0, ChunkSize),
+	}
+}
+
+// original size
+func (dw *DecWriter) Size() int64 { return dw.size }
+
+func (dw *DecWriter) Write(p []byte) (n int, err error) {
+	if dw.err != nil {
+		return 0, dw.err
+	}
+	n = len(p)
+	if dw.id == 0 {
+		m := copy(dw.hdr[len(dw.hdr):HeaderSize], p)
+		dw.hdr = dw.hdr[:len(dw.hdr)+m]
+		p = p[m:]
+		if len(dw.hdr) < HeaderSize {
+			return n, nil
+		}
+		if !IsHeader(dw.hdr) {
+			dw.err = errors.New("compr: not compressed or invalid header")
+			return 0, dw.err
+		}
+		dw.id = dw.hdr[5]
+		dw.need = lenSize
+	}
+	for len(p) > 0 && !dw.tail {
+		m := int(dw.need)
+		if m > len(p) {
+			m = len(p)
+		}
+		dw.buf = append(dw.buf, p[:m]...)
+		dw.need -= int64(m)
+		p = p[m:]
+		if dw.need > 0 {
+			break
+		}
+		if dw.err = dw.chunk(); dw.err != nil {
+			return 0, dw.err
+		}
+	}
+	if dw.tail {
+		if int64(len(dw.buf)+len(p)) > dw.nchk*8+trailerSize {
+			dw.err = errCorrupted
+			return 0, dw.err
+		}
+		dw.buf = append(dw.buf, p...)
+	}
+	return n, nil
+}
+
+// called upon receiving either chunk's length or the entire chunk
+func (dw *DecWriter) chunk() error {
+	l := binary.BigEndian.Uint32(dw.buf)
+	if len(dw.buf) == lenSize {
+		switch stored := storedLen(l); {
+		case l == 0:
+			dw.tail = true
+			dw.buf = dw.buf[:0]
+		case stored > ChunkSize:
+			return errCorrupted
+		default:
+			dw.need = stored
+		}
+		return nil
+	}
+	out, err := decompress(dw.plain, dw.buf[lenSize:], l, dw.id)
+	if err != nil {
+		return err
+	}
+	if dw.size%ChunkSize != 0 { // only the last chunk can be partial
+		return errCorrupted
+	}
+	dw.plain = out
+	dw.nchk++
+	dw.size += int64(len(out))
+	dw.buf, dw.need = dw.buf[:0], lenSize
+	_, err = dw.w.Write(out)
+	return err
+}
+
+// validate the tail
+func (dw *DecWriter) Close() error {
+	if dw.err != nil {
+		return dw.err
+	}
+	dw.err = errors.New("compr: writer closed")
+	t := dw.buf
+	if !dw.tail || int64(len(t)) != dw.nchk*8+trailerSize || string(t[len(t)-4:]) != magic {
+		return errCorrupted
+	}
+	t = t[dw.nchk*8:]
+	if int64(binary.BigEndian.Uint64(t)) != dw.size || int64(binary.BigEndian.Uint32(t[8:])) != dw.nchk {
+		return errCorrupted
+	}
+	return nil
+}
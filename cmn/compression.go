@@ -0,0 +1,50 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/compr"
+)
+
+// Transparent (on-disk) compression: when enabled, newly written objects get stored
+// compressed (see cmn/compr for the format) unless deemed incompressible - by name
+// or the actual content. Object metadata (size, checksum) always refers
+// to the original content that is also what GET returns.
+//
+// Unlike the bucket's encryption, compression can be enabled and disabled at any time:
+// each object "knows" whether it is stored compressed.
+// Not to confuse with intra-cluster (transport) compression - see api/apc/compression.go.
+
+type (
+	CompressionConf struct {
+		Algo    string `json:"algo"` // enum { apc.LZ4Compression (default), apc.ZstdCompression }
+		Enabled bool   `json:"enabled"`
+	}
+	CompressionConfToUpdate struct {
+		Algo    *string `json:"algo,omitempty"`
+		Enabled *bool   `json:"enabled,omitempty"`
+	}
+)
+
+func (c *CompressionConf) ValidateAsProps(...any) error {
+	if err := compr.ValidateAlgo(c.Algo); err != nil {
+		return fmt.Errorf("invalid compression config: %v", err)
+	}
+	return nil
+}
+
+func (c *CompressionConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	if c.Algo == "" {
+		return apc.LZ4Compression
+	}
+	return c.Algo
+}
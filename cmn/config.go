@@ -76,6 +76,7 @@ type (
 		DSort      DSortConf      `json:"distributed_sort"`
 		Transport  TransportConf  `json:"transport"`
 		Memsys     MemsysConf     `json:"memsys"`
+		Quota      NsQuotaConf    `json:"quota"` // per-namespace quotas (see also: BucketProps.Quota)
 
 		// Transform (offline) or Copy src Bucket => dst bucket
 		TCB TCBConf `json:"tcb"`
@@ -116,6 +117,7 @@ type (
 		DSort       *DSortConfToUpdate       `json:"distributed_sort,omitempty"`
 		Transport   *TransportConfToUpdate   `json:"transport,omitempty"`
 		Memsys      *MemsysConfToUpdate      `json:"memsys,omitempty"`
+		Quota       *NsQuotaConf             `json:"quota,omitempty"`
 		TCB         *TCBConfToUpdate         `json:"tcb,omitempty"`
 		WritePolicy *WritePolicyConfToUpdate `json:"write_policy,omitempty"`
 		Proxy       *ProxyConfToUpdate       `json:"proxy,omitempty"`
@@ -159,6 +161,17 @@ type (
 	}
 	BackendConfAIS map[string][]string // cluster alias -> [urls...]
 
+	// named S3-compatible endpoints (MinIO, Ceph RGW, OpenStack Swift, etc.) selectable
+	// on a per-bucket basis via `extra.aws.endpoint`, e.g.:
+	// "aws": {"minio": {"url": "http://minio:9000", "path_style": true}}
+	BackendConfAWS map[string]S3Endpoint // endpoint alias -> endpoint
+	S3Endpoint     struct {
+		URL       string `json:"url"`
+		Region    string `json:"region,omitempty"`     // default region for this endpoint
+		Profile   string `json:"profile,omitempty"`    // credentials profile (~/.aws/credentials and ~/.aws/config)
+		PathStyle bool   `json:"path_style,omitempty"` // path-style addressing (as opposed to virtual-hosted)
+	}
+
 	MirrorConf struct {
 		Copies  int64 `json:"copies"`       // num copies
 		Burst   int   `json:"burst_buffer"` // xaction channel (buffer) size
@@ -371,10 +384,20 @@ type (
 
 		// Validate object version upon warm GET.
 		ValidateWarmGet bool `json:"validate_warm_get"`
+
+		// ais:// buckets only: number of prior versions to retain upon overwrite
+		// (zero - none, unless limited by HistoryTTL)
+		History int `json:"history"`
+
+		// ais:// buckets only: retain prior versions for at most this long
+		// (zero - unlimited, unless limited by History)
+		HistoryTTL cos.Duration `json:"history_ttl"`
 	}
 	VersionConfToUpdate struct {
-		Enabled         *bool `json:"enabled,omitempty"`
-		ValidateWarmGet *bool `json:"validate_warm_get,omitempty"`
+		Enabled         *bool         `json:"enabled,omitempty"`
+		ValidateWarmGet *bool         `json:"validate_warm_get,omitempty"`
+		History         *int          `json:"history,omitempty"`
+		HistoryTTL      *cos.Duration `json:"history_ttl,omitempty"`
 	}
 
 	TestFSPConf struct {
@@ -462,9 +485,13 @@ type (
 
 	DownloaderConf struct {
 		Timeout cos.Duration `json:"timeout"`
+		// max bytes per second downloaded by a given target (all jobs combined; zero - unlimited);
+		// shared between concurrent jobs in proportion to their weights (see dload.Limits)
+		MaxBandwidth cos.SizeIEC `json:"max_bandwidth"`
 	}
 	DownloaderConfToUpdate struct {
-		Timeout *cos.Duration `json:"timeout,omitempty"`
+		Timeout      *cos.Duration `json:"timeout,omitempty"`
+		MaxBandwidth *cos.SizeIEC  `json:"max_bandwidth,omitempty"`
 	}
 
 	DSortConf struct {
@@ -492,6 +519,13 @@ type (
 
 	FSPConf struct {
 		Paths cos.StrSet `json:"paths,omitempty" list:"readonly"`
+		// storage tier of a given mountpath: zero (default) is the fastest,
+		// higher numbers denote slower and (usually) bigger storage - see fs.Tiers()
+		Tiers map[string]int `json:"-"`
+	}
+	// per-fspath options, e.g.: "fspaths": {"/ais/nvme1": {}, "/ais/hdd1": {"tier": 1}}
+	FSPOpts struct {
+		Tier int `json:"tier,omitempty"`
 	}
 
 	TransportConf struct {
@@ -548,7 +582,7 @@ type (
 		MD   apc.WritePolicy `json:"md"`
 	}
 	WritePolicyConfToUpdate struct {
-		Data *apc.WritePolicy `json:"data,omitempty" list:"readonly"` // NOTE: NIY
+		Data *apc.WritePolicy `json:"data,omitempty"`
 		MD   *apc.WritePolicy `json:"md,omitempty"`
 	}
 )
@@ -619,12 +653,15 @@ var (
 	_ Validator = (*MemsysConf)(nil)
 	_ Validator = (*TCBConf)(nil)
 	_ Validator = (*WritePolicyConf)(nil)
+	_ Validator = (*NsQuotaConf)(nil)
 
 	_ PropsValidator = (*CksumConf)(nil)
 	_ PropsValidator = (*SpaceConf)(nil)
 	_ PropsValidator = (*MirrorConf)(nil)
 	_ PropsValidator = (*ECConf)(nil)
 	_ PropsValidator = (*WritePolicyConf)(nil)
+	_ PropsValidator = (*VersionConf)(nil)
+	_ PropsValidator = (*QuotaConf)(nil)
 
 	_ json.Marshaler   = (*BackendConf)(nil)
 	_ json.Unmarshaler = (*BackendConf)(nil)
@@ -721,6 +758,7 @@ func (c *LocalConfig) AddPath(mpath string) {
 func (c *LocalConfig) DelPath(mpath string) {
 	debug.Assert(!c.TestingEnv())
 	c.FSP.Paths.Delete(mpath)
+	delete(c.FSP.Tiers, mpath)
 }
 
 ////////////////
@@ -822,6 +860,18 @@ func (c *BackendConf) Validate() (err error) {
 				}
 			}
 			c.Conf[provider] = aisConf
+		case apc.AWS:
+			var awsConf BackendConfAWS
+			if err := jsoniter.Unmarshal(b, &awsConf); err != nil {
+				return fmt.Errorf("invalid cloud specification: %v", err)
+			}
+			for alias, ep := range awsConf {
+				if err := ep.validate(alias); err != nil {
+					return err
+				}
+			}
+			c.Conf[provider] = awsConf
+			c.setProvider(provider)
 		case apc.HDFS:
 			var hdfsConf BackendConfHDFS
 			if err := jsoniter.Unmarshal(b, &hdfsConf); err != nil {
@@ -863,6 +913,23 @@ func (c *BackendConf) Validate() (err error) {
 	return nil
 }
 
+func (ep *S3Endpoint) validate(alias string) error {
+	if alias == "" || !cos.IsAlphaPlus(alias) {
+		return fmt.Errorf("invalid S3 endpoint alias %q", alias)
+	}
+	if ep.URL == "" {
+		return fmt.Errorf("no URL to connect to S3 endpoint %q", alias)
+	}
+	u, err := url.Parse(ep.URL)
+	if err != nil {
+		return fmt.Errorf("invalid URL of the S3 endpoint %q: %v", alias, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid URL of the S3 endpoint %q: %q (expecting, e.g., \"http://minio:9000\")", alias, ep.URL)
+	}
+	return nil
+}
+
 func (c *BackendConf) setProvider(provider string) {
 	var ns Ns
 	switch provider {
@@ -884,6 +951,14 @@ func (c *BackendConf) Get(provider string) (conf any) {
 	return
 }
 
+// returns the named S3 endpoint, if configured
+func (c *BackendConf) S3Endpoint(alias string) (ep S3Endpoint, ok bool) {
+	if awsConf, isAWS := c.Get(apc.AWS).(BackendConfAWS); isAWS {
+		ep, ok = awsConf[alias]
+	}
+	return
+}
+
 func (c *BackendConf) Set(provider string, newConf any) {
 	c.Conf[provider] = newConf
 }
@@ -1039,9 +1114,22 @@ func (c *VersionConf) Validate() error {
 	if !c.Enabled && c.ValidateWarmGet {
 		return errors.New("versioning.validate_warm_get requires versioning to be enabled")
 	}
+	return c.ValidateAsProps()
+}
+
+func (c *VersionConf) ValidateAsProps(...any) error {
+	if c.History < 0 || c.HistoryTTL < 0 {
+		return fmt.Errorf("invalid versioning.history (%d) and/or versioning.history_ttl (%v)", c.History, c.HistoryTTL)
+	}
+	if !c.Enabled && c.KeepsHistory() {
+		return errors.New("versioning.history and versioning.history_ttl require versioning to be enabled")
+	}
 	return nil
 }
 
+// KeepsHistory returns true if prior versions are to be retained upon overwrite
+func (c *VersionConf) KeepsHistory() bool { return c.History > 0 || c.HistoryTTL > 0 }
+
 func (c *VersionConf) String() string {
 	if !c.Enabled {
 		return "Disabled"
@@ -1053,6 +1141,9 @@ func (c *VersionConf) String() string {
 	} else {
 		text += "no"
 	}
+	if c.KeepsHistory() {
+		text += fmt.Sprintf(" | History: %d, TTL: %v", c.History, c.HistoryTTL)
+	}
 
 	return text
 }
@@ -1169,7 +1260,14 @@ func (c *WritePolicyConf) Validate() (err error) {
 	return
 }
 
-func (c *WritePolicyConf) ValidateAsProps(...any) error { return c.Validate() }
+// bucket props: in addition, data write policy can be `write_back`
+// (remote buckets only - see BucketProps.Validate)
+func (c *WritePolicyConf) ValidateAsProps(...any) error {
+	if c.Data == apc.WriteBack {
+		return c.MD.Validate()
+	}
+	return c.Validate()
+}
 
 ///////////////////
 // KeepaliveConf //
@@ -1333,17 +1431,30 @@ func (c *DSortConf) ValidateWithOpts(allowEmpty bool) (err error) {
 /////////////
 
 func (c *FSPConf) UnmarshalJSON(data []byte) (err error) {
-	m := cos.NewStrSet()
+	m := make(map[string]FSPOpts, 4)
 	err = jsoniter.Unmarshal(data, &m)
 	if err != nil {
 		return
 	}
-	c.Paths = m
+	c.Paths, c.Tiers = make(cos.StrSet, len(m)), nil
+	for fspath, opts := range m {
+		c.Paths.Set(fspath)
+		if opts.Tier != 0 {
+			if c.Tiers == nil {
+				c.Tiers = make(map[string]int, len(m))
+			}
+			c.Tiers[fspath] = opts.Tier
+		}
+	}
 	return
 }
 
 func (c *FSPConf) MarshalJSON() (data []byte, err error) {
-	return cos.MustMarshal(c.Paths), nil
+	m := make(map[string]FSPOpts, len(c.Paths))
+	for fspath := range c.Paths {
+		m[fspath] = FSPOpts{Tier: c.Tiers[fspath]}
+	}
+	return cos.MustMarshal(m), nil
 }
 
 func (c *FSPConf) Validate(contextConfig *Config) error {
@@ -1358,12 +1469,25 @@ func (c *FSPConf) Validate(contextConfig *Config) error {
 		return NewErrInvalidFSPathsConf(ErrNoMountpaths)
 	}
 
-	cleanMpaths := make(map[string]struct{})
+	var (
+		cleanMpaths = make(map[string]struct{})
+		cleanTiers  map[string]int
+	)
 	for fspath := range c.Paths {
 		mpath, err := ValidateMpath(fspath)
 		if err != nil {
 			return err
 		}
+		if tier, ok := c.Tiers[fspath]; ok {
+			if tier < 0 {
+				err := fmt.Errorf("%q: invalid tier %d (expecting non-negative integer)", fspath, tier)
+				return NewErrInvalidFSPathsConf(err)
+			}
+			if cleanTiers == nil {
+				cleanTiers = make(map[string]int, len(c.Tiers))
+			}
+			cleanTiers[mpath] = tier
+		}
 		l := len(mpath)
 		// disallow mountpath nesting
 		for mpath2 := range cleanMpaths {
@@ -1377,7 +1501,7 @@ func (c *FSPConf) Validate(contextConfig *Config) error {
 		}
 		cleanMpaths[mpath] = struct{}{}
 	}
-	c.Paths = cleanMpaths
+	c.Paths, c.Tiers = cleanMpaths, cleanTiers
 	return nil
 }
 
@@ -1571,6 +1695,9 @@ func (c *DownloaderConf) Validate() error {
 	if j := c.Timeout.D(); j < time.Second || j > time.Hour {
 		return fmt.Errorf("invalid downloader.timeout=%s (expected range [1s, 1h])", j)
 	}
+	if c.MaxBandwidth < 0 {
+		return fmt.Errorf("invalid downloader.max_bandwidth=%d (expected non-negative)", c.MaxBandwidth)
+	}
 	return nil
 }
 
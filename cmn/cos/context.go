@@ -20,4 +20,5 @@ const (
 	CtxReadWrapper contextID = "readWrapper" // context key for ReadWrapperFunc
 	CtxSetSize     contextID = "setSize"     // context key for SetSizeFunc
 	CtxOriginalURL contextID = "origURL"     // context key for OriginalURL for HTTP cloud
+	CtxS3Endpoint  contextID = "s3Endpoint"  // context key for S3 endpoint (URL or alias) of the bucket that is not in BMD
 )
@@ -16,6 +16,7 @@ const (
 	HdrContentRange          = "Content-Range"
 	HdrContentRangeValPrefix = "bytes " // Ref: https://tools.ietf.org/html/rfc7233#section-4.2
 	HdrAcceptRanges          = "Accept-Ranges"
+	HdrIfRange               = "If-Range" // Ref: https://www.rfc-editor.org/rfc/rfc7233#section-3.2
 
 	// content length & type
 	HdrContentType        = "Content-Type"
@@ -23,11 +24,12 @@ const (
 	HdrContentLength      = "Content-Length"
 
 	// misc. gen
-	HdrUserAgent = "User-Agent"
-	HdrAccept    = "Accept"
-	HdrLocation  = "Location"
-	HdrServer    = "Server"
-	HdrETag      = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Hdrs/ETag
+	HdrUserAgent    = "User-Agent"
+	HdrAccept       = "Accept"
+	HdrLocation     = "Location"
+	HdrServer       = "Server"
+	HdrETag         = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Hdrs/ETag
+	HdrLastModified = "Last-Modified"
 )
 
 // provider-specific headers (=> custom props, and more)
@@ -46,6 +48,15 @@ const (
 	S3HdrMptCnt        = "x-amz-mp-parts-count"
 	S3HdrContentSHA256 = "x-amz-content-sha256"
 	S3HdrBckRegion     = "x-amz-bucket-region"
+	S3HdrTagging       = "x-amz-tagging"
+	S3HdrTaggingCount  = "x-amz-tagging-count"
+
+	// object lock
+	S3HdrObjLockMode        = "x-amz-object-lock-mode"
+	S3HdrObjLockRetainUntil = "x-amz-object-lock-retain-until-date"
+	S3HdrObjLockLegalHold   = "x-amz-object-lock-legal-hold"
+	S3HdrBypassGovernance   = "x-amz-bypass-governance-retention"
+	S3HdrBckObjLockEnabled  = "x-amz-bucket-object-lock-enabled"
 
 	S3ChecksumCRC32  = "x-amz-checksum-crc32"
 	S3ChecksumCRC32C = "x-amz-checksum-crc32c"
@@ -57,6 +57,12 @@ type (
 		io.Reader
 		io.ReaderAt
 	}
+	// LomReader provides (random) read access to object's content
+	LomReader interface {
+		ReadOpenCloser
+		io.ReaderAt
+		io.Seeker
+	}
 	sizedRC struct {
 		io.ReadCloser
 		size int64
@@ -140,6 +146,7 @@ type (
 var (
 	_ io.Reader      = (*nopReader)(nil)
 	_ ReadOpenCloser = (*FileHandle)(nil)
+	_ LomReader      = (*FileHandle)(nil)
 	_ ReadOpenCloser = (*CallbackROC)(nil)
 	_ ReadSizer      = (*sizedReader)(nil)
 	_ ReadOpenCloser = (*SectionHandle)(nil)
@@ -0,0 +1,54 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn/sse"
+)
+
+// Server-side encryption at rest: objects in an encrypted bucket are stored
+// AES-GCM encrypted with the bucket's current key - see cmn/sse for the on-disk format.
+// Each stored object references its key by (provider, key ID), which makes it possible
+// to rotate keys: updated `key_id` applies to newly written objects, while the existing
+// ones remain readable as long as the (old) key is available.
+//
+// Encryption can be enabled only when creating bucket and cannot be disabled.
+
+type (
+	EncryptionConf struct {
+		Provider string `json:"provider"` // key provider (default: sse.ProviderKeyfile)
+		KeyID    string `json:"key_id"`   // key to encrypt new objects
+		Enabled  bool   `json:"enabled"`
+	}
+	EncryptionConfToUpdate struct {
+		Provider *string `json:"provider,omitempty"`
+		KeyID    *string `json:"key_id,omitempty"`
+		Enabled  *bool   `json:"enabled,omitempty"`
+	}
+)
+
+func (c *EncryptionConf) ValidateAsProps(...any) error {
+	if !c.Enabled {
+		return nil
+	}
+	if err := sse.ValidateNames(c.Provider, c.KeyID); err != nil {
+		return fmt.Errorf("invalid encryption config: %v", err)
+	}
+	return nil
+}
+
+func (c *EncryptionConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	provider := c.Provider
+	if provider == "" {
+		provider = sse.ProviderKeyfile
+	}
+	return provider + ":" + c.KeyID
+}
@@ -117,6 +117,18 @@ type (
 		name   string // object's name
 		d1, d2 uint64 // lom.md.(bucket-ID) and lom.bck.(bucket-ID), respectively
 	}
+	ErrObjLocked struct {
+		until     time.Time // retain-until date
+		name      string    // object's name
+		mode      string    // retention mode (see ObjLockGovernance et al.)
+		legalHold bool
+	}
+	ErrQuotaExceeded struct {
+		scope string // bucket or namespace
+		what  string // "size" or "objects"
+		usage int64
+		limit int64 // this target's share of the configured (hard) limit
+	}
 	ErrAborted struct {
 		err  error
 		what string
@@ -499,6 +511,43 @@ func isErrObjDefunct(err error) bool {
 	return ok
 }
 
+// ErrObjLocked
+
+func NewErrObjLocked(name, mode string, until time.Time, legalHold bool) *ErrObjLocked {
+	return &ErrObjLocked{name: name, mode: mode, until: until, legalHold: legalHold}
+}
+
+func (e *ErrObjLocked) Error() string {
+	if e.legalHold {
+		return fmt.Sprintf("%s is locked: legal hold is on", e.name)
+	}
+	return fmt.Sprintf("%s is locked: retained in %s mode until %s", e.name, e.mode, e.until.Format(time.RFC3339))
+}
+
+func IsErrObjLocked(err error) bool {
+	_, ok := err.(*ErrObjLocked)
+	return ok
+}
+
+// ErrQuotaExceeded
+
+func NewErrQuotaExceeded(scope, what string, usage, limit int64) *ErrQuotaExceeded {
+	return &ErrQuotaExceeded{scope: scope, what: what, usage: usage, limit: limit}
+}
+
+func (e *ErrQuotaExceeded) Error() string {
+	if e.what == QuotaSize {
+		return fmt.Sprintf("%s: quota exceeded: size %s (limit %s)", e.scope,
+			cos.ToSizeIEC(e.usage, 2), cos.ToSizeIEC(e.limit, 2))
+	}
+	return fmt.Sprintf("%s: quota exceeded: %d %s (limit %d)", e.scope, e.usage, e.what, e.limit)
+}
+
+func IsErrQuotaExceeded(err error) bool {
+	_, ok := err.(*ErrQuotaExceeded)
+	return ok
+}
+
 // ErrAborted
 
 func NewErrAborted(what, ctx string, err error) *ErrAborted {
@@ -966,6 +1015,10 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, opts ...int /*[
 	)
 	if IsErrNotFound(err) {
 		status = http.StatusNotFound
+	} else if IsErrObjLocked(err) {
+		status = http.StatusForbidden
+	} else if IsErrQuotaExceeded(err) {
+		status = http.StatusInsufficientStorage
 	} else if l > 0 {
 		status = opts[0]
 	} else if errf, ok := err.(*ErrFailedTo); ok {
@@ -24,6 +24,7 @@ const (
 	DontAutoDetectFshare      // when promoting NFS shares to AIS
 	ProvideS3APIViaRoot       // handle s3 compat via `aistore-hostname/` (default: `aistore-hostname/s3`)
 	FsyncPUT                  // when finalizing PUT(obj) fflush prior to (close, rename) sequence
+	LocalETL                  // run ETL transformers as local processes (or containers) when not deployed on Kubernetes
 )
 
 var All = []string{
@@ -33,6 +34,7 @@ var All = []string{
 	"Do-not-Auto-Detect-FileShare",
 	"Provide-S3-API-via-Root",
 	"Fsync-PUT",
+	"Local-ETL",
 }
 
 func (f Flags) IsSet(flag Flags) bool { return cos.BitFlags(f).IsSet(cos.BitFlags(flag)) }
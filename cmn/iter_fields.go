@@ -353,7 +353,7 @@ func (f *field) SetValue(src any, force ...bool) error {
 					var d time.Duration
 					d, err = time.ParseDuration(s)
 					n = int64(d)
-				} else if dst.Type().Name() == "Size" /*cos.Size*/ {
+				} else if dst.Type().Name() == "SizeIEC" /*cos.SizeIEC*/ {
 					n, err = cos.ParseSize(s, cos.UnitsIEC)
 				}
 			}
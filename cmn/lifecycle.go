@@ -0,0 +1,89 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Bucket lifecycle: a list of rules to periodically expire (ie., remove) objects
+// and abort stale (incomplete) S3 multipart uploads.
+// NOTE: object's age is determined by the time of its last modification (PUT, copy, etc.);
+// for buckets with remote backends, expiration means eviction of the in-cluster copy.
+// See also: S3 PutBucketLifecycleConfiguration and `apc.ActLifecycle` xaction.
+type (
+	LifecycleConf struct {
+		Rules []LifecycleRule `json:"rules,omitempty"`
+	}
+	LifecycleConfToUpdate struct {
+		Rules *[]LifecycleRule `json:"rules,omitempty"`
+	}
+	LifecycleRule struct {
+		ID            string       `json:"id"`
+		Prefix        string       `json:"prefix,omitempty"`          // applies to objects with names starting with
+		ExpireAfter   cos.Duration `json:"expire_after,omitempty"`    // remove objects older than (zero - never)
+		AbortMptAfter cos.Duration `json:"abort_mpt_after,omitempty"` // abort incomplete multipart uploads older than
+		Enabled       bool         `json:"enabled"`
+	}
+)
+
+// interface guard
+var _ PropsValidator = (*LifecycleConf)(nil)
+
+const MaxLifecycleRules = 1000 // (S3 limit)
+
+func (c *LifecycleConf) ValidateAsProps(...any) error {
+	if len(c.Rules) > MaxLifecycleRules {
+		return fmt.Errorf("number of lifecycle rules (%d) exceeds the maximum (%d)", len(c.Rules), MaxLifecycleRules)
+	}
+	ids := make(cos.StrSet, len(c.Rules))
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if rule.ID == "" {
+			return fmt.Errorf("lifecycle rule #%d: missing ID", i)
+		}
+		if ids.Contains(rule.ID) {
+			return fmt.Errorf("duplicate lifecycle rule ID %q", rule.ID)
+		}
+		ids.Set(rule.ID)
+		if rule.ExpireAfter < 0 || rule.AbortMptAfter < 0 {
+			return fmt.Errorf("lifecycle rule %q: negative duration", rule.ID)
+		}
+		if rule.ExpireAfter == 0 && rule.AbortMptAfter == 0 {
+			return fmt.Errorf("lifecycle rule %q: expecting at least one action (expiration and/or abort-multipart)", rule.ID)
+		}
+	}
+	return nil
+}
+
+// returns true if there's at least one enabled rule
+func (c *LifecycleConf) Enabled() bool {
+	for i := range c.Rules {
+		if c.Rules[i].Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// Expired returns the first enabled rule that expires an object that was last modified
+// at `mtime`, or nil otherwise.
+func (c *LifecycleConf) Expired(objName string, mtime, now time.Time) *LifecycleRule {
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if !rule.Enabled || rule.ExpireAfter == 0 || !strings.HasPrefix(objName, rule.Prefix) {
+			continue
+		}
+		if now.Sub(mtime) > rule.ExpireAfter.D() {
+			return rule
+		}
+	}
+	return nil
+}
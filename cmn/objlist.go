@@ -55,6 +55,7 @@ func (be *LsoEntry) SetPresent()       { be.Flags |= apc.EntryIsCached }
 func (be *LsoEntry) IsStatusOK() bool   { return be.Status() == 0 }
 func (be *LsoEntry) Status() uint16     { return be.Flags & apc.EntryStatusMask }
 func (be *LsoEntry) IsInsideArch() bool { return be.Flags&apc.EntryInArch != 0 }
+func (be *LsoEntry) IsVersion() bool    { return be.Flags&apc.EntryIsVersion != 0 }
 func (be *LsoEntry) String() string     { return "{" + be.Name + "}" }
 
 func (be *LsoEntry) CopyWithProps(propsSet cos.StrSet) (ne *LsoEntry) {
@@ -80,5 +81,23 @@ func (be *LsoEntry) CopyWithProps(propsSet cos.StrSet) (ne *LsoEntry) {
 	if propsSet.Contains(apc.GetPropsCopies) {
 		ne.Copies = be.Copies
 	}
+	if be.IsVersion() { // (prior versions share the name with the object)
+		ne.Version, ne.Flags = be.Version, be.Flags
+	}
 	return
 }
+
+// same object or same prior version of the object
+func (be *LsoEntry) sameAs(other *LsoEntry) bool {
+	if be.Name != other.Name || be.IsVersion() != other.IsVersion() {
+		return false
+	}
+	return !be.IsVersion() || be.Version == other.Version
+}
+
+func (be *LsoEntry) key() string {
+	if be.IsVersion() {
+		return be.Name + "\x00" + be.Version // (not a valid object name character)
+	}
+	return be.Name
+}
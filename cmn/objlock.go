@@ -0,0 +1,172 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Object Lock, a.k.a. WORM (write once, read many): objects in a lock-enabled bucket
+// cannot be overwritten, deleted, renamed, or evicted while retained (that is, until
+// their respective retain-until dates) or while under legal hold.
+//
+// In governance mode, retention can be shortened or removed by users with
+// explicit (governance bypass) request; in compliance mode, it cannot be
+// shortened or removed by anyone.
+//
+// Once enabled, bucket's object lock cannot be disabled.
+
+const (
+	ObjLockGovernance = "governance"
+	ObjLockCompliance = "compliance"
+)
+
+// per-object retention and legal hold are stored as LOM custom keys
+const (
+	ObjLockMDPrefix    = "lock."
+	RetainUntilObjMD   = ObjLockMDPrefix + "retain-until" // RFC3339
+	RetentionModeObjMD = ObjLockMDPrefix + "mode"         // ObjLockGovernance | ObjLockCompliance
+	LegalHoldObjMD     = ObjLockMDPrefix + "legal-hold"   // "on" (absent when off)
+
+	legalHoldOn = "on"
+)
+
+type (
+	ObjLockConf struct {
+		Mode      string       `json:"mode"`      // default retention mode
+		Retention cos.Duration `json:"retention"` // default retention period applied to new objects (0 - none)
+		Enabled   bool         `json:"enabled"`
+	}
+	ObjLockConfToUpdate struct {
+		Mode      *string       `json:"mode,omitempty"`
+		Retention *cos.Duration `json:"retention,omitempty"`
+		Enabled   *bool         `json:"enabled,omitempty"`
+	}
+)
+
+/////////////////
+// ObjLockConf //
+/////////////////
+
+func (c *ObjLockConf) ValidateAsProps(...any) error {
+	if c.Retention < 0 {
+		return fmt.Errorf("invalid object_lock.retention %v (expecting non-negative duration)", c.Retention)
+	}
+	if c.Mode == "" {
+		if c.Retention > 0 {
+			return fmt.Errorf("object_lock.mode must be specified when default retention (%v) is set", c.Retention)
+		}
+		return nil
+	}
+	if !IsValidObjLockMode(c.Mode) {
+		return fmt.Errorf("invalid object_lock.mode %q (expecting %q or %q)", c.Mode, ObjLockGovernance, ObjLockCompliance)
+	}
+	if !c.Enabled {
+		return fmt.Errorf("cannot set object_lock.mode %q when object lock is disabled", c.Mode)
+	}
+	return nil
+}
+
+func (c *ObjLockConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	if c.Retention == 0 {
+		return "Enabled"
+	}
+	return fmt.Sprintf("%s for %v", c.Mode, c.Retention)
+}
+
+func IsValidObjLockMode(mode string) bool {
+	return mode == ObjLockGovernance || mode == ObjLockCompliance
+}
+
+//////////////////////////////////////
+// ObjAttrs: retention & legal hold //
+//////////////////////////////////////
+
+// Retention returns object's retention mode and retain-until date (zero if not set)
+func (oa *ObjAttrs) Retention() (mode string, until time.Time) {
+	v, ok := oa.GetCustomKey(RetainUntilObjMD)
+	if !ok {
+		return
+	}
+	until, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return "", time.Time{}
+	}
+	mode, _ = oa.GetCustomKey(RetentionModeObjMD)
+	return
+}
+
+// SetRetention sets (or, if `until` is zero, removes) object's retention
+func (oa *ObjAttrs) SetRetention(mode string, until time.Time) {
+	if until.IsZero() {
+		oa.DelCustomKeys(RetainUntilObjMD, RetentionModeObjMD)
+		return
+	}
+	oa.SetCustomKey(RetainUntilObjMD, until.UTC().Format(time.RFC3339))
+	oa.SetCustomKey(RetentionModeObjMD, mode)
+}
+
+func (oa *ObjAttrs) LegalHold() bool {
+	v, _ := oa.GetCustomKey(LegalHoldObjMD)
+	return v == legalHoldOn
+}
+
+func (oa *ObjAttrs) SetLegalHold(on bool) {
+	if on {
+		oa.SetCustomKey(LegalHoldObjMD, legalHoldOn)
+	} else {
+		oa.DelCustomKeys(LegalHoldObjMD)
+	}
+}
+
+// CheckLocked returns ErrObjLocked if the object is under legal hold or retained
+// as of `now`; otherwise, nil
+func (oa *ObjAttrs) CheckLocked(name string, now time.Time) error {
+	if oa.LegalHold() {
+		return NewErrObjLocked(name, "", time.Time{}, true)
+	}
+	if mode, until := oa.Retention(); now.Before(until) {
+		return NewErrObjLocked(name, mode, until, false)
+	}
+	return nil
+}
+
+// SetDefaultRetention applies bucket's default retention to a new object
+// unless the latter already has one (that hasn't expired)
+func (oa *ObjAttrs) SetDefaultRetention(conf *ObjLockConf, now time.Time) {
+	if !conf.Enabled || conf.Retention == 0 {
+		return
+	}
+	if _, until := oa.Retention(); now.Before(until) {
+		return
+	}
+	oa.SetRetention(conf.Mode, now.Add(conf.Retention.D()))
+}
+
+// CheckRetentionUpdate validates new retention against the current one:
+// retention can be always extended; in governance mode, it can be also
+// shortened or removed but only with `bypass` (governance);
+// in compliance mode - never
+func (oa *ObjAttrs) CheckRetentionUpdate(name, mode string, until time.Time, bypass bool, now time.Time) error {
+	curMode, curUntil := oa.Retention()
+	if !now.Before(curUntil) {
+		return nil
+	}
+	weaken := until.Before(curUntil) || (curMode == ObjLockCompliance && mode != ObjLockCompliance)
+	if !weaken || (curMode == ObjLockGovernance && bypass) {
+		return nil
+	}
+	return NewErrObjLocked(name, curMode, curUntil, false)
+}
+
+func IsObjLockMD(key string) bool { return strings.HasPrefix(key, ObjLockMDPrefix) }
@@ -38,6 +38,10 @@ const (
 	// additional backend
 	LastModified    = "LastModified"
 	ContentEncoding = "ContentEncoding"
+
+	// user-defined object tags (S3 object tagging) are stored as custom keys
+	// with the following prefix, e.g. "tag.project=imagenet"
+	TagObjMDPrefix = "tag."
 )
 
 // object properties
@@ -123,6 +127,48 @@ func (oa *ObjAttrs) DelCustomKeys(keys ...string) {
 	}
 }
 
+//
+// object tags
+//
+
+// GetTags returns user-defined object tags (with `TagObjMDPrefix` stripped)
+func (oa *ObjAttrs) GetTags() (tags cos.StrKVs) {
+	for k, v := range oa.CustomMD {
+		if !strings.HasPrefix(k, TagObjMDPrefix) {
+			continue
+		}
+		if tags == nil {
+			tags = make(cos.StrKVs, 4)
+		}
+		tags[k[len(TagObjMDPrefix):]] = v
+	}
+	return
+}
+
+// SetTags replaces the entire set of existing tags (empty `tags` removes all)
+func (oa *ObjAttrs) SetTags(tags cos.StrKVs) {
+	for k := range oa.CustomMD {
+		if strings.HasPrefix(k, TagObjMDPrefix) {
+			delete(oa.CustomMD, k)
+		}
+	}
+	for k, v := range tags {
+		oa.SetCustomKey(TagObjMDPrefix+k, v)
+	}
+}
+
+// HasTags returns true if the object has all the tags in the `filter`;
+// an empty filter value matches any value of the corresponding tag
+func (oa *ObjAttrs) HasTags(filter cos.StrKVs) bool {
+	for k, v := range filter {
+		val, ok := oa.CustomMD[TagObjMDPrefix+k]
+		if !ok || (v != "" && v != val) {
+			return false
+		}
+	}
+	return true
+}
+
 // clone ObjAttrsHolder => ObjAttrs (see also lom.CopyAttrs)
 func (oa *ObjAttrs) CopyFrom(oah ObjAttrsHolder, skipCksum ...bool) {
 	oa.Atime = oah.AtimeUnix()
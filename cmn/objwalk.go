@@ -18,6 +18,9 @@ type LsoEntries []*LsoEntry // separately from (code-generated) objlist* - no ne
 func SortLso(bckEntries LsoEntries) {
 	entryLess := func(i, j int) bool {
 		if bckEntries[i].Name == bckEntries[j].Name {
+			if bckEntries[i].IsVersion() || bckEntries[j].IsVersion() {
+				return lessVersion(bckEntries[i], bckEntries[j])
+			}
 			return bckEntries[i].Flags&apc.EntryStatusMask < bckEntries[j].Flags&apc.EntryStatusMask
 		}
 		return bckEntries[i].Name < bckEntries[j].Name
@@ -25,6 +28,20 @@ func SortLso(bckEntries LsoEntries) {
 	sort.Slice(bckEntries, entryLess)
 }
 
+// same name: the object itself, followed by its prior versions - latest first
+func lessVersion(a, b *LsoEntry) bool {
+	switch {
+	case !a.IsVersion():
+		return true
+	case !b.IsVersion():
+		return false
+	case len(a.Version) != len(b.Version):
+		return len(a.Version) > len(b.Version)
+	default:
+		return a.Version > b.Version
+	}
+}
+
 func dedupLso(entries LsoEntries, maxSize uint) ([]*LsoEntry, string) {
 	var (
 		token    string
@@ -32,15 +49,15 @@ func dedupLso(entries LsoEntries, maxSize uint) ([]*LsoEntry, string) {
 		objCount = uint(len(entries))
 	)
 	for _, obj := range entries {
-		if j > 0 && entries[j-1].Name == obj.Name {
+		if j > 0 && entries[j-1].sameAs(obj) {
 			continue
 		}
-		entries[j] = obj
-		j++
-
-		if maxSize > 0 && j == int(maxSize) {
+		// (prior versions of the object always go together with the object)
+		if maxSize > 0 && j >= int(maxSize) && !obj.IsVersion() {
 			break
 		}
+		entries[j] = obj
+		j++
 	}
 	// nullify discarded entries to avoid leaks (e.g. https://github.com/golang/go/wiki/SliceTricks)
 	for i := j; i < int(objCount); i++ {
@@ -111,15 +128,16 @@ func MergeLso(lists []*LsoResult, maxSize uint) *LsoResult {
 			continuationToken = l.ContinuationToken
 		}
 		for _, e := range l.Entries {
-			entry, exists := lst[e.Name]
+			key := e.key()
+			entry, exists := lst[key]
 			if !exists {
-				lst[e.Name] = e
+				lst[key] = e
 				continue
 			}
 			// detect which list contains real information about the object
 			if !entry.CheckExists() && e.CheckExists() {
 				e.Version = cos.Either(e.Version, entry.Version)
-				lst[e.Name] = e
+				lst[key] = e
 			} else {
 				entry.Location = cos.Either(entry.Location, e.Location)
 				entry.Version = cos.Either(entry.Version, e.Version)
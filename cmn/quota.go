@@ -0,0 +1,137 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Quotas: limits on the number of objects and their total size - per bucket (BucketProps.Quota)
+// and per namespace (ClusterConfig.Quota). Zero means unlimited.
+// Writes (PUT, APPEND, promote, copy) that would exceed a hard limit fail with ErrQuotaExceeded;
+// exceeding a soft limit gets logged.
+// Each target enforces its own share of the limits (limit divided by the number of active
+// targets), based on its local usage - see ais/tgtquota.go.
+
+// ErrQuotaExceeded enum
+const (
+	QuotaSize    = "size"
+	QuotaObjects = "objects"
+)
+
+type (
+	QuotaLimits struct {
+		Size    cos.SizeIEC `json:"size"`    // total size of all objects
+		Objects int64       `json:"objects"` // number of objects
+	}
+	QuotaLimitsToUpdate struct {
+		Size    *cos.SizeIEC `json:"size,omitempty"`
+		Objects *int64       `json:"objects,omitempty"`
+	}
+	QuotaConf struct {
+		Soft QuotaLimits `json:"soft"`
+		Hard QuotaLimits `json:"hard"`
+	}
+	QuotaConfToUpdate struct {
+		Soft *QuotaLimitsToUpdate `json:"soft,omitempty"`
+		Hard *QuotaLimitsToUpdate `json:"hard,omitempty"`
+	}
+
+	// per-namespace quotas (cluster config), e.g.:
+	// "quota": {"namespaces": {"#ml": {"hard": {"size": "100TiB"}}}}
+	NsQuotaConf struct {
+		Namespaces map[string]QuotaConf `json:"namespaces,omitempty"` // keyed by Ns.String()
+	}
+)
+
+///////////////
+// QuotaConf //
+///////////////
+
+func (c *QuotaConf) IsSet() bool { return c.Soft.isSet() || c.Hard.isSet() }
+
+func (c *QuotaConf) ValidateAsProps(...any) error {
+	if err := c.Soft.validate("soft"); err != nil {
+		return err
+	}
+	if err := c.Hard.validate("hard"); err != nil {
+		return err
+	}
+	if c.Hard.Size > 0 && c.Soft.Size > c.Hard.Size {
+		return fmt.Errorf("invalid quota config: soft size limit %s exceeds hard limit %s", c.Soft.Size, c.Hard.Size)
+	}
+	if c.Hard.Objects > 0 && c.Soft.Objects > c.Hard.Objects {
+		return fmt.Errorf("invalid quota config: soft object limit %d exceeds hard limit %d",
+			c.Soft.Objects, c.Hard.Objects)
+	}
+	return nil
+}
+
+func (c *QuotaConf) String() string {
+	if !c.IsSet() {
+		return "Unlimited"
+	}
+	return "soft: " + c.Soft.String() + ", hard: " + c.Hard.String()
+}
+
+func (l *QuotaLimits) isSet() bool { return l.Size > 0 || l.Objects > 0 }
+
+func (l *QuotaLimits) validate(tag string) error {
+	if l.Size < 0 || l.Objects < 0 {
+		return fmt.Errorf("invalid quota config: negative %s limit (%s, %d)", tag, l.Size, l.Objects)
+	}
+	return nil
+}
+
+func (l *QuotaLimits) String() string {
+	if !l.isSet() {
+		return "-"
+	}
+	var s string
+	if l.Size > 0 {
+		s = l.Size.String()
+	}
+	if l.Objects > 0 {
+		if s != "" {
+			s += ", "
+		}
+		s += fmt.Sprintf("%d objects", l.Objects)
+	}
+	return s
+}
+
+/////////////////
+// NsQuotaConf //
+/////////////////
+
+func (c *NsQuotaConf) Validate() error {
+	for s, conf := range c.Namespaces {
+		ns := ParseNsUname(s)
+		if ns.IsGlobal() || ns.String() != s {
+			return fmt.Errorf("invalid quota config: invalid namespace %q (expecting, e.g., \"#ml\")", s)
+		}
+		if err := ns.validate(); err != nil {
+			return err
+		}
+		if err := conf.ValidateAsProps(); err != nil {
+			return fmt.Errorf("namespace %q: %v", s, err)
+		}
+	}
+	return nil
+}
+
+// returns nil if the namespace has no quota
+func (c *NsQuotaConf) Get(ns Ns) *QuotaConf {
+	if len(c.Namespaces) == 0 || ns.IsGlobal() {
+		return nil
+	}
+	if conf, ok := c.Namespaces[ns.String()]; ok && conf.IsSet() {
+		return &conf
+	}
+	return nil
+}
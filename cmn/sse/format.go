@@ -0,0 +1,189 @@
+// Package sse provides server-side encryption at rest: chunked AES-GCM
+// on-disk format and pluggable (KMS-like) key providers.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package sse
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// On-disk format:
+//
+//	header (HeaderSize bytes) | chunk 0 | chunk 1 | ... | chunk N-1
+//
+// where:
+// - header: magic, version, key provider and key ID (the key used to encrypt this
+//   particular object), and random salt;
+// - each object is encrypted with its own data key derived from the (bucket) key and
+//   the salt (HKDF-SHA256) - never reusing (key, nonce) pairs across objects;
+// - each chunk: AES-GCM sealed (up to) ChunkSize bytes of plaintext followed by tag;
+// - chunk's nonce: chunk index | last-chunk flag - the latter to detect truncation;
+//   the header itself is authenticated as additional data.
+//
+// Fixed-size chunking makes it possible to read (and decrypt) arbitrary ranges
+// and to compute on-disk size from plaintext size, and vice versa.
+
+const (
+	ChunkSize  = 64 * 1024
+	HeaderSize = 128
+
+	magic       = "AISE"
+	version     = 2
+	tagSize     = 16
+	saltSize    = 32
+	offProvider = 7                            // [4]version, [5]len(provider), [6]len(keyID)
+	offKeyID    = offProvider + maxProviderLen // 23
+	offSalt     = offKeyID + maxKeyIDLen       // 87
+	aadSize     = offSalt + saltSize           // 119 (the rest is reserved)
+	diskChunk   = ChunkSize + tagSize
+
+	hkdfInfo = "aistore sse data key"
+)
+
+var errTruncated = errors.New("sse: truncated or corrupted ciphertext")
+
+type cryptor struct {
+	aead   cipher.AEAD
+	aad    []byte
+	nonce  [12]byte
+	header [HeaderSize]byte
+}
+
+func newHeader(provider, keyID string) (*cryptor, error) {
+	if err := ValidateNames(provider, keyID); err != nil {
+		return nil, err
+	}
+	c := &cryptor{}
+	h := c.header[:]
+	copy(h, magic)
+	h[4], h[5], h[6] = version, byte(len(provider)), byte(len(keyID))
+	copy(h[offProvider:], provider)
+	copy(h[offKeyID:], keyID)
+	if _, err := rand.Read(h[offSalt:aadSize]); err != nil {
+		return nil, err
+	}
+	return c, c.init(provider, keyID)
+}
+
+func parseHeader(h []byte) (*cryptor, error) {
+	if len(h) < HeaderSize || string(h[:4]) != magic {
+		return nil, errors.New("sse: not encrypted or invalid header")
+	}
+	if h[4] != version {
+		return nil, fmt.Errorf("sse: unsupported format version %d", h[4])
+	}
+	lp, lk := int(h[5]), int(h[6])
+	if lp > maxProviderLen || lk == 0 || lk > maxKeyIDLen {
+		return nil, errors.New("sse: invalid header")
+	}
+	c := &cryptor{}
+	copy(c.header[:], h[:HeaderSize])
+	return c, c.init(string(h[offProvider:offProvider+lp]), string(h[offKeyID:offKeyID+lk]))
+}
+
+func (c *cryptor) init(provider, keyID string) error {
+	key, err := Key(provider, keyID)
+	if err != nil {
+		return err
+	}
+	if key, err = dataKey(key, c.header[offSalt:aadSize]); err != nil {
+		return err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	if c.aead, err = cipher.NewGCM(block); err != nil {
+		return err
+	}
+	c.aad = c.header[:aadSize]
+	return nil
+}
+
+// per-object data key of the same length as the (bucket) key
+func dataKey(key, salt []byte) ([]byte, error) {
+	dk := make([]byte, len(key))
+	if _, err := io.ReadFull(hkdf.New(sha256.New, key, salt, []byte(hkdfInfo)), dk); err != nil {
+		return nil, err
+	}
+	return dk, nil
+}
+
+func (c *cryptor) setNonce(idx int64, last bool) []byte {
+	binary.BigEndian.PutUint64(c.nonce[3:11], uint64(idx))
+	c.nonce[11] = 0
+	if last {
+		c.nonce[11] = 1
+	}
+	return c.nonce[:]
+}
+
+func (c *cryptor) seal(dst, plain []byte, idx int64, last bool) []byte {
+	return c.aead.Seal(dst, c.setNonce(idx, last), plain, c.aad)
+}
+
+func (c *cryptor) open(dst, sealed []byte, idx int64, last bool) ([]byte, error) {
+	plain, err := c.aead.Open(dst, c.setNonce(idx, last), sealed, c.aad)
+	if err != nil {
+		return nil, errTruncated
+	}
+	return plain, nil
+}
+
+//
+// sizes
+//
+
+func numChunks(size int64) int64 {
+	if size <= 0 {
+		return 1
+	}
+	return (size + ChunkSize - 1) / ChunkSize
+}
+
+// DiskSize returns on-disk size of encrypted `size` bytes
+func DiskSize(size int64) int64 {
+	return HeaderSize + size + numChunks(size)*tagSize
+}
+
+// PlainSize is the inverse of the DiskSize
+func PlainSize(diskSize int64) (int64, error) {
+	rem := diskSize - HeaderSize
+	if rem < tagSize {
+		return 0, errTruncated
+	}
+	n := (rem + diskChunk - 1) / diskChunk
+	size := rem - n*tagSize
+	if size < 0 || DiskSize(size) != diskSize {
+		return 0, errTruncated
+	}
+	return size, nil
+}
+
+// read and validate the header, and compute plaintext size
+func NewReader(ra io.ReaderAt, diskSize int64) (*Reader, error) {
+	var h [HeaderSize]byte
+	if _, err := ra.ReadAt(h[:], 0); err != nil {
+		return nil, err
+	}
+	c, err := parseHeader(h[:])
+	if err != nil {
+		return nil, err
+	}
+	size, err := PlainSize(diskSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{c: c, ra: ra, size: size, nchunks: numChunks(size), cur: -1}, nil
+}
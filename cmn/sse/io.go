@@ -0,0 +1,256 @@
+// Package sse provides server-side encryption at rest: chunked AES-GCM
+// on-disk format and pluggable (KMS-like) key providers.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package sse
+
+import (
+	"errors"
+	"io"
+)
+
+type (
+	// Writer encrypts plaintext into the underlying writer;
+	// Close must be called to seal the last chunk (it does not close the underlying writer).
+	Writer struct {
+		w   io.Writer
+		c   *cryptor
+		buf []byte // plaintext
+		out []byte // sealed
+		idx int64
+		err error
+	}
+	// Reader decrypts (any part of) encrypted content
+	Reader struct {
+		ra      io.ReaderAt
+		c       *cryptor
+		buf     []byte // sealed
+		plain   []byte // decrypted chunk `cur`
+		size    int64  // plaintext
+		nchunks int64
+		off     int64
+		cur     int64
+	}
+	// DecWriter consumes already encrypted content (e.g., restored by erasure decoding)
+	// and writes the corresponding plaintext into the underlying writer, thus
+	// authenticating the former and making it possible to checksum the latter
+	DecWriter struct {
+		w     io.Writer
+		c     *cryptor
+		hdr   []byte
+		buf   []byte // sealed
+		plain []byte
+		idx   int64
+		size  int64
+		err   error
+	}
+)
+
+// interface guard
+var (
+	_ io.WriteCloser = (*Writer)(nil)
+	_ io.ReaderAt    = (*Reader)(nil)
+	_ io.ReadSeeker  = (*Reader)(nil)
+	_ io.WriteCloser = (*DecWriter)(nil)
+)
+
+////////////
+// Writer //
+////////////
+
+func NewWriter(w io.Writer, provider, keyID string) (*Writer, error) {
+	c, err := newHeader(provider, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(c.header[:]); err != nil {
+		return nil, err
+	}
+	return &Writer{w: w, c: c, buf: make([]byte, 0, ChunkSize), out: make([]byte, 0, diskChunk)}, nil
+}
+
+func (ew *Writer) Write(p []byte) (n int, err error) {
+	if ew.err != nil {
+		return 0, ew.err
+	}
+	for len(p) > 0 {
+		// the chunk is sealed only when there's more to follow (see Close)
+		if len(ew.buf) == ChunkSize {
+			if ew.err = ew.flush(false); ew.err != nil {
+				return n, ew.err
+			}
+		}
+		m := copy(ew.buf[len(ew.buf):ChunkSize], p)
+		ew.buf = ew.buf[:len(ew.buf)+m]
+		n += m
+		p = p[m:]
+	}
+	return n, nil
+}
+
+func (ew *Writer) flush(last bool) error {
+	ew.out = ew.c.seal(ew.out[:0], ew.buf, ew.idx, last)
+	ew.idx++
+	ew.buf = ew.buf[:0]
+	_, err := ew.w.Write(ew.out)
+	return err
+}
+
+func (ew *Writer) Close() error {
+	if ew.err != nil {
+		return ew.err
+	}
+	ew.err = ew.flush(true)
+	if ew.err == nil {
+		ew.err = errors.New("sse: writer closed")
+		return nil
+	}
+	return ew.err
+}
+
+////////////
+// Reader //
+////////////
+
+func (r *Reader) Size() int64 { return r.size }
+
+func (r *Reader) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.New("sse: negative offset")
+	}
+	for len(p) > 0 {
+		if off >= r.size {
+			return n, io.EOF
+		}
+		idx := off / ChunkSize
+		if err = r.load(idx); err != nil {
+			return n, err
+		}
+		m := copy(p, r.plain[off-idx*ChunkSize:])
+		n += m
+		off += int64(m)
+		p = p[m:]
+	}
+	return n, nil
+}
+
+func (r *Reader) load(idx int64) error {
+	if idx == r.cur {
+		return nil
+	}
+	var (
+		last  = idx == r.nchunks-1
+		plain = int64(ChunkSize)
+	)
+	if last {
+		plain = r.size - idx*ChunkSize
+	}
+	if r.buf == nil {
+		r.buf = make([]byte, diskChunk)
+		r.plain = make([]byte, 0, ChunkSize)
+	}
+	sealed := r.buf[:plain+tagSize]
+	if _, err := r.ra.ReadAt(sealed, HeaderSize+idx*diskChunk); err != nil {
+		if err == io.EOF {
+			err = errTruncated
+		}
+		return err
+	}
+	out, err := r.c.open(r.plain[:0], sealed, idx, last)
+	if err != nil {
+		r.cur = -1
+		return err
+	}
+	r.plain, r.cur = out, idx
+	return nil
+}
+
+func (r *Reader) Read(p []byte) (n int, err error) {
+	n, err = r.ReadAt(p, r.off)
+	r.off += int64(n)
+	return
+}
+
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.off
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.New("sse: invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("sse: negative position")
+	}
+	r.off = offset
+	return offset, nil
+}
+
+///////////////
+// DecWriter //
+///////////////
+
+func NewDecWriter(w io.Writer) *DecWriter {
+	return &DecWriter{w: w, hdr: make([]byte, 0, HeaderSize), buf: make([]byte, 0, diskChunk)}
+}
+
+// plaintext size
+func (dw *DecWriter) Size() int64 { return dw.size }
+
+func (dw *DecWriter) Write(p []byte) (n int, err error) {
+	if dw.err != nil {
+		return 0, dw.err
+	}
+	n = len(p)
+	if dw.c == nil {
+		m := copy(dw.hdr[len(dw.hdr):HeaderSize], p)
+		dw.hdr = dw.hdr[:len(dw.hdr)+m]
+		p = p[m:]
+		if len(dw.hdr) < HeaderSize {
+			return n, nil
+		}
+		if dw.c, dw.err = parseHeader(dw.hdr); dw.err != nil {
+			return 0, dw.err
+		}
+	}
+	for len(p) > 0 {
+		if len(dw.buf) == diskChunk {
+			if dw.err = dw.flush(false); dw.err != nil {
+				return 0, dw.err
+			}
+		}
+		m := copy(dw.buf[len(dw.buf):diskChunk], p)
+		dw.buf = dw.buf[:len(dw.buf)+m]
+		p = p[m:]
+	}
+	return n, nil
+}
+
+func (dw *DecWriter) flush(last bool) (err error) {
+	if dw.plain, err = dw.c.open(dw.plain[:0], dw.buf, dw.idx, last); err != nil {
+		return
+	}
+	dw.idx++
+	dw.buf = dw.buf[:0]
+	dw.size += int64(len(dw.plain))
+	_, err = dw.w.Write(dw.plain)
+	return
+}
+
+func (dw *DecWriter) Close() error {
+	if dw.err != nil {
+		return dw.err
+	}
+	if dw.c == nil {
+		return errTruncated
+	}
+	dw.err = dw.flush(true)
+	if dw.err == nil {
+		dw.err = errors.New("sse: writer closed")
+		return nil
+	}
+	return dw.err
+}
@@ -0,0 +1,154 @@
+// Package sse provides server-side encryption at rest: chunked AES-GCM
+// on-disk format and pluggable (KMS-like) key providers.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package sse
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+)
+
+type testProvider struct{ key []byte }
+
+func (tp *testProvider) Key(string) ([]byte, error) { return tp.key, nil }
+
+func init() {
+	key := make([]byte, 32)
+	rand.Read(key)
+	RegisterProvider("test", &testProvider{key})
+}
+
+func encrypt(t *testing.T, plain []byte) []byte {
+	var (
+		out     bytes.Buffer
+		ew, err = NewWriter(&out, "test", "key-1")
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// write in odd-sized pieces
+	for p := plain; len(p) > 0; {
+		n := len(p)
+		if n > 1000 {
+			n = 1000
+		}
+		if _, err := ew.Write(p[:n]); err != nil {
+			t.Fatal(err)
+		}
+		p = p[n:]
+	}
+	if err := ew.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return out.Bytes()
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, size := range []int{0, 1, ChunkSize - 1, ChunkSize, ChunkSize + 1, 3*ChunkSize + 17} {
+		plain := make([]byte, size)
+		rand.Read(plain)
+		sealed := encrypt(t, plain)
+		if int64(len(sealed)) != DiskSize(int64(size)) {
+			t.Fatalf("size %d: on-disk %d, expected %d", size, len(sealed), DiskSize(int64(size)))
+		}
+		if n, err := PlainSize(int64(len(sealed))); err != nil || n != int64(size) {
+			t.Fatalf("size %d: plain size %d (%v)", size, n, err)
+		}
+
+		// read all
+		r, err := NewReader(bytes.NewReader(sealed), int64(len(sealed)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil || !bytes.Equal(got, plain) {
+			t.Fatalf("size %d: read-all mismatch (%v)", size, err)
+		}
+
+		// range
+		if size > 10 {
+			off, l := int64(size/2-5), 10
+			rng := make([]byte, l)
+			if _, err := r.ReadAt(rng, off); err != nil || !bytes.Equal(rng, plain[off:off+int64(l)]) {
+				t.Fatalf("size %d: range mismatch (%v)", size, err)
+			}
+		}
+
+		// decrypting writer
+		var dec bytes.Buffer
+		dw := NewDecWriter(&dec)
+		if _, err := io.Copy(dw, bytes.NewReader(sealed)); err != nil {
+			t.Fatal(err)
+		}
+		if err := dw.Close(); err != nil || !bytes.Equal(dec.Bytes(), plain) || dw.Size() != int64(size) {
+			t.Fatalf("size %d: dec-writer mismatch (%v)", size, err)
+		}
+	}
+}
+
+func TestTampered(t *testing.T) {
+	plain := make([]byte, 2*ChunkSize+100)
+	sealed := encrypt(t, plain)
+
+	// flip a bit
+	corrupted := bytes.Clone(sealed)
+	corrupted[HeaderSize+ChunkSize+tagSize+1] ^= 1
+	r, err := NewReader(bytes.NewReader(corrupted), int64(len(corrupted)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(r); err == nil {
+		t.Fatal("expecting authentication failure")
+	}
+
+	// truncate at chunk boundary
+	truncated := sealed[:HeaderSize+2*(ChunkSize+tagSize)]
+	dw := NewDecWriter(io.Discard)
+	dw.Write(truncated)
+	if err := dw.Close(); err == nil {
+		t.Fatal("expecting truncation error")
+	}
+}
+
+// same plaintext, same (bucket) key => different data keys and ciphertexts
+func TestDataKeys(t *testing.T) {
+	plain := make([]byte, ChunkSize+100)
+	rand.Read(plain)
+	sealed1, sealed2 := encrypt(t, plain), encrypt(t, plain)
+	if bytes.Equal(sealed1[HeaderSize:], sealed2[HeaderSize:]) {
+		t.Fatal("expecting different ciphertexts")
+	}
+	key, err := Key("test", "key-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	salt1, salt2 := sealed1[offSalt:aadSize], sealed2[offSalt:aadSize]
+	if bytes.Equal(salt1, salt2) {
+		t.Fatal("expecting different salts")
+	}
+	dk1, err := dataKey(key, salt1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dk2, err := dataKey(key, salt2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dk1) != len(key) || bytes.Equal(dk1, dk2) || bytes.Equal(dk1, key) {
+		t.Fatal("expecting distinct per-object data keys")
+	}
+
+	// salt is authenticated
+	sealed1[offSalt] ^= 1
+	r, err := NewReader(bytes.NewReader(sealed1), int64(len(sealed1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(r); err == nil {
+		t.Fatal("expecting authentication failure")
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/jsp"
 	"github.com/NVIDIA/aistore/tools/tassert"
 )
@@ -72,3 +73,62 @@ func TestValidateMpath(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateNsQuota(t *testing.T) {
+	valid := cmn.QuotaConf{Soft: cmn.QuotaLimits{Size: 8 * cos.GiB}, Hard: cmn.QuotaLimits{Size: 10 * cos.GiB, Objects: 1000}}
+	tests := []struct {
+		conf cmn.NsQuotaConf
+		ok   bool
+	}{
+		{cmn.NsQuotaConf{}, true},
+		{cmn.NsQuotaConf{Namespaces: map[string]cmn.QuotaConf{"#ml": valid}}, true},
+		{cmn.NsQuotaConf{Namespaces: map[string]cmn.QuotaConf{"@uuid#ml": valid}}, true},
+		{cmn.NsQuotaConf{Namespaces: map[string]cmn.QuotaConf{"ml": valid}}, false},
+		{cmn.NsQuotaConf{Namespaces: map[string]cmn.QuotaConf{"": valid}}, false},
+		{cmn.NsQuotaConf{Namespaces: map[string]cmn.QuotaConf{"#ml": {
+			Soft: cmn.QuotaLimits{Objects: 2000}, Hard: cmn.QuotaLimits{Objects: 1000},
+		}}}, false},
+		{cmn.NsQuotaConf{Namespaces: map[string]cmn.QuotaConf{"#ml": {Hard: cmn.QuotaLimits{Size: -1}}}}, false},
+	}
+	for _, test := range tests {
+		err := test.conf.Validate()
+		if test.ok && err != nil {
+			t.Errorf("%+v: unexpected error: %v", test.conf, err)
+		} else if !test.ok && err == nil {
+			t.Errorf("%+v: expected validation to fail", test.conf)
+		}
+	}
+	conf := cmn.NsQuotaConf{Namespaces: map[string]cmn.QuotaConf{"#ml": valid}}
+	tassert.Errorf(t, conf.Get(cmn.Ns{Name: "ml"}) != nil, "expected quota for namespace #ml")
+	tassert.Errorf(t, conf.Get(cmn.Ns{Name: "other"}) == nil, "expected no quota for namespace #other")
+	tassert.Errorf(t, conf.Get(cmn.NsGlobal) == nil, "expected no quota for global namespace")
+}
+
+func TestValidateBackendAWS(t *testing.T) {
+	tests := []struct {
+		conf any
+		ok   bool
+	}{
+		{map[string]any{}, true},
+		{map[string]any{"minio": map[string]any{"url": "http://minio:9000", "path_style": true}}, true},
+		{map[string]any{"rgw": map[string]any{"url": "https://rgw.local", "region": "default", "profile": "ceph"}}, true},
+		{map[string]any{"minio": map[string]any{"region": "us-east-1"}}, false},
+		{map[string]any{"minio": map[string]any{"url": "minio:9000"}}, false},
+		{map[string]any{"a/b": map[string]any{"url": "http://minio:9000"}}, false},
+	}
+	for _, test := range tests {
+		conf := cmn.BackendConf{Conf: map[string]any{apc.AWS: test.conf}}
+		err := conf.Validate()
+		if test.ok && err != nil {
+			t.Errorf("%+v: unexpected error: %v", test.conf, err)
+		} else if !test.ok && err == nil {
+			t.Errorf("%+v: expected validation to fail", test.conf)
+		}
+		if err != nil {
+			continue
+		}
+		if ep, ok := conf.S3Endpoint("minio"); ok && !ep.PathStyle {
+			t.Errorf("%+v: expected path-style addressing", ep)
+		}
+	}
+}
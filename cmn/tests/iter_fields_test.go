@@ -80,6 +80,8 @@ var _ = Describe("IterFields", func() {
 
 					"versioning.enabled":           false,
 					"versioning.validate_warm_get": false,
+					"versioning.history":           0,
+					"versioning.history_ttl":       cos.Duration(0),
 
 					"checksum.type":              cos.ChecksumXXHash,
 					"checksum.validate_warm_get": false,
@@ -99,6 +101,26 @@ var _ = Describe("IterFields", func() {
 
 					"write_policy.data": apc.WritePolicy(""),
 					"write_policy.md":   apc.WritePolicy(""),
+
+					"object_lock.mode":      "",
+					"object_lock.retention": cos.Duration(0),
+					"object_lock.enabled":   false,
+
+					"encryption.provider": "",
+					"encryption.key_id":   "",
+					"encryption.enabled":  false,
+
+					"compression.algo":    "",
+					"compression.enabled": false,
+
+					"tiering.demote_after": cos.Duration(0),
+					"tiering.enabled":      false,
+					"trash.retention":      cos.Duration(0),
+					"trash.enabled":        false,
+					"quota.soft.size":      cos.SizeIEC(0),
+					"quota.soft.objects":   int64(0),
+					"quota.hard.size":      cos.SizeIEC(0),
+					"quota.hard.objects":   int64(0),
 				},
 			),
 			Entry("list BucketPropsToUpdate fields",
@@ -134,6 +156,8 @@ var _ = Describe("IterFields", func() {
 
 					"versioning.enabled":           (*bool)(nil),
 					"versioning.validate_warm_get": (*bool)(nil),
+					"versioning.history":           (*int)(nil),
+					"versioning.history_ttl":       (*cos.Duration)(nil),
 
 					"checksum.type":              api.String(cos.ChecksumXXHash),
 					"checksum.validate_warm_get": (*bool)(nil),
@@ -150,10 +174,36 @@ var _ = Describe("IterFields", func() {
 					"write_policy.data": (*apc.WritePolicy)(nil),
 					"write_policy.md":   api.WritePolicy(apc.WriteDelayed),
 
-					"extra.hdfs.ref_directory": (*string)(nil),
-					"extra.aws.cloud_region":   (*string)(nil),
-					"extra.aws.endpoint":       (*string)(nil),
-					"extra.http.original_url":  (*string)(nil),
+					"extra.hdfs.ref_directory":   (*string)(nil),
+					"extra.fs.ref_directory":     (*string)(nil),
+					"extra.aws.cloud_region":     (*string)(nil),
+					"extra.aws.endpoint":         (*string)(nil),
+					"extra.http.original_url":    (*string)(nil),
+					"extra.http.manifest":        (*string)(nil),
+					"extra.cold_get.chunk_size":  (*cos.SizeIEC)(nil),
+					"extra.cold_get.concurrency": (*int)(nil),
+
+					"lifecycle.rules": (*[]cmn.LifecycleRule)(nil),
+
+					"object_lock.mode":      (*string)(nil),
+					"object_lock.retention": (*cos.Duration)(nil),
+					"object_lock.enabled":   (*bool)(nil),
+
+					"encryption.provider": (*string)(nil),
+					"encryption.key_id":   (*string)(nil),
+					"encryption.enabled":  (*bool)(nil),
+
+					"compression.algo":    (*string)(nil),
+					"compression.enabled": (*bool)(nil),
+
+					"tiering.demote_after": (*cos.Duration)(nil),
+					"tiering.enabled":      (*bool)(nil),
+					"trash.retention":      (*cos.Duration)(nil),
+					"trash.enabled":        (*bool)(nil),
+					"quota.soft.size":      (*cos.SizeIEC)(nil),
+					"quota.soft.objects":   (*int64)(nil),
+					"quota.hard.size":      (*cos.SizeIEC)(nil),
+					"quota.hard.objects":   (*int64)(nil),
 				},
 			),
 			Entry("check for omit tag",
@@ -253,6 +303,20 @@ var _ = Describe("IterFields", func() {
 					WritePolicy: cmn.WritePolicyConf{MD: apc.WriteNever},
 				},
 			),
+			Entry("update BucketProps quota",
+				&cmn.BucketProps{},
+				map[string]any{
+					"quota.soft.size":    "8GiB", // type == cos.SizeIEC
+					"quota.hard.size":    "10GiB",
+					"quota.hard.objects": "1000", // type == int64
+				},
+				&cmn.BucketProps{
+					Quota: cmn.QuotaConf{
+						Soft: cmn.QuotaLimits{Size: 8 * cos.GiB},
+						Hard: cmn.QuotaLimits{Size: 10 * cos.GiB, Objects: 1000},
+					},
+				},
+			),
 			Entry("update some BucketPropsToUpdate",
 				&cmn.BucketPropsToUpdate{
 					Cksum: &cmn.CksumConfToUpdate{
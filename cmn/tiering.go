@@ -0,0 +1,47 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Tiering: objects of a tiered bucket are written into the target's fastest ("hot")
+// storage tier and get demoted, one tier at a time, when not accessed for `DemoteAfter`;
+// upon access (GET), demoted objects are promoted back into the hot tier.
+// Storage tiers are assigned to mountpaths via local config (see FSPConf);
+// a target with a single tier ignores this configuration.
+// See also: fs.Tiers() and `apc.ActTiering` xaction.
+
+type (
+	TieringConf struct {
+		DemoteAfter cos.Duration `json:"demote_after"` // demote objects that were not accessed for so long
+		Enabled     bool         `json:"enabled"`
+	}
+	TieringConfToUpdate struct {
+		DemoteAfter *cos.Duration `json:"demote_after,omitempty"`
+		Enabled     *bool         `json:"enabled,omitempty"`
+	}
+)
+
+func (c *TieringConf) ValidateAsProps(...any) error {
+	if c.Enabled && c.DemoteAfter <= 0 {
+		return fmt.Errorf("invalid tiering config: demote_after must be positive (got %s)", c.DemoteAfter)
+	}
+	if c.DemoteAfter < 0 {
+		return fmt.Errorf("invalid tiering config: negative demote_after %s", c.DemoteAfter)
+	}
+	return nil
+}
+
+func (c *TieringConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	return "demote after " + c.DemoteAfter.String()
+}
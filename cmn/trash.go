@@ -0,0 +1,46 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Trash (soft delete, ais:// buckets only): when enabled, deleted objects get moved into
+// the bucket's trash (on the same mountpath) where they remain for the configured `Retention`.
+// In the meantime, trashed objects can be listed (apc.LsDeleted) and undeleted
+// (apc.ActUndeleteObject); expired ones get purged by the target's housekeeper.
+// Note: only the most recently deleted instance of a given object is retained.
+
+type (
+	TrashConf struct {
+		Retention cos.Duration `json:"retention"` // undelete window
+		Enabled   bool         `json:"enabled"`
+	}
+	TrashConfToUpdate struct {
+		Retention *cos.Duration `json:"retention,omitempty"`
+		Enabled   *bool         `json:"enabled,omitempty"`
+	}
+)
+
+func (c *TrashConf) ValidateAsProps(...any) error {
+	if c.Enabled && c.Retention <= 0 {
+		return fmt.Errorf("invalid trash config: retention must be positive (got %s)", c.Retention)
+	}
+	if c.Retention < 0 {
+		return fmt.Errorf("invalid trash config: negative retention %s", c.Retention)
+	}
+	return nil
+}
+
+func (c *TrashConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	return "retain deleted for " + c.Retention.String()
+}
@@ -151,7 +151,8 @@ cat > $AIS_CONF_FILE <<EOL
 		"retry_factor":   5
 	},
 	"downloader": {
-		"timeout": "1h"
+		"timeout": "1h",
+		"max_bandwidth": "0"
 	},
 	"distributed_sort": {
 		"duplicated_records":    "ignore",
@@ -197,7 +197,7 @@ func (c *getJogger) copyMissingReplicas(ctx *restoreCtx, reader cos.ReadOpenClos
 	}
 	src := &dataSource{
 		reader:   srcReader,
-		size:     ctx.lom.SizeBytes(),
+		size:     ctx.lom.SizeOnDisk(),
 		metadata: ctx.meta,
 		reqType:  reqPut,
 	}
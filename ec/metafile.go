@@ -19,7 +19,7 @@ const MDVersionLast = 1 // current version of metadata
 
 // Metadata - EC information stored in metafiles for every encoded object
 type Metadata struct {
-	Size        int64            `json:"obj_size"`      // obj size (after EC'ing sum size of slices differs from the original)
+	Size        int64            `json:"obj_size"`      // obj size on disk (after EC'ing sum size of slices differs from the original)
 	Generation  int64            `json:"generation"`    // Timestamp when the object was EC'ed
 	ObjCksum    string           `json:"obj_cksum"`     // checksum of the original object
 	ObjVersion  string           `json:"obj_version"`   // object version
@@ -86,9 +86,9 @@ func (*putJogger) newCtx(lom *cluster.LOM, meta *Metadata) (ctx *encodeCtx, err
 	ctx.meta = meta
 
 	totalCnt := ctx.paritySlices + ctx.dataSlices
-	ctx.sliceSize = SliceSize(ctx.lom.SizeBytes(), ctx.dataSlices)
+	ctx.sliceSize = SliceSize(ctx.lom.SizeOnDisk(), ctx.dataSlices)
 	ctx.slices = make([]*slice, totalCnt)
-	ctx.padSize = ctx.sliceSize*int64(ctx.dataSlices) - ctx.lom.SizeBytes()
+	ctx.padSize = ctx.sliceSize*int64(ctx.dataSlices) - ctx.lom.SizeOnDisk()
 
 	ctx.fh, err = cos.NewFileHandle(lom.FQN)
 	return ctx, err
@@ -125,7 +125,7 @@ func (c *putJogger) processRequest(req *request) {
 			return
 		}
 		ecConf := lom.Bprops().EC
-		memRequired := lom.SizeBytes() * int64(ecConf.DataSlices+ecConf.ParitySlices) / int64(ecConf.ParitySlices)
+		memRequired := lom.SizeOnDisk() * int64(ecConf.DataSlices+ecConf.ParitySlices) / int64(ecConf.ParitySlices)
 		c.toDisk = useDisk(memRequired)
 	}
 
@@ -234,7 +234,7 @@ func (c *putJogger) encode(req *request, lom *cluster.LOM) error {
 	meta := &Metadata{
 		MDVersion:   MDVersionLast,
 		Generation:  generation,
-		Size:        lom.SizeBytes(),
+		Size:        lom.SizeOnDisk(),
 		Data:        ecConf.DataSlices,
 		Parity:      ecConf.ParitySlices,
 		IsCopy:      req.IsCopy,
@@ -334,7 +334,7 @@ func (c *putJogger) createCopies(ctx *encodeCtx) error {
 	// broadcast the replica to the targets
 	src := &dataSource{
 		reader:   ctx.fh,
-		size:     ctx.lom.SizeBytes(),
+		size:     ctx.lom.SizeOnDisk(),
 		metadata: ctx.meta,
 		reqType:  reqPut,
 	}
@@ -380,7 +380,7 @@ func generateSlicesToMemory(ctx *encodeCtx) error {
 func initializeSlices(ctx *encodeCtx) (err error) {
 	// readers are slices of original object(no memory allocated)
 	cksmReaders := make([]io.Reader, ctx.dataSlices)
-	sizeLeft := ctx.lom.SizeBytes()
+	sizeLeft := ctx.lom.SizeOnDisk()
 	for i := 0; i < ctx.dataSlices; i++ {
 		var (
 			reader     cos.ReadOpenCloser
@@ -161,10 +161,10 @@ func newReplicaResponse(attrs *cmn.ObjAttrs, bck *cluster.Bck, objName string) (
 	if err != nil {
 		return nil, err
 	}
-	if lom.SizeBytes() == 0 {
+	if lom.SizeOnDisk() == 0 {
 		return nil, nil
 	}
-	attrs.Size = lom.SizeBytes()
+	attrs.Size = lom.SizeOnDisk()
 	attrs.Ver = lom.Version()
 	attrs.Atime = lom.AtimeUnix()
 	attrs.Cksum = lom.Checksum()
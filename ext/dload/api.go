@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -57,6 +58,8 @@ type (
 		Total         int       `json:"total"`          // total number of tasks, negative if unknown
 		AllDispatched bool      `json:"all_dispatched"` // if true, dispatcher has already scheduled all tasks for given job
 		Aborted       bool      `json:"aborted"`
+		Schedule      string    `json:"schedule,omitempty"` // scheduled job (see Base.Schedule)
+		NextRun       time.Time `json:"next_run,omitempty"`
 	}
 
 	JobInfos []*Job
@@ -68,9 +71,21 @@ type (
 		Errs          []TaskErrInfo `json:"download_errors,omitempty"`
 	}
 
+	// NOTE: bandwidth limits are cluster-wide (divided between the targets), and can be adjusted
+	// while the job is running (see AdminBody.Limits); weight determines the job's share of
+	// target's bandwidth (see DownloaderConf.MaxBandwidth) and its download queues (see fairShare)
 	Limits struct {
-		Connections  int `json:"connections"`
-		BytesPerHour int `json:"bytes_per_hour"`
+		Connections  int   `json:"connections"`
+		BytesPerHour int   `json:"bytes_per_hour"`
+		BytesPerSec  int64 `json:"bytes_per_sec,omitempty"`
+		Weight       int   `json:"weight,omitempty"` // default: 1
+	}
+	// limits of the running job: only the specified (non-nil) ones get changed
+	LimitsToUpdate struct {
+		Connections  *int   `json:"connections,omitempty"` // (cannot be changed)
+		BytesPerHour *int   `json:"bytes_per_hour,omitempty"`
+		BytesPerSec  *int64 `json:"bytes_per_sec,omitempty"`
+		Weight       *int   `json:"weight,omitempty"`
 	}
 
 	Base struct {
@@ -79,6 +94,11 @@ type (
 		Timeout          string  `json:"timeout"`
 		ProgressInterval string  `json:"progress_interval"`
 		Limits           Limits  `json:"limits"`
+		// checksum manifest (e.g., SHA256SUMS): URL or bucket/object in the cluster (see manifest.go)
+		Manifest      string `json:"manifest,omitempty"`
+		VerifyRetries int    `json:"verify_retries,omitempty"` // number of times to re-download upon checksum mismatch
+		// run periodically: interval (e.g., "24h") or cron expression (e.g., "0 2 * * *") - see schedule.go
+		Schedule string `json:"schedule,omitempty"`
 	}
 
 	SingleObj struct {
@@ -88,9 +108,10 @@ type (
 	}
 
 	AdminBody struct {
-		ID         string `json:"id"`
-		Regex      string `json:"regex"`
-		OnlyActive bool   `json:"only_active_tasks"` // Skips detailed info about tasks finished/errored
+		ID         string          `json:"id"`
+		Regex      string          `json:"regex"`
+		OnlyActive bool            `json:"only_active_tasks"` // Skips detailed info about tasks finished/errored
+		Limits     *LimitsToUpdate `json:"limits,omitempty"`  // new limits of the running job (PUT /v1/download/limits)
 	}
 
 	TaskDlInfo struct {
@@ -153,6 +174,12 @@ func (j *Job) Aggregate(rhs *Job) {
 	if j.StartedTime.After(rhs.StartedTime) {
 		j.StartedTime = rhs.StartedTime
 	}
+	if j.Schedule == "" {
+		j.Schedule = rhs.Schedule
+	}
+	if cos.IsTimeZero(j.NextRun) || (!cos.IsTimeZero(rhs.NextRun) && j.NextRun.After(rhs.NextRun)) {
+		j.NextRun = rhs.NextRun
+	}
 	// Compute max out of `FinishedTime` only when both are non-zero.
 	if !cos.IsTimeZero(j.FinishedTime) {
 		if cos.IsTimeZero(rhs.FinishedTime) {
@@ -211,6 +238,9 @@ func (j *Job) String() string {
 	switch {
 	case j.Aborted:
 		sb.WriteString("aborted")
+	case finished && j.Schedule != "":
+		sb.WriteString("scheduled, next run at ")
+		sb.WriteString(j.NextRun.Format(time.RFC3339))
 	case finished:
 		sb.WriteString("finished")
 	default:
@@ -300,8 +330,25 @@ func (b *Base) Validate() error {
 	if b.Limits.Connections < 0 {
 		return fmt.Errorf("'limit.connections' must be non-negative (got: %d)", b.Limits.Connections)
 	}
-	if b.Limits.BytesPerHour < 0 {
-		return fmt.Errorf("'limit.bytes_per_hour' must be non-negative (got: %d)", b.Limits.BytesPerHour)
+	if err := b.Limits.validateBandwidth(); err != nil {
+		return err
+	}
+	if b.VerifyRetries < 0 {
+		return fmt.Errorf("'verify_retries' must be non-negative (got: %d)", b.VerifyRetries)
+	}
+	if b.Schedule != "" {
+		if _, err := parseSchedule(b.Schedule); err != nil {
+			return fmt.Errorf("invalid 'schedule' %q: %v", b.Schedule, err)
+		}
+	}
+	if b.Manifest != "" && !cos.IsHTTP(b.Manifest) && !cos.IsHTTPS(b.Manifest) {
+		_, objName, err := cmn.ParseBckObjectURI(b.Manifest, cmn.ParseURIOpts{DefaultProvider: apc.AIS})
+		if err != nil {
+			return fmt.Errorf("invalid 'manifest' %q: %v", b.Manifest, err)
+		}
+		if objName == "" {
+			return fmt.Errorf("invalid 'manifest' %q: expecting URL or bucket/object", b.Manifest)
+		}
 	}
 	return nil
 }
@@ -327,6 +374,67 @@ func (b *SingleObj) Validate() error {
 	return nil
 }
 
+////////////
+// Limits //
+////////////
+
+func (l *Limits) validateBandwidth() error {
+	if l.BytesPerHour < 0 {
+		return fmt.Errorf("'limit.bytes_per_hour' must be non-negative (got: %d)", l.BytesPerHour)
+	}
+	if l.BytesPerSec < 0 {
+		return fmt.Errorf("'limit.bytes_per_sec' must be non-negative (got: %d)", l.BytesPerSec)
+	}
+	if l.Weight < 0 {
+		return fmt.Errorf("'limit.weight' must be non-negative (got: %d)", l.Weight)
+	}
+	return nil
+}
+
+// (cluster-wide) bytes per second; zero - unlimited
+func (l *Limits) bandwidth() (bps int64) {
+	bps = l.BytesPerSec
+	if perHour := int64(l.BytesPerHour) / 3600; l.BytesPerHour > 0 && (bps == 0 || perHour < bps) {
+		bps = cos.MaxI64(perHour, 1)
+	}
+	return
+}
+
+func (l *Limits) weight() int64 {
+	if l.Weight <= 0 {
+		return 1
+	}
+	return int64(l.Weight)
+}
+
+func (l *Limits) apply(upd *LimitsToUpdate) {
+	if upd.BytesPerHour != nil {
+		l.BytesPerHour = *upd.BytesPerHour
+	}
+	if upd.BytesPerSec != nil {
+		l.BytesPerSec = *upd.BytesPerSec
+	}
+	if upd.Weight != nil {
+		l.Weight = *upd.Weight
+	}
+}
+
+////////////////////
+// LimitsToUpdate //
+////////////////////
+
+func (upd *LimitsToUpdate) validate() error {
+	if upd.Connections != nil {
+		return errors.New("'limit.connections' cannot be changed while the job is running")
+	}
+	if upd.BytesPerHour == nil && upd.BytesPerSec == nil && upd.Weight == nil {
+		return errors.New("no limits to change")
+	}
+	l := &Limits{}
+	l.apply(upd)
+	return l.validateBandwidth()
+}
+
 ///////////////
 // AdminBody //
 ///////////////
@@ -341,6 +449,9 @@ func (b *AdminBody) Validate(requireID bool) error {
 	} else if b.ID == "" && requireID {
 		return errors.New("UUID not specified")
 	}
+	if b.Limits != nil {
+		return b.Limits.validate()
+	}
 	return nil
 }
 
@@ -11,11 +11,15 @@ import (
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cmn/kvdb"
+	jsoniter "github.com/json-iterator/go"
 )
 
 const (
 	downloaderErrors     = "errors"
 	downloaderTasks      = "tasks"
+	downloaderCkpts      = "checkpoints" // partially downloaded objects, see ckpt
+	downloaderJobs       = "jobs"        // specs of the jobs to restart upon reboot, see JobSpec
+	downloaderSchedules  = "schedules"   // scheduled jobs, see ScheduleSpec
 	downloaderCollection = "downloads"
 
 	// Number of errors stored in memory. When the number of errors exceeds
@@ -174,11 +178,136 @@ func (db *downloaderDB) flush(id string) error {
 	return nil
 }
 
-func (db *downloaderDB) delete(id string) {
+// removes errors and tasks (e.g., of the previous run of a scheduled job)
+func (db *downloaderDB) reset(id string) {
 	db.mtx.Lock()
 	key := path.Join(downloaderErrors, id)
 	db.driver.Delete(downloaderCollection, key)
 	key = path.Join(downloaderTasks, id)
 	db.driver.Delete(downloaderCollection, key)
+	delete(db.errCache, id)
+	delete(db.taskInfoCache, id)
 	db.mtx.Unlock()
 }
+
+func (db *downloaderDB) delete(id string) {
+	db.reset(id)
+	db.delJobSpec(id)
+	db.delCkpts(id)
+	db.delSchedule(id)
+}
+
+//
+// checkpoints
+//
+
+func (db *downloaderDB) getCkpt(id, objName string) *ckpt {
+	ck := &ckpt{}
+	key := path.Join(downloaderCkpts, id, objName)
+	if err := db.driver.Get(downloaderCollection, key, ck); err != nil {
+		if !kvdb.IsErrNotFound(err) {
+			glog.Error(err)
+		}
+		return nil
+	}
+	return ck
+}
+
+func (db *downloaderDB) setCkpt(id, objName string, ck *ckpt) {
+	key := path.Join(downloaderCkpts, id, objName)
+	if err := db.driver.Set(downloaderCollection, key, ck); err != nil {
+		glog.Error(err)
+	}
+}
+
+func (db *downloaderDB) delCkpt(id, objName string) {
+	key := path.Join(downloaderCkpts, id, objName)
+	if err := db.driver.Delete(downloaderCollection, key); err != nil && !kvdb.IsErrNotFound(err) {
+		glog.Error(err)
+	}
+}
+
+func (db *downloaderDB) delCkpts(id string) {
+	ckpts, err := db.driver.GetAll(downloaderCollection, path.Join(downloaderCkpts, id)+"/")
+	if err != nil {
+		glog.Error(err)
+		return
+	}
+	for key := range ckpts {
+		db.driver.Delete(downloaderCollection, key)
+	}
+}
+
+//
+// job specs
+//
+
+func (db *downloaderDB) setJobSpec(spec *JobSpec) {
+	key := path.Join(downloaderJobs, spec.ID)
+	if err := db.driver.Set(downloaderCollection, key, spec); err != nil {
+		glog.Error(err)
+	}
+}
+
+func (db *downloaderDB) delJobSpec(id string) {
+	key := path.Join(downloaderJobs, id)
+	if err := db.driver.Delete(downloaderCollection, key); err != nil && !kvdb.IsErrNotFound(err) {
+		glog.Error(err)
+	}
+}
+
+func (db *downloaderDB) hasJobSpec(id string) bool {
+	spec := &JobSpec{}
+	key := path.Join(downloaderJobs, id)
+	return db.driver.Get(downloaderCollection, key, spec) == nil
+}
+
+func (db *downloaderDB) jobSpecs() (specs []*JobSpec, err error) {
+	all, err := db.driver.GetAll(downloaderCollection, downloaderJobs+"/")
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range all {
+		spec := &JobSpec{}
+		if err := jsoniter.UnmarshalFromString(value, spec); err != nil {
+			glog.Errorf("failed to unmarshal %q: %v", key, err)
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+//
+// schedules
+//
+
+func (db *downloaderDB) setSchedule(spec *ScheduleSpec) {
+	key := path.Join(downloaderSchedules, spec.ID)
+	if err := db.driver.Set(downloaderCollection, key, spec); err != nil {
+		glog.Error(err)
+	}
+}
+
+func (db *downloaderDB) delSchedule(id string) {
+	key := path.Join(downloaderSchedules, id)
+	if err := db.driver.Delete(downloaderCollection, key); err != nil && !kvdb.IsErrNotFound(err) {
+		glog.Error(err)
+	}
+}
+
+func (db *downloaderDB) scheduleSpecs() (specs []*ScheduleSpec, err error) {
+	all, err := db.driver.GetAll(downloaderCollection, downloaderSchedules+"/")
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range all {
+		spec := &ScheduleSpec{}
+		if err := jsoniter.UnmarshalFromString(value, spec); err != nil {
+			glog.Errorf("failed to unmarshal %q: %v", key, err)
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
@@ -42,6 +42,7 @@ type (
 
 	BackendResource struct {
 		ObjName string
+		Size    int64
 	}
 
 	WebResource struct {
@@ -53,6 +54,7 @@ type (
 		ObjName string
 		Version string
 		Link    string
+		Size    int64
 	}
 
 	DiffResolverResult struct {
@@ -163,6 +165,7 @@ func (dr *DiffResolver) PushDst(v any) {
 	case *BackendResource:
 		d = &DstElement{
 			ObjName: x.ObjName,
+			Size:    x.Size,
 		}
 	case *WebResource:
 		d = &DstElement{
@@ -24,6 +24,14 @@ import (
 
 // Dispatcher serves as middle layer between receiving download requests
 // and serving them to joggers which actually download objects from a remote location.
+//
+// Concurrent jobs share the target in proportion to their weights (see Limits.Weight):
+// - each job can have at most its share of `fairSlotsPerJogger * number of joggers` tasks
+//   queued or being downloaded (so that a large job does not fill up the joggers' queues);
+// - when the target's bandwidth is limited (see DownloaderConf.MaxBandwidth), each job that is
+//   currently downloading gets its share of it (in addition to the job's own limit, if any).
+
+const fairSlotsPerJogger = 16
 
 type (
 	dispatcher struct {
@@ -34,6 +42,18 @@ type (
 		abortJob    map[string]*cos.StopCh // jobID -> abort job chan
 		workCh      chan jobif
 		stopCh      *cos.StopCh
+		fair        *fairShare
+	}
+
+	fairShare struct {
+		mu     sync.Mutex
+		jobs   map[string]*fairJob // jobID -> running job
+		wakeCh chan struct{}       // closed (and replaced) whenever a job may proceed
+		slots  int
+	}
+	fairJob struct {
+		throt    *throttler
+		inflight int // tasks queued or being downloaded
 	}
 
 	startupSema struct {
@@ -55,6 +75,7 @@ func newDispatcher(xdl *Xact) *dispatcher {
 		workCh:      make(chan jobif),
 		stopCh:      cos.NewStopCh(),
 		abortJob:    make(map[string]*cos.StopCh, 100),
+		fair:        newFairShare(),
 	}
 }
 
@@ -68,6 +89,7 @@ func (d *dispatcher) run() (err error) {
 	for mpath := range availablePaths {
 		d.addJogger(mpath)
 	}
+	d.fair.slots = fairSlotsPerJogger * cos.Max(len(d.joggers), 1)
 	// allow other goroutines to run
 	d.startupSema.markStarted()
 
@@ -143,10 +165,12 @@ func (d *dispatcher) cleanupJob(jobID string) {
 
 // forward request to designated jogger
 func (d *dispatcher) dispatchDownload(job jobif) (ok bool) {
+	d.fair.register(job)
 	defer func() {
 		debug.Infof("Waiting for job %q", job.ID())
 		d.waitFor(job.ID())
 		debug.Infof("Job %q finished waiting for all tasks", job.ID())
+		d.fair.unregister(job.ID())
 		d.cleanupJob(job.ID())
 		debug.Infof("Job %q cleaned up", job.ID())
 		job.cleanup()
@@ -240,6 +264,7 @@ func (d *dispatcher) dispatchDownload(job jobif) (ok bool) {
 				} else {
 					diffResolver.PushDst(&BackendResource{
 						ObjName: obj.objName,
+						Size:    obj.size,
 					})
 				}
 			}
@@ -258,6 +283,7 @@ func (d *dispatcher) dispatchDownload(job jobif) (ok bool) {
 				obj = dlObj{
 					objName:    dst.ObjName,
 					link:       dst.Link,
+					size:       dst.Size,
 					fromRemote: dst.Link == "",
 				}
 			} else {
@@ -362,27 +388,42 @@ func (d *dispatcher) doSingle(task *singleTask) (ok bool, err error) {
 
 	// NOTE: Throttle job before making jogger busy - we don't want to clog the
 	//  jogger as other tasks from other jobs can be already ready to download.
+	abortCh := d.jobAbortedCh(task.job.ID()).Listen()
+	if !d.fair.acquire(task.jobID(), abortCh, d.stopCh.Listen()) {
+		return !d.checkAborted(), nil
+	}
 	select {
 	case <-task.job.throttler().tryAcquire():
 		break
-	case <-d.jobAbortedCh(task.job.ID()).Listen():
+	case <-abortCh:
+		d.fair.release(task.jobID())
 		return true, nil
 	}
 
 	// Secondly, try to push the new task into queue.
+	ch, queued := jogger.putCh(task)
 	select {
 	// TODO -- FIXME: currently, dispatcher halts if any given jogger is "full" but others available
-	case jogger.putCh(task) <- task:
+	case ch <- task:
+		if !queued {
+			d.release(task) // omitted (see queue.putCh)
+		}
 		return true, nil
-	case <-d.jobAbortedCh(task.job.ID()).Listen():
-		task.job.throttler().release()
+	case <-abortCh:
+		d.release(task)
 		return true, nil
 	case <-d.stopCh.Listen():
-		task.job.throttler().release()
+		d.release(task)
 		return false, nil
 	}
 }
 
+// undo doSingle when the task is done (or won't be downloaded)
+func (d *dispatcher) release(task *singleTask) {
+	task.job.throttler().release()
+	d.fair.release(task.jobID())
+}
+
 func (d *dispatcher) adminReq(req *request) (resp any, statusCode int, err error) {
 	debug.Infof("Admin request (id: %q, action: %q, onlyActive: %t)", req.id, req.action, req.onlyActive)
 
@@ -397,6 +438,8 @@ func (d *dispatcher) adminReq(req *request) (resp any, statusCode int, err error
 		d.handleAbort(req)
 	case actRemove:
 		d.handleRemove(req)
+	case actLimits:
+		d.handleLimits(req)
 	default:
 		debug.Assertf(false, "%v; %v", req, req.action)
 	}
@@ -434,9 +477,19 @@ func (d *dispatcher) handleAbort(req *request) {
 	}
 
 	dlStore.setAborted(req.id)
+	dlStore.delSchedule(req.id) // (aborting scheduled job cancels all future runs)
 	req.okRsp(nil)
 }
 
+func (d *dispatcher) handleLimits(req *request) {
+	if _, err := d.xdl.checkJob(req); err != nil {
+		return
+	}
+	// NOTE: responding with false when this target's part of the job is not running (anymore)
+	numTs := d.xdl.t.Sowner().Get().CountActiveTs()
+	req.okRsp(d.fair.setLimits(req.id, req.limits, numTs))
+}
+
 func (d *dispatcher) handleStatus(req *request) {
 	var (
 		finishedTasks []TaskDlInfo
@@ -504,6 +557,117 @@ func (d *dispatcher) waitFor(jobID string) {
 	}
 }
 
+///////////////
+// fairShare //
+///////////////
+
+func newFairShare() *fairShare {
+	return &fairShare{
+		jobs:   make(map[string]*fairJob, 8),
+		wakeCh: make(chan struct{}),
+		slots:  fairSlotsPerJogger,
+	}
+}
+
+func (fs *fairShare) register(job jobif) {
+	fs.mu.Lock()
+	fs.jobs[job.ID()] = &fairJob{throt: job.throttler()}
+	fs._wake()
+	fs.mu.Unlock()
+}
+
+func (fs *fairShare) unregister(jobID string) {
+	fs.mu.Lock()
+	delete(fs.jobs, jobID)
+	fs._wake()
+	fs.mu.Unlock()
+}
+
+// blocks until the job is within its share of the slots; returns false when aborted or stopped
+func (fs *fairShare) acquire(jobID string, abortCh, stopCh <-chan struct{}) bool {
+	for {
+		fs.mu.Lock()
+		fj, ok := fs.jobs[jobID]
+		debug.Assert(ok, jobID)
+		if fj.inflight < fs._quota(fj) {
+			fj.inflight++
+			fs.mu.Unlock()
+			return true
+		}
+		wakeCh := fs.wakeCh
+		fs.mu.Unlock()
+
+		select {
+		case <-wakeCh:
+		case <-abortCh:
+			return false
+		case <-stopCh:
+			return false
+		}
+	}
+}
+
+func (fs *fairShare) release(jobID string) {
+	fs.mu.Lock()
+	if fj, ok := fs.jobs[jobID]; ok {
+		fj.inflight--
+		debug.Assert(fj.inflight >= 0, jobID)
+	}
+	fs._wake()
+	fs.mu.Unlock()
+}
+
+func (fs *fairShare) setLimits(jobID string, upd *LimitsToUpdate, numTs int) (ok bool) {
+	var fj *fairJob
+	fs.mu.Lock()
+	if fj, ok = fs.jobs[jobID]; ok {
+		fj.throt.update(upd, numTs)
+		fs._wake() // (quotas may have changed)
+	}
+	fs.mu.Unlock()
+	return
+}
+
+// the job's share of the target's bandwidth (zero - unlimited)
+func (fs *fairShare) share(jobID string) int64 {
+	maxbw := int64(cmn.GCO.Get().Downloader.MaxBandwidth)
+	if maxbw <= 0 {
+		return 0
+	}
+	var weight, total int64
+	fs.mu.Lock()
+	for id, fj := range fs.jobs {
+		w := fj.throt.weight.Load()
+		if id == jobID {
+			weight = w
+		} else if fj.inflight == 0 {
+			continue // (not downloading - not competing)
+		}
+		total += w
+	}
+	fs.mu.Unlock()
+	if total == 0 {
+		return maxbw
+	}
+	return cos.MaxI64(maxbw*weight/total, 1)
+}
+
+// PRECONDITION: `fs.mu` must be taken.
+func (fs *fairShare) _quota(fj *fairJob) int {
+	var total int64
+	for _, other := range fs.jobs {
+		total += other.throt.weight.Load()
+	}
+	quota := int64(fs.slots) * fj.throt.weight.Load() / total
+	return int(cos.MaxI64(quota, 1))
+}
+
+// PRECONDITION: `fs.mu` must be taken.
+func (fs *fairShare) _wake() {
+	close(fs.wakeCh)
+	fs.wakeCh = make(chan struct{})
+}
+
 /////////////////
 // startupSema //
 /////////////////
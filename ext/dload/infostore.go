@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/kvdb"
 	"github.com/NVIDIA/aistore/hk"
@@ -20,10 +21,11 @@ var (
 	dlStoreOnce sync.Once
 )
 
-// TODO: stored only in memory, should be persisted at some point (powercycle)
+// NOTE: jobs are kept in memory; specs of the running ones are persisted to restart upon reboot (see JobSpec)
 type infoStore struct {
 	*downloaderDB
-	dljobs map[string]*dljob
+	dljobs    map[string]*dljob
+	schedules map[string]*ScheduleSpec // scheduled jobs (see schedule.go)
 	sync.RWMutex
 }
 
@@ -40,7 +42,9 @@ func newInfoStore(driver kvdb.Driver) *infoStore {
 	is := &infoStore{
 		downloaderDB: db,
 		dljobs:       make(map[string]*dljob),
+		schedules:    make(map[string]*ScheduleSpec),
 	}
+	is.loadSchedules()
 	hk.Reg("downloader"+hk.NameSuffix, is.housekeep, hk.DayInterval)
 	return is
 }
@@ -135,14 +139,65 @@ func (is *infoStore) setAborted(id string) {
 
 func (is *infoStore) delJob(id string) {
 	delete(is.dljobs, id)
+	delete(is.schedules, id)
 	is.downloaderDB.delete(id)
 }
 
+//
+// scheduled jobs
+//
+
+// (upon reboot)
+func (is *infoStore) loadSchedules() {
+	specs, err := is.downloaderDB.scheduleSpecs()
+	if err != nil {
+		glog.Errorf("failed to load scheduled download jobs: %v", err)
+		return
+	}
+	for _, spec := range specs {
+		desc, err := validateBody(spec.Body)
+		if err != nil {
+			glog.Errorf("scheduled download job %q: %v", spec.ID, err)
+			continue
+		}
+		is.schedules[spec.ID] = spec
+		is.dljobs[spec.ID] = idleJob(spec.ID, desc)
+	}
+}
+
+// NOTE: until its first run, scheduled job is represented by an idle (finished) one
+func (is *infoStore) setSchedule(spec *ScheduleSpec, desc string) {
+	is.Lock()
+	is.downloaderDB.setSchedule(spec)
+	is.schedules[spec.ID] = spec
+	is.dljobs[spec.ID] = idleJob(spec.ID, desc)
+	is.Unlock()
+}
+
+func (is *infoStore) getSchedule(id string) (sched string, next time.Time) {
+	is.RLock()
+	if spec, ok := is.schedules[id]; ok {
+		sched, next = spec.Schedule, spec.NextRun
+	}
+	is.RUnlock()
+	return
+}
+
+func (is *infoStore) delSchedule(id string) {
+	is.Lock()
+	delete(is.schedules, id)
+	is.downloaderDB.delSchedule(id)
+	is.Unlock()
+}
+
 func (is *infoStore) housekeep() time.Duration {
 	const interval = hk.DayInterval
 
 	is.Lock()
 	for id, dljob := range is.dljobs {
+		if _, ok := is.schedules[id]; ok {
+			continue
+		}
 		if time.Since(dljob.finishedTime.Load()) > interval {
 			is.delJob(id)
 		}
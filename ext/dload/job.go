@@ -37,6 +37,7 @@ type (
 	dlObj struct {
 		objName    string
 		link       string
+		size       int64 // when known (backend listing)
 		fromRemote bool
 	}
 
@@ -67,6 +68,9 @@ type (
 		// via tryAcquire and release
 		throttler() *throttler
 
+		// checksum manifest (nil when not specified)
+		verifier() *verifier
+
 		// job cleanup
 		cleanup()
 	}
@@ -79,6 +83,7 @@ type (
 		description string
 		timeout     time.Duration
 		throt       throttler
+		verif       *verifier
 	}
 
 	sliceDlJob struct {
@@ -135,22 +140,24 @@ type (
 ///////////////
 
 func (j *baseDlJob) init(t cluster.Target, id string, bck *cluster.Bck, timeout, desc string, limits Limits, xdl *Xact) {
-	// TODO: this might be inaccurate if we download 1 or 2 objects because then
-	//  other targets will have limits but will not use them.
-	if limits.BytesPerHour > 0 {
-		limits.BytesPerHour /= t.Sowner().Get().CountActiveTs()
-	}
 	td, _ := time.ParseDuration(timeout)
 	{
 		j.id = id
 		j.bck = bck
 		j.timeout = td
 		j.description = desc
-		j.throt.init(limits)
+		j.throt.init(limits, t.Sowner().Get().CountActiveTs())
 		j.xdl = xdl
 	}
 }
 
+func (j *baseDlJob) initManifest(t cluster.Target, base *Base) (err error) {
+	if base.Manifest != "" {
+		j.verif, err = newVerifier(t, base)
+	}
+	return
+}
+
 func (j *baseDlJob) ID() string             { return j.id }
 func (j *baseDlJob) XactID() string         { return j.xdl.ID() }
 func (j *baseDlJob) Bck() *cmn.Bck          { return j.bck.Bucket() }
@@ -190,14 +197,19 @@ func (j *baseDlJob) ActiveStats() (*StatusResp, error) {
 
 func (*baseDlJob) checkObj(string) bool    { debug.Assert(false); return false }
 func (j *baseDlJob) throttler() *throttler { return &j.throt }
+func (j *baseDlJob) verifier() *verifier   { return j.verif }
 
 func (j *baseDlJob) cleanup() {
-	j.throttler().stop()
 	err := dlStore.markFinished(j.ID())
 	if err != nil {
 		glog.Errorf("%s: %v", j, err)
 	}
 	dlStore.flush(j.ID())
+	// keep the spec and checkpoints of the job interrupted by shutdown - to restart it upon reboot
+	if !j.xdl.dispatcher.checkAborted() {
+		dlStore.delJobSpec(j.ID())
+		dlStore.delCkpts(j.ID())
+	}
 	nl.OnFinished(j.Notif(), err)
 }
 
@@ -236,6 +248,9 @@ func newMultiDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *Multi
 
 	mj = &multiDlJob{}
 	mj.baseDlJob.init(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	if err = mj.initManifest(t, &payload.Base); err != nil {
+		return nil, err
+	}
 
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
@@ -251,6 +266,9 @@ func newSingleDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *Sing
 
 	sj = &singleDlJob{}
 	sj.baseDlJob.init(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	if err = sj.initManifest(t, &payload.Base); err != nil {
+		return nil, err
+	}
 
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
@@ -274,6 +292,9 @@ func newRangeDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *Range
 		return nil, err
 	}
 	rj.baseDlJob.init(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	if err = rj.initManifest(t, &payload.Base); err != nil {
+		return nil, err
+	}
 
 	if rj.count, err = countObjects(t, rj.pt, payload.Subdir, rj.bck); err != nil {
 		return nil, err
@@ -338,6 +359,9 @@ func newBackendDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *Bac
 	}
 	bj = &backendDlJob{}
 	bj.baseDlJob.init(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	if err = bj.initManifest(t, &payload.Base); err != nil {
+		return nil, err
+	}
 	{
 		bj.t = t
 		bj.sync = payload.Sync
@@ -399,6 +423,7 @@ func (j *backendDlJob) getNextObjs() error {
 				}
 				return err
 			}
+			obj.size = entry.Size
 			j.objs = append(j.objs, obj)
 		}
 		if j.continuationToken == "" {
@@ -414,7 +439,7 @@ func (j *backendDlJob) getNextObjs() error {
 ///////////
 
 func (j *dljob) clone() Job {
-	return Job{
+	job := Job{
 		ID:            j.id,
 		XactID:        j.xid,
 		Description:   j.description,
@@ -428,6 +453,8 @@ func (j *dljob) clone() Job {
 		StartedTime:   j.startedTime,
 		FinishedTime:  j.finishedTime.Load(),
 	}
+	job.Schedule, job.NextRun = dlStore.getSchedule(j.id)
+	return job
 }
 
 // Used for debugging purposes to ensure integrity of the struct.
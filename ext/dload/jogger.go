@@ -56,7 +56,7 @@ func (j *jogger) jog() {
 		// we waited on the queue. We must do it under jogger lock to ensure that
 		// there is no race between aborting job and marking it as being handled.
 		if !j.checkTaskExists(t) {
-			t.job.throttler().release()
+			j.parent.release(t)
 			j.mtx.Unlock()
 			continue
 		}
@@ -66,7 +66,7 @@ func (j *jogger) jog() {
 			// `break` here because we want to drain the queue, otherwise some
 			// of the tasks may be in the queue and therefore the finished
 			// counter won't be correct.
-			t.job.throttler().release()
+			j.parent.release(t)
 			t.markFailed(internalErrorMsg)
 			j.mtx.Unlock()
 			continue
@@ -77,7 +77,7 @@ func (j *jogger) jog() {
 		j.mtx.Unlock()
 
 		t.download()
-		t.job.throttler().release()
+		j.parent.release(t)
 
 		j.mtx.Lock()
 		j.task.persist()
@@ -107,13 +107,14 @@ func (j *jogger) stop() {
 	<-j.terminateCh.Listen()
 }
 
-// Returns channel which task should be put into.
-func (j *jogger) putCh(t *singleTask) chan<- *singleTask {
+// Returns channel which task should be put into and whether the task
+// is going to be queued (or omitted).
+func (j *jogger) putCh(t *singleTask) (chan<- *singleTask, bool) {
 	ok, ch := j.q.putCh(t)
 	if ok {
 		j.parent.xdl.IncPending()
 	}
-	return ch
+	return ch, ok
 }
 
 func (j *jogger) getTask(jobID string) (task *singleTask) {
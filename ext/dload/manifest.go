@@ -0,0 +1,165 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Checksum manifests
+//
+// Download job may reference a checksum manifest (e.g., SHA256SUMS or MD5SUMS) - by URL or as an
+// object in the cluster (see Base.Manifest). Each downloaded object listed in the manifest is then
+// verified against it; upon mismatch, the object gets re-downloaded (up to Base.VerifyRetries times)
+// and, if still corrupted, removed - with the corresponding task marked as failed.
+// Supported formats (one entry per line):
+// - GNU: "<hex checksum> [*]<file name>" (output of `sha256sum`, `md5sum`, etc.)
+// - BSD: "<TYPE> (<file name>) = <hex checksum>" (output of `sha256sum --tag`, `shasum`, etc.)
+
+const manifestTimeout = time.Minute
+
+type verifier struct {
+	cksums  cos.StrKVs // file name => checksum
+	ty      string     // checksum type (determined by the length of the checksums)
+	retries int
+}
+
+var manifestCksumTypes = map[int]string{
+	2 * 16: cos.ChecksumMD5,
+	2 * 32: cos.ChecksumSHA256,
+	2 * 64: cos.ChecksumSHA512,
+}
+
+func newVerifier(t cluster.Target, base *Base) (*verifier, error) {
+	b, err := fetchManifest(t, base.Manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checksum manifest %q: %v", base.Manifest, err)
+	}
+	v := &verifier{retries: base.VerifyRetries}
+	if err := v.parse(string(b)); err != nil {
+		return nil, fmt.Errorf("invalid checksum manifest %q: %v", base.Manifest, err)
+	}
+	return v, nil
+}
+
+// via HTTP(S) or, when the manifest is an object in the cluster, from its (HRW) target
+func fetchManifest(t cluster.Target, uri string) ([]byte, error) {
+	var (
+		req         *http.Request
+		client      *http.Client
+		ctx, cancel = context.WithTimeout(context.Background(), manifestTimeout)
+		err         error
+	)
+	defer cancel()
+	if cos.IsHTTP(uri) || cos.IsHTTPS(uri) {
+		if req, err = http.NewRequestWithContext(ctx, http.MethodGet, uri, http.NoBody); err != nil {
+			return nil, err
+		}
+		client = clientForURL(uri)
+	} else {
+		bck, objName, err := cmn.ParseBckObjectURI(uri, cmn.ParseURIOpts{DefaultProvider: apc.AIS})
+		if err != nil {
+			return nil, err
+		}
+		if objName == "" {
+			return nil, errors.New("expecting URL or bucket/object")
+		}
+		tsi, err := cluster.HrwTarget(bck.MakeUname(objName), t.Sowner().Get())
+		if err != nil {
+			return nil, err
+		}
+		u := tsi.URL(cmn.NetIntraData) + apc.URLPathObjects.Join(bck.Name, objName) + "?" + bck.AddToQuery(nil).Encode()
+		if req, err = http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody); err != nil {
+			return nil, err
+		}
+		// is intra-call
+		req.Header.Set(apc.HdrCallerID, t.SID())
+		req.Header.Set(apc.HdrCallerName, t.String())
+		client = t.DataClient()
+	}
+	resp, err := client.Do(req) //nolint:bodyclose // closed below
+	if err != nil {
+		return nil, err
+	}
+	defer cos.Close(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, cmn.NewErrHTTP(req, errors.New("failed to GET"), resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (v *verifier) parse(s string) error {
+	v.cksums = make(cos.StrKVs, 64)
+	for _, line := range strings.Split(s, "\n") {
+		var name, cksum string
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if i, j := strings.Index(line, " ("), strings.LastIndex(line, ") = "); i > 0 && j > i {
+			name, cksum = line[i+2:j], line[j+4:] // BSD
+		} else {
+			fields := strings.SplitN(line, " ", 2) // GNU
+			if len(fields) != 2 {
+				return fmt.Errorf("invalid line %q", line)
+			}
+			cksum, name = fields[0], strings.TrimLeft(fields[1], " *")
+		}
+		ty, ok := manifestCksumTypes[len(cksum)]
+		if _, err := hex.DecodeString(cksum); !ok || err != nil {
+			return fmt.Errorf("invalid checksum in line %q", line)
+		}
+		if v.ty == "" {
+			v.ty = ty
+		} else if v.ty != ty {
+			return fmt.Errorf("mixed checksum types (%s, %s)", v.ty, ty)
+		}
+		v.cksums[strings.TrimPrefix(name, "./")] = strings.ToLower(cksum)
+	}
+	if len(v.cksums) == 0 {
+		return errors.New("no checksums")
+	}
+	return nil
+}
+
+// by object name or, otherwise, its base
+func (v *verifier) lookup(objName string) (cksum string, ok bool) {
+	if cksum, ok = v.cksums[objName]; !ok {
+		cksum, ok = v.cksums[path.Base(objName)]
+	}
+	return
+}
+
+// (objects that are not listed in the manifest are not verified)
+func (v *verifier) verify(lom *cluster.LOM) error {
+	expected, ok := v.lookup(lom.ObjName)
+	if !ok {
+		return nil
+	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		return err
+	}
+	cksum, err := lom.ComputeCksum(v.ty)
+	if err != nil {
+		return err
+	}
+	if cksum.Value() != expected {
+		return cos.NewBadDataCksumError(cos.NewCksum(v.ty, expected), &cksum.Cksum, lom.String())
+	}
+	return nil
+}
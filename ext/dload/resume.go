@@ -0,0 +1,226 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Resumable downloads
+//
+// Objects of (known) size >= ckptMinSize get downloaded into a partial workfile (fs.PartialDlType)
+// that survives failures and restarts. The current offset is periodically checkpointed (see
+// downloaderDB), so that the next attempt - a retry or the job restarted upon reboot (see JobSpec) -
+// could continue from there via HTTP range request or, when downloading from a remote bucket,
+// cluster.RangeReader. The checkpoint includes a validator (ETag, Last-Modified, or version):
+// when the source changes in the meantime, the download starts over.
+
+const (
+	ckptMinSize  = 64 * cos.MiB // smaller objects are downloaded in one shot (and from scratch upon failure)
+	ckptInterval = 64 * cos.MiB // fsync the partial workfile and checkpoint its size every so often
+)
+
+type (
+	ckpt struct {
+		Validator string `json:"validator"`
+		Size      int64  `json:"size,string"`
+		Offset    int64  `json:"offset,string"`
+	}
+
+	// JobSpec is persisted when the job starts and gets removed when the job finishes, is aborted, or
+	// is removed; the jobs interrupted by shutdown are then restarted by the target (see PendingJobs)
+	JobSpec struct {
+		ID     string `json:"id"`
+		XactID string `json:"xaction_id"`
+		Body   Body   `json:"body"`
+	}
+)
+
+// returns specs of the jobs to restart upon reboot
+func PendingJobs() ([]*JobSpec, error) {
+	initInfoStore(db)
+	return dlStore.jobSpecs()
+}
+
+// forget the job that cannot be restarted
+func DropPendingJob(id string) {
+	initInfoStore(db)
+	dlStore.delJobSpec(id)
+	dlStore.delCkpts(id)
+}
+
+// strong ETag or, otherwise, Last-Modified
+func httpValidator(resp *http.Response) string {
+	if etag := resp.Header.Get(cos.HdrETag); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return resp.Header.Get(cos.HdrLastModified)
+}
+
+func backendValidator(oa *cmn.ObjAttrs) string {
+	if oa.Ver != "" {
+		return oa.Ver
+	}
+	if etag, ok := oa.GetCustomKey(cmn.ETag); ok {
+		return etag
+	}
+	md5, _ := oa.GetCustomKey(cmn.MD5ObjMD)
+	return md5
+}
+
+////////////////
+// singleTask //
+////////////////
+
+func (task *singleTask) partialFQN(lom *cluster.LOM) string {
+	return fs.CSM.Gen(lom, fs.PartialDlType, task.jobID())
+}
+
+// returns checkpoint iff the partial workfile is still there
+func (task *singleTask) loadCkpt(lom *cluster.LOM) *ckpt {
+	ck := dlStore.getCkpt(task.jobID(), task.obj.objName)
+	if ck == nil {
+		return nil
+	}
+	finfo, err := os.Stat(task.partialFQN(lom))
+	if err != nil || finfo.Size() < ck.Offset || ck.Offset >= ck.Size || ck.Validator == "" {
+		task.delCkpt(lom)
+		return nil
+	}
+	return ck
+}
+
+func (task *singleTask) delCkpt(lom *cluster.LOM) {
+	dlStore.delCkpt(task.jobID(), task.obj.objName)
+	if err := cos.RemoveFile(task.partialFQN(lom)); err != nil {
+		glog.Errorf("%s: %v", task, err)
+	}
+}
+
+// (download remote object via cluster.RangeReader, see downloadRemote)
+func (task *singleTask) tryDownloadRange(lom *cluster.LOM, timeout time.Duration) (bool /*err is fatal*/, error) {
+	ctx, cancel := context.WithTimeout(task.downloadCtx, timeout)
+	defer cancel()
+
+	backend := task.xdl.t.Backend(lom.Bck())
+	oa, errCode, err := backend.HeadObj(ctx, lom)
+	if err != nil {
+		_, fatal := terminalStatuses[errCode]
+		return fatal, err
+	}
+	validator := backendValidator(oa)
+	if validator == "" {
+		return true, cmn.NewErrUnsupp("resume download of", lom.String()+" (no version or ETag)")
+	}
+	ck := task.loadCkpt(lom)
+	if ck != nil && (ck.Validator != validator || ck.Size != oa.Size) {
+		task.delCkpt(lom) // changed remotely - start over
+		ck = nil
+	}
+	if ck == nil {
+		ck = &ckpt{Validator: validator, Size: oa.Size}
+	}
+	task.setTotalSize(oa.Size)
+
+	// object metadata (compare with backend GetObjReader)
+	lom.SetCustomMD(oa.GetCustomMD())
+	lom.ObjAttrs().DelCustomKeys(cos.HdrContentType)
+	if oa.Ver != "" {
+		lom.SetVersion(oa.Ver)
+	}
+
+	r, errCode, err := backend.(cluster.RangeReader).GetObjRange(ctx, lom, oa, ck.Offset, ck.Size-ck.Offset)
+	if err != nil {
+		_, fatal := terminalStatuses[errCode]
+		return fatal, err
+	}
+	defer cos.Close(r)
+	return task.downloadPartial(lom, task.wrapReader(ctx, r), ck, cmn.OwtGetPrefetchLock)
+}
+
+// write the (remaining) content into the partial workfile while checkpointing, and then store the object
+func (task *singleTask) downloadPartial(lom *cluster.LOM, r io.Reader, ck *ckpt, owt cmn.OWT) (bool /*fatal*/, error) {
+	wfqn := task.partialFQN(lom)
+	if err := cos.CreateDir(filepath.Dir(wfqn)); err != nil {
+		return true, err
+	}
+	fh, err := os.OpenFile(wfqn, os.O_CREATE|os.O_WRONLY, cos.PermRWR)
+	if err != nil {
+		return true, err
+	}
+	if err = fh.Truncate(ck.Offset); err == nil {
+		_, err = fh.Seek(ck.Offset, io.SeekStart)
+	}
+	if err != nil {
+		cos.Close(fh)
+		return true, err
+	}
+	if glog.FastV(4, glog.SmoduleAIS) && ck.Offset > 0 {
+		glog.Infof("%s: resuming at offset %d (size %d)", task, ck.Offset, ck.Size)
+	}
+	task.currentSize.Store(ck.Offset)
+	for ck.Offset < ck.Size {
+		n, errN := io.CopyN(fh, r, cos.MinI64(ckptInterval, ck.Size-ck.Offset))
+		if n > 0 {
+			if err := fh.Sync(); err != nil {
+				cos.Close(fh)
+				return true, err
+			}
+			ck.Offset += n
+			dlStore.setCkpt(task.jobID(), task.obj.objName, ck)
+		}
+		if errN != nil {
+			cos.Close(fh)
+			if errN == io.EOF {
+				errN = io.ErrUnexpectedEOF // (to retry and resume)
+			}
+			return false, errN
+		}
+	}
+	if err := fh.Close(); err != nil {
+		return true, err
+	}
+	return task.putPartial(lom, wfqn, owt)
+}
+
+func (task *singleTask) putPartial(lom *cluster.LOM, wfqn string, owt cmn.OWT) (bool /*err is fatal*/, error) {
+	fh, err := os.Open(wfqn)
+	if err != nil {
+		return true, err
+	}
+	params := cluster.AllocPutObjParams()
+	{
+		params.WorkTag = "dl"
+		params.Reader = fh
+		params.OWT = owt
+		params.Atime = task.started.Load()
+		params.Xact = task.xdl
+	}
+	erp := task.xdl.t.PutObject(lom, params)
+	cluster.FreePutObjParams(params)
+	task.delCkpt(lom) // either way
+	if erp != nil {
+		if erp == cmn.ErrSkip {
+			return false, nil // (being written by someone else)
+		}
+		return true, erp
+	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		return true, err
+	}
+	return false, nil
+}
@@ -0,0 +1,300 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Scheduled (recurring) downloads
+//
+// Download job with Base.Schedule - interval (e.g., "24h") or cron expression (e.g., "0 2 * * *", UTC) -
+// does not run upon submission. Instead, each target persists the job's ScheduleSpec (see downloaderDB),
+// and the primary proxy periodically collects the specs from all targets (see ListSchedules) and,
+// when the job is due (see ScheduleSpec.Due), runs it cluster-wide under the same job ID and a new
+// xaction ID (see PrepRun). Since the dispatcher compares existing objects with their sources
+// (see DiffResolver), each run downloads only new or changed objects. When the previous run is still
+// in progress the next one gets skipped. Aborting (or removing) scheduled job cancels its schedule.
+
+const minSchedInterval = time.Minute
+
+type (
+	// persisted when the job is scheduled; removed when the job is aborted or removed
+	ScheduleSpec struct {
+		ID       string    `json:"id"`
+		Schedule string    `json:"schedule"`
+		Body     Body      `json:"body"`
+		NextRun  time.Time `json:"next_run"`
+	}
+
+	schedule interface {
+		next(after time.Time) time.Time // zero time if never
+	}
+	intervalSched time.Duration
+	cronSched     struct {
+		min, hour, dom, month, dow uint64 // bitmasks
+		anyDom, anyDow             bool
+	}
+)
+
+// interface guard
+var (
+	_ schedule = intervalSched(0)
+	_ schedule = (*cronSched)(nil)
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// schedule the job instead of running it (see Base.Schedule)
+func Schedule(id string, dlb Body) (resp any, statusCode int, err error) {
+	var (
+		base  = &Base{}
+		sched schedule
+		desc  string
+	)
+	if desc, err = validateBody(dlb); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if err = jsoniter.Unmarshal(dlb.RawMessage, base); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if sched, err = parseSchedule(base.Schedule); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	initInfoStore(db)
+	spec := &ScheduleSpec{ID: id, Schedule: base.Schedule, Body: dlb, NextRun: sched.next(time.Now())}
+	dlStore.setSchedule(spec, desc)
+	return id, http.StatusOK, nil
+}
+
+// (target) all scheduled jobs - to be run by the primary
+func ListSchedules() (specs []*ScheduleSpec) {
+	initInfoStore(db)
+	dlStore.RLock()
+	specs = make([]*ScheduleSpec, 0, len(dlStore.schedules))
+	for _, spec := range dlStore.schedules {
+		clone := *spec
+		specs = append(specs, &clone)
+	}
+	dlStore.RUnlock()
+	return
+}
+
+// (primary) returns true if the job is due to run, in which case also advances its next run
+func (spec *ScheduleSpec) Due(now time.Time) bool {
+	if spec.NextRun.After(now) {
+		return false
+	}
+	sched, err := parseSchedule(spec.Schedule)
+	if err != nil {
+		glog.Errorf("%s: %v", spec.ID, err) // (validated upon submission)
+		return false
+	}
+	spec.NextRun = sched.next(now)
+	return true
+}
+
+// (target) prepares to run scheduled job on behalf of the primary: persists the spec (including
+// the next run and including targets that joined the cluster after the job was submitted) and
+// removes the previous run's errors and tasks; returns false if the previous run is still in progress
+func PrepRun(spec *ScheduleSpec) (bool, error) {
+	if _, err := validateBody(spec.Body); err != nil {
+		return false, err
+	}
+	initInfoStore(db)
+	dlStore.Lock()
+	defer dlStore.Unlock()
+	if job, ok := dlStore.dljobs[spec.ID]; ok && _isRunning(job.finishedTime.Load()) {
+		glog.Warningf("download job %q: skipping scheduled run - the previous one is still running", spec.ID)
+		return false, nil
+	}
+	if dlStore.downloaderDB.hasJobSpec(spec.ID) {
+		return false, nil // interrupted run that is about to be restarted (see JobSpec)
+	}
+	dlStore.downloaderDB.setSchedule(spec)
+	dlStore.schedules[spec.ID] = spec
+	dlStore.downloaderDB.reset(spec.ID) // previous run's errors and tasks
+	return true, nil
+}
+
+// (validated upon submission but the job gets created upon each run)
+func validateBody(dlb Body) (desc string, err error) {
+	var body interface {
+		Validate() error
+		Describe() string
+	}
+	switch dlb.Type {
+	case TypeBackend:
+		body = &BackendBody{}
+	case TypeMulti:
+		body = &MultiBody{}
+	case TypeRange:
+		body = &RangeBody{}
+	case TypeSingle:
+		body = &SingleBody{}
+	default:
+		return "", errors.New("input does not match any of the supported formats (single, range, multi, backend)")
+	}
+	if err = jsoniter.Unmarshal(dlb.RawMessage, body); err != nil {
+		return "", err
+	}
+	if err = body.Validate(); err != nil {
+		return "", err
+	}
+	return body.Describe(), nil
+}
+
+func idleJob(id, desc string) (job *dljob) {
+	job = &dljob{id: id, description: desc, startedTime: time.Now()}
+	job.allDispatched.Store(true)
+	job.finishedTime.Store(job.startedTime)
+	return
+}
+
+// interval (e.g., "24h") or cron expression (e.g., "0 2 * * *") or predefined descriptor (e.g., "@daily")
+func parseSchedule(s string) (schedule, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		if d < minSchedInterval {
+			return nil, fmt.Errorf("interval must be at least %v", minSchedInterval)
+		}
+		return intervalSched(d), nil
+	}
+	if expr, ok := cronDescriptors[s]; ok {
+		s = expr
+	}
+	fields := strings.Fields(s)
+	if len(fields) != 5 {
+		return nil, errors.New("expecting interval (e.g., \"24h\") or cron expression with 5 fields (e.g., \"0 2 * * *\")")
+	}
+	var (
+		c   = &cronSched{}
+		err error
+	)
+	if c.min, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("minute: %v", err)
+	}
+	if c.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("hour: %v", err)
+	}
+	if c.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("day of month: %v", err)
+	}
+	if c.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("month: %v", err)
+	}
+	if c.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("day of week: %v", err)
+	}
+	if c.dow&(1<<7) != 0 { // 7 is Sunday, same as 0
+		c.dow |= 1
+		c.dow &^= 1 << 7
+	}
+	c.anyDom, c.anyDow = fields[2][0] == '*', fields[4][0] == '*'
+	if c.next(time.Now()).IsZero() {
+		return nil, errors.New("never runs")
+	}
+	return c, nil
+}
+
+// comma-separated list of values, ranges ("a-b"), and steps ("*/n", "a-b/n", "a/n")
+func parseCronField(field string, lo, hi int) (mask uint64, err error) {
+	for _, part := range strings.Split(field, ",") {
+		var (
+			from, to int
+			step     = 1
+		)
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			part = part[:i]
+		}
+		switch {
+		case part == "*":
+			from, to = lo, hi
+		case strings.Contains(part, "-"):
+			lohi := strings.SplitN(part, "-", 2)
+			if from, err = strconv.Atoi(lohi[0]); err != nil {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+			if to, err = strconv.Atoi(lohi[1]); err != nil {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+		default:
+			if from, err = strconv.Atoi(part); err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			to = from
+			if step > 1 {
+				to = hi
+			}
+		}
+		if from < lo || to > hi || from > to {
+			return 0, fmt.Errorf("%q is out of range [%d, %d]", part, lo, hi)
+		}
+		for v := from; v <= to; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	return mask, nil
+}
+
+///////////////////
+// intervalSched //
+///////////////////
+
+func (d intervalSched) next(after time.Time) time.Time { return after.Add(time.Duration(d)) }
+
+///////////////
+// cronSched //
+///////////////
+
+// the first matching minute (UTC) after the given time; searching up to 5 years ahead
+func (c *cronSched) next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	for limit := t.AddDate(5, 0, 0); t.Before(limit); {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case c.min&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// when both day of month and day of week are restricted, either one matches (same as cron)
+func (c *cronSched) matchDay(t time.Time) bool {
+	var (
+		dom = c.dom&(1<<uint(t.Day())) != 0
+		dow = c.dow&(1<<uint(t.Weekday())) != 0
+	)
+	if c.anyDom || c.anyDow {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,59 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestParseSchedule(t *testing.T) {
+	// Wednesday
+	after := time.Date(2023, time.March, 15, 10, 30, 45, 0, time.UTC)
+	tests := []struct {
+		sched    string
+		expected time.Time
+	}{
+		{"24h", after.Add(24 * time.Hour)},
+		{"90m", after.Add(90 * time.Minute)},
+		{"* * * * *", time.Date(2023, time.March, 15, 10, 31, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2023, time.March, 16, 2, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2023, time.March, 16, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2023, time.March, 15, 11, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2023, time.March, 15, 10, 45, 0, 0, time.UTC)},
+		{"5,35 10-12 * * *", time.Date(2023, time.March, 15, 10, 35, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2023, time.March, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2023, time.March, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 1-5", time.Date(2023, time.March, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 * *", time.Date(2023, time.March, 31, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"30 4 1 6 *", time.Date(2023, time.June, 1, 4, 30, 0, 0, time.UTC)},
+		// both day of month and day of week restricted: either one
+		{"0 0 20 * 5", time.Date(2023, time.March, 17, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		t.Run(test.sched, func(t *testing.T) {
+			sched, err := parseSchedule(test.sched)
+			tassert.CheckFatal(t, err)
+			next := sched.next(after)
+			tassert.Errorf(t, next.Equal(test.expected), "expected %s, got %s", test.expected, next)
+		})
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	for _, sched := range []string{
+		"", "10s", "-1h", "daily", "* * * *", "* * * * * *",
+		"60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8",
+		"*/0 * * * *", "5-1 * * * *", "a * * * *", "0 0 30 2 *",
+	} {
+		if _, err := parseSchedule(sched); err == nil {
+			t.Errorf("%q: expected error", sched)
+		}
+	}
+}
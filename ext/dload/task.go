@@ -85,14 +85,32 @@ func (task *singleTask) download() {
 
 	task.started.Store(time.Now())
 	lom.SetAtimeUnix(task.started.Load().UnixNano())
-	if task.obj.fromRemote {
-		err = task.downloadRemote(lom)
-	} else {
-		err = task.downloadLocal(lom)
+	for attempt := 0; ; attempt++ {
+		if task.obj.fromRemote {
+			err = task.downloadRemote(lom)
+		} else {
+			err = task.downloadLocal(lom)
+		}
+		if err != nil || task.job.verifier() == nil {
+			break
+		}
+		// verify against checksum manifest (see manifest.go)
+		if err = task.job.verifier().verify(lom); err == nil || !cos.IsErrBadCksum(err) {
+			break
+		}
+		if attempt >= task.job.verifier().retries {
+			task.removeCorrupted(lom)
+			break
+		}
+		glog.Warningf("%s: %v - retrying (attempt %d)", task, err, attempt+1)
+		task.reset()
 	}
 	task.ended.Store(time.Now())
 
 	if err != nil {
+		if !task.xdl.dispatcher.checkAborted() {
+			task.delCkpt(lom) // (keeping it only to resume upon restart - see JobSpec)
+		}
 		task.markFailed(err.Error())
 		return
 	}
@@ -120,6 +138,12 @@ func (task *singleTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Duration
 	if cos.IsGoogleStorageURL(req.URL) {
 		req.Header.Add("User-Agent", gcsUA)
 	}
+	// resume partial download (see resume.go)
+	ck := task.loadCkpt(lom)
+	if ck != nil {
+		req.Header.Set(cos.HdrRange, fmt.Sprintf("%s%d-", cos.HdrRangeValPrefix, ck.Offset))
+		req.Header.Set(cos.HdrIfRange, ck.Validator)
+	}
 
 	resp, err := clientForURL(task.obj.link).Do(req)
 	if err != nil {
@@ -131,9 +155,25 @@ func (task *singleTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Duration
 		return false, cmn.NewErrHTTP(req, errors.New("nil error w/ bad status"), resp.StatusCode)
 	}
 
+	if ck != nil && (resp.StatusCode != http.StatusPartialContent ||
+		(resp.ContentLength >= 0 && resp.ContentLength != ck.Size-ck.Offset)) {
+		task.delCkpt(lom) // changed at the source - start over
+		ck = nil
+	}
+
 	r := task.wrapReader(ctx, resp.Body)
 	size := attrsFromLink(task.obj.link, resp, lom)
+	if ck != nil {
+		size = ck.Size
+	} else if size >= ckptMinSize && resp.Header.Get(cos.HdrAcceptRanges) == "bytes" {
+		if validator := httpValidator(resp); validator != "" {
+			ck = &ckpt{Validator: validator, Size: size}
+		}
+	}
 	task.setTotalSize(size)
+	if ck != nil {
+		return task.downloadPartial(lom, r, ck, cmn.OwtPut)
+	}
 
 	params := cluster.AllocPutObjParams()
 	{
@@ -154,18 +194,22 @@ func (task *singleTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Duration
 	return false, nil
 }
 
-func (task *singleTask) downloadLocal(lom *cluster.LOM) (err error) {
+func (task *singleTask) downloadLocal(lom *cluster.LOM) error {
+	return task.retry(lom, task.tryDownloadLocal)
+}
+
+func (task *singleTask) retry(lom *cluster.LOM, try func(*cluster.LOM, time.Duration) (bool, error)) (err error) {
 	var (
 		timeout = task.initialTimeout()
 		fatal   bool
 	)
 	for i := 0; i < retryCnt; i++ {
-		fatal, err = task.tryDownloadLocal(lom, timeout)
+		fatal, err = try(lom, timeout)
 		if err == nil || fatal {
 			return err
 		}
-		if errors.Is(err, context.Canceled) || errors.Is(err, errThrottlerStopped) {
-			// Download was canceled or stopped, so just return.
+		if errors.Is(err, context.Canceled) {
+			// Download was canceled, so just return.
 			return err
 		}
 		if errors.Is(err, context.DeadlineExceeded) {
@@ -199,8 +243,8 @@ func (task *singleTask) wrapReader(ctx context.Context, r io.ReadCloser) io.Read
 			nl.OnProgress(task.job.Notif())
 		},
 	}
-	// Wrap around throttler reader (noop if throttling is disabled).
-	r = task.job.throttler().wrapReader(ctx, r)
+	// Wrap around throttler reader (bandwidth limits, see throttler and fairShare).
+	r = task.job.throttler().wrapReader(ctx, r, task.xdl.dispatcher.fair, task.jobID())
 	return r
 }
 
@@ -210,12 +254,29 @@ func (task *singleTask) setTotalSize(size int64) {
 	}
 }
 
+func (task *singleTask) removeCorrupted(lom *cluster.LOM) {
+	var err error
+	if task.obj.fromRemote {
+		_, err = task.xdl.t.EvictObject(lom)
+	} else {
+		_, err = task.xdl.t.DeleteObject(lom, false /*evict*/)
+	}
+	if err != nil {
+		glog.Errorf("%s: failed to remove corrupted %s: %v", task, lom, err)
+	}
+}
+
 func (task *singleTask) reset() {
 	task.totalSize.Store(0)
 	task.currentSize.Store(0)
 }
 
 func (task *singleTask) downloadRemote(lom *cluster.LOM) error {
+	if task.obj.size >= ckptMinSize {
+		if _, ok := task.xdl.t.Backend(lom.Bck()).(cluster.RangeReader); ok {
+			return task.retry(lom, task.tryDownloadRange) // resumable (see resume.go)
+		}
+	}
 	// Set custom context values (used by `ais/backend/*`).
 	ctx, cancel := context.WithTimeout(task.downloadCtx, task.initialTimeout())
 	defer cancel()
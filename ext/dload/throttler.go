@@ -1,97 +1,78 @@
 // Package dload implements functionality to download resources into AIS cluster from external source.
 /*
- * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
  */
 package dload
 
 import (
 	"context"
-	"errors"
 	"io"
+	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
 )
 
-var errThrottlerStopped = errors.New("throttler has been stopped")
+// max time to sleep at a time - to pick up changed limits (see setLimits and fairShare)
+const maxThrottleSleep = time.Second
 
 type (
 	throttler struct {
 		sema    *cos.Semaphore
 		emptyCh chan struct{} // Empty, closed channel (set only if `sema == nil`).
 
-		maxBytesPerMinute int
-		capacityCh        chan int
-		giveBackCh        chan int
-		ticker            *time.Ticker
-		stopCh            *cos.StopCh
+		limits Limits       // current limits (see update)
+		bps    atomic.Int64 // this target's part of the job's bandwidth limit (zero - unlimited)
+		weight atomic.Int64 // see fairShare
+		bucket tokenBucket
 	}
 
-	throughputThrottler interface {
-		acquireAllowance(ctx context.Context, n int) error
+	// allows for (at most) one second burst; goes into debt when the reader is faster than the rate
+	tokenBucket struct {
+		mu     sync.Mutex
+		last   time.Time
+		tokens float64
+		rate   int64 // bytes per second
 	}
 
 	throttledReader struct {
-		t   throughputThrottler
+		t   *throttler
+		fs  *fairShare
+		id  string
 		ctx context.Context
 		r   io.ReadCloser
 	}
 )
 
-func (t *throttler) init(limits Limits) {
+func (t *throttler) init(limits Limits, numTs int) {
 	if limits.Connections > 0 {
 		t.sema = cos.NewSemaphore(limits.Connections)
 	} else {
 		t.emptyCh = make(chan struct{})
 		close(t.emptyCh)
 	}
-	if limits.BytesPerHour > 0 {
-		t.initThroughputThrottling(limits.BytesPerHour / 60)
+	t.setLimits(limits, numTs)
+}
+
+// (re)set bandwidth limit and weight - the latter can be changed while the job is running
+// NOTE: cluster-wide bandwidth limit gets divided between the targets
+// TODO: might be inaccurate when downloading a few objects - other targets won't use their parts
+func (t *throttler) setLimits(limits Limits, numTs int) {
+	t.limits = limits
+	bps := limits.bandwidth()
+	if bps > 0 && numTs > 1 {
+		bps = cos.MaxI64(bps/int64(numTs), 1)
 	}
+	t.bps.Store(bps)
+	t.weight.Store(limits.weight())
 }
 
-func (t *throttler) initThroughputThrottling(maxBytesPerMinute int) {
-	t.maxBytesPerMinute = maxBytesPerMinute
-	t.capacityCh = make(chan int, 1)
-	t.giveBackCh = make(chan int, 1)
-	t.ticker = time.NewTicker(time.Minute)
-	t.stopCh = cos.NewStopCh()
-	go func() {
-		defer func() {
-			t.ticker.Stop()
-			close(t.capacityCh)
-		}()
-		t.capacityCh <- t.maxBytesPerMinute
-
-		// LOOP-INVARIANT: `t.capacityCh` has 1 element and `t.giveBackCh` has 0 elements.
-		// LOOP-INVARIANT: `t.capacityCh` and `t.giveBackCh` can't have size > 0 at the same time.
-		// Readers start to compete for resources on `t.capacityCh`.
-		for {
-			select {
-			case <-t.stopCh.Listen():
-				return
-			case leftover := <-t.giveBackCh:
-				// Reader took value from `t.capacityCh` and put it to `t.giveBackCh`.
-				// `t.capacityCh` has 0 elements and `t.giveBackCh` has 0 elements
-				// (we've just read from `t.giveBackCh`).
-				if leftover > 0 {
-					select {
-					// By default put leftover to capacity channel.
-					case t.capacityCh <- leftover:
-						break
-					// But if time has passed, put a big chunk.
-					case <-t.ticker.C:
-						t.capacityCh <- t.maxBytesPerMinute
-					}
-				} else {
-					// Readers are faster than bandwidth, throttle here.
-					<-t.ticker.C
-					t.capacityCh <- t.maxBytesPerMinute
-				}
-				// Regardless of chosen if-branch we put 1 element to `t.capacityCh`.
-			}
-		}
-	}()
+// change only the specified limits while keeping the rest (see LimitsToUpdate)
+func (t *throttler) update(upd *LimitsToUpdate, numTs int) {
+	limits := t.limits
+	limits.apply(upd)
+	t.setLimits(limits, numTs)
 }
 
 func (t *throttler) tryAcquire() <-chan struct{} {
@@ -108,50 +89,66 @@ func (t *throttler) release() {
 	t.sema.Release()
 }
 
-func (t *throttler) wrapReader(ctx context.Context, r io.ReadCloser) io.ReadCloser {
-	if t.maxBytesPerMinute == 0 {
-		return r
-	}
-	return &throttledReader{
-		t:   t,
-		ctx: ctx,
-		r:   r,
-	}
+// NOTE: wrapping unconditionally - the limits may change in the middle of a download
+func (t *throttler) wrapReader(ctx context.Context, r io.ReadCloser, fs *fairShare, id string) io.ReadCloser {
+	return &throttledReader{t: t, fs: fs, id: id, ctx: ctx, r: r}
 }
 
-func (t *throttler) stop() {
-	if t.ticker != nil {
-		t.ticker.Stop()
+/////////////////
+// tokenBucket //
+/////////////////
+
+// consumes n tokens and returns the time to wait to pay back the debt (if any);
+// changing the rate forgives the debt
+func (tb *tokenBucket) reserve(n int, rate int64) (d time.Duration) {
+	now := time.Now()
+	tb.mu.Lock()
+	if rate != tb.rate {
+		tb.rate, tb.tokens, tb.last = rate, 0, now
 	}
-	if t.stopCh != nil {
-		t.stopCh.Close()
+	if rate > 0 {
+		tb.tokens += now.Sub(tb.last).Seconds() * float64(rate)
+		if tb.tokens > float64(rate) {
+			tb.tokens = float64(rate)
+		}
+		tb.last = now
+		tb.tokens -= float64(n)
+		if tb.tokens < 0 {
+			d = time.Duration(-tb.tokens / float64(rate) * float64(time.Second))
+		}
 	}
+	tb.mu.Unlock()
+	return
 }
 
-func (t *throttler) giveBack(leftoverSize int) {
-	// Never waits. If we took value from `t.capacityCh`, it means that
-	// `t.giveBack` was empty.
-	t.giveBackCh <- leftoverSize
-}
+/////////////////////
+// throttledReader //
+/////////////////////
 
-func (t *throttler) acquireAllowance(ctx context.Context, n int) error {
-	select {
-	case size, ok := <-t.capacityCh:
-		if !ok {
-			return errThrottlerStopped
-		}
-		t.giveBack(size - n)
-		return nil
-	case <-ctx.Done():
-		return context.Canceled
+// the minimum of the job's own limit and its share of the target's bandwidth
+func (tr *throttledReader) rate() int64 {
+	rate := tr.t.bps.Load()
+	if share := tr.fs.share(tr.id); share > 0 && (rate == 0 || share < rate) {
+		rate = share
 	}
+	return rate
 }
 
 func (tr *throttledReader) Read(p []byte) (n int, err error) {
-	if err := tr.t.acquireAllowance(tr.ctx, len(p)); err != nil {
-		return 0, err
+	n, err = tr.r.Read(p)
+	if n == 0 {
+		return
+	}
+	for d := tr.t.bucket.reserve(n, tr.rate()); d > 0; d = tr.t.bucket.reserve(0, tr.rate()) {
+		timer := time.NewTimer(cos.MinDuration(d, maxThrottleSleep))
+		select {
+		case <-timer.C:
+		case <-tr.ctx.Done():
+			timer.Stop()
+			return n, context.Canceled
+		}
 	}
-	return tr.r.Read(p)
+	return
 }
 
 func (tr *throttledReader) Close() (err error) {
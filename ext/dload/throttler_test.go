@@ -0,0 +1,130 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func setMaxBandwidth(bps int64) {
+	config := cmn.GCO.BeginUpdate()
+	config.Downloader.MaxBandwidth = cos.SizeIEC(bps)
+	cmn.GCO.CommitUpdate(config)
+}
+
+func TestTokenBucket(t *testing.T) {
+	var tb tokenBucket
+	tassert.Errorf(t, tb.reserve(cos.MiB, 0) == 0, "unlimited: expected no wait")
+
+	d := tb.reserve(cos.MiB/2, cos.MiB)
+	tassert.Errorf(t, d > 400*time.Millisecond && d <= 500*time.Millisecond, "expected ~0.5s, got %v", d)
+	d = tb.reserve(cos.MiB/2, cos.MiB)
+	tassert.Errorf(t, d > 900*time.Millisecond && d <= time.Second, "expected ~1s, got %v", d)
+
+	// changing the rate forgives the debt
+	tassert.Errorf(t, tb.reserve(0, 2*cos.MiB) == 0, "expected no wait upon rate change")
+}
+
+func TestThrottledReader(t *testing.T) {
+	setMaxBandwidth(0)
+	var (
+		fs   = newFairShare()
+		job  = &sliceDlJob{baseDlJob: baseDlJob{id: "job"}}
+		size = 2 * cos.MiB
+	)
+	job.throt.init(Limits{BytesPerSec: 8 * cos.MiB}, 2 /*numTs*/) // => 4MiB/s per target
+	fs.register(job)
+
+	r := job.throt.wrapReader(context.Background(), io.NopCloser(bytes.NewReader(make([]byte, size))), fs, job.id)
+	started := time.Now()
+	n, err := io.Copy(io.Discard, r)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, n == int64(size), "expected %d, got %d", size, n)
+	elapsed := time.Since(started)
+	tassert.Errorf(t, elapsed > 400*time.Millisecond && elapsed < 2*time.Second, "expected ~0.5s, got %v", elapsed)
+
+	// changing weight keeps the bandwidth limit (and vice versa)
+	weight := 3
+	job.throt.update(&LimitsToUpdate{Weight: &weight}, 2)
+	tassert.Errorf(t, job.throt.bps.Load() == 4*cos.MiB && job.throt.weight.Load() == 3, "unexpected bps %d, weight %d",
+		job.throt.bps.Load(), job.throt.weight.Load())
+	bph := 3600 * 2 * cos.MiB
+	job.throt.update(&LimitsToUpdate{BytesPerHour: &bph}, 2)
+	tassert.Errorf(t, job.throt.bps.Load() == cos.MiB && job.throt.weight.Load() == 3, "unexpected bps %d, weight %d",
+		job.throt.bps.Load(), job.throt.weight.Load())
+
+	// canceled while throttled
+	job.throt.setLimits(Limits{BytesPerSec: 1}, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	r = job.throt.wrapReader(ctx, io.NopCloser(bytes.NewReader(make([]byte, size))), fs, job.id)
+	time.AfterFunc(100*time.Millisecond, cancel)
+	_, err = io.Copy(io.Discard, r)
+	tassert.Errorf(t, err == context.Canceled, "expected %v, got %v", context.Canceled, err)
+}
+
+func TestFairShare(t *testing.T) {
+	const maxbw = 90 * cos.MiB
+	setMaxBandwidth(maxbw)
+	defer setMaxBandwidth(0)
+
+	var (
+		fs     = newFairShare()
+		jobs   = make([]*sliceDlJob, 3)
+		stopCh = cos.NewStopCh()
+	)
+	fs.slots = 8
+	for i, weight := range []int{1, 2, 5} {
+		jobs[i] = &sliceDlJob{baseDlJob: baseDlJob{id: string(rune('a' + i))}}
+		jobs[i].throt.init(Limits{Weight: weight}, 1)
+		fs.register(jobs[i])
+	}
+
+	// queue slots: in proportion to the weights (8 slots, total weight 8)
+	for i, quota := range []int{1, 2, 5} {
+		for j := 0; j < quota; j++ {
+			tassert.Fatalf(t, fs.acquire(jobs[i].id, nil, stopCh.Listen()), "job %d: expected to acquire", i)
+		}
+	}
+	acquired := make(chan bool)
+	go func() { acquired <- fs.acquire(jobs[0].id, nil, stopCh.Listen()) }()
+	select {
+	case <-acquired:
+		t.Fatal("expected to block (over quota)")
+	case <-time.After(100 * time.Millisecond):
+	}
+	fs.release(jobs[0].id)
+	tassert.Errorf(t, <-acquired, "expected to acquire upon release")
+
+	// bandwidth: in proportion to the weights of the jobs that are downloading
+	tassert.Errorf(t, fs.share(jobs[0].id) == maxbw/8, "expected %d, got %d", maxbw/8, fs.share(jobs[0].id))
+	tassert.Errorf(t, fs.share(jobs[2].id) == maxbw*5/8, "expected %d, got %d", maxbw*5/8, fs.share(jobs[2].id))
+
+	// job that is not downloading is not competing
+	for j := 0; j < 5; j++ {
+		fs.release(jobs[2].id)
+	}
+	tassert.Errorf(t, fs.share(jobs[0].id) == maxbw/3, "expected %d, got %d", maxbw/3, fs.share(jobs[0].id))
+
+	// adjusted while running
+	weight := 4
+	fs.setLimits(jobs[0].id, &LimitsToUpdate{Weight: &weight}, 1)
+	tassert.Errorf(t, fs.share(jobs[1].id) == maxbw/3, "expected %d, got %d", maxbw/3, fs.share(jobs[1].id))
+	tassert.Errorf(t, !fs.setLimits("unknown", &LimitsToUpdate{Weight: &weight}, 1), "expected false for unknown job")
+
+	// stopped while waiting
+	fs.unregister(jobs[2].id)
+	go func() { acquired <- fs.acquire(jobs[1].id, nil, stopCh.Listen()) }()
+	time.Sleep(50 * time.Millisecond)
+	stopCh.Close()
+	tassert.Errorf(t, !<-acquired, "expected not to acquire when stopped")
+}
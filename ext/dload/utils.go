@@ -205,6 +205,13 @@ func attrsFromLink(link string, resp *http.Response, oah cmn.ObjAttrsHolder) (si
 		}
 	default:
 		oah.SetCustomKey(cmn.SourceObjMD, cmn.WebObjMD)
+		// to tell whether the source has changed (see CompareObjects)
+		if v := resp.Header.Get(cos.HdrETag); v != "" {
+			oah.SetCustomKey(cmn.ETag, v)
+		}
+		if v := resp.Header.Get(cos.HdrLastModified); v != "" {
+			oah.SetCustomKey(cmn.LastModified, v)
+		}
 	}
 	return resp.ContentLength
 }
@@ -259,9 +266,25 @@ func CompareObjects(lom *cluster.LOM, dst *DstElement) (equal bool, err error) {
 		}
 	}
 	equal = lom.Equal(oa)
+	if !equal && dst.Link != "" {
+		equal = eqLastModified(lom, oa)
+	}
 	return
 }
 
+// web source that provides neither checksum nor ETag: same size and Last-Modified
+func eqLastModified(lom *cluster.LOM, oa *cmn.ObjAttrs) bool {
+	if _, ok := oa.GetCustomKey(cmn.ETag); ok {
+		return false
+	}
+	remMeta, ok := oa.GetCustomKey(cmn.LastModified)
+	if !ok || oa.Size != lom.SizeBytes() {
+		return false
+	}
+	locMeta, ok := lom.GetCustomKey(cmn.LastModified)
+	return ok && locMeta == remMeta
+}
+
 // called via ais/prxnotifs generic mechanism
 func AbortReq(jobID string) cmn.HreqArgs {
 	var (
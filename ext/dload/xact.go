@@ -111,6 +111,7 @@ const (
 	actRemove = "REMOVE"
 	actAbort  = "ABORT"
 	actStatus = "STATUS"
+	actLimits = "LIMITS"
 	actList   = "LIST"
 )
 
@@ -150,11 +151,12 @@ type (
 	// objects are used by Downloader to process the request, and are then
 	// dispatched to the correct jogger to be handled.
 	request struct {
-		action     string         // one of: adminAbort, adminList, adminStatus, adminRemove
-		id         string         // id of the job task
-		regex      *regexp.Regexp // regex of descriptions to return if id is empty
-		response   *response      // where the outcome of the request is written
-		onlyActive bool           // request status of only active tasks
+		action     string          // one of: adminAbort, adminList, adminStatus, adminRemove
+		id         string          // id of the job task
+		regex      *regexp.Regexp  // regex of descriptions to return if id is empty
+		response   *response       // where the outcome of the request is written
+		onlyActive bool            // request status of only active tasks
+		limits     *LimitsToUpdate // new limits of the running job
 	}
 
 	progressReader struct {
@@ -219,11 +221,13 @@ func (xld *Xact) stop(err error) {
 	xld.Finish(err)
 }
 
-func (xld *Xact) Download(job jobif) (resp any, statusCode int, err error) {
+// (dlb is the job's original request body - persisted to restart the job upon reboot, see JobSpec)
+func (xld *Xact) Download(job jobif, dlb Body) (resp any, statusCode int, err error) {
 	xld.IncPending()
 	defer xld.DecPending()
 
 	dljob := dlStore.setJob(job)
+	dlStore.setJobSpec(&JobSpec{ID: job.ID(), XactID: job.XactID(), Body: dlb})
 
 	select {
 	case xld.dispatcher.workCh <- job:
@@ -233,6 +237,7 @@ func (xld *Xact) Download(job jobif) (resp any, statusCode int, err error) {
 		case xld.dispatcher.workCh <- job:
 			return dljob.id, http.StatusOK, nil
 		case <-time.After(cmn.Timeout.CplaneOperation()):
+			dlStore.delJobSpec(job.ID())
 			return "downloader job queue is full", http.StatusTooManyRequests, nil
 		}
 	}
@@ -254,6 +259,14 @@ func (xld *Xact) RemoveJob(id string) (resp any, statusCode int, err error) {
 	return
 }
 
+func (xld *Xact) SetLimits(id string, limits *LimitsToUpdate) (resp any, statusCode int, err error) {
+	xld.IncPending()
+	req := &request{action: actLimits, id: id, limits: limits}
+	resp, statusCode, err = xld.dispatcher.adminReq(req)
+	xld.DecPending()
+	return
+}
+
 func (xld *Xact) JobStatus(id string, onlyActive bool) (resp any, statusCode int, err error) {
 	xld.IncPending()
 	req := &request{action: actStatus, id: id, onlyActive: onlyActive}
@@ -13,7 +13,6 @@ import (
 	"math"
 	"net/http"
 	"net/url"
-	"os"
 	"sort"
 	"strconv"
 	"strings"
@@ -103,7 +102,7 @@ func (m *Manager) start() (err error) {
 	// Phase 3. - run only by the final target
 	if curTargetIsFinal {
 		shardSize := m.rs.OutputShardSize
-		if m.extractCreator.UsingCompression() {
+		if m.extractCreator.UsingCompression() && m.rs.OutputExtension == m.rs.Extension {
 			// By making the assumption that the input content is reasonably
 			// uniform across all shards, the output shard size required (such
 			// that each gzip compressed output shard will have a size close to
@@ -200,7 +199,7 @@ func (m *Manager) extractShard(name string, metrics *LocalExtraction) func() err
 		}
 
 		lom.Lock(false)
-		f, err := os.Open(lom.FQN)
+		f, err := lom.Open()
 		if err != nil {
 			phaseInfo.adjuster.releaseSema(lom.Mountpath())
 			lom.Unlock(false)
@@ -230,7 +229,7 @@ func (m *Manager) extractShard(name string, metrics *LocalExtraction) func() err
 
 		m.dsorter.postShardExtraction(expectedUncompressedSize) // schedule unreserving reserved memory on next memory update
 		if err != nil {
-			return errors.Errorf("error in ExtractShard, file: %s, err: %v", f.Name(), err)
+			return errors.Errorf("error in ExtractShard, file: %s, err: %v", lom.FQN, err)
 		}
 
 		metrics.mu.Lock()
@@ -430,7 +429,7 @@ func (m *Manager) createShard(s *extract.Shard) (err error) {
 			goto exit
 		}
 
-		file, err := cos.NewFileHandle(lom.FQN)
+		file, err := lom.Open()
 		if err != nil {
 			return err
 		}
@@ -615,6 +614,10 @@ func (m *Manager) participateInRecordDistribution(targetOrder cluster.Nodes) (cu
 		m.recManager.MergeEnqueuedRecords()
 	}
 
+	if m.rs.WebDataset {
+		// deterministic output: same input => same output shards (names and content)
+		m.recManager.Records.SortSamples()
+	}
 	err = sortRecords(m.recManager.Records, m.rs.Algorithm)
 	m.dsorter.postRecordDistribution()
 	return true, err
@@ -650,7 +653,7 @@ func (m *Manager) generateShardsWithTemplate(maxSize int64) ([]*extract.Shard, e
 			return nil, errors.Errorf("number of shards to be created exceeds expected number of shards (%d)", shardCount)
 		}
 		shard := &extract.Shard{
-			Name: name + m.rs.Extension,
+			Name: name + m.rs.OutputExtension,
 		}
 
 		shard.Size = curShardSize
@@ -0,0 +1,26 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import "io"
+
+// interface guard
+var _ Creator = (*convertCreator)(nil)
+
+// convertCreator extracts input shards with one creator and creates output shards with another.
+// The latter must be able to handle (skip) metadata of the input format - see `loadObjContent`.
+type convertCreator struct {
+	Creator // input
+	output  Creator
+}
+
+func ConvertCreator(input, output Creator) Creator {
+	return &convertCreator{Creator: input, output: output}
+}
+
+// CreateShard creates a new shard formatted by the output creator.
+func (c *convertCreator) CreateShard(s *Shard, w io.Writer, loadContent LoadContentFunc) (int64, error) {
+	return c.output.CreateShard(s, w, loadContent)
+}
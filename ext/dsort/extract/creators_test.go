@@ -0,0 +1,293 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/vmihailenco/msgpack"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// golden Parquet shard (go test -run TestExtract -update-golden) is verified by the test itself -
+// decoded with the (writer-independent) thriftReader below, see checkParquet; when updating,
+// it is also a good idea to check it with a third-party reader, e.g.:
+// python -c "import pyarrow.parquet as pq; print(pq.read_table('testdata/shard.parquet'))"
+var updateGolden = flag.Bool("update-golden", false, "update golden files in testdata")
+
+type testExtractor struct {
+	names    []string
+	contents [][]byte
+}
+
+func (te *testExtractor) ExtractRecordWithBuffer(args extractRecordArgs) (int64, error) {
+	b, err := io.ReadAll(args.r)
+	te.names = append(te.names, args.recordName)
+	te.contents = append(te.contents, b)
+	return int64(len(b)), err
+}
+
+// (minimal) thrift compact protocol decoder: structs => map[field ID]value
+type thriftReader struct {
+	b   []byte
+	off int
+}
+
+func (tr *thriftReader) byte() byte {
+	tr.off++
+	return tr.b[tr.off-1]
+}
+
+func (tr *thriftReader) varint() int64 {
+	v, n := binary.Varint(tr.b[tr.off:])
+	tr.off += n
+	return v
+}
+
+func (tr *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(tr.b[tr.off:])
+	tr.off += n
+	return v
+}
+
+func (tr *thriftReader) strct() map[int16]any {
+	var (
+		m    = make(map[int16]any)
+		last int16
+	)
+	for {
+		h := tr.byte()
+		if h == 0 {
+			return m
+		}
+		id := last + int16(h>>4)
+		if h>>4 == 0 {
+			id = int16(tr.varint())
+		}
+		m[id] = tr.value(h & 0x0f)
+		last = id
+	}
+}
+
+func (tr *thriftReader) value(ty byte) any {
+	switch ty {
+	case tcI32, tcI64:
+		return tr.varint()
+	case tcBinary:
+		l := int(tr.uvarint())
+		tr.off += l
+		return string(tr.b[tr.off-l : tr.off])
+	case tcList:
+		h := tr.byte()
+		l := int(h >> 4)
+		if l == 15 {
+			l = int(tr.uvarint())
+		}
+		list := make([]any, l)
+		for i := range list {
+			list[i] = tr.value(h & 0x0f)
+		}
+		return list
+	case tcStruct:
+		return tr.strct()
+	default:
+		Fail("unexpected thrift type")
+		return nil
+	}
+}
+
+var _ = Describe("Creators", func() {
+	var (
+		t       = mock.NewTarget(nil)
+		meta    = []byte("<md>") // input format's metadata, must be skipped in output
+		samples = []struct {
+			name string
+			objs map[string]string
+		}{
+			{name: "shard-1|a/sample-0", objs: map[string]string{".jpg": "jpeg-0", ".cls": "0"}},
+			{name: "shard-1|a/sample-1", objs: map[string]string{".cls": "1"}},
+			{name: "shard-2|sample-2", objs: map[string]string{".jpg": "", ".cls": "2", ".seg.png": "png-2"}},
+		}
+		contents map[string]string
+
+		loadContent = func(w io.Writer, rec *Record, obj *RecordObj) (int64, error) {
+			n, err := w.Write(append(append([]byte{}, meta...), contents[rec.MakeUniqueName(obj)]...))
+			return int64(n), err
+		}
+
+		// decode Parquet file: footer (FileMetaData), page headers, and column values
+		checkParquet = func(b []byte) {
+			Expect(string(b[:4])).To(Equal(parquetMagic))
+			Expect(string(b[len(b)-4:])).To(Equal(parquetMagic))
+			l := int(binary.LittleEndian.Uint32(b[len(b)-8:]))
+			tr := &thriftReader{b: b, off: len(b) - 8 - l}
+			md := tr.strct()
+			Expect(tr.off).To(Equal(len(b) - 8))
+			Expect(md[1]).To(Equal(int64(1)))            // version
+			Expect(md[3]).To(Equal(int64(len(samples)))) // num_rows
+
+			schema := md[2].([]any)
+			names := make([]string, 0, len(schema))
+			for _, el := range schema {
+				names = append(names, el.(map[int16]any)[4].(string))
+			}
+			Expect(names).To(Equal([]string{"schema", ParquetKeyColumn, "cls", "jpg", "seg.png"}))
+			Expect(schema[0].(map[int16]any)[5]).To(Equal(int64(len(schema) - 1))) // num_children
+
+			// column => values (nil when null)
+			var (
+				columns   = make(map[string][]any)
+				rowGroups = md[4].([]any)
+				offset    = int64(len(parquetMagic)) // column chunks are contiguous
+			)
+			Expect(rowGroups).To(HaveLen(1))
+			rg := rowGroups[0].(map[int16]any)
+			for i, chunk := range rg[1].([]any) {
+				cmd := chunk.(map[int16]any)[3].(map[int16]any)
+				Expect(chunk.(map[int16]any)[2]).To(Equal(offset)) // file_offset
+				Expect(cmd[9]).To(Equal(offset))                   // data_page_offset
+				Expect(cmd[3]).To(Equal([]any{names[i+1]}))        // path_in_schema
+				Expect(cmd[4]).To(Equal(int64(0)))                 // codec: uncompressed
+				Expect(cmd[5]).To(Equal(int64(len(samples))))      // num_values
+				Expect(cmd[6]).To(Equal(cmd[7]))                   // total (un)compressed size
+				ptr := &thriftReader{b: b, off: int(offset)}
+				ph := ptr.strct()
+				Expect(ph[1]).To(Equal(int64(0))) // DATA_PAGE
+				Expect(ph[2]).To(Equal(ph[3]))
+				Expect(ph[5].(map[int16]any)[1]).To(Equal(int64(len(samples))))
+				Expect(int64(ptr.off) + ph[3].(int64)).To(Equal(offset + cmd[7].(int64)))
+				page := b[ptr.off : ptr.off+int(ph[3].(int64))]
+				offset += cmd[7].(int64)
+
+				defs := make([]byte, 0, len(samples))
+				if i == 0 {
+					for range samples {
+						defs = append(defs, 1)
+					}
+				} else {
+					l := int(binary.LittleEndian.Uint32(page))
+					lr := &thriftReader{b: page[4 : 4+l]}
+					for lr.off < l {
+						run := int(lr.uvarint() >> 1)
+						v := lr.byte()
+						for ; run > 0; run-- {
+							defs = append(defs, v)
+						}
+					}
+					page = page[4+l:]
+				}
+				Expect(defs).To(HaveLen(len(samples)))
+				values := make([]any, 0, len(samples))
+				for _, def := range defs {
+					if def == 0 {
+						values = append(values, nil)
+						continue
+					}
+					l := int(binary.LittleEndian.Uint32(page))
+					values = append(values, string(page[4:4+l]))
+					page = page[4+l:]
+				}
+				Expect(page).To(BeEmpty())
+				columns[names[i+1]] = values
+			}
+			Expect(rg[2]).To(Equal(offset - int64(len(parquetMagic)))) // total_byte_size
+			Expect(rg[3]).To(Equal(int64(len(samples))))               // num_rows
+			Expect(offset).To(Equal(int64(len(b) - 8 - l)))            // followed by the footer
+
+			Expect(columns[ParquetKeyColumn]).To(Equal([]any{"a/sample-0", "a/sample-1", "sample-2"}))
+			Expect(columns["cls"]).To(Equal([]any{"0", "1", "2"}))
+			Expect(columns["jpg"]).To(Equal([]any{"jpeg-0", nil, ""}))
+			Expect(columns["seg.png"]).To(Equal([]any{nil, nil, "png-2"}))
+		}
+	)
+
+	BeforeEach(func() {
+		t.ByteMM() // (initialize the sibling - for small-size allocations)
+	})
+
+	makeShard := func() *Shard {
+		contents = make(map[string]string)
+		records := NewRecords(len(samples))
+		for _, sample := range samples {
+			rec := &Record{Name: sample.name}
+			for ext, content := range sample.objs {
+				rec.Objects = append(rec.Objects, &RecordObj{
+					StoreType:    SGLStoreType,
+					MetadataSize: int64(len(meta)),
+					Size:         int64(len(content)),
+					Extension:    ext,
+				})
+				contents[sample.name+ext] = content
+			}
+			records.Insert(rec)
+		}
+		records.SortSamples()
+		return &Shard{Name: "out", Records: records}
+	}
+
+	It("should create and extract msgpack shards", func() {
+		var (
+			buf     bytes.Buffer
+			creator = NewMsgpackExtractCreator(t)
+		)
+		_, err := creator.CreateShard(makeShard(), &buf, loadContent)
+		Expect(err).NotTo(HaveOccurred())
+
+		var shard map[string][]byte
+		Expect(msgpack.Unmarshal(buf.Bytes(), &shard)).NotTo(HaveOccurred())
+		Expect(shard).To(HaveLen(6))
+		Expect(string(shard["a/sample-0.jpg"])).To(Equal("jpeg-0"))
+		Expect(string(shard["a/sample-1.cls"])).To(Equal("1"))
+		Expect(string(shard["sample-2.seg.png"])).To(Equal("png-2"))
+		Expect(shard["sample-2.jpg"]).To(BeEmpty())
+
+		var (
+			lom = &cluster.LOM{ObjName: "out.msgpack"}
+			te  = &testExtractor{}
+		)
+		lom.SetSize(int64(buf.Len()))
+		_, cnt, err := creator.ExtractShard(lom, bytes.NewReader(buf.Bytes()), te, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cnt).To(Equal(6))
+		// (in order)
+		Expect(te.names).To(Equal([]string{
+			"a/sample-0.cls", "a/sample-0.jpg", "a/sample-1.cls", "sample-2.cls", "sample-2.jpg", "sample-2.seg.png",
+		}))
+		Expect(string(te.contents[5])).To(Equal("png-2"))
+	})
+
+	It("should create parquet shards", func() {
+		var buf bytes.Buffer
+		_, err := ConvertCreator(NewTarExtractCreator(t), NewParquetCreator(t)).CreateShard(makeShard(), &buf, loadContent)
+		Expect(err).NotTo(HaveOccurred())
+
+		checkParquet(buf.Bytes())
+	})
+
+	It("should create parquet shards matching the golden file", func() {
+		var buf bytes.Buffer
+		_, err := ConvertCreator(NewTarExtractCreator(t), NewParquetCreator(t)).CreateShard(makeShard(), &buf, loadContent)
+		Expect(err).NotTo(HaveOccurred())
+
+		golden := filepath.Join("testdata", "shard.parquet")
+		if *updateGolden {
+			Expect(os.WriteFile(golden, buf.Bytes(), cos.PermRWR)).NotTo(HaveOccurred())
+		}
+		b, err := os.ReadFile(golden)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buf.Bytes()).To(Equal(b), "output differs from %q (see updateGolden)", golden)
+		checkParquet(b)
+	})
+})
@@ -11,6 +11,7 @@ import (
 	"hash"
 	"io"
 	"strconv"
+	"strings"
 
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/pkg/errors"
@@ -50,6 +51,12 @@ type (
 		ty  string // type of key extracted, supported: supportedFormatTypes
 		ext string // extension of object record whose content will be read
 	}
+
+	// WebDataset: keys are computed from the sample key (aka `__key__`) - the record name
+	// without extension - so that all files of a given sample produce the same key
+	webdatasetKeyExtractor struct {
+		KeyExtractor
+	}
 )
 
 func NewMD5KeyExtractor() (KeyExtractor, error) {
@@ -120,6 +127,14 @@ func (ke *contentKeyExtractor) ExtractKey(ske *SingleKeyExtractor) (any, error)
 	}
 }
 
+func NewWebDatasetKeyExtractor(ke KeyExtractor) KeyExtractor {
+	return &webdatasetKeyExtractor{ke}
+}
+
+func (ke *webdatasetKeyExtractor) PrepareExtractor(name string, r cos.ReadSizer, ext string) (cos.ReadSizer, *SingleKeyExtractor, bool) {
+	return ke.KeyExtractor.PrepareExtractor(strings.TrimSuffix(name, ext), r, ext)
+}
+
 func ValidateAlgorithmFormatType(ty string) error {
 	if !cos.StringInSlice(ty, supportedFormatTypes) {
 		return errInvalidAlgorithmFormatTypes
@@ -396,3 +396,42 @@ func copyMetadataAndData(dst io.Writer, src io.Reader, metadata, buf []byte) (in
 	// Save data to dst
 	return io.CopyBuffer(dst, src, buf)
 }
+
+// contentWriter drops the first `skip` bytes - the metadata of the input format (see
+// `LoadContentFunc`) - and writes the rest, that is, the content of the record object.
+type contentWriter struct {
+	w    io.Writer
+	skip int64
+	n    int64
+}
+
+func (cw *contentWriter) Write(p []byte) (int, error) {
+	l := len(p)
+	if cw.skip > 0 {
+		if int64(l) <= cw.skip {
+			cw.skip -= int64(l)
+			return l, nil
+		}
+		p = p[cw.skip:]
+		cw.skip = 0
+	}
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	if err != nil {
+		return l - len(p) + n, err
+	}
+	return l, nil
+}
+
+// loadObjContent writes the content of the record object (without metadata) to `w`;
+// used when output shards are formatted differently than input shards.
+func loadObjContent(w io.Writer, rec *Record, obj *RecordObj, loadContent LoadContentFunc) error {
+	cw := &contentWriter{w: w, skip: obj.MetadataSize}
+	if _, err := loadContent(cw, rec, obj); err != nil {
+		return err
+	}
+	if cw.n != obj.Size {
+		return errors.Errorf("record object %q: loaded %d bytes, expected %d", rec.MakeUniqueName(obj), cw.n, obj.Size)
+	}
+	return nil
+}
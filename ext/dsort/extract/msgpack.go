@@ -0,0 +1,123 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/vmihailenco/msgpack"
+)
+
+// msgpack-formatted shard is a single map (name => content), same as `cmn.GenShard` produced
+// by the archiving xaction (see xact/xs/archive.go): there are no per-file headers and,
+// therefore, no metadata to carry over - when created, the files are named
+// `<sample key><extension>` (see `Record.SampleKey`).
+
+// interface guard
+var _ Creator = (*msgpackExtractCreator)(nil)
+
+type msgpackExtractCreator struct {
+	t cluster.Target
+}
+
+func NewMsgpackExtractCreator(t cluster.Target) Creator {
+	return &msgpackExtractCreator{t: t}
+}
+
+// ExtractShard reads the msgpack-formatted shard and extracts its records.
+func (c *msgpackExtractCreator) ExtractShard(lom *cluster.LOM, r cos.ReadReaderAt, extractor RecordExtractor,
+	toDisk bool) (extractedSize int64, extractedCount int, err error) {
+	var (
+		cnt, n int
+		size   int64
+		name   string
+		br     = bufio.NewReader(r) // NOTE: used by the decoder as is (no additional buffering)
+		dec    = msgpack.NewDecoder(br)
+	)
+	if cnt, err = dec.DecodeMapLen(); err != nil {
+		return
+	}
+
+	buf, slab := c.t.PageMM().AllocSize(lom.SizeBytes())
+	defer slab.Free(buf)
+
+	extractMethod := ExtractToMem
+	if toDisk {
+		extractMethod = ExtractToDisk
+	}
+	for i := 0; i < cnt; i++ {
+		if name, err = dec.DecodeString(); err != nil {
+			return
+		}
+		if n, err = dec.DecodeBytesLen(); err != nil {
+			return
+		}
+		n = cos.Max(n, 0) // nil
+		lr := io.LimitReader(br, int64(n))
+		args := extractRecordArgs{
+			shardName:     lom.ObjName,
+			fileType:      fs.ObjectType,
+			recordName:    name,
+			r:             cos.NewSizedReader(lr, int64(n)),
+			extractMethod: extractMethod,
+			buf:           buf,
+		}
+		if size, err = extractor.ExtractRecordWithBuffer(args); err != nil {
+			return
+		}
+		// skip whatever's left (e.g., duplicated record)
+		if _, err = io.CopyBuffer(io.Discard, lr, buf); err != nil {
+			return
+		}
+		extractedSize += size
+		extractedCount++
+	}
+	return
+}
+
+// CreateShard creates a new msgpack-formatted shard; unlike `msgpack.Encoder.Encode(cmn.GenShard)`
+// it streams the content and preserves the order of records (and record objects).
+func (*msgpackExtractCreator) CreateShard(s *Shard, w io.Writer, loadContent LoadContentFunc) (written int64, err error) {
+	var (
+		cnt int
+		enc = msgpack.NewEncoder(w)
+	)
+	for _, rec := range s.Records.All() {
+		cnt += len(rec.Objects)
+	}
+	names := make(map[string]struct{}, cnt)
+	if err = enc.EncodeMapLen(cnt); err != nil {
+		return
+	}
+	for _, rec := range s.Records.All() {
+		for _, obj := range rec.Objects {
+			name := rec.SampleKey() + obj.Extension
+			if _, ok := names[name]; ok {
+				glog.Warningf("%s: duplicate name %q (msgpack-formatted shards cannot have duplicates)", s.Name, name)
+			}
+			names[name] = struct{}{}
+			if err = enc.EncodeString(name); err != nil {
+				return
+			}
+			if err = enc.EncodeBytesLen(int(obj.Size)); err != nil {
+				return
+			}
+			if err = loadObjContent(w, rec, obj, loadContent); err != nil {
+				return
+			}
+			written += obj.Size
+		}
+	}
+	return
+}
+
+func (*msgpackExtractCreator) UsingCompression() bool { return false }
+func (*msgpackExtractCreator) SupportsOffset() bool   { return false }
+func (*msgpackExtractCreator) MetadataSize() int64    { return 0 } // no per-file metadata
@@ -0,0 +1,430 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/pkg/errors"
+)
+
+// Parquet output shards
+//
+// Each record (sample) becomes a single row that has:
+// * the (required, UTF8) `__key__` column - sample key (see `Record.SampleKey`);
+// * one optional binary column per extension (without the leading dot, e.g. "jpg", "cls")
+//   found in the shard, in alphabetical order; null when the sample has no such file.
+// Rows are written in row groups of up to `ParquetRowGroupSize` bytes, each column chunk
+// being a single data page (v1) with PLAIN-encoded values and no compression.
+//
+// Parquet is an output-only format - input shards cannot be Parquet files.
+
+const (
+	ExtParquet = ".parquet"
+
+	ParquetKeyColumn    = "__key__"
+	ParquetRowGroupSize = 64 * cos.MiB
+)
+
+const (
+	parquetMagic     = "PAR1"
+	parquetCreatedBy = "aistore dsort"
+)
+
+// parquet.thrift
+const (
+	pqTypeByteArray     = 6
+	pqRequired          = 0
+	pqOptional          = 1
+	pqConvertedUTF8     = 0
+	pqEncodingPlain     = 0
+	pqEncodingRLE       = 3
+	pqCodecUncompressed = 0
+	pqPageData          = 0
+)
+
+// thrift compact protocol types
+const (
+	tcI32    = 5
+	tcI64    = 6
+	tcBinary = 8
+	tcList   = 9
+	tcStruct = 12
+)
+
+// interface guard
+var _ Creator = (*parquetCreator)(nil)
+
+var errParquetExtract = errors.New("extracting parquet shards is not supported")
+
+type (
+	parquetCreator struct {
+		t cluster.Target
+	}
+
+	pqColumn struct {
+		name   string
+		ext    string      // record object extension (empty for the key column)
+		values *memsys.SGL // PLAIN-encoded values of the current row group
+		defs   []byte      // definition levels of the current row group (optional columns only)
+		chunks []pqChunk   // one per written row group
+	}
+	pqChunk struct {
+		offset int64
+		size   int64 // including page header
+	}
+	pqRowGroup struct {
+		rows int64
+		size int64
+	}
+
+	parquetWriter struct {
+		w         io.Writer
+		cols      []*pqColumn
+		rowGroups []pqRowGroup
+		off       int64 // written so far
+		rows      int64 // rows in the current row group
+		buffered  int64 // bytes in the current row group
+	}
+
+	// (minimal) thrift compact protocol encoder
+	thriftWriter struct {
+		b    []byte
+		last []int16 // last field ID - one per nested struct
+	}
+)
+
+func NewParquetCreator(t cluster.Target) Creator {
+	return &parquetCreator{t: t}
+}
+
+func (*parquetCreator) ExtractShard(*cluster.LOM, cos.ReadReaderAt, RecordExtractor, bool) (int64, int, error) {
+	return 0, 0, errParquetExtract
+}
+
+// CreateShard creates a new Parquet-formatted shard.
+func (c *parquetCreator) CreateShard(s *Shard, w io.Writer, loadContent LoadContentFunc) (written int64, err error) {
+	recs := s.Records.All()
+	pw, err := newParquetWriter(w, c.t.PageMM(), recs)
+	if err != nil {
+		return 0, err
+	}
+	defer pw.free()
+	if err = pw.write([]byte(parquetMagic)); err != nil {
+		return pw.off, err
+	}
+	for _, rec := range recs {
+		if err = pw.addRow(rec, loadContent); err != nil {
+			return pw.off, err
+		}
+		if pw.buffered >= ParquetRowGroupSize {
+			if err = pw.flush(); err != nil {
+				return pw.off, err
+			}
+		}
+	}
+	if err = pw.flush(); err != nil {
+		return pw.off, err
+	}
+	err = pw.footer()
+	return pw.off, err
+}
+
+func (*parquetCreator) UsingCompression() bool { return false }
+func (*parquetCreator) SupportsOffset() bool   { return false }
+func (*parquetCreator) MetadataSize() int64    { return 0 }
+
+///////////////////
+// parquetWriter //
+///////////////////
+
+func newParquetWriter(w io.Writer, mm *memsys.MMSA, recs []*Record) (*parquetWriter, error) {
+	exts := make(map[string]struct{}, 8)
+	for _, rec := range recs {
+		for _, obj := range rec.Objects {
+			exts[obj.Extension] = struct{}{}
+		}
+	}
+	cols := make([]*pqColumn, 0, len(exts)+1)
+	cols = append(cols, &pqColumn{name: ParquetKeyColumn})
+	for ext := range exts {
+		name := strings.TrimPrefix(ext, ".")
+		if name == "" || name == ParquetKeyColumn {
+			return nil, errors.Errorf("extension %q cannot be used as parquet column name", ext)
+		}
+		cols = append(cols, &pqColumn{name: name, ext: ext})
+	}
+	tail := cols[1:]
+	sort.Slice(tail, func(i, j int) bool { return tail[i].name < tail[j].name })
+	for _, col := range cols {
+		col.values = mm.NewSGL(0)
+	}
+	return &parquetWriter{w: w, cols: cols}, nil
+}
+
+func (pw *parquetWriter) free() {
+	for _, col := range pw.cols {
+		col.values.Free()
+	}
+}
+
+func (pw *parquetWriter) write(b []byte) error {
+	n, err := pw.w.Write(b)
+	pw.off += int64(n)
+	return err
+}
+
+func (pw *parquetWriter) addRow(rec *Record, loadContent LoadContentFunc) error {
+	var (
+		lbuf [4]byte
+		key  = rec.SampleKey()
+		col  = pw.cols[0]
+	)
+	binary.LittleEndian.PutUint32(lbuf[:], uint32(len(key)))
+	col.values.Write(lbuf[:])
+	col.values.Write([]byte(key))
+	pw.buffered += int64(len(lbuf) + len(key))
+
+	for _, col := range pw.cols[1:] {
+		idx := rec.find(col.ext)
+		if idx < 0 {
+			col.defs = append(col.defs, 0) // null
+			continue
+		}
+		obj := rec.Objects[idx]
+		if obj.Size > math.MaxInt32 {
+			return errors.Errorf("record object %q is too large (%d) to be stored in parquet", rec.MakeUniqueName(obj), obj.Size)
+		}
+		col.defs = append(col.defs, 1)
+		binary.LittleEndian.PutUint32(lbuf[:], uint32(obj.Size))
+		col.values.Write(lbuf[:])
+		if err := loadObjContent(col.values, rec, obj, loadContent); err != nil {
+			return err
+		}
+		pw.buffered += int64(len(lbuf)) + obj.Size
+	}
+	pw.rows++
+	return nil
+}
+
+// flush writes the current row group
+func (pw *parquetWriter) flush() error {
+	if pw.rows == 0 {
+		return nil
+	}
+	rg := pqRowGroup{rows: pw.rows}
+	for _, col := range pw.cols {
+		var levels []byte
+		if col.ext != "" {
+			levels = encodeLevels(col.defs)
+		}
+		pageSize := int64(len(levels)) + col.values.Size()
+		if pageSize > math.MaxInt32 {
+			return errors.Errorf("parquet column %q: page size %d exceeds the maximum", col.name, pageSize)
+		}
+		var (
+			offset = pw.off
+			header = pageHeader(int32(pw.rows), int32(pageSize))
+		)
+		if err := pw.write(header); err != nil {
+			return err
+		}
+		if err := pw.write(levels); err != nil {
+			return err
+		}
+		n, err := col.values.WriteTo(pw.w)
+		pw.off += n
+		if err != nil {
+			return err
+		}
+		chunk := pqChunk{offset: offset, size: pw.off - offset}
+		col.chunks = append(col.chunks, chunk)
+		col.values.Reset()
+		col.defs = col.defs[:0]
+		rg.size += chunk.size
+	}
+	pw.rowGroups = append(pw.rowGroups, rg)
+	pw.rows, pw.buffered = 0, 0
+	return nil
+}
+
+// footer writes file metadata (FileMetaData), its length, and the trailing magic
+func (pw *parquetWriter) footer() error {
+	var numRows int64
+	for _, rg := range pw.rowGroups {
+		numRows += rg.rows
+	}
+	tw := &thriftWriter{}
+	tw.begin(0)
+	tw.i32(1, 1) // version
+	tw.list(2, tcStruct, len(pw.cols)+1)
+	{
+		tw.begin(0)
+		tw.str(4, "schema")
+		tw.i32(5, int32(len(pw.cols)))
+		tw.end()
+		for _, col := range pw.cols {
+			tw.begin(0)
+			tw.i32(1, pqTypeByteArray)
+			if col.ext == "" {
+				tw.i32(3, pqRequired)
+				tw.str(4, col.name)
+				tw.i32(6, pqConvertedUTF8)
+			} else {
+				tw.i32(3, pqOptional)
+				tw.str(4, col.name)
+			}
+			tw.end()
+		}
+	}
+	tw.i64(3, numRows)
+	tw.list(4, tcStruct, len(pw.rowGroups))
+	for i, rg := range pw.rowGroups {
+		tw.begin(0)
+		tw.list(1, tcStruct, len(pw.cols))
+		for _, col := range pw.cols {
+			chunk := col.chunks[i]
+			tw.begin(0)
+			tw.i64(2, chunk.offset) // file_offset
+			tw.begin(3)             // meta_data
+			tw.i32(1, pqTypeByteArray)
+			tw.listI32(2, pqEncodingPlain, pqEncodingRLE)
+			tw.listStr(3, col.name)
+			tw.i32(4, pqCodecUncompressed)
+			tw.i64(5, rg.rows)
+			tw.i64(6, chunk.size)
+			tw.i64(7, chunk.size)
+			tw.i64(9, chunk.offset) // data_page_offset
+			tw.end()
+			tw.end()
+		}
+		tw.i64(2, rg.size)
+		tw.i64(3, rg.rows)
+		tw.end()
+	}
+	tw.str(6, parquetCreatedBy)
+	tw.end()
+
+	var lbuf [4]byte
+	binary.LittleEndian.PutUint32(lbuf[:], uint32(len(tw.b)))
+	if err := pw.write(tw.b); err != nil {
+		return err
+	}
+	if err := pw.write(lbuf[:]); err != nil {
+		return err
+	}
+	return pw.write([]byte(parquetMagic))
+}
+
+// PageHeader with DataPageHeader
+func pageHeader(numValues, size int32) []byte {
+	tw := &thriftWriter{}
+	tw.begin(0)
+	tw.i32(1, pqPageData)
+	tw.i32(2, size) // uncompressed
+	tw.i32(3, size) // compressed
+	tw.begin(5)
+	tw.i32(1, numValues)
+	tw.i32(2, pqEncodingPlain)
+	tw.i32(3, pqEncodingRLE) // definition levels
+	tw.i32(4, pqEncodingRLE) // repetition levels
+	tw.end()
+	tw.end()
+	return tw.b
+}
+
+// encodeLevels encodes definition levels (bit width 1) as RLE runs of the RLE/bit-packing
+// hybrid, prefixed with the (4-byte, little-endian) length.
+func encodeLevels(defs []byte) []byte {
+	b := make([]byte, 4, 16)
+	for i := 0; i < len(defs); {
+		j := i + 1
+		for j < len(defs) && defs[j] == defs[i] {
+			j++
+		}
+		b = binary.AppendUvarint(b, uint64(j-i)<<1)
+		b = append(b, defs[i])
+		i = j
+	}
+	binary.LittleEndian.PutUint32(b, uint32(len(b)-4))
+	return b
+}
+
+//////////////////
+// thriftWriter //
+//////////////////
+
+// begin a struct: nested (field `id`) or a list element (`id` == 0)
+func (tw *thriftWriter) begin(id int16) {
+	if id != 0 {
+		tw.field(id, tcStruct)
+	}
+	tw.last = append(tw.last, 0)
+}
+
+func (tw *thriftWriter) end() {
+	tw.b = append(tw.b, 0) // stop
+	tw.last = tw.last[:len(tw.last)-1]
+}
+
+func (tw *thriftWriter) field(id int16, ty byte) {
+	top := len(tw.last) - 1
+	if delta := id - tw.last[top]; delta > 0 && delta <= 15 {
+		tw.b = append(tw.b, byte(delta)<<4|ty)
+	} else {
+		tw.b = append(tw.b, ty)
+		tw.b = binary.AppendVarint(tw.b, int64(id))
+	}
+	tw.last[top] = id
+}
+
+// (zigzag varints)
+func (tw *thriftWriter) i32(id int16, v int32) {
+	tw.field(id, tcI32)
+	tw.b = binary.AppendVarint(tw.b, int64(v))
+}
+
+func (tw *thriftWriter) i64(id int16, v int64) {
+	tw.field(id, tcI64)
+	tw.b = binary.AppendVarint(tw.b, v)
+}
+
+func (tw *thriftWriter) str(id int16, s string) {
+	tw.field(id, tcBinary)
+	tw.b = binary.AppendUvarint(tw.b, uint64(len(s)))
+	tw.b = append(tw.b, s...)
+}
+
+func (tw *thriftWriter) list(id int16, ty byte, n int) {
+	tw.field(id, tcList)
+	if n < 15 {
+		tw.b = append(tw.b, byte(n)<<4|ty)
+	} else {
+		tw.b = append(tw.b, 0xf0|ty)
+		tw.b = binary.AppendUvarint(tw.b, uint64(n))
+	}
+}
+
+func (tw *thriftWriter) listI32(id int16, vs ...int32) {
+	tw.list(id, tcI32, len(vs))
+	for _, v := range vs {
+		tw.b = binary.AppendVarint(tw.b, int64(v))
+	}
+}
+
+func (tw *thriftWriter) listStr(id int16, vs ...string) {
+	tw.list(id, tcBinary, len(vs))
+	for _, v := range vs {
+		tw.b = binary.AppendUvarint(tw.b, uint64(len(v)))
+		tw.b = append(tw.b, v...)
+	}
+}
@@ -7,6 +7,8 @@ package extract
 import (
 	"encoding/json"
 	"os"
+	"sort"
+	"strings"
 	"sync"
 	"unsafe"
 
@@ -127,6 +129,15 @@ func (r *Record) MakeUniqueName(obj *RecordObj) string {
 	return r.Name + obj.Extension
 }
 
+// SampleKey returns the name of the record in its (input) shard without extension,
+// aka WebDataset sample key (`__key__`).
+func (r *Record) SampleKey() string {
+	if idx := strings.IndexByte(r.Name, '|'); idx >= 0 {
+		return r.Name[idx+1:]
+	}
+	return r.Name
+}
+
 // NewRecords creates new instance of Records struct and allocates n places for
 // the actual Record's
 func NewRecords(n int) *Records {
@@ -249,6 +260,17 @@ func (r *Records) Less(i, j int, formatType string) (bool, error) {
 	return false, nil
 }
 
+// SortSamples puts records in canonical order (by name) and the objects of each record
+// in the order of their extensions - the order that does not depend on which target
+// extracted (or merged) what and when.
+func (r *Records) SortSamples() {
+	sort.Slice(r.arr, func(i, j int) bool { return r.arr[i].Name < r.arr[j].Name })
+	for _, rec := range r.arr {
+		objs := rec.Objects
+		sort.Slice(objs, func(i, j int) bool { return objs[i].Extension < objs[j].Extension })
+	}
+}
+
 func (r *Records) TotalObjectCount() int {
 	return r.totalObjectCount
 }
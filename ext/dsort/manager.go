@@ -177,6 +177,9 @@ func (m *Manager) init(rs *ParsedRequestSpec) error {
 
 	targetCount := m.smap.CountActiveTs()
 
+	if rs.OutputExtension == "" {
+		rs.OutputExtension = rs.Extension
+	}
 	m.rs = rs
 	m.Metrics = newMetrics(rs.Description, rs.ExtendedMetrics)
 	m.startShardCreation = make(chan struct{}, 1)
@@ -462,6 +465,9 @@ func (m *Manager) setExtractCreator() (err error) {
 	if err != nil {
 		return errors.WithStack(err)
 	}
+	if m.rs.WebDataset {
+		keyExtractor = extract.NewWebDatasetKeyExtractor(keyExtractor)
+	}
 
 	onDuplicatedRecords := func(msg string) error {
 		return m.react(m.rs.DuplicatedRecords, msg)
@@ -475,9 +481,23 @@ func (m *Manager) setExtractCreator() (err error) {
 		extractCreator = extract.NewTargzExtractCreator(m.ctx.t)
 	case cos.ExtZip:
 		extractCreator = extract.NewZipExtractCreator(m.ctx.t)
+	case cos.ExtMsgpack:
+		extractCreator = extract.NewMsgpackExtractCreator(m.ctx.t)
 	default:
 		cos.Assertf(false, "unknown extension %s", m.rs.Extension)
 	}
+	if m.rs.OutputExtension != m.rs.Extension {
+		var createCreator extract.Creator
+		switch m.rs.OutputExtension {
+		case cos.ExtMsgpack:
+			createCreator = extract.NewMsgpackExtractCreator(m.ctx.t)
+		case extract.ExtParquet:
+			createCreator = extract.NewParquetCreator(m.ctx.t)
+		default:
+			cos.Assertf(false, "unknown output extension %s", m.rs.OutputExtension)
+		}
+		extractCreator = extract.ConvertCreator(extractCreator, createCreator)
+	}
 
 	if !m.rs.DryRun {
 		m.extractCreator = extractCreator
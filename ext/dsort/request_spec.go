@@ -34,7 +34,8 @@ var (
 
 var (
 	errMissingBucket            = errors.New("missing field 'bucket'")
-	errInvalidExtension         = errors.New("extension must be one of '.tar', '.tar.gz', or '.tgz'")
+	errInvalidExtension         = errors.New("extension must be one of '.tar', '.tar.gz', '.tgz', '.zip', or '.msgpack'")
+	errInvalidOutputExtension   = errors.New("output extension must be either the same as input extension, or '.msgpack', or '.parquet'")
 	errNegOutputShardSize       = errors.New("output shard size must be >= 0")
 	errEmptyOutputShardSize     = errors.New("output shard size must be set (cannot be 0)")
 	errNegativeConcurrencyLimit = errors.New("concurrency max limit must be 0 (limits will be calculated) or > 0")
@@ -49,6 +50,9 @@ var (
 // supportedExtensions is a list of extensions (archives) supported by dSort
 var supportedExtensions = cos.ArchExtensions
 
+// convertExtensions is a list of extensions of output shards that can differ from input shards
+var convertExtensions = []string{cos.ExtMsgpack, extract.ExtParquet}
+
 // TODO: maybe this struct should be composed of `type` and `template` where
 // template is interface and each template has it's own struct. Then we could
 // reflect the interface and based on it start different traverse function.
@@ -83,6 +87,10 @@ type RequestSpec struct {
 	Description string `json:"description" yaml:"description"`
 	// Default: same as `bck` field
 	OutputBck cmn.Bck `json:"output_bck" yaml:"output_bck"`
+	// Default: same as `extension` field
+	OutputExtension string `json:"output_extension" yaml:"output_extension"`
+	// Default: false
+	WebDataset bool `json:"webdataset" yaml:"webdataset"`
 	// Default: alphanumeric, increasing
 	Algorithm SortAlgorithm `json:"algorithm" yaml:"algorithm"`
 	// Default: ""
@@ -112,6 +120,8 @@ type ParsedRequestSpec struct {
 	Description         string                `json:"description"`
 	OutputBck           cmn.Bck               `json:"output_bck"`
 	Extension           string                `json:"extension"`
+	OutputExtension     string                `json:"output_extension"`
+	WebDataset          bool                  `json:"webdataset"`
 	OutputShardSize     int64                 `json:"output_shard_size,string"`
 	InputFormat         *parsedInputTemplate  `json:"input_format"`
 	OutputFormat        *parsedOutputTemplate `json:"output_format"`
@@ -187,6 +197,13 @@ func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
 		return nil, errInvalidExtension
 	}
 	parsedRS.Extension = rs.Extension
+	parsedRS.OutputExtension = rs.OutputExtension
+	if parsedRS.OutputExtension == "" {
+		parsedRS.OutputExtension = rs.Extension
+	} else if rs.OutputExtension != rs.Extension && !cos.StringInSlice(rs.OutputExtension, convertExtensions) {
+		return nil, errInvalidOutputExtension
+	}
+	parsedRS.WebDataset = rs.WebDataset
 
 	parsedRS.OutputShardSize, err = cos.ParseSize(rs.OutputShardSize, cos.UnitsIEC)
 	if err != nil {
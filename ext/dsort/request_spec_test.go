@@ -13,6 +13,7 @@ import (
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/dsort/extract"
 	"github.com/NVIDIA/aistore/fs"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -28,6 +29,30 @@ var _ = Describe("RequestSpec", func() {
 	})
 
 	Context("requests specs which should pass", func() {
+		It("should parse spec with output extension", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				OutputExtension: extract.ExtParquet,
+				WebDataset:      true,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				MaxMemUsage:     "80%",
+				Algorithm:       SortAlgorithm{Kind: SortKindNone},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed.Extension).To(Equal(cos.ExtTar))
+			Expect(parsed.OutputExtension).To(Equal(extract.ExtParquet))
+			Expect(parsed.WebDataset).To(BeTrue())
+
+			rs.OutputExtension = ""
+			parsed, err = rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsed.OutputExtension).To(Equal(cos.ExtTar))
+		})
+
 		It("should parse minimal spec", func() {
 			rs := RequestSpec{
 				Bck:             cmn.Bck{Name: "test"},
@@ -384,6 +409,21 @@ var _ = Describe("RequestSpec", func() {
 			Expect(err).To(Equal(errInvalidExtension))
 		})
 
+		It("should fail due to invalid output extension", func() {
+			rs := RequestSpec{
+				Bck:             cmn.Bck{Name: "test"},
+				Extension:       cos.ExtTar,
+				OutputExtension: cos.ExtZip,
+				InputFormat:     "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       SortAlgorithm{Kind: SortKindNone},
+			}
+			_, err := rs.Parse()
+			Expect(err).Should(HaveOccurred())
+			Expect(err).To(Equal(errInvalidOutputExtension))
+		})
+
 		It("should fail due to invalid mem usage specification", func() {
 			rs := RequestSpec{
 				Bck:             cmn.Bck{Name: "test"},
@@ -72,9 +72,9 @@ func sortRecords(r *extract.Records, algo *SortAlgorithm) (err error) {
 			cos.AssertNoErr(err)
 		}
 
-		rand.Seed(seed)
+		rnd := rand.New(rand.NewSource(seed))
 		for i := 0; i < r.Len(); i++ { // https://en.wikipedia.org/wiki/Fisher%E2%80%93Yates_shuffle
-			j := rand.Intn(i + 1)
+			j := rnd.Intn(i + 1)
 			r.Swap(i, j)
 		}
 	} else {
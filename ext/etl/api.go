@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -21,16 +22,29 @@ import (
 const PrefixXactID = "etl-"
 
 const (
-	Spec = "spec"
-	Code = "code"
+	Spec     = "spec"
+	Code     = "code"
+	Builtin  = "builtin"
+	Pipeline = "pipeline"
 )
 
-const HealthStatusRunning = "Running" // TODO: add the full enum, if exists
+// health status (the names follow K8s pod phases)
+const (
+	HealthStatusRunning = "Running"
+	HealthStatusPending = "Pending" // (local runtime) starting or restarting
+	HealthStatusFailed  = "Failed"  // (local runtime) crashed too many times
+)
+
+// local (non-Kubernetes) runtime - see ext/etl/local.go
+const (
+	LocalProcess   = "process"   // run the container's command directly on the target host
+	LocalContainer = "container" // run the container image via local OCI runtime (podman or docker)
+)
 
 type (
 	InitMsg interface {
 		Name() string
-		Type() string // Code or Spec
+		Type() string // Code, Spec, Builtin, or Pipeline
 		CommType() string
 		Validate() error
 		String() string
@@ -41,6 +55,12 @@ type (
 		IDX       string       `json:"id"`
 		CommTypeX string       `json:"communication"`
 		Timeout   cos.Duration `json:"timeout"`
+		// when not deployed on Kubernetes (and the feature `Local-ETL` is enabled):
+		// one of the `LocalProcess`, `LocalContainer` (default: process for spec, container for code)
+		Local string `json:"local,omitempty"`
+		// multiple objects per transformed object: one of the `OutputMultipart`, `OutputTar`
+		// (default: single object) - see outputs.go
+		Output string `json:"output,omitempty"`
 	}
 	InitSpecMsg struct {
 		InitMsgBase
@@ -67,6 +87,27 @@ type (
 		// bitwise flags: (streaming | debug | strict | ...)
 		Flags int64 `json:"flags"`
 	}
+
+	// in-process transformer: a Go function compiled into aisnode (see RegisterTransformer)
+	// or a WebAssembly module (requires a runtime registered via RegisterWasmRuntime hook)
+	InitBuiltinMsg struct {
+		InitMsgBase
+		Builtin string `json:"builtin"`        // NOTE: eq. `Builtin`; name of the registered transformer or `BuiltinWasm`
+		Wasm    []byte `json:"wasm,omitempty"` // WebAssembly module (required when `Builtin` is `BuiltinWasm`)
+		Args    string `json:"args,omitempty"` // transformer-specific arguments
+		// max size of the buffered (offline) transformed object (and, for WebAssembly, the module's memory);
+		// zero (0) - use the default `DefaultInprocMemLimit`
+		MemLimit cos.SizeIEC `json:"mem_limit,omitempty"`
+		// max time to transform a single object; zero (0) - use the default `DefaultInprocTimeout`
+		ObjTimeout cos.Duration `json:"obj_timeout,omitempty"`
+	}
+
+	// pipeline of already initialized ETLs: each stage streams its output into the next one
+	// (see pipeline.go)
+	InitPipelineMsg struct {
+		InitMsgBase
+		Stages []string `json:"pipeline"` // NOTE: eq. `Pipeline`; names of the ETLs, in order
+	}
 )
 
 type (
@@ -112,6 +153,8 @@ const (
 	Hrev = "hrev://"
 	// Stdin/stdout communication.
 	HpushStdin = "io://"
+	// In-process transformer (see InitBuiltinMsg), no communication with containers.
+	InProc = "inproc://"
 )
 
 var commTypes = []string{Hpush, Hpull, Hrev, HpushStdin} // NOTE: must contain all
@@ -124,13 +167,17 @@ var commTypes = []string{Hpush, Hpull, Hrev, HpushStdin} // NOTE: must contain a
 var (
 	_ InitMsg = (*InitCodeMsg)(nil)
 	_ InitMsg = (*InitSpecMsg)(nil)
+	_ InitMsg = (*InitBuiltinMsg)(nil)
+	_ InitMsg = (*InitPipelineMsg)(nil)
 )
 
 func (m InitMsgBase) CommType() string { return m.CommTypeX }
 func (m InitMsgBase) Name() string     { return m.IDX }
 
-func (*InitCodeMsg) Type() string { return Code }
-func (*InitSpecMsg) Type() string { return Spec }
+func (*InitCodeMsg) Type() string     { return Code }
+func (*InitSpecMsg) Type() string     { return Spec }
+func (*InitBuiltinMsg) Type() string  { return Builtin }
+func (*InitPipelineMsg) Type() string { return Pipeline }
 
 func (m *InitCodeMsg) String() string {
 	return fmt.Sprintf("init-%s[%s-%s-%s]", Code, m.IDX, m.CommTypeX, m.Runtime)
@@ -140,6 +187,14 @@ func (m *InitSpecMsg) String() string {
 	return fmt.Sprintf("init-%s[%s-%s]", Spec, m.IDX, m.CommTypeX)
 }
 
+func (m *InitBuiltinMsg) String() string {
+	return fmt.Sprintf("init-%s[%s-%s]", Builtin, m.IDX, m.Builtin)
+}
+
+func (m *InitPipelineMsg) String() string {
+	return fmt.Sprintf("init-%s[%s-%s]", Pipeline, m.IDX, strings.Join(m.Stages, "->"))
+}
+
 // TODO: double-take, unmarshaling-wise. To avoid, include (`Spec`, `Code`) in API calls
 func UnmarshalInitMsg(b []byte) (msg InitMsg, err error) {
 	var msgInf map[string]json.RawMessage
@@ -156,6 +211,16 @@ func UnmarshalInitMsg(b []byte) (msg InitMsg, err error) {
 		err = jsoniter.Unmarshal(b, msg)
 		return
 	}
+	if _, ok := msgInf[Builtin]; ok {
+		msg = &InitBuiltinMsg{}
+		err = jsoniter.Unmarshal(b, msg)
+		return
+	}
+	if _, ok := msgInf[Pipeline]; ok {
+		msg = &InitPipelineMsg{}
+		err = jsoniter.Unmarshal(b, msg)
+		return
+	}
 	err = fmt.Errorf("invalid etl.InitMsg: %+v", msgInf)
 	return
 }
@@ -179,6 +244,15 @@ func (m *InitCodeMsg) Validate() error {
 	} else if !cos.StringInSlice(m.CommTypeX, commTypes) {
 		return fmt.Errorf("unsupported comm-type %q (%q)", m.CommTypeX, m.Runtime)
 	}
+	if m.Local == LocalProcess {
+		return fmt.Errorf("runtime %q requires container (local %q is not supported)", m.Runtime, m.Local)
+	}
+	if err := validateLocal(m.Local); err != nil {
+		return err
+	}
+	if err := validateOutput(m.Output); err != nil {
+		return err
+	}
 	if m.Funcs.Transform == "" {
 		return fmt.Errorf("transform function cannot be empty (comm-type %q, funcs %+v)", m.CommTypeX, m.Funcs)
 	}
@@ -219,6 +293,12 @@ func (m *InitSpecMsg) Validate() (err error) {
 	if m.CommType() == "" {
 		m.CommTypeX = Hpush
 	}
+	if err := validateLocal(m.Local); err != nil {
+		return cmn.NewErrETL(errCtx, err.Error())
+	}
+	if err := validateOutput(m.Output); err != nil {
+		return cmn.NewErrETL(errCtx, err.Error())
+	}
 
 	// Check pod specification constraints.
 	if len(pod.Spec.Containers) != 1 {
@@ -254,6 +334,61 @@ func (m *InitSpecMsg) Validate() (err error) {
 	return nil
 }
 
+func (m *InitBuiltinMsg) Validate() error {
+	if err := k8s.ValidateEtlName(m.IDX); err != nil {
+		return fmt.Errorf("%v (builtin %q)", err, m.Builtin)
+	}
+	if m.CommTypeX == "" {
+		m.CommTypeX = InProc
+	} else if m.CommTypeX != InProc {
+		return fmt.Errorf("unsupported comm-type %q (builtin %q runs in-process)", m.CommTypeX, m.Builtin)
+	}
+	// NOTE: the registered transformers (and WebAssembly runtime) are checked by targets - see InitBuiltin
+	switch {
+	case m.Builtin == "":
+		return fmt.Errorf("builtin transformer is not specified (%q)", m.IDX)
+	case m.Builtin == BuiltinWasm:
+		if len(m.Wasm) == 0 {
+			return fmt.Errorf("WebAssembly module is empty (%q)", m.IDX)
+		}
+	case len(m.Wasm) != 0:
+		return fmt.Errorf("WebAssembly module requires builtin %q (got %q)", BuiltinWasm, m.Builtin)
+	}
+	if m.MemLimit < 0 || m.ObjTimeout < 0 {
+		return fmt.Errorf("invalid limits: mem-limit %d, obj-timeout %v (%q)", m.MemLimit, m.ObjTimeout, m.IDX)
+	}
+	return validateOutput(m.Output)
+}
+
+// NOTE: the stages must exist (and cannot be pipelines themselves) - checked by proxy
+func (m *InitPipelineMsg) Validate() error {
+	if err := k8s.ValidateEtlName(m.IDX); err != nil {
+		return fmt.Errorf("%v (pipeline %v)", err, m.Stages)
+	}
+	if m.CommTypeX != "" {
+		return fmt.Errorf("pipeline %q: comm-type %q is not supported (stages have their own)", m.IDX, m.CommTypeX)
+	}
+	if len(m.Stages) < 2 {
+		return fmt.Errorf("pipeline %q must have at least 2 stages, got %v", m.IDX, m.Stages)
+	}
+	for _, stage := range m.Stages {
+		if err := k8s.ValidateEtlName(stage); err != nil {
+			return fmt.Errorf("pipeline %q: %v", m.IDX, err)
+		}
+		if stage == m.IDX {
+			return fmt.Errorf("pipeline %q cannot reference itself", m.IDX)
+		}
+	}
+	return validateOutput(m.Output)
+}
+
+func validateLocal(local string) error {
+	if local != "" && local != LocalProcess && local != LocalContainer {
+		return fmt.Errorf("invalid local runtime %q (expecting %q or %q)", local, LocalProcess, LocalContainer)
+	}
+	return nil
+}
+
 //////////////
 // InfoList //
 //////////////
@@ -35,6 +35,7 @@ type etlBootstrapper struct {
 	pod             *corev1.Pod
 	svc             *corev1.Service
 	uri             string
+	peers           []string // pod's host and pod IPs (nil when running locally)
 	originalPodName string
 	originalCommand []string
 }
@@ -344,6 +345,7 @@ func (b *etlBootstrapper) _getHost() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	b.peers = []string{p.Status.HostIP, p.Status.PodIP}
 	return p.Status.HostIP, nil
 }
 
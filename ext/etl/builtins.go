@@ -0,0 +1,64 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// always available in-process transformers (see inproc.go)
+const (
+	BuiltinEcho = "echo" // returns the object as is
+	BuiltinMD5  = "md5"  // returns hex-encoded MD5 of the object
+	BuiltinGzip = "gzip" // compresses the object; args: compression level (1-9, default 6)
+)
+
+func init() {
+	RegisterTransformer(BuiltinEcho, func(string) (Transformer, error) { return TransformFunc(echoTransform), nil })
+	RegisterTransformer(BuiltinMD5, func(string) (Transformer, error) { return TransformFunc(md5Transform), nil })
+	RegisterTransformer(BuiltinGzip, newGzipTransformer)
+}
+
+func echoTransform(_ context.Context, r io.Reader, w io.Writer) error {
+	_, err := io.Copy(w, r)
+	return err
+}
+
+func md5Transform(_ context.Context, r io.Reader, w io.Writer) error {
+	h := md5.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, hex.EncodeToString(h.Sum(nil)))
+	return err
+}
+
+func newGzipTransformer(args string) (Transformer, error) {
+	level := gzip.DefaultCompression
+	if args != "" {
+		var err error
+		if level, err = strconv.Atoi(args); err != nil || level < gzip.BestSpeed || level > gzip.BestCompression {
+			return nil, fmt.Errorf("invalid gzip compression level %q (expecting %d to %d)",
+				args, gzip.BestSpeed, gzip.BestCompression)
+		}
+	}
+	return TransformFunc(func(_ context.Context, r io.Reader, w io.Writer) error {
+		gzw, err := gzip.NewWriterLevel(w, level)
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(gzw, r); err != nil {
+			gzw.Close()
+			return err
+		}
+		return gzw.Close()
+	}), nil
+}
@@ -5,21 +5,29 @@
 package etl
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/md5"
 	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cluster/mock"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -133,6 +141,153 @@ var _ = Describe("CommunicatorTest", func() {
 			Expect(b).To(Equal(transformData))
 		})
 	}
+
+	It("should perform transformation "+InProc, func() {
+		lom := &cluster.LOM{ObjName: objName}
+		err := lom.InitBck(clusterBck.Bucket())
+		Expect(err).NotTo(HaveOccurred())
+		data, err := os.ReadFile(lom.FQN)
+		Expect(err).NotTo(HaveOccurred())
+		sum := md5.Sum(data)
+
+		c := &inprocComm{
+			baseComm: baseComm{t: tMock, xctn: mock.NewXact(apc.ActETLInline), name: "inproc", commType: InProc},
+			tr:       TransformFunc(md5Transform),
+			mem:      memsys.PageMM(),
+			memLimit: cos.MiB,
+			timeout:  time.Minute,
+		}
+		r, err := c.OfflineTransform(clusterBck, objName, 0)
+		Expect(err).NotTo(HaveOccurred())
+		b, err := io.ReadAll(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Close()).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(hex.EncodeToString(sum[:])))
+
+		// memory limit
+		c.tr = TransformFunc(echoTransform)
+		_, err = c.OfflineTransform(clusterBck, objName, 0)
+		Expect(err).To(MatchError(ContainSubstring(errMemLimit.Error())))
+
+		// time limit
+		c.tr = TransformFunc(func(ctx context.Context, _ io.Reader, _ io.Writer) error {
+			<-ctx.Done()
+			return nil
+		})
+		_, err = c.OfflineTransform(clusterBck, objName, 10*time.Millisecond)
+		Expect(err).To(MatchError(ContainSubstring("timed out")))
+
+		// transformer panics
+		c.tr = TransformFunc(func(context.Context, io.Reader, io.Writer) error { panic("oops") })
+		_, err = c.OfflineTransform(clusterBck, objName, 0)
+		Expect(err).To(MatchError(ContainSubstring("panicked")))
+
+		// inline transformation streams the result - not limited by memory
+		c.tr = TransformFunc(echoTransform)
+		w := httptest.NewRecorder()
+		Expect(c.OnlineTransform(w, nil, clusterBck, objName)).NotTo(HaveOccurred())
+		Expect(w.Body.Bytes()).To(Equal(data))
+
+		// CPU-bound transformer that ignores the context
+		var stop atomic.Bool
+		defer stop.Store(true)
+		c.timeout = 50 * time.Millisecond
+		c.tr = TransformFunc(func(context.Context, io.Reader, io.Writer) error {
+			for !stop.Load() {
+			}
+			return nil
+		})
+		for i := 0; i < maxRunaway; i++ {
+			started := time.Now()
+			_, err = c.OfflineTransform(clusterBck, objName, 0)
+			Expect(err).To(MatchError(ContainSubstring("timed out")))
+			Expect(time.Since(started)).To(BeNumerically("<", time.Second))
+		}
+		Expect(c.xctn.IsAborted()).To(BeFalse())
+		_, err = c.OfflineTransform(clusterBck, objName, 0)
+		Expect(err).To(HaveOccurred())
+		Expect(c.xctn.IsAborted()).To(BeTrue())
+	})
+
+	It("should chain transformations in "+Pipeline, func() {
+		lom := &cluster.LOM{ObjName: objName}
+		err := lom.InitBck(clusterBck.Bucket())
+		Expect(err).NotTo(HaveOccurred())
+		data, err := os.ReadFile(lom.FQN)
+		Expect(err).NotTo(HaveOccurred())
+		var compressed bytes.Buffer
+		gzw := gzip.NewWriter(&compressed)
+		_, err = gzw.Write(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gzw.Close()).NotTo(HaveOccurred())
+		sum := md5.Sum(compressed.Bytes())
+
+		for name, tr := range map[string]Transformer{"stage-gzip": nil, "stage-md5": TransformFunc(md5Transform)} {
+			if tr == nil {
+				tr, err = newGzipTransformer("")
+				Expect(err).NotTo(HaveOccurred())
+			}
+			c := &inprocComm{
+				baseComm: baseComm{t: tMock, xctn: mock.NewXact(apc.ActETLInline), name: name, commType: InProc},
+				tr:       tr,
+				mem:      memsys.PageMM(),
+				memLimit: 2 * dataSize,
+				timeout:  time.Minute,
+			}
+			Expect(reg.add(name, c)).NotTo(HaveOccurred())
+			defer reg.del(name)
+		}
+		pc := &pipelineComm{
+			baseComm: baseComm{t: tMock, xctn: mock.NewXact(apc.ActETLInline), name: "pipeline", commType: Pipeline},
+			stages:   []string{"stage-gzip", "stage-md5"},
+			mem:      memsys.PageMM(),
+		}
+		r, err := pc.OfflineTransform(clusterBck, objName, 0)
+		Expect(err).NotTo(HaveOccurred())
+		b, err := io.ReadAll(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Close()).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(hex.EncodeToString(sum[:])))
+
+		// stopped stage
+		reg.del("stage-md5")
+		_, err = pc.OfflineTransform(clusterBck, objName, 0)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should serve staged stream once", func() {
+		// (see httptest.NewRequest: RemoteAddr "192.0.2.1:1234")
+		name, err := staged.add(cos.NewSizedRC(io.NopCloser(bytes.NewReader(transformData)), int64(len(transformData))),
+			objName, []string{"192.0.2.1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ServeStaged(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), objName)).To(BeFalse())
+
+		// not from the transformer
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "192.0.2.2:1234"
+		Expect(ServeStaged(w, req, name)).To(BeTrue())
+		Expect(w.Code).To(Equal(http.StatusForbidden))
+
+		// unguessable
+		other, err := staged.add(cos.NewSizedRC(io.NopCloser(bytes.NewReader(nil)), 0), objName, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(other).NotTo(Equal(name))
+		staged.del(other)
+
+		w = httptest.NewRecorder()
+		Expect(ServeStaged(w, httptest.NewRequest(http.MethodHead, "/", nil), name)).To(BeTrue())
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get(cos.HdrContentLength)).To(Equal(strconv.Itoa(len(transformData))))
+
+		w = httptest.NewRecorder()
+		Expect(ServeStaged(w, httptest.NewRequest(http.MethodGet, "/", nil), name)).To(BeTrue())
+		Expect(w.Body.Bytes()).To(Equal(transformData))
+
+		w = httptest.NewRecorder()
+		Expect(ServeStaged(w, httptest.NewRequest(http.MethodGet, "/", nil), name)).To(BeTrue())
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+	})
 })
 
 // Creates a file with random content.
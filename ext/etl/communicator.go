@@ -41,6 +41,7 @@ type (
 		SvcName() string
 
 		CommType() string
+		Output() string // "" (single object) or one of the multi-object outputs (see outputs.go)
 
 		String() string
 
@@ -71,6 +72,8 @@ type (
 		name     string
 		podName  string
 		commType string
+		output   string
+		peers    []string // transformer's IPs (K8s pod) - see `ServeStaged`
 	}
 
 	pushComm struct {
@@ -116,6 +119,8 @@ func makeCommunicator(args commArgs) Communicator {
 		name:      args.bootstrapper.originalPodName,
 		podName:   args.bootstrapper.pod.Name,
 		xctn:      args.bootstrapper.xctn,
+		output:    args.bootstrapper.msg.Output,
+		peers:     args.bootstrapper.peers,
 	}
 
 	switch args.bootstrapper.msg.CommTypeX {
@@ -164,6 +169,7 @@ func (c *baseComm) Name() string     { return c.name }
 func (c *baseComm) PodName() string  { return c.podName }
 func (c *baseComm) SvcName() string  { return c.podName /*pod name is same as service name*/ }
 func (c *baseComm) CommType() string { return c.commType }
+func (c *baseComm) Output() string   { return c.output }
 
 func (c *baseComm) String() string {
 	return fmt.Sprintf("%s[%s]-%s", c.name, c.xctn.ID(), c.commType)
@@ -255,26 +261,31 @@ func (pc *pushComm) tryDoRequest(lom *cluster.LOM, timeout time.Duration) (cos.R
 	size := lom.SizeBytes()
 
 	// `fh` is closed by Do(req).
-	fh, err := cos.NewFileHandle(lom.FQN)
+	fh, err := lom.Open()
 	if err != nil {
 		return nil, err
 	}
+	return pc.put(fh, size, lom.Bck().Name, lom.ObjName, timeout)
+}
 
+// PUT (and close) the body - the object or the output of the previous pipeline stage
+func (pc *pushComm) put(body io.ReadCloser, size int64, bckName, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
 	var (
 		req    *http.Request
 		resp   *http.Response
 		cancel func()
-		url    = pc.uri + "/" + lom.Bck().Name + "/" + lom.ObjName
+		err    error
+		url    = pc.uri + "/" + bckName + "/" + objName
 	)
 	if timeout != 0 {
 		var ctx context.Context
 		ctx, cancel = context.WithTimeout(context.Background(), timeout)
-		req, err = http.NewRequestWithContext(ctx, http.MethodPut, url, fh)
+		req, err = http.NewRequestWithContext(ctx, http.MethodPut, url, body)
 	} else {
-		req, err = http.NewRequest(http.MethodPut, url, fh)
+		req, err = http.NewRequest(http.MethodPut, url, body)
 	}
 	if err != nil {
-		cos.Close(fh)
+		cos.Close(body)
 		goto finish
 	}
 	if len(pc.command) != 0 {
@@ -282,7 +293,7 @@ func (pc *pushComm) tryDoRequest(lom *cluster.LOM, timeout time.Duration) (cos.R
 		q["command"] = []string{"bash", "-c", strings.Join(pc.command, " ")}
 		req.URL.RawQuery = q.Encode()
 	}
-	req.ContentLength = size
+	req.ContentLength = size // (-1 when unknown)
 	req.Header.Set(cos.HdrContentType, cos.ContentBinary)
 	resp, err = pc.t.DataClient().Do(req) //nolint:bodyclose // Closed by the caller.
 finish:
@@ -301,7 +312,7 @@ finish:
 			if cancel != nil {
 				cancel()
 			}
-			pc.xctn.InObjsAdd(1, size)
+			pc.xctn.InObjsAdd(1, cos.MaxI64(size, 0))
 		},
 	}), nil
 }
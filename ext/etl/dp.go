@@ -5,6 +5,8 @@
 package etl
 
 import (
+	"fmt"
+	"io"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -21,7 +23,7 @@ type OfflineDataProvider struct {
 }
 
 // interface guard
-var _ cluster.DP = (*OfflineDataProvider)(nil)
+var _ cluster.MultiDP = (*OfflineDataProvider)(nil)
 
 func NewOfflineDataProvider(msg *apc.TCBMsg, lsnode *cluster.Snode) (*OfflineDataProvider, error) {
 	comm, err := GetCommunicator(msg.Transform.Name, lsnode)
@@ -35,10 +37,36 @@ func NewOfflineDataProvider(msg *apc.TCBMsg, lsnode *cluster.Snode) (*OfflineDat
 
 // Returns reader resulting from lom ETL transformation.
 func (dp *OfflineDataProvider) Reader(lom *cluster.LOM) (cos.ReadOpenCloser, cmn.ObjAttrsHolder, error) {
-	var (
-		r   cos.ReadCloseSizer
-		err error
-	)
+	r, err := dp.transform(lom)
+	if err != nil {
+		return nil, nil, err
+	}
+	oah := &cmn.ObjAttrs{
+		Size:  r.Size(),
+		Ver:   "",            // after ETL a new object
+		Cksum: cos.NoneCksum, // TODO: checksum
+		Atime: lom.AtimeUnix(),
+	}
+	return cos.NopOpener(r), oah, nil
+}
+
+// one-to-many: ETL's output contains multiple objects (see outputs.go)
+func (dp *OfflineDataProvider) Multi() bool { return dp.comm.Output() != "" }
+
+func (dp *OfflineDataProvider) Walk(lom *cluster.LOM, cb func(name string, r io.Reader, size int64) error) error {
+	r, err := dp.transform(lom)
+	if err != nil {
+		return err
+	}
+	err = walkOutputs(dp.comm.Output(), r, cb)
+	if err != nil {
+		err = fmt.Errorf("%s: %s (%s output): %w", dp.comm, lom, dp.comm.Output(), err)
+	}
+	r.Close()
+	return err
+}
+
+func (dp *OfflineDataProvider) transform(lom *cluster.LOM) (r cos.ReadCloseSizer, err error) {
 	debug.Assert(dp.tcbMsg != nil)
 	call := func() (int, error) {
 		r, err = dp.comm.OfflineTransform(lom.Bck(), lom.ObjName, dp.requestTimeout)
@@ -54,14 +82,5 @@ func (dp *OfflineDataProvider) Reader(lom *cluster.LOM) (cos.ReadOpenCloser, cmn
 		BackOff:   true,
 		Verbosity: cmn.RetryLogQuiet,
 	})
-	if err != nil {
-		return nil, nil, err
-	}
-	oah := &cmn.ObjAttrs{
-		Size:  r.Size(),
-		Ver:   "",            // after ETL a new object
-		Cksum: cos.NoneCksum, // TODO: checksum
-		Atime: lom.AtimeUnix(),
-	}
-	return cos.NopOpener(r), oah, nil
+	return
 }
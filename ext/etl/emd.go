@@ -102,6 +102,10 @@ func (e *MD) UnmarshalJSON(data []byte) (err error) {
 			e.ETLs[k] = &InitCodeMsg{}
 		case Spec:
 			e.ETLs[k] = &InitSpecMsg{}
+		case Builtin:
+			e.ETLs[k] = &InitBuiltinMsg{}
+		case Pipeline:
+			e.ETLs[k] = &InitPipelineMsg{}
 		default:
 			err = fmt.Errorf("invalid InitMsg type %q", v.Type)
 			debug.AssertNoErr(err)
@@ -0,0 +1,391 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// In-process transformers
+//
+// Unlike pods (and local processes or containers - see local.go), in-process transformers
+// run inside the target itself, thus eliminating HTTP round trips per transformed object.
+// There are two kinds:
+// * Go functions compiled into aisnode and registered via `RegisterTransformer`
+//   (see builtins.go for the ones that are always available);
+// * WebAssembly modules - via the `RegisterWasmRuntime` hook only: no runtime ships with aisnode,
+//   and unless a (sandboxed) runtime gets linked in and registered, `BuiltinWasm` fails to init.
+//
+// Limits (see InitBuiltinMsg):
+// * time: the context passed to `Transformer.Transform` expires after `ObjTimeout`, at which point
+//   reading the object and writing the result start to fail. A transformer that keeps running
+//   nonetheless (e.g., CPU-bound and ignoring the context) gets detached: the request fails
+//   with timeout while the transformer's goroutine - which cannot be preempted - is left to
+//   complete in the background; once there are more than `maxRunaway` of those, the ETL is aborted;
+// * memory: the size of the transformed object cannot exceed `MemLimit` when the result gets
+//   buffered in memory (offline transformation); inline transformation and pipeline stages
+//   stream the result and are not limited. WebAssembly runtime must also cap the module's memory.
+//
+// NOTE: Go transformers are trusted code - they must not retain the reader and writer upon return.
+
+const BuiltinWasm = "wasm"
+
+const (
+	DefaultInprocMemLimit = 256 * cos.MiB
+	DefaultInprocTimeout  = time.Minute
+)
+
+// max number of timed-out transformers still running in the background (see above)
+const maxRunaway = 4
+
+type (
+	// Transformer reads the original object from `r` and writes the transformed one into `w`.
+	Transformer interface {
+		Transform(ctx context.Context, r io.Reader, w io.Writer) error
+	}
+	TransformFunc func(ctx context.Context, r io.Reader, w io.Writer) error
+
+	// NewTransformer is called once per ETL instance (and target) with user-specified `args`.
+	NewTransformer func(args string) (Transformer, error)
+
+	// NewWasmTransformer compiles and instantiates WebAssembly `module` in a sandbox
+	// with the module's memory limited to `memLimit` bytes.
+	NewWasmTransformer func(module []byte, args string, memLimit int64) (Transformer, error)
+
+	inprocComm struct {
+		baseComm
+		tr       Transformer
+		mem      *memsys.MMSA
+		memLimit int64
+		timeout  time.Duration
+		runaway  atomic.Int32 // timed-out transformers that are still running
+	}
+
+	// reader and writer given to the transformer fail upon context done; in addition,
+	// upon timeout the caller waits for the in-progress I/O (if any) - see detach
+	ioGuard struct {
+		ctx      context.Context
+		mu       sync.RWMutex
+		detached bool
+	}
+	ctxReader struct {
+		g *ioGuard
+		r io.Reader
+	}
+	// fails writing when exceeding the limit (zero - unlimited)
+	limitWriter struct {
+		g     *ioGuard
+		w     io.Writer
+		n     int64
+		limit int64
+	}
+)
+
+// interface guard
+var (
+	_ Communicator = (*inprocComm)(nil)
+	_ Transformer  = (TransformFunc)(nil)
+)
+
+var (
+	builtins struct {
+		m   map[string]NewTransformer
+		mtx sync.RWMutex
+	}
+	wasmRuntime NewWasmTransformer
+
+	errMemLimit = errors.New("transformed object exceeds memory limit")
+	errDetached = errors.New("transformer timed out")
+)
+
+func (f TransformFunc) Transform(ctx context.Context, r io.Reader, w io.Writer) error {
+	return f(ctx, r, w)
+}
+
+// RegisterTransformer makes Go transformer available for `InitBuiltinMsg` (must be called
+// at init time, e.g. from the `init()` of the package that implements it).
+func RegisterTransformer(name string, f NewTransformer) {
+	debug.Assert(name != BuiltinWasm && name != "", name)
+	builtins.mtx.Lock()
+	if builtins.m == nil {
+		builtins.m = make(map[string]NewTransformer, 4)
+	}
+	_, dup := builtins.m[name]
+	debug.Assert(!dup, "duplicate builtin transformer "+name)
+	builtins.m[name] = f
+	builtins.mtx.Unlock()
+}
+
+// RegisterWasmRuntime enables `BuiltinWasm`; there's at most one WebAssembly runtime.
+func RegisterWasmRuntime(f NewWasmTransformer) {
+	debug.Assert(wasmRuntime == nil)
+	wasmRuntime = f
+}
+
+func getBuiltin(name string) (f NewTransformer, ok bool) {
+	builtins.mtx.RLock()
+	f, ok = builtins.m[name]
+	builtins.mtx.RUnlock()
+	return
+}
+
+func builtinNames() (names []string) {
+	builtins.mtx.RLock()
+	names = make([]string, 0, len(builtins.m)+1)
+	for name := range builtins.m {
+		names = append(names, name)
+	}
+	builtins.mtx.RUnlock()
+	sort.Strings(names)
+	if wasmRuntime != nil {
+		names = append(names, BuiltinWasm)
+	}
+	return
+}
+
+func InitBuiltin(t cluster.Target, msg *InitBuiltinMsg, xid string) (err error) {
+	var (
+		tr       Transformer
+		errCtx   = &cmn.ETLErrCtx{TID: t.SID(), ETLName: msg.IDX}
+		memLimit = int64(msg.MemLimit)
+		timeout  = time.Duration(msg.ObjTimeout)
+	)
+	if memLimit == 0 {
+		memLimit = DefaultInprocMemLimit
+	}
+	if timeout == 0 {
+		timeout = DefaultInprocTimeout
+	}
+	if msg.Builtin == BuiltinWasm {
+		if wasmRuntime == nil {
+			return cmn.NewErrETL(errCtx, "no WebAssembly runtime in this build")
+		}
+		tr, err = wasmRuntime(msg.Wasm, msg.Args, memLimit)
+	} else {
+		newTr, ok := getBuiltin(msg.Builtin)
+		if !ok {
+			return cmn.NewErrETL(errCtx, "unknown builtin transformer %q (supported: %v)", msg.Builtin, builtinNames())
+		}
+		tr, err = newTr(msg.Args)
+	}
+	if err != nil {
+		return cmn.NewErrETL(errCtx, "failed to instantiate %q: %v", msg.Builtin, err)
+	}
+
+	boot := &etlBootstrapper{errCtx: errCtx, t: t}
+	boot.msg.InitMsgBase = msg.InitMsgBase
+	boot.setupXaction(xid)
+
+	c := &inprocComm{
+		baseComm: baseComm{
+			Slistener: newAborter(t, msg.IDX),
+			t:         t,
+			xctn:      boot.xctn,
+			name:      msg.IDX,
+			commType:  InProc,
+			output:    msg.Output,
+		},
+		tr:       tr,
+		mem:      t.PageMM(),
+		memLimit: memLimit,
+		timeout:  timeout,
+	}
+	if err = reg.add(msg.IDX, c); err != nil {
+		return
+	}
+	t.Sowner().Listeners().Reg(c)
+	return
+}
+
+////////////////
+// inprocComm //
+////////////////
+
+// NOTE: streaming the result - not limited by `memLimit`
+func (c *inprocComm) OnlineTransform(w http.ResponseWriter, _ *http.Request, bck *cluster.Bck, objName string) error {
+	return c.do(bck, objName, w, 0 /*timeout*/, 0 /*unlimited*/)
+}
+
+// NOTE: buffering the entire result (and returning memory to the pool when the reader gets closed)
+func (c *inprocComm) OfflineTransform(bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	sgl := c.mem.NewSGL(0)
+	if err := c.do(bck, objName, sgl, timeout, c.memLimit); err != nil {
+		sgl.Free()
+		return nil, err
+	}
+	return cos.NewReaderWithArgs(cos.ReaderArgs{R: sgl, Size: sgl.Size(), DeferCb: sgl.Free}), nil
+}
+
+func (c *inprocComm) do(bck *cluster.Bck, objName string, w io.Writer, timeout time.Duration, limit int64) (err error) {
+	if err = c.xctn.AbortErr(); err != nil {
+		return cmn.NewErrAborted(c.String(), "do", err)
+	}
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err = lom.InitBck(bck.Bucket()); err != nil {
+		return
+	}
+	if timeout == 0 || timeout > c.timeout {
+		timeout = c.timeout
+	}
+	err = c.transform(lom, w, timeout, limit)
+	if err != nil && cmn.IsObjNotExist(err) && bck.IsRemote() {
+		if _, err = c.t.GetCold(context.Background(), lom, cmn.OwtGetLock); err != nil {
+			return
+		}
+		err = c.transform(lom, w, timeout, limit)
+	}
+	return
+}
+
+func (c *inprocComm) transform(lom *cluster.LOM, w io.Writer, timeout time.Duration, limit int64) (err error) {
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err = lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return
+	}
+	fh, err := lom.Open()
+	if err != nil {
+		return
+	}
+	defer cos.Close(fh)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var (
+		g  = &ioGuard{ctx: ctx}
+		r  = &ctxReader{g: g, r: fh}
+		lw = &limitWriter{g: g, w: w, limit: limit}
+	)
+	err = c._transform(g, r, lw)
+	if err == nil {
+		err = ctx.Err() // (transformer may've ignored errors)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			err = fmt.Errorf("%s: timed out transforming %s (timeout %v)", c, lom, timeout)
+		case errors.Is(err, errMemLimit):
+			err = fmt.Errorf("%s: %s: %v (%s)", c, lom, err, cos.ToSizeIEC(limit, 0))
+		}
+		return
+	}
+	c.xctn.InObjsAdd(1, lom.SizeBytes())
+	c.xctn.OutObjsAdd(1, lw.n)
+	return nil
+}
+
+// runs the transformer in a separate goroutine to enforce the timeout even when the transformer
+// does not respect the context
+func (c *inprocComm) _transform(g *ioGuard, r io.Reader, w io.Writer) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- c.safeTransform(g.ctx, r, w)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-g.ctx.Done():
+	}
+	select {
+	case err := <-done: // (most likely)
+		return err
+	case <-time.After(cos.MinDuration(c.timeout/10, time.Second)):
+	}
+	// detach: the caller is about to close the reader and (in the online case) the writer
+	// is about to be gone as well
+	g.detach()
+	n := c.runaway.Inc()
+	go func() {
+		<-done
+		c.runaway.Dec()
+	}()
+	if n > maxRunaway {
+		err := fmt.Errorf("%s: %d transformers keep running past timeout", c, n)
+		glog.Errorln(err)
+		c.xctn.Abort(err)
+	}
+	return g.ctx.Err()
+}
+
+// a panic in the transformer must not take down the target
+func (c *inprocComm) safeTransform(ctx context.Context, r io.Reader, w io.Writer) (err error) {
+	defer func() {
+		if x := recover(); x != nil {
+			err = fmt.Errorf("%s: transformer panicked: %v", c, x)
+		}
+	}()
+	return c.tr.Transform(ctx, r, w)
+}
+
+/////////////
+// ioGuard //
+/////////////
+
+func (g *ioGuard) enter() error {
+	g.mu.RLock()
+	if g.detached {
+		g.mu.RUnlock()
+		return errDetached
+	}
+	if err := g.ctx.Err(); err != nil {
+		g.mu.RUnlock()
+		return err
+	}
+	return nil
+}
+
+func (g *ioGuard) leave() { g.mu.RUnlock() }
+
+// waits for the in-progress read or write (if any) - the subsequent ones fail
+func (g *ioGuard) detach() {
+	g.mu.Lock()
+	g.detached = true
+	g.mu.Unlock()
+}
+
+///////////////
+// ctxReader //
+///////////////
+
+func (cr *ctxReader) Read(p []byte) (int, error) {
+	if err := cr.g.enter(); err != nil {
+		return 0, err
+	}
+	defer cr.g.leave()
+	return cr.r.Read(p)
+}
+
+/////////////////
+// limitWriter //
+/////////////////
+
+func (lw *limitWriter) Write(p []byte) (n int, err error) {
+	if err = lw.g.enter(); err != nil {
+		return
+	}
+	defer lw.g.leave()
+	if lw.limit > 0 && lw.n+int64(len(p)) > lw.limit {
+		return 0, errMemLimit
+	}
+	n, err = lw.w.Write(p)
+	lw.n += int64(n)
+	return
+}
@@ -0,0 +1,700 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/k8s"
+	"github.com/NVIDIA/aistore/sys"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Local (non-Kubernetes) runtime
+//
+// When the cluster is not deployed on Kubernetes and the `Local-ETL` feature is enabled,
+// each target runs its own ETL transformer directly on the target's host as either:
+// * a process - the (pod spec) container's command and args (`LocalProcess`), or
+// * a container - the container's image via local OCI runtime: podman or docker (`LocalContainer`).
+//
+// The rest is the same as with Kubernetes: the pod spec is parsed and validated, the target waits
+// for the readiness probe to succeed and then uses one of the supported communication types.
+// In addition, the target:
+// * restarts the transformer when it crashes (with exponential backoff);
+// * periodically probes the transformer (readiness path) and restarts it upon consecutive failures;
+// * keeps the tail of the transformer's stdout and stderr (see `PodLogs`).
+//
+// Limitations:
+// * volumes: only `emptyDir` (a per-ETL host directory) and `hostPath`;
+// * process: the transformer must listen on the port provided via `AIS_ETL_PORT` environment;
+// * process: `io://` commands are executed by the target itself, one command per object.
+
+const (
+	localLogsSize    = cos.MiB          // max size of stdout/stderr tail kept in memory
+	localMaxRestarts = 5                // consecutive restarts prior to giving up (HealthStatusFailed)
+	localMinUptime   = time.Minute      // running longer than that resets the restarts counter
+	localMaxBackoff  = 30 * time.Second // max delay between consecutive restarts
+	localProbeFails  = 3                // consecutive failed probes that trigger restart
+	localStopTimeout = 10 * time.Second // SIGTERM => SIGKILL
+	localDefTimeout  = 2 * time.Minute  // (init containers, readiness) when `InitMsg.Timeout` is not specified
+)
+
+// environment variables (in addition to `AIS_TARGET_URL`)
+const (
+	envLocalPort    = "AIS_ETL_PORT"    // (process) port to listen on
+	envLocalWorkdir = "AIS_ETL_WORKDIR" // (process) working directory, contains volumes (subdirs)
+)
+
+type (
+	localRunner struct {
+		t       cluster.Target
+		errCtx  *cmn.ETLErrCtx
+		name    string // container name (same as K8s pod name)
+		mode    string // enum { LocalProcess, LocalContainer }
+		oci     string // (container) OCI runtime
+		main    corev1.Container
+		inits   []corev1.Container
+		volumes map[string]string // volume name => host directory
+		workdir string
+		port    int    // host port
+		probe   string // readiness (health) URL
+		uri     string // communicator's URI
+		ioCmd   []string
+		ios     *http.Server // (process, io://)
+		logs    logsTail
+		stopCh  *cos.StopCh
+
+		mu     sync.Mutex
+		cmd    *exec.Cmd
+		exited chan struct{}
+		status string
+	}
+
+	logsTail struct {
+		mu  sync.Mutex
+		buf []byte
+	}
+
+	localRegistry struct {
+		m   map[string]*localRunner
+		mtx sync.RWMutex
+	}
+)
+
+var (
+	lreg = &localRegistry{m: make(map[string]*localRunner)}
+
+	// $(VAR_NAME) - K8s expansion of environment variables in command and args
+	envRefRegex = regexp.MustCompile(`\$\(([A-Za-z_][A-Za-z0-9_]*)\)`)
+)
+
+// interface guard
+var _ io.Writer = (*logsTail)(nil)
+
+// CheckDeployment returns nil if ETL can run in this deployment:
+// either Kubernetes or bare-metal with the `Local-ETL` feature enabled.
+func CheckDeployment() error {
+	err := k8s.Detect()
+	if err == nil || cmn.Features.IsSet(feat.LocalETL) {
+		return nil
+	}
+	return fmt.Errorf("%v (or, feature %q to run ETL as local processes or containers)", err, feat.LocalETL)
+}
+
+func startLocal(t cluster.Target, msg *InitSpecMsg, xid string, opts StartOpts) (err error) {
+	var (
+		lr     *localRunner
+		errCtx = &cmn.ETLErrCtx{TID: t.SID(), ETLName: msg.IDX}
+		boot   = &etlBootstrapper{errCtx: errCtx, t: t, env: opts.Env, msg: *msg}
+	)
+	if _, exists := reg.get(msg.IDX); exists {
+		return cmn.NewErrETL(errCtx, "already running")
+	}
+	if err = boot.createPodSpec(); err != nil {
+		return
+	}
+	if lr, err = newLocalRunner(boot); err != nil {
+		return
+	}
+	if err = lr.start(); err == nil {
+		err = lr.waitReady(time.Duration(msg.Timeout))
+	}
+	if err != nil {
+		glog.Warning(cmn.NewErrETL(errCtx, "%s: cleanup after unsuccessful Start", t))
+		lr.stop()
+		return
+	}
+	boot.uri = lr.uri
+	boot.setupXaction(xid)
+
+	c := makeCommunicator(commArgs{
+		listener:     newAborter(t, msg.IDX),
+		bootstrapper: boot,
+	})
+	if err = reg.add(msg.IDX, c); err != nil {
+		lr.stop()
+		return
+	}
+	lreg.add(msg.IDX, lr)
+	t.Sowner().Listeners().Reg(c)
+	return
+}
+
+/////////////////
+// localRunner //
+/////////////////
+
+func newLocalRunner(boot *etlBootstrapper) (lr *localRunner, err error) {
+	pod := boot.pod
+	lr = &localRunner{
+		t:       boot.t,
+		errCtx:  boot.errCtx,
+		name:    pod.Name,
+		mode:    boot.msg.Local,
+		main:    pod.Spec.Containers[0],
+		inits:   pod.Spec.InitContainers,
+		volumes: make(map[string]string, len(pod.Spec.Volumes)),
+		workdir: filepath.Join(os.TempDir(), "ais-etl", pod.Name),
+		ioCmd:   boot.originalCommand,
+		stopCh:  cos.NewStopCh(),
+		status:  HealthStatusPending,
+	}
+	if lr.mode == "" {
+		lr.mode = LocalProcess
+	}
+	switch lr.mode {
+	case LocalProcess:
+		if len(lr.main.Command) == 0 || (boot.msg.CommTypeX == HpushStdin && len(lr.ioCmd) == 0) {
+			return nil, cmn.NewErrETL(lr.errCtx, "%s: container %q must specify command", lr.mode, lr.main.Name)
+		}
+	case LocalContainer:
+		if lr.oci, err = ociRuntime(); err != nil {
+			return nil, cmn.NewErrETL(lr.errCtx, err.Error())
+		}
+	default:
+		return nil, cmn.NewErrETL(lr.errCtx, "invalid local runtime %q", lr.mode)
+	}
+	for i := range pod.Spec.Volumes {
+		v := &pod.Spec.Volumes[i]
+		switch {
+		case v.EmptyDir != nil:
+			lr.volumes[v.Name] = filepath.Join(lr.workdir, v.Name)
+		case v.HostPath != nil:
+			lr.volumes[v.Name] = v.HostPath.Path
+		default:
+			return nil, cmn.NewErrETL(lr.errCtx, "volume %q: only emptyDir and hostPath are supported", v.Name)
+		}
+	}
+	if rprobe := lr.main.ReadinessProbe; rprobe.PeriodSeconds == 0 || rprobe.TimeoutSeconds == 0 {
+		rprobe.PeriodSeconds, rprobe.TimeoutSeconds = 10, 5 // (see _updReady)
+	}
+	if lr.port, err = freePort(); err != nil {
+		return nil, cmn.NewErrETL(lr.errCtx, err.Error())
+	}
+
+	var (
+		host = "127.0.0.1"
+		port = strconv.Itoa(lr.port)
+		path = lr.main.ReadinessProbe.HTTPGet.Path
+	)
+	lr.probe = "http://" + net.JoinHostPort(host, port) + path
+	if boot.msg.CommTypeX == HpushStdin && lr.mode == LocalProcess {
+		// the target itself (and only the target) talks to the io:// server
+		lr.uri = "http://" + net.JoinHostPort(host, port)
+	} else {
+		// NOTE: with `hpull://` clients get redirected to the transformer
+		if h := lr.t.Snode().PubNet.Hostname; h != "" {
+			host = h
+		}
+		lr.uri = "http://" + net.JoinHostPort(host, port)
+	}
+	return lr, nil
+}
+
+func (lr *localRunner) String() string { return "local-etl[" + lr.name + "-" + lr.mode + "]" }
+
+func (lr *localRunner) start() error {
+	if err := os.RemoveAll(lr.workdir); err != nil {
+		return cmn.NewErrETL(lr.errCtx, err.Error())
+	}
+	for _, dir := range lr.volumes {
+		if err := cos.CreateDir(dir); err != nil {
+			return cmn.NewErrETL(lr.errCtx, err.Error())
+		}
+	}
+	if err := cos.CreateDir(lr.workdir); err != nil {
+		return cmn.NewErrETL(lr.errCtx, err.Error())
+	}
+	if lr.mode == LocalContainer {
+		lr.ociRemove(lr.name) // (previous incarnation, if any)
+	}
+	for i := range lr.inits {
+		if err := lr.runInit(&lr.inits[i], i); err != nil {
+			return cmn.NewErrETL(lr.errCtx, "init container %q: %v", lr.inits[i].Name, err)
+		}
+	}
+
+	// io:// with no container: serve the transforming command ourselves
+	if lr.mode == LocalProcess && lr.ioCmd != nil {
+		return lr.serveIO()
+	}
+
+	cmd, exited, err := lr.spawn()
+	if err != nil {
+		return cmn.NewErrETL(lr.errCtx, "failed to start: %v", err)
+	}
+	go lr.supervise(cmd, exited)
+	go lr.probeLoop()
+	return nil
+}
+
+// runs init container to completion
+func (lr *localRunner) runInit(c *corev1.Container, idx int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), localDefTimeout)
+	defer cancel()
+	cmd, err := lr.command(ctx, c, lr.name+"-init-"+strconv.Itoa(idx))
+	if err != nil {
+		return err
+	}
+	lr.logs.notef("init %q", c.Name)
+	if err = cmd.Run(); err != nil && lr.mode == LocalContainer {
+		lr.ociRemove(lr.name + "-init-" + strconv.Itoa(idx))
+	}
+	return err
+}
+
+func (lr *localRunner) spawn() (cmd *exec.Cmd, exited chan struct{}, err error) {
+	if cmd, err = lr.command(context.Background(), &lr.main, lr.name); err != nil {
+		return
+	}
+	if err = cmd.Start(); err != nil {
+		return
+	}
+	exited = make(chan struct{})
+	lr.mu.Lock()
+	lr.cmd, lr.exited = cmd, exited
+	lr.mu.Unlock()
+	lr.logs.notef("started (pid %d)", cmd.Process.Pid)
+	if lr.stopped() {
+		lr.kill() // (racing with stop)
+	}
+	return
+}
+
+// restarts crashed (or killed by probeLoop) transformer
+func (lr *localRunner) supervise(cmd *exec.Cmd, exited chan struct{}) {
+	var (
+		fails   int
+		started = time.Now()
+	)
+	for {
+		err := cmd.Wait()
+		close(exited)
+		if lr.stopped() {
+			return
+		}
+		if time.Since(started) > localMinUptime {
+			fails = 0
+		}
+		fails++
+		lr.logs.notef("exited: %v", err)
+		if fails > localMaxRestarts {
+			lr.setStatus(HealthStatusFailed)
+			glog.Errorf("%s: exited %d times in a row, giving up (err: %v)", lr, fails, err)
+			return
+		}
+		lr.setStatus(HealthStatusPending)
+		backoff := cos.MinDuration(time.Second<<(fails-1), localMaxBackoff)
+		glog.Warningf("%s: exited (err: %v), restarting in %v", lr, err, backoff)
+		select {
+		case <-time.After(backoff):
+		case <-lr.stopCh.Listen():
+			return
+		}
+		if lr.mode == LocalContainer {
+			lr.ociRemove(lr.name)
+		}
+		started = time.Now()
+		if cmd, exited, err = lr.spawn(); err != nil {
+			lr.setStatus(HealthStatusFailed)
+			glog.Errorf("%s: failed to restart: %v", lr, err)
+			return
+		}
+	}
+}
+
+// liveness: same as readiness probe (K8s-wise), same period and timeout
+func (lr *localRunner) probeLoop() {
+	var (
+		fails  int
+		rprobe = lr.main.ReadinessProbe
+		ticker = time.NewTicker(time.Duration(rprobe.PeriodSeconds) * time.Second)
+		client = &http.Client{Timeout: time.Duration(rprobe.TimeoutSeconds) * time.Second}
+	)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if lr.health() == HealthStatusFailed {
+				return
+			}
+			if err := lr.ping(client); err == nil {
+				fails = 0
+				lr.setStatus(HealthStatusRunning)
+				continue
+			} else if lr.health() != HealthStatusRunning {
+				continue // (re)starting
+			}
+			if fails++; fails < localProbeFails {
+				continue
+			}
+			fails = 0
+			lr.logs.notef("failed %d health probes in a row, restarting", localProbeFails)
+			glog.Warningf("%s: failed %d health probes in a row, restarting", lr, localProbeFails)
+			lr.setStatus(HealthStatusPending)
+			lr.kill()
+		case <-lr.stopCh.Listen():
+			return
+		}
+	}
+}
+
+func (lr *localRunner) ping(client *http.Client) error {
+	resp, err := client.Get(lr.probe) //nolint:noctx // timeout via client
+	if err != nil {
+		return err
+	}
+	cos.DrainReader(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health probe: %s", resp.Status)
+	}
+	return nil
+}
+
+func (lr *localRunner) waitReady(timeout time.Duration) error {
+	if timeout == 0 {
+		timeout = localDefTimeout
+	}
+	var (
+		err      error
+		client   = &http.Client{Timeout: cmn.Timeout.MaxKeepalive()}
+		deadline = time.Now().Add(timeout)
+	)
+	for time.Now().Before(deadline) {
+		if lr.health() == HealthStatusFailed {
+			return cmn.NewErrETL(lr.errCtx, "failed to start (see logs)")
+		}
+		if err = lr.ping(client); err == nil {
+			lr.setStatus(HealthStatusRunning)
+			return nil
+		}
+		time.Sleep(time.Second)
+	}
+	return cmn.NewErrETL(lr.errCtx, "not ready in %v: %v", timeout, err)
+}
+
+func (lr *localRunner) stop() {
+	lr.stopCh.Close()
+	if lr.ios != nil {
+		lr.ios.Close()
+	}
+	lr.kill()
+	if err := os.RemoveAll(lr.workdir); err != nil {
+		glog.Errorf("%s: %v", lr, err)
+	}
+}
+
+// SIGTERM (the entire process group) and, if need be, SIGKILL;
+// the caller (if not stopping) counts on `supervise` to restart
+func (lr *localRunner) kill() {
+	lr.mu.Lock()
+	cmd, exited := lr.cmd, lr.exited
+	lr.mu.Unlock()
+	if cmd == nil {
+		return
+	}
+	if lr.mode == LocalContainer {
+		lr.ociRemove(lr.name)
+	}
+	pgid := -cmd.Process.Pid
+	if err := syscall.Kill(pgid, syscall.SIGTERM); err != nil {
+		return // already exited
+	}
+	select {
+	case <-exited:
+	case <-time.After(localStopTimeout):
+		syscall.Kill(pgid, syscall.SIGKILL)
+	}
+}
+
+func (lr *localRunner) stopped() bool {
+	select {
+	case <-lr.stopCh.Listen():
+		return true
+	default:
+		return false
+	}
+}
+
+func (lr *localRunner) setStatus(status string) {
+	lr.mu.Lock()
+	if lr.status != HealthStatusFailed {
+		lr.status = status
+	}
+	lr.mu.Unlock()
+}
+
+func (lr *localRunner) health() (status string) {
+	lr.mu.Lock()
+	status = lr.status
+	lr.mu.Unlock()
+	return
+}
+
+// NOTE: in containers, ETL runs under the OCI runtime's daemon (docker) or monitor (podman)
+func (lr *localRunner) metrics() (*CPUMemUsed, error) {
+	lr.mu.Lock()
+	cmd := lr.cmd
+	lr.mu.Unlock()
+	if lr.mode != LocalProcess || cmd == nil {
+		return nil, cmn.NewErrUnsupp("get metrics of", lr.String())
+	}
+	stats, err := sys.ProcessStats(cmd.Process.Pid)
+	if err != nil {
+		return nil, err
+	}
+	return &CPUMemUsed{TargetID: lr.t.SID(), CPU: stats.CPU.Percent / 100, Mem: int64(stats.Mem.Resident)}, nil
+}
+
+// (process) container's command and args
+// (container) run the image with OCI runtime, passing environment by names only
+func (lr *localRunner) command(ctx context.Context, c *corev1.Container, name string) (*exec.Cmd, error) {
+	var (
+		cmd    *exec.Cmd
+		env    = lr.environ(c)
+		envs   = os.Environ()
+		lookup = func(name string) string { return env[name] }
+		args   = expandEnv(append(append([]string{}, c.Command...), c.Args...), lookup)
+	)
+	for k, v := range env {
+		envs = append(envs, k+"="+v)
+	}
+	switch lr.mode {
+	case LocalProcess:
+		if len(args) == 0 {
+			return nil, fmt.Errorf("container %q: no command to run", c.Name)
+		}
+		cmd = exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Dir = lr.workdir
+	case LocalContainer:
+		if c.Image == "" {
+			return nil, fmt.Errorf("container %q: no image", c.Name)
+		}
+		oargs := []string{"run", "--rm", "--name", name}
+		for _, p := range c.Ports {
+			oargs = append(oargs, "-p", strconv.Itoa(lr.port)+":"+strconv.Itoa(int(p.ContainerPort)))
+		}
+		for k := range env {
+			oargs = append(oargs, "-e", k)
+		}
+		for _, m := range c.VolumeMounts {
+			dir, ok := lr.volumes[m.Name]
+			if !ok {
+				return nil, fmt.Errorf("container %q: volume %q not found", c.Name, m.Name)
+			}
+			oargs = append(oargs, "-v", dir+":"+m.MountPath)
+		}
+		if c.WorkingDir != "" {
+			oargs = append(oargs, "-w", c.WorkingDir)
+		}
+		if len(c.Command) > 0 {
+			oargs = append(oargs, "--entrypoint", args[0], c.Image)
+			oargs = append(oargs, args[1:]...)
+		} else {
+			oargs = append(oargs, c.Image)
+			oargs = append(oargs, args...)
+		}
+		cmd = exec.CommandContext(ctx, lr.oci, oargs...)
+	}
+	cmd.Env = envs
+	cmd.Stdout, cmd.Stderr = &lr.logs, &lr.logs
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	return cmd, nil
+}
+
+// container's environment including `AIS_TARGET_URL` and `StartOpts.Env` (see _setPodEnv)
+func (lr *localRunner) environ(c *corev1.Container) map[string]string {
+	env := make(map[string]string, len(c.Env)+2)
+	for _, e := range c.Env {
+		if e.ValueFrom != nil {
+			continue // K8s only
+		}
+		env[e.Name] = expandEnv([]string{e.Value}, func(name string) string { return env[name] })[0]
+	}
+	if lr.mode == LocalProcess {
+		env[envLocalPort] = strconv.Itoa(lr.port)
+		env[envLocalWorkdir] = lr.workdir
+	}
+	return env
+}
+
+func (lr *localRunner) ociRemove(name string) {
+	if out, err := exec.Command(lr.oci, "rm", "-f", name).CombinedOutput(); err != nil {
+		glog.Errorf("%s: failed to remove container %q: %v (%s)", lr, name, err, out)
+	}
+}
+
+//
+// io:// - process mode
+//
+
+func (lr *localRunner) serveIO() error {
+	ln, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(lr.port)))
+	if err != nil {
+		return cmn.NewErrETL(lr.errCtx, err.Error())
+	}
+	lr.ios = &http.Server{Handler: http.HandlerFunc(lr.ioHandler), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := lr.ios.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			lr.setStatus(HealthStatusFailed)
+			glog.Errorf("%s: %v", lr, err)
+		}
+	}()
+	lr.logs.notef("serving %s on %s", HpushStdin, ln.Addr())
+	return nil
+}
+
+// NOTE: always executing the command from the spec - ignoring the one that arrives with the request
+func (lr *localRunner) ioHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		w.WriteHeader(http.StatusOK) // health
+	case http.MethodPut:
+		main := lr.main
+		main.Command, main.Args = []string{"bash", "-c", strings.Join(lr.ioCmd, " ")}, nil
+		cmd, err := lr.command(r.Context(), &main, lr.name)
+		if err != nil {
+			cmn.WriteErr(w, r, err)
+			return
+		}
+		cmd.Stdin, cmd.Stdout = r.Body, nil
+		out, err := cmd.Output()
+		if err != nil {
+			cmn.WriteErr(w, r, fmt.Errorf("%s: %v", lr, err))
+			return
+		}
+		w.Header().Set(cos.HdrContentLength, strconv.Itoa(len(out)))
+		w.Write(out)
+	default:
+		cmn.WriteErr405(w, r, http.MethodGet, http.MethodPut)
+	}
+}
+
+//////////////
+// logsTail //
+//////////////
+
+func (lt *logsTail) Write(p []byte) (int, error) {
+	lt.mu.Lock()
+	lt.buf = append(lt.buf, p...)
+	if l := len(lt.buf); l > localLogsSize {
+		lt.buf = append(lt.buf[:0], lt.buf[l-localLogsSize:]...)
+	}
+	lt.mu.Unlock()
+	return len(p), nil
+}
+
+func (lt *logsTail) notef(format string, a ...any) {
+	fmt.Fprintf(lt, "--- %s: %s ---\n", time.Now().Format(time.RFC3339), fmt.Sprintf(format, a...))
+}
+
+func (lt *logsTail) get() (b []byte) {
+	lt.mu.Lock()
+	b = append(b, lt.buf...)
+	lt.mu.Unlock()
+	return
+}
+
+///////////////////
+// localRegistry //
+///////////////////
+
+func (r *localRegistry) add(name string, lr *localRunner) {
+	r.mtx.Lock()
+	r.m[name] = lr
+	r.mtx.Unlock()
+}
+
+func (r *localRegistry) get(name string) (lr *localRunner, exists bool) {
+	r.mtx.RLock()
+	lr, exists = r.m[name]
+	r.mtx.RUnlock()
+	return
+}
+
+func (r *localRegistry) del(name string) (lr *localRunner) {
+	r.mtx.Lock()
+	if lr = r.m[name]; lr != nil {
+		delete(r.m, name)
+	}
+	r.mtx.Unlock()
+	return
+}
+
+//
+// utils
+//
+
+func ociRuntime() (string, error) {
+	for _, name := range []string{"podman", "docker"} {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	return "", errors.New("no OCI runtime (podman or docker) found in PATH")
+}
+
+func freePort() (int, error) {
+	ln, err := net.Listen("tcp", ":0")
+	if err != nil {
+		return 0, err
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+	return port, nil
+}
+
+// K8s semantics: $(VAR_NAME) is expanded if VAR_NAME is defined; otherwise, left as is
+func expandEnv(args []string, lookup func(string) string) []string {
+	for i, arg := range args {
+		args[i] = envRefRegex.ReplaceAllStringFunc(arg, func(ref string) string {
+			if v := lookup(ref[2 : len(ref)-1]); v != "" {
+				return v
+			}
+			return ref
+		})
+	}
+	return args
+}
@@ -0,0 +1,65 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"bytes"
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("LocalRuntime", func() {
+	It("should expand environment variables K8s-style", func() {
+		env := map[string]string{"AIS_ETL_PORT": "8080", "NAME": "etl"}
+		args := expandEnv(
+			[]string{"--port=$(AIS_ETL_PORT)", "$(NAME)-$(NAME)", "$(UNKNOWN)", "$NAME"},
+			func(name string) string { return env[name] },
+		)
+		Expect(args).To(Equal([]string{"--port=8080", "etl-etl", "$(UNKNOWN)", "$NAME"}))
+	})
+
+	It("should keep the tail of the logs", func() {
+		var lt logsTail
+		lt.Write(bytes.Repeat([]byte{'a'}, localLogsSize))
+		lt.Write([]byte("tail"))
+		b := lt.get()
+		Expect(len(b)).To(Equal(localLogsSize))
+		Expect(bytes.HasSuffix(b, []byte("tail"))).To(BeTrue())
+	})
+
+	It("should restart crashed process and give up eventually", func() {
+		workdir, err := os.MkdirTemp("", "")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(workdir)
+
+		lr := &localRunner{
+			errCtx:  &cmn.ETLErrCtx{},
+			name:    "crash",
+			mode:    LocalProcess,
+			main:    corev1.Container{Command: []string{"sh", "-c", "echo $(MSG) in $AIS_ETL_WORKDIR; exit 1"}},
+			workdir: workdir,
+			stopCh:  cos.NewStopCh(),
+			status:  HealthStatusPending,
+		}
+		lr.main.Env = []corev1.EnvVar{{Name: "MSG", Value: "crashing"}}
+		cmd, exited, err := lr.spawn()
+		Expect(err).NotTo(HaveOccurred())
+		go lr.supervise(cmd, exited)
+
+		Eventually(func() int {
+			return bytes.Count(lr.logs.get(), []byte("crashing in "+workdir))
+		}, 5*time.Second).Should(BeNumerically(">=", 3))
+		Expect(lr.health()).To(Equal(HealthStatusPending))
+
+		lr.stop()
+		Expect(lr.stopped()).To(BeTrue())
+	})
+})
@@ -0,0 +1,133 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"path"
+	"strings"
+)
+
+// One-to-many transformations
+//
+// By default, the transformer returns a single (transformed) object. Alternatively, it can
+// declare (see `InitMsgBase.Output`) that it returns multiple objects packed as:
+// * `OutputMultipart` - MIME multipart body where each part has a `filename` (or `name`) in its
+//   Content-Disposition header; the body must start with the boundary delimiter line (no preamble),
+//   the boundary itself is not required to be communicated in any other way;
+// * `OutputTar` - TAR archive where each regular file is an object.
+// Offline (bucket-to-bucket) transformation unpacks the response and stores each of the named
+// objects separately (see `cluster.MultiDP`), while inline transformation (GET) returns
+// the response as is.
+
+const (
+	OutputMultipart = "multipart"
+	OutputTar       = "tar"
+)
+
+// the max length of the boundary delimiter line (RFC 2046: boundary is at most 70 characters)
+const maxBoundaryLine = 2 + 70 + 2
+
+var errNoBoundary = errors.New("multipart output must start with the boundary delimiter line")
+
+func validateOutput(output string) error {
+	if output != "" && output != OutputMultipart && output != OutputTar {
+		return fmt.Errorf("invalid output %q (expecting %q or %q)", output, OutputMultipart, OutputTar)
+	}
+	return nil
+}
+
+// walkOutputs unpacks multi-object output and calls `cb` for each object, in order;
+// `size` is -1 when not known; the reader is valid only for the duration of the callback.
+func walkOutputs(output string, r io.Reader, cb func(name string, r io.Reader, size int64) error) error {
+	switch output {
+	case OutputMultipart:
+		return walkMultipart(r, cb)
+	case OutputTar:
+		return walkTar(r, cb)
+	default:
+		return fmt.Errorf("cannot unpack output %q", output)
+	}
+}
+
+func walkMultipart(r io.Reader, cb func(string, io.Reader, int64) error) error {
+	br := bufio.NewReaderSize(r, maxBoundaryLine+1)
+	line, err := br.Peek(maxBoundaryLine)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if i := bytes.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+	line = bytes.TrimRight(line, "\r \t")
+	if len(line) < 3 || !bytes.HasPrefix(line, []byte("--")) {
+		return errNoBoundary
+	}
+	mr := multipart.NewReader(br, string(line[2:]))
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		// (not using part.FileName() that strips directories)
+		var name string
+		if _, params, err := mime.ParseMediaType(part.Header.Get("Content-Disposition")); err == nil {
+			if name = params["filename"]; name == "" {
+				name = params["name"]
+			}
+		}
+		if name, err = outputName(name); err != nil {
+			part.Close()
+			return err
+		}
+		err = cb(name, part, -1)
+		part.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func walkTar(r io.Reader, cb func(string, io.Reader, int64) error) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue // directories and such
+		}
+		name, err := outputName(hdr.Name)
+		if err != nil {
+			return err
+		}
+		if err := cb(name, tr, hdr.Size); err != nil {
+			return err
+		}
+	}
+}
+
+// output names are relative - must not escape the destination "directory"
+func outputName(name string) (string, error) {
+	clean := path.Clean(strings.TrimPrefix(name, "./"))
+	if name == "" || clean == "." || path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("invalid output object name %q", name)
+	}
+	return clean, nil
+}
@@ -0,0 +1,92 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Outputs", func() {
+	var (
+		names = []string{"frame-0001.jpg", "frames/frame-0002.jpg", "./frame-0003.jpg"}
+		datas = []string{"first", "second", ""}
+	)
+
+	walk := func(output string, r io.Reader) (objs map[string]string, err error) {
+		objs = make(map[string]string)
+		err = walkOutputs(output, r, func(name string, r io.Reader, _ int64) error {
+			b, err := io.ReadAll(r)
+			objs[name] = string(b)
+			return err
+		})
+		return
+	}
+
+	It("should unpack multipart output", func() {
+		var (
+			body bytes.Buffer
+			mw   = multipart.NewWriter(&body)
+		)
+		for i, name := range names {
+			h := make(textproto.MIMEHeader)
+			if i == 0 {
+				h.Set("Content-Disposition", `form-data; name="`+name+`"`)
+			} else {
+				h.Set("Content-Disposition", `attachment; filename="`+name+`"`)
+			}
+			w, err := mw.CreatePart(h)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = w.Write([]byte(datas[i]))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(mw.Close()).NotTo(HaveOccurred())
+
+		objs, err := walk(OutputMultipart, &body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objs).To(Equal(map[string]string{
+			"frame-0001.jpg": "first", "frames/frame-0002.jpg": "second", "frame-0003.jpg": "",
+		}))
+
+		_, err = walk(OutputMultipart, bytes.NewReader([]byte("preamble\r\n--boundary\r\n")))
+		Expect(err).To(MatchError(errNoBoundary))
+	})
+
+	It("should unpack tar output", func() {
+		var (
+			body bytes.Buffer
+			tw   = tar.NewWriter(&body)
+		)
+		Expect(tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: "frames/", Mode: 0o755})).NotTo(HaveOccurred())
+		for i, name := range names {
+			hdr := &tar.Header{Typeflag: tar.TypeReg, Name: name, Size: int64(len(datas[i])), Mode: 0o644}
+			Expect(tw.WriteHeader(hdr)).NotTo(HaveOccurred())
+			_, err := tw.Write([]byte(datas[i]))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(tw.Close()).NotTo(HaveOccurred())
+
+		objs, err := walk(OutputTar, &body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objs).To(HaveLen(len(names)))
+		Expect(objs["frames/frame-0002.jpg"]).To(Equal("second"))
+	})
+
+	It("should reject names escaping the destination", func() {
+		for _, name := range []string{"", ".", "/etc/passwd", "..", "../a", "a/../../b"} {
+			_, err := outputName(name)
+			Expect(err).To(HaveOccurred(), name)
+		}
+		name, err := outputName("a/../b/./c")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(name).To(Equal("b/c"))
+	})
+})
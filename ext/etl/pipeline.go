@@ -0,0 +1,350 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Pipelines
+//
+// A pipeline references already initialized ETLs (stages). The first stage transforms the object
+// (same as it would do when used alone); each subsequent stage gets the output of the previous one:
+// * push-based stages (`hpush://`, `io://`) receive it in the request body;
+// * pull-based stages (`hpull://`, `hrev://`) fetch it from the target - the same way they fetch
+//   objects, via `AIS_TARGET_URL` - under a "staged" name (see `stagedStreams.add`) that the target
+//   serves from memory (see `ServeStaged`); the name contains random (unguessable) per-request token,
+//   and the target serves it only once and only to the stage's own transformer;
+// * in-process stages read it directly.
+// In all cases the output is streamed from one stage into the next - intermediate results are
+// never written to disk.
+//
+// Stages are looked up by name for every transformed object - stopping any of them makes the
+// pipeline fail (with "not found") until the stage is restarted.
+
+// (see stagedStreams.add)
+const stagedPrefix = ".etl-staged/"
+
+type (
+	// implemented by all communicators except pipelines (no nesting)
+	stageComm interface {
+		// transform (and close) the output of the previous stage
+		transformStream(r cos.ReadCloseSizer, bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error)
+	}
+
+	pipelineComm struct {
+		baseComm
+		stages []string
+		mem    *memsys.MMSA
+	}
+
+	stagedStreams struct {
+		m   map[string]*stagedStream
+		mtx sync.Mutex
+	}
+	stagedStream struct {
+		r     cos.ReadCloseSizer
+		peers []string // transformer's addresses (none: local transformer)
+	}
+)
+
+// interface guard
+var (
+	_ Communicator = (*pipelineComm)(nil)
+
+	_ stageComm = (*pushComm)(nil)
+	_ stageComm = (*redirectComm)(nil)
+	_ stageComm = (*revProxyComm)(nil)
+	_ stageComm = (*inprocComm)(nil)
+)
+
+var staged = &stagedStreams{m: make(map[string]*stagedStream)}
+
+func InitPipeline(t cluster.Target, msg *InitPipelineMsg, xid string) (err error) {
+	var (
+		errCtx = &cmn.ETLErrCtx{TID: t.SID(), ETLName: msg.IDX}
+		output = msg.Output
+	)
+	for _, stage := range msg.Stages {
+		c, err := GetCommunicator(stage, t.Snode())
+		if err != nil {
+			return cmn.NewErrETL(errCtx, "stage %v", err)
+		}
+		if _, ok := c.(stageComm); !ok {
+			return cmn.NewErrETL(errCtx, "stage %s cannot be a pipeline", c)
+		}
+		if msg.Output == "" {
+			output = c.Output() // unless specified, same as the last stage
+		}
+	}
+
+	boot := &etlBootstrapper{errCtx: errCtx, t: t}
+	boot.msg.InitMsgBase = msg.InitMsgBase
+	boot.setupXaction(xid)
+
+	c := &pipelineComm{
+		baseComm: baseComm{
+			Slistener: newAborter(t, msg.IDX),
+			t:         t,
+			xctn:      boot.xctn,
+			name:      msg.IDX,
+			commType:  Pipeline,
+			output:    output,
+		},
+		stages: msg.Stages,
+		mem:    t.PageMM(),
+	}
+	if err = reg.add(msg.IDX, c); err != nil {
+		return
+	}
+	t.Sowner().Listeners().Reg(c)
+	return
+}
+
+//////////////////
+// pipelineComm //
+//////////////////
+
+func (pc *pipelineComm) OnlineTransform(w http.ResponseWriter, _ *http.Request, bck *cluster.Bck, objName string) error {
+	r, err := pc.OfflineTransform(bck, objName, 0 /*timeout*/)
+	if err != nil {
+		return err
+	}
+	buf, slab := pc.mem.Alloc()
+	_, err = io.CopyBuffer(w, r, buf)
+	slab.Free(buf)
+	r.Close()
+	return err
+}
+
+func (pc *pipelineComm) OfflineTransform(bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if err := pc.xctn.AbortErr(); err != nil {
+		return nil, cmn.NewErrAborted(pc.String(), "pipeline", err)
+	}
+	comms := make([]Communicator, 0, len(pc.stages))
+	for _, stage := range pc.stages {
+		c, err := GetCommunicator(stage, pc.t.Snode())
+		if err != nil {
+			return nil, fmt.Errorf("%s: stage %v", pc, err)
+		}
+		comms = append(comms, c)
+	}
+	size, err := determineSize(bck, objName)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := comms[0].OfflineTransform(bck, objName, timeout)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range comms[1:] {
+		s := c.(stageComm) // (checked at init time; stages cannot be reinitialized as pipelines while in use)
+		if r, err = s.transformStream(r, bck, objName, timeout); err != nil {
+			return nil, fmt.Errorf("%s: stage %s: %v", pc, c.Name(), err)
+		}
+	}
+	return cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:       r,
+		Size:    r.Size(),
+		ReadCb:  func(i int, _ error) { pc.xctn.OutObjsAdd(1, int64(i)) },
+		DeferCb: func() { pc.xctn.InObjsAdd(1, size) },
+	}), nil
+}
+
+//
+// stageComm implementations
+//
+
+func (pc *pushComm) transformStream(r cos.ReadCloseSizer, bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if err := pc.xctn.AbortErr(); err != nil {
+		r.Close()
+		return nil, cmn.NewErrAborted(pc.String(), "stage", err)
+	}
+	return pc.put(r, r.Size(), bck.Name, objName, timeout)
+}
+
+func (rc *redirectComm) transformStream(r cos.ReadCloseSizer, bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	return rc.stagedGet(rc.uri, r, bck, objName, timeout)
+}
+
+func (pc *revProxyComm) transformStream(r cos.ReadCloseSizer, bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	return pc.stagedGet(pc.uri, r, bck, objName, timeout)
+}
+
+// the transformer gets "staged" object and fetches it from the target (see ServeStaged)
+func (c *baseComm) stagedGet(uri string, r cos.ReadCloseSizer, bck *cluster.Bck, objName string,
+	timeout time.Duration) (cos.ReadCloseSizer, error) {
+	name, err := staged.add(r, objName, c.peers)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	etlURL := cos.JoinPath(uri, transformerPath(bck, name))
+	rr, err := c.getWithTimeout(etlURL, cos.MaxI64(r.Size(), 0), timeout, "stage" /*tag*/)
+	if err != nil {
+		staged.del(name)
+		return nil, err
+	}
+	return cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:       rr,
+		Size:    rr.Size(),
+		DeferCb: func() { staged.del(name) },
+	}), nil
+}
+
+func (c *inprocComm) transformStream(r cos.ReadCloseSizer, _ *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if err := c.xctn.AbortErr(); err != nil {
+		r.Close()
+		return nil, cmn.NewErrAborted(c.String(), "stage", err)
+	}
+	if timeout == 0 || timeout > c.timeout {
+		timeout = c.timeout
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		var (
+			g  = &ioGuard{ctx: ctx}
+			cr = &ctxReader{g: g, r: r}
+			lw = &limitWriter{g: g, w: pw} // (streaming - unlimited)
+		)
+		err := c._transform(g, cr, lw)
+		if err == nil {
+			err = ctx.Err()
+		}
+		cancel()
+		r.Close()
+		if err == nil {
+			c.xctn.InObjsAdd(1, cos.MaxI64(r.Size(), 0))
+			c.xctn.OutObjsAdd(1, lw.n)
+		} else {
+			err = fmt.Errorf("%s: %s: %v", c, objName, err)
+		}
+		pw.CloseWithError(err) // (nil => io.EOF)
+	}()
+	return cos.NewReaderWithArgs(cos.ReaderArgs{R: pr, Size: -1}), nil
+}
+
+///////////////////
+// stagedStreams //
+///////////////////
+
+// e.g. ".etl-staged/8c6f0e5c0b1d4f3a9e2d7b6a5c4f3e2d/images/001.jpg" - preserving the original name
+// (and extension) for the transformer
+func (s *stagedStreams) add(r cos.ReadCloseSizer, objName string, peers []string) (string, error) {
+	var token [16]byte
+	if _, err := rand.Read(token[:]); err != nil {
+		return "", err
+	}
+	name := stagedPrefix + hex.EncodeToString(token[:]) + "/" + objName
+	s.mtx.Lock()
+	s.m[name] = &stagedStream{r: r, peers: peers}
+	s.mtx.Unlock()
+	return name, nil
+}
+
+// removes (and returns) the stream - each staged stream can be read only once
+func (s *stagedStreams) take(name string) (r cos.ReadCloseSizer) {
+	s.mtx.Lock()
+	if ss := s.m[name]; ss != nil {
+		r = ss.r
+		delete(s.m, name)
+	}
+	s.mtx.Unlock()
+	return
+}
+
+func (s *stagedStreams) get(name string) (ss *stagedStream) {
+	s.mtx.Lock()
+	ss = s.m[name]
+	s.mtx.Unlock()
+	return
+}
+
+func (s *stagedStreams) del(name string) {
+	if r := s.take(name); r != nil {
+		r.Close()
+	}
+}
+
+// ServeStaged handles GET and HEAD requests from pull-based pipeline stages (see stagedGet);
+// returns false if the object is not staged.
+func ServeStaged(w http.ResponseWriter, r *http.Request, objName string) bool {
+	if !strings.HasPrefix(objName, stagedPrefix) {
+		return false
+	}
+	ss := staged.get(objName)
+	if ss == nil {
+		cmn.WriteErr(w, r, cmn.NewErrNotFound("staged object %q (or already read)", objName), http.StatusNotFound)
+		return true
+	}
+	if !ss.fromTransformer(r) {
+		cmn.WriteErr(w, r, fmt.Errorf("staged object %q: unrecognized request source %q", objName, r.RemoteAddr),
+			http.StatusForbidden)
+		return true
+	}
+	if r.Method == http.MethodHead {
+		if size := ss.r.Size(); size >= 0 {
+			w.Header().Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
+		}
+		return true
+	}
+	sr := staged.take(objName)
+	if sr == nil {
+		cmn.WriteErr(w, r, cmn.NewErrNotFound("staged object %q (or already read)", objName), http.StatusNotFound)
+		return true
+	}
+	if size := sr.Size(); size >= 0 {
+		w.Header().Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
+	}
+	io.Copy(w, sr)
+	sr.Close()
+	return true
+}
+
+// staged streams are served only to the transformer (of the stage) itself:
+// K8s pod (see `etlBootstrapper.setupConnection`) or local process/container
+func (ss *stagedStream) fromTransformer(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	if ip.IsLoopback() {
+		return true
+	}
+	if len(ss.peers) > 0 {
+		return cos.StringInSlice(host, ss.peers)
+	}
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return false
+	}
+	for _, addr := range addrs {
+		if ipnet, ok := addr.(*net.IPNet); ok && ipnet.IP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -147,6 +147,9 @@ func (e *Aborter) ListenSmapChanged() {
 
 // (common for both `InitCode` and `InitSpec` flows)
 func InitSpec(t cluster.Target, msg *InitSpecMsg, etlName string, opts StartOpts) error {
+	if k8s.Detect() != nil {
+		return startLocal(t, msg, etlName, opts) // see local.go
+	}
 	errCtx, podName, svcName, err := start(t, msg, etlName, opts)
 	if err != nil {
 		glog.Warning(cmn.NewErrETL(errCtx, "%s: cleanup after unsuccessful Start", t))
@@ -171,10 +174,16 @@ func InitCode(t cluster.Target, msg *InitCodeMsg, xid string) error {
 
 	podSpec := replacer.Replace(r.PodSpec())
 
+	// pre-built runtimes are container images
+	base := msg.InitMsgBase
+	if base.Local == "" {
+		base.Local = LocalContainer
+	}
+
 	// Start ETL
 	// (the point where InitCode flow converges w/ InitSpec)
 	return InitSpec(t,
-		&InitSpecMsg{msg.InitMsgBase, []byte(podSpec)},
+		&InitSpecMsg{base, []byte(podSpec)},
 		xid,
 		StartOpts{Env: map[string]string{
 			r.CodeEnvName(): string(msg.Code),
@@ -312,7 +321,9 @@ func Stop(t cluster.Target, id string, errCause error) error {
 	errCtx.PodName = c.PodName()
 	errCtx.SvcName = c.SvcName()
 
-	if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
+	if lr := lreg.del(id); lr != nil {
+		lr.stop()
+	} else if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
 		return err
 	}
 
@@ -327,9 +338,6 @@ func Stop(t cluster.Target, id string, errCause error) error {
 
 // StopAll terminates all running ETLs.
 func StopAll(t cluster.Target) {
-	if k8s.Detect() != nil {
-		return
-	}
 	for _, e := range List() {
 		if err := Stop(t, e.Name, nil); err != nil {
 			glog.Error(err)
@@ -347,11 +355,20 @@ func GetCommunicator(etlName string, lsnode *cluster.Snode) (Communicator, error
 
 func List() []Info { return reg.list() }
 
+// in-process transformers and pipelines
+func isPodless(c Communicator) bool { return c.PodName() == "" }
+
 func PodLogs(t cluster.Target, transformID string) (logs Logs, err error) {
 	c, err := GetCommunicator(transformID, t.Snode())
 	if err != nil {
 		return logs, err
 	}
+	if lr, ok := lreg.get(transformID); ok {
+		return Logs{TargetID: t.SID(), Logs: lr.logs.get()}, nil
+	}
+	if isPodless(c) {
+		return Logs{TargetID: t.SID()}, nil // (see target's log)
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return logs, err
@@ -371,6 +388,12 @@ func PodHealth(t cluster.Target, etlName string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if lr, ok := lreg.get(etlName); ok {
+		return lr.health(), nil
+	}
+	if isPodless(c) {
+		return HealthStatusRunning, nil
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return "", err
@@ -383,6 +406,12 @@ func PodMetrics(t cluster.Target, etlName string) (*CPUMemUsed, error) {
 	if err != nil {
 		return nil, err
 	}
+	if lr, ok := lreg.get(etlName); ok {
+		return lr.metrics()
+	}
+	if isPodless(c) {
+		return nil, cmn.NewErrUnsupp("get metrics of", c.String())
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return nil, err
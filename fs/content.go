@@ -33,12 +33,18 @@ import (
 const (
 	contentTypeLen = 2
 
-	ObjectType   = "ob"
-	WorkfileType = "wk"
-	ECSliceType  = "ec"
-	ECMetaType   = "mt"
+	ObjectType     = "ob"
+	WorkfileType   = "wk"
+	ECSliceType    = "ec"
+	ECMetaType     = "mt"
+	ObjVersionType = "ov" // prior (retained) versions of ais objects
+	TrashType      = "tr" // deleted (soft-deleted) ais objects, see cmn.TrashConf
+	WriteBackType  = "wb" // zero-length markers of objects pending upload, see apc.WriteBack
+	PartialDlType  = "dl" // partially downloaded objects (resumable downloads), see ext/dload
 )
 
+const objVersionSepa = ".v"
+
 type (
 	ContentResolver interface {
 		// When set to true, services like rebalance have permission to move
@@ -163,10 +169,14 @@ func (f *contentSpecMgr) PermToProcess(fqn string) (ok bool) {
 // FIXME: This should be probably placed somewhere else \/
 
 type (
-	ObjectContentResolver   struct{}
-	WorkfileContentResolver struct{}
-	ECSliceContentResolver  struct{}
-	ECMetaContentResolver   struct{}
+	ObjectContentResolver     struct{}
+	WorkfileContentResolver   struct{}
+	ECSliceContentResolver    struct{}
+	ECMetaContentResolver     struct{}
+	ObjVersionContentResolver struct{}
+	TrashContentResolver      struct{}
+	WriteBackContentResolver  struct{}
+	PartialDlContentResolver  struct{}
 )
 
 func (*ObjectContentResolver) PermToMove() bool                   { return true }
@@ -235,3 +245,66 @@ func (*ECMetaContentResolver) GenUniqueFQN(base, _ string) string { return base
 func (*ECMetaContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
 	return base, false, true
 }
+
+func (*ObjVersionContentResolver) PermToMove() bool    { return false }
+func (*ObjVersionContentResolver) PermToEvict() bool   { return true }
+func (*ObjVersionContentResolver) PermToProcess() bool { return false }
+
+// prefix is the (numeric) version, e.g. "a/b/c" version 3 => "a/b/c.v3"
+func (*ObjVersionContentResolver) GenUniqueFQN(base, prefix string) string {
+	return base + objVersionSepa + prefix
+}
+
+func (*ObjVersionContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	orig, _, ok = ParseObjVersion(base)
+	return
+}
+
+// ParseObjVersion splits the base name of a prior version into object name (or its
+// base name) and version (see ObjVersionContentResolver.GenUniqueFQN)
+func ParseObjVersion(base string) (orig, ver string, ok bool) {
+	i := strings.LastIndex(base, objVersionSepa)
+	if i <= 0 {
+		return
+	}
+	ver = base[i+len(objVersionSepa):]
+	if _, err := strconv.ParseUint(ver, 10, 64); err != nil {
+		return "", "", false
+	}
+	return base[:i], ver, true
+}
+
+func (*TrashContentResolver) PermToMove() bool                   { return false }
+func (*TrashContentResolver) PermToEvict() bool                  { return true }
+func (*TrashContentResolver) PermToProcess() bool                { return false }
+func (*TrashContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*TrashContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
+
+func (*WriteBackContentResolver) PermToMove() bool                   { return false }
+func (*WriteBackContentResolver) PermToEvict() bool                  { return false }
+func (*WriteBackContentResolver) PermToProcess() bool                { return false }
+func (*WriteBackContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*WriteBackContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
+
+func (*PartialDlContentResolver) PermToMove() bool    { return false }
+func (*PartialDlContentResolver) PermToEvict() bool   { return true }
+func (*PartialDlContentResolver) PermToProcess() bool { return false }
+
+// prefix is the download job ID (so that the next attempt could find and resume it)
+func (*PartialDlContentResolver) GenUniqueFQN(base, prefix string) string {
+	return base + "." + prefix
+}
+
+func (*PartialDlContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	i := strings.LastIndex(base, ".")
+	if i <= 0 {
+		return "", false, false
+	}
+	return base[:i], false, true
+}
@@ -16,7 +16,8 @@ import (
 	"github.com/NVIDIA/aistore/cmn/mono"
 )
 
-// TODO: undelete (feature)
+// NOTE: removed directories only; soft-deleted objects (that can be undeleted) are kept
+// separately - see TrashType and cmn.TrashConf
 
 const deletedRoot = ".$deleted"
 
@@ -57,6 +57,7 @@ type (
 		capacity   Capacity
 		flags      uint64 // bit flags (set/get atomic)
 		PathDigest uint64 // (HRW logic)
+		Tier       int    // storage tier (see cmn.FSPConf and tier.go)
 		cmu        sync.RWMutex
 	}
 	MPI map[string]*Mountpath
@@ -120,6 +121,7 @@ func NewMountpath(mpath string) (mi *Mountpath, err error) {
 		Path:       cleanMpath,
 		FS:         fsInfo,
 		PathDigest: xxhash.ChecksumString64S(cleanMpath, cos.MLCG32),
+		Tier:       cmn.GCO.Get().FSP.Tiers[cleanMpath],
 	}
 	mi.bpc.m = make(map[uint64]string, 16)
 	return
@@ -144,6 +146,9 @@ func (mi *Mountpath) String() string {
 		default:
 			mi.info = fmt.Sprintf("mp[%s, %v]", mi.Path, mi.Disks)
 		}
+		if mi.Tier != 0 {
+			mi.info = mi.info[:len(mi.info)-1] + fmt.Sprintf(", tier=%d]", mi.Tier)
+		}
 	}
 	if !mi.IsAnySet(FlagWaitingDD) {
 		return mi.info
@@ -0,0 +1,83 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"sort"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Storage tiers: mountpaths may be configured with a tier (see cmn.FSPConf) where
+// zero (default) denotes the fastest storage (e.g., NVMe), and higher numbers - slower
+// and usually bigger media (e.g., HDD).
+//
+// When a target has more than one tier, objects of the buckets with tiering enabled
+// (see cmn.TieringConf) are written into the fastest ("hot") tier and get demoted to
+// slower tiers when not accessed for a while - and promoted back upon access.
+
+// Tiers returns (sorted) storage tiers of the available mountpaths
+func Tiers() (tiers []int) {
+	availablePaths := GetAvail()
+outer:
+	for _, mi := range availablePaths {
+		if mi.IsAnySet(FlagWaitingDD) {
+			continue
+		}
+		for _, tier := range tiers {
+			if tier == mi.Tier {
+				continue outer
+			}
+		}
+		tiers = append(tiers, mi.Tier)
+	}
+	sort.Ints(tiers)
+	return
+}
+
+// NumTiers returns the number of distinct storage tiers
+func NumTiers() int {
+	var (
+		availablePaths = GetAvail()
+		first, n       = -1, 0
+	)
+	for _, mi := range availablePaths {
+		if mi.IsAnySet(FlagWaitingDD) {
+			continue
+		}
+		switch {
+		case first < 0:
+			first, n = mi.Tier, 1
+		case mi.Tier != first:
+			return len(Tiers())
+		}
+	}
+	return n
+}
+
+// HotTier returns the fastest storage tier of the available mountpaths
+func HotTier() (tier int) {
+	tier = -1
+	availablePaths := GetAvail()
+	for _, mi := range availablePaths {
+		if mi.IsAnySet(FlagWaitingDD) {
+			continue
+		}
+		if tier < 0 || mi.Tier < tier {
+			tier = mi.Tier
+		}
+	}
+	return cos.Max(tier, 0)
+}
+
+// NextTier returns the next (slower) storage tier, if available
+func NextTier(tier int) (next int, ok bool) {
+	for _, t := range Tiers() {
+		if t > tier {
+			return t, true
+		}
+	}
+	return
+}
@@ -0,0 +1,153 @@
+// Package mirror provides local mirroring and replica management
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package mirror
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// XactTierPromote moves accessed objects of a tiered bucket back into the hot storage tier
+// (see cmn.TieringConf and, for demotion, space.XactTier)
+
+type (
+	tierPromoteFactory struct {
+		xreg.RenewBase
+		xctn *XactTierPromote
+		lom  *cluster.LOM
+	}
+	XactTierPromote struct {
+		// implements cluster.Xact interface
+		xact.DemandBase
+		// runtime
+		workers *mpather.WorkerGroup
+		burst   int
+		total   atomic.Int64
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact   = (*XactTierPromote)(nil)
+	_ xreg.Renewable = (*tierPromoteFactory)(nil)
+)
+
+////////////////////////
+// tierPromoteFactory //
+////////////////////////
+
+func (*tierPromoteFactory) New(args xreg.Args, bck *cluster.Bck) xreg.Renewable {
+	p := &tierPromoteFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}, lom: args.Custom.(*cluster.LOM)}
+	return p
+}
+
+func (p *tierPromoteFactory) Start() error {
+	slab, err := p.T.PageMM().GetSlab(memsys.MaxPageSlabSize)
+	cos.AssertNoErr(err)
+	burst := cos.Max(p.lom.MirrorConf().Burst, 1)
+	r := &XactTierPromote{burst: burst}
+	r.DemandBase.Init(cos.GenUUID(), apc.ActTierPromote, p.lom.Bck(), 0 /*use default*/)
+	r.workers = mpather.NewWorkerGroup(&mpather.WorkerGroupOpts{
+		Callback:  r.workCb,
+		Slab:      slab,
+		QueueSize: burst,
+	})
+	p.xctn = r
+	go r.Run(nil)
+	return nil
+}
+
+func (*tierPromoteFactory) Kind() string        { return apc.ActTierPromote }
+func (p *tierPromoteFactory) Get() cluster.Xact { return p.xctn }
+
+func (p *tierPromoteFactory) WhenPrevIsRunning(xprev xreg.Renewable) (xreg.WPR, error) {
+	debug.Assertf(false, "%s vs %s", p.Str(p.Kind()), xprev) // xreg.usePrev() must've returned true
+	return xreg.WprUse, nil
+}
+
+/////////////////////
+// XactTierPromote //
+/////////////////////
+
+// mpather/worker callback (one worker per mountpath)
+func (r *XactTierPromote) workCb(lom *cluster.LOM, buf []byte) {
+	lom.Lock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err == nil && lom.ToPromote() {
+		if err = lom.MoveToTier(fs.HotTier(), buf); err == nil {
+			r.ObjsAdd(1, lom.SizeBytes())
+		} else {
+			glog.Errorf("%s: failed to promote %s: %v", r, lom, err)
+		}
+	}
+	lom.Unlock(true)
+	r.DecPending() // to support action renewal on-demand
+	cluster.FreeLOM(lom)
+}
+
+// control logic: stop and idle timer
+func (r *XactTierPromote) Run(*sync.WaitGroup) {
+	glog.Infoln(r.Name())
+	r.workers.Run()
+	for {
+		select {
+		case <-r.IdleTimer():
+			err := r.stop()
+			r.Finish(err)
+			return
+		case errCause := <-r.ChanAbort():
+			if err := r.stop(); err != nil {
+				glog.Errorf("%s aborted (cause %v): %v", r, errCause, err)
+			} else {
+				glog.Infof("%s aborted (cause %v)", r, errCause)
+			}
+			r.Finish(cmn.NewErrAborted(r.Name(), "", errCause))
+			return
+		}
+	}
+}
+
+// main method: promote a given (demoted) object unless too busy
+func (r *XactTierPromote) Promote(lom *cluster.LOM) {
+	debug.Assert(!r.Finished(), r.String())
+	r.total.Inc()
+	if int(r.Pending()) >= r.burst {
+		return // (will be promoted upon next access)
+	}
+	r.IncPending() // ref-count via base to support on-demand action
+	if ok := r.workers.Do(lom); !ok {
+		r.DecPending()
+		glog.Errorf("%s: failed to post %s work", r, lom)
+	}
+}
+
+func (r *XactTierPromote) stop() (err error) {
+	r.DemandBase.Stop()
+	if n := r.workers.Stop(); n > 0 {
+		r.SubPending(n)
+		err = fmt.Errorf("%s: dropped (ie., failed to promote) %d object%s", r, n, cos.Plural(n))
+	}
+	return err
+}
+
+func (r *XactTierPromote) Snap() (snap *cluster.Snap) {
+	snap = &cluster.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
@@ -29,6 +29,8 @@ import (
 
 // TODO: unify and refactor (lru, cleanup-store)
 
+const partialDlRetention = 24 * time.Hour
+
 type (
 	IniCln struct {
 		T       cluster.Target
@@ -315,9 +317,12 @@ func (j *clnJ) removeDeleted() (err error) {
 
 func (j *clnJ) jogBck() (size int64, err error) {
 	opts := &fs.WalkOpts{
-		Mi:       j.mi,
-		Bck:      j.bck,
-		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType},
+		Mi:  j.mi,
+		Bck: j.bck,
+		CTs: []string{
+			fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType, fs.ObjVersionType, fs.TrashType,
+			fs.WriteBackType, fs.PartialDlType,
+		},
 		Callback: j.walk,
 		Sorted:   false,
 	}
@@ -380,11 +385,75 @@ func (j *clnJ) visitCT(parsedFQN fs.ParsedFQN, fqn string) {
 			return
 		}
 		j.oldWork = append(j.oldWork, fqn)
+	case fs.ObjVersionType:
+		// prior versions of ais objects: remove those that are no longer retained
+		// (including versions of deleted objects)
+		if !j.isVersionRetained(parsedFQN, fqn) {
+			j.oldWork = append(j.oldWork, fqn)
+		}
+	case fs.TrashType:
+		// deleted ais objects: remove those that can no longer be undeleted
+		if !isTrashRetained(&j.bck, parsedFQN.ObjName, fqn, time.Unix(0, j.now)) {
+			j.oldWork = append(j.oldWork, fqn)
+		}
+	case fs.WriteBackType:
+		// write-back markers: remove those that outlived their objects
+		if j.isWriteBackStray(parsedFQN, fqn) {
+			j.oldWork = append(j.oldWork, fqn)
+		}
+	case fs.PartialDlType:
+		// partially downloaded objects: remove those that haven't been resumed for a while
+		// (ext/dload then restarts the download from scratch)
+		if finfo, err := os.Stat(fqn); err == nil && finfo.ModTime().Add(partialDlRetention).UnixNano() < j.now {
+			j.oldWork = append(j.oldWork, fqn)
+		}
 	default:
 		debug.Assertf(false, "Unsupported content type: %s", parsedFQN.ContentType)
 	}
 }
 
+func (j *clnJ) isWriteBackStray(parsedFQN fs.ParsedFQN, fqn string) bool {
+	finfo, err := os.Stat(fqn)
+	if err != nil || finfo.ModTime().UnixNano()+int64(j.config.LRU.DontEvictTime) > j.now {
+		return false // (being written, etc.)
+	}
+	lom := cluster.AllocLOM(parsedFQN.ObjName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(&j.bck); err != nil {
+		return false
+	}
+	if !lom.TryLock(false) {
+		return false
+	}
+	defer lom.Unlock(false)
+	err = lom.Load(false /*cache it*/, true /*locked*/)
+	return cmn.IsObjNotExist(err)
+}
+
+func (j *clnJ) isVersionRetained(parsedFQN fs.ParsedFQN, fqn string) bool {
+	objName, ver, ok := fs.ParseObjVersion(parsedFQN.ObjName)
+	if !ok {
+		return false
+	}
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		return true // (nothing to do)
+	}
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(&j.bck); err != nil {
+		return false
+	}
+	if !lom.TryLock(false) {
+		return true // being written - will check next time
+	}
+	defer lom.Unlock(false)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return !cmn.IsObjNotExist(err)
+	}
+	return lom.IsVersionRetained(ver, finfo.ModTime(), time.Unix(0, j.now))
+}
+
 // TODO: add stats error counters (stats.ErrLmetaCorruptedCount, ...)
 // TODO: revisit rm-ed byte counting
 func (j *clnJ) visitObj(fqn string) {
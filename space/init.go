@@ -16,6 +16,8 @@ var verbose bool
 func Xreg() {
 	xreg.RegNonBckXact(&lruFactory{})
 	xreg.RegNonBckXact(&clnFactory{})
+	xreg.RegNonBckXact(&lifeFactory{})
+	xreg.RegNonBckXact(&tierFactory{})
 
 	verbose = bool(glog.FastV(4, glog.SmoduleSpace))
 }
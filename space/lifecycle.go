@@ -0,0 +1,276 @@
+// Package space provides storage cleanup and eviction functionality (the latter based on the
+// least recently used cache replacement). It also serves as a built-in garbage-collection
+// mechanism for orphaned workfiles.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package space
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Lifecycle xaction periodically applies bucket lifecycle rules (`cmn.LifecycleConf`):
+//   - removes objects that are older than the rule's `ExpireAfter`, and
+//   - aborts incomplete S3 multipart uploads that are older than `AbortMptAfter`.
+//
+// Unlike LRU, lifecycle does not depend on capacity watermarks and runs
+// regardless of `LRUConf.Enabled`.
+
+type (
+	IniLife struct {
+		T       cluster.Target
+		Xaction *XactLife
+		Buckets []cmn.Bck // optional list of specific buckets (default: all buckets with lifecycle rules)
+		WG      *sync.WaitGroup
+		// abort incomplete multipart uploads older than `maxAge`; returns the number of aborted uploads
+		AbortMpt func(bck *cluster.Bck, prefix string, maxAge time.Duration) int
+		// remove expired object via the regular delete path (trash, quota, write-back);
+		// is called under w-lock with the object loaded
+		DelObj func(lom *cluster.LOM) error
+	}
+	XactLife struct {
+		xact.Base
+	}
+)
+
+// private
+type (
+	// lifeJ represents a single lifecycle /jogger/ that traverses a given mountpath
+	lifeJ struct {
+		bck    *cluster.Bck
+		now    time.Time
+		ini    *IniLife
+		stopCh chan struct{}
+		mi     *fs.Mountpath
+	}
+	lifeFactory struct {
+		xreg.RenewBase
+		xctn *XactLife
+	}
+)
+
+// interface guard
+var (
+	_ xreg.Renewable = (*lifeFactory)(nil)
+	_ cluster.Xact   = (*XactLife)(nil)
+)
+
+func (*XactLife) Run(*sync.WaitGroup) { debug.Assert(false) }
+
+func (r *XactLife) Snap() (snap *cluster.Snap) {
+	snap = &cluster.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
+
+/////////////////
+// lifeFactory //
+/////////////////
+
+func (*lifeFactory) New(args xreg.Args, _ *cluster.Bck) xreg.Renewable {
+	return &lifeFactory{RenewBase: xreg.RenewBase{Args: args}}
+}
+
+func (p *lifeFactory) Start() error {
+	p.xctn = &XactLife{}
+	p.xctn.InitBase(p.UUID(), apc.ActLifecycle, nil)
+	return nil
+}
+
+func (*lifeFactory) Kind() string        { return apc.ActLifecycle }
+func (p *lifeFactory) Get() cluster.Xact { return p.xctn }
+
+func (*lifeFactory) WhenPrevIsRunning(prevEntry xreg.Renewable) (wpr xreg.WPR, err error) {
+	return xreg.WprUse, cmn.NewErrUsePrevXaction(prevEntry.Get().String())
+}
+
+func RunLifecycle(ini *IniLife) {
+	var (
+		xlife          = ini.Xaction
+		availablePaths = fs.GetAvail()
+		bcks           = lifeBcks(ini)
+		wg             sync.WaitGroup
+	)
+	defer func() {
+		if ini.WG != nil {
+			ini.WG.Done()
+		}
+	}()
+	if len(availablePaths) == 0 {
+		glog.Warning(cmn.ErrNoMountpaths)
+		xlife.Finish(cmn.ErrNoMountpaths)
+		return
+	}
+	glog.Infof("%s started, %d bucket(s) with lifecycle rules", xlife, len(bcks))
+	if ini.WG != nil {
+		ini.WG.Done()
+		ini.WG = nil
+	}
+
+	// multipart uploads are tracked in memory (not per mountpath)
+	if ini.AbortMpt != nil {
+		for _, bck := range bcks {
+			for i := range bck.Props.Lifecycle.Rules {
+				rule := &bck.Props.Lifecycle.Rules[i]
+				if rule.Enabled && rule.AbortMptAfter > 0 {
+					if n := ini.AbortMpt(bck, rule.Prefix, rule.AbortMptAfter.D()); n > 0 {
+						glog.Infof("%s: %s rule %q: aborted %d multipart upload(s)", xlife, bck, rule.ID, n)
+					}
+				}
+			}
+		}
+	}
+
+	joggers := make([]*lifeJ, 0, len(availablePaths))
+	for _, mi := range availablePaths {
+		j := &lifeJ{ini: ini, mi: mi, stopCh: make(chan struct{}, 1)}
+		joggers = append(joggers, j)
+		wg.Add(1)
+		go j.run(bcks, &wg)
+	}
+	wg.Wait()
+	for _, j := range joggers {
+		j.stop()
+	}
+	xlife.Finish(nil)
+	glog.Infof("%s finished", xlife)
+}
+
+// buckets that have at least one enabled lifecycle rule
+func lifeBcks(ini *IniLife) (bcks []*cluster.Bck) {
+	if len(ini.Buckets) > 0 {
+		bowner := ini.T.Bowner()
+		for i := range ini.Buckets {
+			bck := cluster.CloneBck(&ini.Buckets[i])
+			if err := bck.Init(bowner); err != nil {
+				glog.Errorf("%s: %v", ini.Xaction, err)
+				continue
+			}
+			if bck.Props.Lifecycle.Enabled() {
+				bcks = append(bcks, bck)
+			}
+		}
+		return
+	}
+	ini.T.Bowner().Get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		if bck.Props.Lifecycle.Enabled() {
+			bcks = append(bcks, bck)
+		}
+		return false
+	})
+	return
+}
+
+///////////
+// lifeJ //
+///////////
+
+func (j *lifeJ) String() string { return fmt.Sprintf("%s: jog-%s", j.ini.Xaction, j.mi) }
+
+func (j *lifeJ) stop() { j.stopCh <- struct{}{} }
+
+func (j *lifeJ) run(bcks []*cluster.Bck, wg *sync.WaitGroup) {
+	defer wg.Done()
+	for _, bck := range bcks {
+		j.bck, j.now = bck, time.Now()
+		if bck.Allow(apc.AceObjDELETE) != nil {
+			glog.Warningf("%s: %s does not allow to delete objects - skipping", j, bck)
+			continue
+		}
+		opts := &fs.WalkOpts{
+			Mi:       j.mi,
+			Bck:      *bck.Bucket(),
+			CTs:      []string{fs.ObjectType},
+			Callback: j.walk,
+			Sorted:   false,
+		}
+		if err := fs.Walk(opts); err != nil {
+			if cmn.IsErrAborted(err) {
+				return
+			}
+			if !cmn.IsErrBucketNought(err) && !cmn.IsErrObjNought(err) {
+				glog.Errorf("%s: %v", j, err)
+			}
+		}
+	}
+}
+
+func (j *lifeJ) walk(fqn string, de fs.DirEntry) error {
+	if de.IsDir() {
+		return nil
+	}
+	if err := j.yieldTerm(); err != nil {
+		return err
+	}
+	lom := cluster.AllocLOM("")
+	j.visit(lom, fqn)
+	cluster.FreeLOM(lom)
+	return nil
+}
+
+func (j *lifeJ) visit(lom *cluster.LOM, fqn string) {
+	if err := lom.InitFQN(fqn, j.bck.Bucket()); err != nil {
+		return
+	}
+	if !lom.IsHRW() { // copies (and misplaced) are taken care of via the main replica
+		return
+	}
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		return
+	}
+	rule := j.bck.Props.Lifecycle.Expired(lom.ObjName, finfo.ModTime(), j.now)
+	if rule == nil {
+		return
+	}
+	if !lom.TryLock(true) {
+		return // busy - will try again next time
+	}
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return
+	}
+	if lom.CheckLocked() != nil {
+		return // retained or under legal hold
+	}
+	size := lom.SizeBytes()
+	if err := j.ini.DelObj(lom); err != nil {
+		glog.Errorf("%s: failed to remove %s: %v", j, lom, err)
+		return
+	}
+	if verbose {
+		glog.Infof("%s: rule %q: expired %s", j, rule.ID, lom)
+	}
+	j.ini.Xaction.ObjsAdd(1, size)
+}
+
+func (j *lifeJ) yieldTerm() error {
+	xlife := j.ini.Xaction
+	select {
+	case errCause := <-xlife.ChanAbort():
+		return cmn.NewErrAborted(xlife.Name(), "", errCause)
+	case <-j.stopCh:
+		return cmn.NewErrAborted(xlife.Name(), "", nil)
+	default:
+		break
+	}
+	if xlife.Finished() {
+		return cmn.NewErrAborted(xlife.Name(), "", nil)
+	}
+	return nil
+}
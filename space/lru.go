@@ -9,6 +9,7 @@ package space
 import (
 	"container/heap"
 	"fmt"
+	"os"
 	"sort"
 	"sync"
 	"time"
@@ -38,6 +39,9 @@ import (
 // runs automatically. In order to reduce its impact on the live workload, LRU throttles itself
 // in accordance with the current storage-target's utilization (see xaction_throttle.go).
 //
+// Objects that are expired as per bucket lifecycle rules (`cmn.LifecycleConf`) are
+// evicted first - regardless of their access times and `LRUConf.DontEvictTime`.
+//
 // There's only one API that this module provides to the rest of the code:
 //   - runLRU - to initiate a new LRU extended action on the local target
 // All other methods are private to this module and are used only internally.
@@ -84,6 +88,7 @@ type (
 		totalSize int64 // difference between lowWM size and used size
 		newest    int64
 		heap      *minHeap
+		expired   []*cluster.LOM // expired as per lifecycle rules - to evict first
 		bck       cmn.Bck
 		now       int64
 		// init-time
@@ -286,6 +291,7 @@ func (j *lruJ) jogBck() (size int64, err error) {
 	h := (*j.heap)[:0]
 	j.heap = &h
 	heap.Init(j.heap)
+	j.expired = j.expired[:0]
 
 	// 2. collect
 	opts := &fs.WalkOpts{
@@ -321,12 +327,24 @@ func (j *lruJ) _visit(lom *cluster.LOM) (pushed bool) {
 	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
 		return
 	}
-	if lom.AtimeUnix()+int64(j.config.LRU.DontEvictTime) > j.now {
+	expired := j.lifeExpired(lom)
+	if !expired && lom.AtimeUnix()+int64(j.config.LRU.DontEvictTime) > j.now {
 		return
 	}
 	if lom.HasCopies() && lom.IsCopy() {
 		return
 	}
+	if lom.CheckLocked() != nil {
+		return // object lock: retained or under legal hold
+	}
+	if lom.IsWriteBackPending() {
+		return // not yet written to the remote backend (see apc.WriteBack)
+	}
+	if expired {
+		j.expired = append(j.expired, lom)
+		j.curSize += lom.SizeBytes()
+		return true
+	}
 	// do nothing if the heap's curSize >= totalSize and
 	// the file is more recent then the the heap's newest.
 	if j.curSize >= j.totalSize && lom.AtimeUnix() > j.newest {
@@ -340,6 +358,16 @@ func (j *lruJ) _visit(lom *cluster.LOM) (pushed bool) {
 	return true
 }
 
+// (see `lifeJ` that removes expired objects periodically - independently of the capacity)
+func (j *lruJ) lifeExpired(lom *cluster.LOM) bool {
+	lc := &lom.Bprops().Lifecycle
+	if !lc.Enabled() {
+		return false
+	}
+	finfo, err := os.Stat(lom.FQN)
+	return err == nil && lc.Expired(lom.ObjName, finfo.ModTime(), time.Unix(0, j.now)) != nil
+}
+
 func (j *lruJ) walk(fqn string, de fs.DirEntry) error {
 	if de.IsDir() {
 		return nil
@@ -366,9 +394,14 @@ func (j *lruJ) evict() (size int64, err error) {
 		xlru               = j.ini.Xaction
 	)
 
-	// evict(sic!) and house-keep
-	for h.Len() > 0 && j.totalSize > 0 {
-		lom := heap.Pop(h).(*cluster.LOM)
+	// evict(sic!) and house-keep: expired first, and then the least recently used
+	for i := 0; j.totalSize > 0 && (i < len(j.expired) || h.Len() > 0); i++ {
+		var lom *cluster.LOM
+		if i < len(j.expired) {
+			lom, j.expired[i] = j.expired[i], nil
+		} else {
+			lom = heap.Pop(h).(*cluster.LOM)
+		}
 		if !evictObj(lom) {
 			cluster.FreeLOM(lom)
 			continue
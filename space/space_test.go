@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
@@ -36,6 +37,7 @@ const (
 	basePath             = "/tmp/space-tests"
 	bucketName           = "space-bck"
 	bucketNameAnother    = bucketName + "-another"
+	expiredPrefix        = "expired-"
 )
 
 type fileMetadata struct {
@@ -134,6 +136,36 @@ var _ = Describe("space evict/cleanup tests", func() {
 				}
 			})
 
+			It("should evict lifecycle-expired files first", func() {
+				const numberOfFiles = 6
+				config := cmn.GCO.BeginUpdate()
+				config.LRU.DontEvictTime = cos.Duration(5 * time.Minute)
+				cmn.GCO.CommitUpdate(config)
+
+				ini.GetFSStats = getMockGetFSStats(numberOfFiles)
+
+				expiredFiles := []fileMetadata{
+					{expiredPrefix + getRandomFileName(0), fileSize},
+					{expiredPrefix + getRandomFileName(1), fileSize},
+					{expiredPrefix + getRandomFileName(2), fileSize},
+				}
+				saveRandomFilesWithMetadata(filesPath, expiredFiles)
+				mtime := time.Now().Add(-2 * time.Hour)
+				for _, file := range expiredFiles {
+					Expect(os.Chtimes(path.Join(filesPath, file.name), mtime, mtime)).NotTo(HaveOccurred())
+				}
+				saveRandomFiles(filesPath, 3)
+
+				space.RunLRU(ini)
+
+				files, err := os.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(files)).To(Equal(3))
+				for _, file := range files {
+					Expect(strings.HasPrefix(file.Name(), expiredPrefix)).To(BeFalse())
+				}
+			})
+
 			It("should evict files of different sizes", func() {
 				const totalSize = 32 * cos.MiB
 				if testing.Short() {
@@ -320,8 +352,11 @@ func newTargetLRUMock() *mock.TargetMock {
 			cluster.NewBck(
 				bucketName, apc.AIS, cmn.NsGlobal,
 				&cmn.BucketProps{
-					Cksum:  cmn.CksumConf{Type: cos.ChecksumNone},
-					LRU:    cmn.LRUConf{Enabled: true},
+					Cksum: cmn.CksumConf{Type: cos.ChecksumNone},
+					LRU:   cmn.LRUConf{Enabled: true},
+					Lifecycle: cmn.LifecycleConf{Rules: []cmn.LifecycleRule{
+						{ID: "expire", Prefix: expiredPrefix, ExpireAfter: cos.Duration(time.Hour), Enabled: true},
+					}},
 					Access: apc.AccessAll,
 					BID:    0xa7b8c1d2,
 				},
@@ -0,0 +1,255 @@
+// Package space provides storage cleanup and eviction functionality (the latter based on the
+// least recently used cache replacement). It also serves as a built-in garbage-collection
+// mechanism for orphaned workfiles.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package space
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Tiering xaction periodically demotes objects of the tiered buckets (`cmn.TieringConf`)
+// that were not accessed for `DemoteAfter` - into the next (slower) storage tier.
+// Promotion (back into the hot tier) is done on demand, upon access - see mirror.XactTierPromote.
+//
+// Like lifecycle, tiering does not depend on capacity watermarks; it is a no-op
+// on a target with a single storage tier.
+
+type (
+	IniTier struct {
+		T       cluster.Target
+		Xaction *XactTier
+		Buckets []cmn.Bck // optional list of specific buckets (default: all tiered buckets)
+		WG      *sync.WaitGroup
+	}
+	XactTier struct {
+		xact.Base
+	}
+)
+
+// private
+type (
+	// tierJ represents a single tiering /jogger/ that traverses a given mountpath
+	tierJ struct {
+		bck    *cluster.Bck
+		now    time.Time
+		ini    *IniTier
+		stopCh chan struct{}
+		mi     *fs.Mountpath
+		buf    []byte
+	}
+	tierFactory struct {
+		xreg.RenewBase
+		xctn *XactTier
+	}
+)
+
+// interface guard
+var (
+	_ xreg.Renewable = (*tierFactory)(nil)
+	_ cluster.Xact   = (*XactTier)(nil)
+)
+
+func (*XactTier) Run(*sync.WaitGroup) { debug.Assert(false) }
+
+func (r *XactTier) Snap() (snap *cluster.Snap) {
+	snap = &cluster.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
+
+/////////////////
+// tierFactory //
+/////////////////
+
+func (*tierFactory) New(args xreg.Args, _ *cluster.Bck) xreg.Renewable {
+	return &tierFactory{RenewBase: xreg.RenewBase{Args: args}}
+}
+
+func (p *tierFactory) Start() error {
+	p.xctn = &XactTier{}
+	p.xctn.InitBase(p.UUID(), apc.ActTiering, nil)
+	return nil
+}
+
+func (*tierFactory) Kind() string        { return apc.ActTiering }
+func (p *tierFactory) Get() cluster.Xact { return p.xctn }
+
+func (*tierFactory) WhenPrevIsRunning(prevEntry xreg.Renewable) (wpr xreg.WPR, err error) {
+	return xreg.WprUse, cmn.NewErrUsePrevXaction(prevEntry.Get().String())
+}
+
+func RunTiering(ini *IniTier) {
+	var (
+		xtier          = ini.Xaction
+		availablePaths = fs.GetAvail()
+		wg             sync.WaitGroup
+	)
+	defer func() {
+		if ini.WG != nil {
+			ini.WG.Done()
+		}
+	}()
+	if len(availablePaths) == 0 {
+		glog.Warning(cmn.ErrNoMountpaths)
+		xtier.Finish(cmn.ErrNoMountpaths)
+		return
+	}
+	if fs.NumTiers() < 2 {
+		xtier.Finish(nil)
+		return
+	}
+	bcks := tierBcks(ini)
+	glog.Infof("%s started, %d tiered bucket(s)", xtier, len(bcks))
+	if ini.WG != nil {
+		ini.WG.Done()
+		ini.WG = nil
+	}
+	var (
+		joggers = make([]*tierJ, 0, len(availablePaths))
+		slab, _ = ini.T.PageMM().GetSlab(memsys.MaxPageSlabSize)
+	)
+	for _, mi := range availablePaths {
+		if _, ok := fs.NextTier(mi.Tier); !ok {
+			continue // nowhere to demote
+		}
+		j := &tierJ{ini: ini, mi: mi, stopCh: make(chan struct{}, 1), buf: slab.Alloc()}
+		joggers = append(joggers, j)
+		wg.Add(1)
+		go j.run(bcks, &wg)
+	}
+	wg.Wait()
+	for _, j := range joggers {
+		j.stop()
+		slab.Free(j.buf)
+	}
+	xtier.Finish(nil)
+	glog.Infof("%s finished", xtier)
+}
+
+func tierBcks(ini *IniTier) (bcks []*cluster.Bck) {
+	if len(ini.Buckets) > 0 {
+		bowner := ini.T.Bowner()
+		for i := range ini.Buckets {
+			bck := cluster.CloneBck(&ini.Buckets[i])
+			if err := bck.Init(bowner); err != nil {
+				glog.Errorf("%s: %v", ini.Xaction, err)
+				continue
+			}
+			if bck.Props.Tiering.Enabled {
+				bcks = append(bcks, bck)
+			}
+		}
+		return
+	}
+	ini.T.Bowner().Get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		if bck.Props.Tiering.Enabled {
+			bcks = append(bcks, bck)
+		}
+		return false
+	})
+	return
+}
+
+///////////
+// tierJ //
+///////////
+
+func (j *tierJ) String() string { return fmt.Sprintf("%s: jog-%s", j.ini.Xaction, j.mi) }
+
+func (j *tierJ) stop() { j.stopCh <- struct{}{} }
+
+func (j *tierJ) run(bcks []*cluster.Bck, wg *sync.WaitGroup) {
+	defer wg.Done()
+	for _, bck := range bcks {
+		j.bck, j.now = bck, time.Now()
+		opts := &fs.WalkOpts{
+			Mi:       j.mi,
+			Bck:      *bck.Bucket(),
+			CTs:      []string{fs.ObjectType},
+			Callback: j.walk,
+			Sorted:   false,
+		}
+		if err := fs.Walk(opts); err != nil {
+			if cmn.IsErrAborted(err) {
+				return
+			}
+			if !cmn.IsErrBucketNought(err) && !cmn.IsErrObjNought(err) {
+				glog.Errorf("%s: %v", j, err)
+			}
+		}
+	}
+}
+
+func (j *tierJ) walk(fqn string, de fs.DirEntry) error {
+	if de.IsDir() {
+		return nil
+	}
+	if err := j.yieldTerm(); err != nil {
+		return err
+	}
+	lom := cluster.AllocLOM("")
+	j.visit(lom, fqn)
+	cluster.FreeLOM(lom)
+	return nil
+}
+
+func (j *tierJ) visit(lom *cluster.LOM, fqn string) {
+	if err := lom.InitFQN(fqn, j.bck.Bucket()); err != nil {
+		return
+	}
+	if !lom.IsHRW() { // copies and misplaced objects stay put
+		return
+	}
+	if !lom.TryLock(true) {
+		return // busy - will try again next time
+	}
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return
+	}
+	tier, ok := lom.ToDemote(j.now)
+	if !ok {
+		return
+	}
+	if err := lom.MoveToTier(tier, j.buf); err != nil {
+		glog.Errorf("%s: failed to demote %s to tier %d: %v", j, lom, tier, err)
+		return
+	}
+	if verbose {
+		glog.Infof("%s: demoted %s", j, lom)
+	}
+	j.ini.Xaction.ObjsAdd(1, lom.SizeBytes())
+}
+
+func (j *tierJ) yieldTerm() error {
+	xtier := j.ini.Xaction
+	select {
+	case errCause := <-xtier.ChanAbort():
+		return cmn.NewErrAborted(xtier.Name(), "", errCause)
+	case <-j.stopCh:
+		return cmn.NewErrAborted(xtier.Name(), "", nil)
+	default:
+		break
+	}
+	if xtier.Finished() {
+		return cmn.NewErrAborted(xtier.Name(), "", nil)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Package space provides storage cleanup and eviction functionality (the latter based on the
+// least recently used cache replacement). It also serves as a built-in garbage-collection
+// mechanism for orphaned workfiles.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package space
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// trashJ purges a single mountpath
+type trashJ struct {
+	mi  *fs.Mountpath
+	bck *cluster.Bck
+	now time.Time
+	n   int
+}
+
+var purging atomic.Bool
+
+// PurgeTrash removes trashed objects that can no longer be undeleted (see cmn.TrashConf),
+// including those left in the buckets that have their trash disabled; runs one jogger
+// per mountpath and returns upon completion (no-op if already running)
+func PurgeTrash(t cluster.Target) {
+	if !purging.CAS(false, true) {
+		return
+	}
+	defer purging.Store(false)
+	var (
+		bcks     []*cluster.Bck
+		provider = apc.AIS
+	)
+	t.Bowner().Get().Range(&provider, nil, func(bck *cluster.Bck) bool {
+		bcks = append(bcks, bck)
+		return false
+	})
+	if len(bcks) == 0 {
+		return
+	}
+	var (
+		wg             = &sync.WaitGroup{}
+		availablePaths = fs.GetAvail()
+		now            = time.Now()
+	)
+	for _, mi := range availablePaths {
+		wg.Add(1)
+		j := &trashJ{mi: mi, now: now}
+		go j.run(bcks, wg)
+	}
+	wg.Wait()
+}
+
+func (j *trashJ) String() string { return fmt.Sprintf("purge-trash: jog-%s", j.mi) }
+
+func (j *trashJ) run(bcks []*cluster.Bck, wg *sync.WaitGroup) {
+	defer wg.Done()
+	for _, bck := range bcks {
+		j.bck = bck
+		opts := &fs.WalkOpts{
+			Mi:       j.mi,
+			Bck:      *bck.Bucket(),
+			CTs:      []string{fs.TrashType},
+			Callback: j.walk,
+			Sorted:   false,
+		}
+		if err := fs.Walk(opts); err != nil {
+			if !cmn.IsErrBucketNought(err) && !cmn.IsErrObjNought(err) && !os.IsNotExist(err) {
+				glog.Errorf("%s: %v", j, err)
+			}
+		}
+	}
+	if j.n > 0 {
+		glog.Infof("%s: purged %d", j, j.n)
+	}
+}
+
+func (j *trashJ) walk(fqn string, de fs.DirEntry) error {
+	if de.IsDir() {
+		return nil
+	}
+	parsedFQN, err := fs.ParseFQN(fqn)
+	if err != nil {
+		return nil
+	}
+	if isTrashRetained(j.bck.Bucket(), parsedFQN.ObjName, fqn, j.now) {
+		return nil
+	}
+	if err := cos.RemoveFile(fqn); err != nil {
+		glog.Errorf("%s: %v", j, err)
+	} else {
+		j.n++
+	}
+	return nil
+}
+
+// (compare with clnJ.isVersionRetained)
+func isTrashRetained(bck *cmn.Bck, objName, fqn string, now time.Time) bool {
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		return true // (nothing to do)
+	}
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(bck); err != nil {
+		return false
+	}
+	if !lom.TryLock(false) {
+		return true // being undeleted, etc. - will check next time
+	}
+	defer lom.Unlock(false)
+	return lom.IsTrashRetained(finfo.ModTime(), now)
+}
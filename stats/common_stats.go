@@ -355,7 +355,7 @@ func (s *coreStats) doAdd(name, nameSuffix string, val int64) {
 		v.cumulative += val
 		v.Value += val
 		v.mu.Unlock()
-	case KindCounter, KindSize:
+	case KindCounter, KindSize, KindGauge:
 		// NOTE: not locking (KindCounter isn't compound, making an exception to speed-up)
 		ratomic.AddInt64(&v.Value, val)
 
@@ -517,9 +517,9 @@ func (s *coreStats) reset(errorsOnly bool) {
 			v.mu.Lock()
 			v.Value, v.cumulative = 0, 0
 			v.mu.Unlock()
-		case KindCounter, KindSize, KindComputedThroughput, KindGauge:
+		case KindCounter, KindSize, KindComputedThroughput:
 			ratomic.StoreInt64(&v.Value, 0)
-		default: // KindSpecial - do nothing
+		default: // KindSpecial and KindGauge (current state) - do nothing
 		}
 	}
 }
@@ -45,6 +45,10 @@ const (
 	VerChangeCount = "ver.change.n"
 	VerChangeSize  = "ver.change.size"
 
+	// write-back (see apc.WriteBack)
+	WriteBackCount = "wb.n"
+	WriteBackSize  = "wb.size"
+
 	// intra-cluster transmit & receive
 	StreamsOutObjCount = transport.OutObjCount
 	StreamsOutObjSize  = transport.OutObjSize
@@ -52,10 +56,15 @@ const (
 	StreamsInObjSize   = transport.InObjSize
 
 	// errors
-	ErrCksumCount    = "err.cksum.n"
-	ErrCksumSize     = "err.cksum.size"
-	ErrMetadataCount = "err.md.n"
-	ErrIOCount       = "err.io.n"
+	ErrCksumCount     = "err.cksum.n"
+	ErrCksumSize      = "err.cksum.size"
+	ErrMetadataCount  = "err.md.n"
+	ErrIOCount        = "err.io.n"
+	ErrWriteBackCount = "err.wb.n"
+
+	// KindGauge
+	WriteBackPending = "wb.pending" // objects queued for (asynchronous) upload
+
 	// special
 	RestartCount = "restart.n"
 
@@ -192,6 +201,10 @@ func (r *Trunner) RegMetrics(node *cluster.Snode) {
 	r.reg(VerChangeCount, KindCounter)
 	r.reg(VerChangeSize, KindSize)
 
+	r.reg(WriteBackCount, KindCounter)
+	r.reg(WriteBackSize, KindSize)
+	r.reg(WriteBackPending, KindGauge)
+
 	r.reg(PutLatency, KindLatency)
 	r.reg(AppendLatency, KindLatency)
 	r.reg(GetRedirLatency, KindLatency)
@@ -210,6 +223,7 @@ func (r *Trunner) RegMetrics(node *cluster.Snode) {
 
 	r.reg(ErrMetadataCount, KindCounter)
 	r.reg(ErrIOCount, KindCounter)
+	r.reg(ErrWriteBackCount, KindCounter)
 
 	// streams
 	r.reg(StreamsOutObjCount, KindCounter)
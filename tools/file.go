@@ -151,6 +151,10 @@ func PrepareObjects(t *testing.T, desc ObjectsDesc) *ObjectsOut {
 	_ = fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
 	_ = fs.CSM.Reg(fs.ECSliceType, &fs.ECSliceContentResolver{})
 	_ = fs.CSM.Reg(fs.ECMetaType, &fs.ECMetaContentResolver{})
+	_ = fs.CSM.Reg(fs.ObjVersionType, &fs.ObjVersionContentResolver{})
+	_ = fs.CSM.Reg(fs.TrashType, &fs.TrashContentResolver{})
+	_ = fs.CSM.Reg(fs.WriteBackType, &fs.WriteBackContentResolver{})
+	_ = fs.CSM.Reg(fs.PartialDlType, &fs.PartialDlContentResolver{})
 
 	dir := t.TempDir()
 
@@ -113,6 +113,8 @@ var Table = map[string]Descriptor{
 	// (one bucket) | (all buckets)
 	apc.ActLRU:          {DisplayName: "lru-eviction", Scope: ScopeGB, Startable: true, Mountpath: true},
 	apc.ActStoreCleanup: {DisplayName: "cleanup", Scope: ScopeGB, Startable: true, Mountpath: true},
+	apc.ActLifecycle:    {Scope: ScopeGB, Startable: true, Mountpath: true},
+	apc.ActTiering:      {Scope: ScopeGB, Startable: true, Mountpath: true},
 	apc.ActSummaryBck: {
 		DisplayName: "summary",
 		Scope:       ScopeGB,
@@ -133,6 +135,12 @@ var Table = map[string]Descriptor{
 	apc.ActECRespond: {Scope: ScopeB, Startable: false, Idles: true},
 	apc.ActPutCopies: {Scope: ScopeB, Startable: false, Mountpath: true, RefreshCap: true, Idles: true},
 
+	// on-demand promotion of demoted objects (triggered by GET => tiered bucket)
+	apc.ActTierPromote: {Scope: ScopeB, Startable: false, Mountpath: true, Idles: true},
+
+	// on-demand upload to remote backend (triggered by PUT => write-back bucket)
+	apc.ActWriteBack: {Scope: ScopeB, Startable: false, Idles: true},
+
 	// on-demand multi-object
 	apc.ActArchive:     {Scope: ScopeB, Startable: false, RefreshCap: true, Idles: true},
 	apc.ActCopyObjects: {DisplayName: "copy-objects", Scope: ScopeB, Startable: false, RefreshCap: true, Idles: true},
@@ -163,6 +171,13 @@ var Table = map[string]Descriptor{
 		Startable:   true,
 		RefreshCap:  true,
 	},
+	apc.ActSyncBck: {
+		DisplayName: "sync-bucket",
+		Scope:       ScopeB,
+		Access:      apc.AccessRW,
+		Startable:   false,
+		RefreshCap:  true,
+	},
 
 	// entire bucket (storage svcs)
 	apc.ActECEncode: {
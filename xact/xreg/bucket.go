@@ -9,6 +9,7 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/xact"
 )
 
@@ -43,6 +44,11 @@ type (
 		Tag    string
 		Copies int
 	}
+
+	SyncBckArgs struct {
+		Msg    *apc.SyncBckMsg
+		StatsT stats.Tracker // (to push local-only objects via write-back)
+	}
 )
 
 //////////////
@@ -113,6 +119,18 @@ func RenewPutMirror(t cluster.Target, lom *cluster.LOM) RenewRes {
 	return RenewBucketXact(apc.ActPutCopies, lom.Bck(), Args{T: t, Custom: lom})
 }
 
+func RenewTierPromote(t cluster.Target, lom *cluster.LOM) RenewRes {
+	return RenewBucketXact(apc.ActTierPromote, lom.Bck(), Args{T: t, Custom: lom})
+}
+
+func RenewWriteBack(t cluster.Target, statsT stats.Tracker, bck *cluster.Bck) RenewRes {
+	return RenewBucketXact(apc.ActWriteBack, bck, Args{T: t, Custom: statsT})
+}
+
+func RenewSyncBck(t cluster.Target, uuid string, bck *cluster.Bck, custom *SyncBckArgs) RenewRes {
+	return RenewBucketXact(apc.ActSyncBck, bck, Args{T: t, UUID: uuid, Custom: custom})
+}
+
 func RenewTCB(t cluster.Target, uuid, kind string, custom *TCBArgs) RenewRes {
 	return RenewBucketXact(
 		kind,
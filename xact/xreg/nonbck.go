@@ -45,6 +45,16 @@ func RenewStoreCleanup(id string) RenewRes {
 	return dreg.renew(e, nil)
 }
 
+func RenewLifecycle(id string) RenewRes {
+	e := dreg.nonbckXacts[apc.ActLifecycle].New(Args{UUID: id}, nil)
+	return dreg.renew(e, nil)
+}
+
+func RenewTiering(id string) RenewRes {
+	e := dreg.nonbckXacts[apc.ActTiering].New(Args{UUID: id}, nil)
+	return dreg.renew(e, nil)
+}
+
 func RenewDownloader(t cluster.Target, statsT stats.Tracker, xid string) RenewRes {
 	e := dreg.nonbckXacts[apc.ActDownload].New(Args{T: t, UUID: xid, Custom: statsT}, nil)
 	return dreg.renew(e, nil)
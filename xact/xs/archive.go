@@ -69,9 +69,10 @@ type (
 		r         *XactArch
 		msg       *cmn.ArchiveMsg
 		tsi       *cluster.Snode
-		lom       *cluster.LOM // of the archive
-		fqn       string       // workFQN --/--
-		fh        *os.File     // --/--
+		lom       *cluster.LOM   // of the archive
+		fqn       string         // workFQN --/--
+		fh        *os.File       // --/--
+		ew        io.WriteCloser // encrypting writer (iff the destination bucket is encrypted)
 		cksum     cos.CksumHashSize
 		appendPos int64 // append to existing archive
 		wmu       sync.Mutex
@@ -160,6 +161,14 @@ func (r *XactArch) Begin(msg *cmn.ArchiveMsg) (err error) {
 	if r.p.T.SID() == wi.tsi.ID() {
 		if errExists := cos.Stat(wi.lom.FQN); errExists != nil {
 			wi.fh, err = wi.lom.CreateFile(wi.fqn)
+			if err == nil && wi.lom.IsEncrypted() {
+				if wi.ew, err = wi.lom.NewEncWriter(wi.fh); err != nil {
+					cos.Close(wi.fh)
+					cos.RemoveFile(wi.fqn)
+				}
+			}
+		} else if wi.lom.IsEncrypted() {
+			err = fmt.Errorf("%s: appending to encrypted %s is not supported", r.p.T, msg.FullName())
 		} else if wi.msg.AllowAppendToExisting {
 			switch msg.Mime {
 			case cos.ExtTar:
@@ -361,6 +370,11 @@ func (r *XactArch) finalize(wi *archwi) {
 func (r *XactArch) fini(wi *archwi) (errCode int, err error) {
 	var size int64
 	wi.writer.fini()
+	if wi.ew != nil {
+		if err = wi.ew.Close(); err != nil {
+			return http.StatusInternalServerError, err
+		}
+	}
 	if size, err = wi.finalize(); err != nil {
 		return http.StatusInternalServerError, err
 	}
@@ -439,7 +453,7 @@ func (wi *archwi) do(lom *cluster.LOM, lrit *lriterator) {
 		}
 	}
 
-	fh, err := cos.NewFileHandle(lom.FQN)
+	fh, err := lom.Open()
 	debug.AssertNoErr(err)
 	if err != nil {
 		wi.r.raiseErr(err, 0, wi.msg.ContinueOnError)
@@ -458,6 +472,14 @@ func (wi *archwi) do(lom *cluster.LOM, lrit *lriterator) {
 	}
 }
 
+// the archive's file or, if encrypted, encrypting writer
+func (wi *archwi) writerTo() io.Writer {
+	if wi.ew != nil {
+		return wi.ew
+	}
+	return wi.fh
+}
+
 func (wi *archwi) quiesce() cluster.QuiRes {
 	return wi.r.Quiesce(cmn.Timeout.MaxKeepalive(), func(total time.Duration) cluster.QuiRes {
 		return xact.RefcntQuiCB(&wi.refc, wi.r.config.Timeout.SendFile.D()/2, total)
@@ -476,6 +498,12 @@ func (wi *archwi) nameInArch(objName string) string {
 }
 
 func (wi *archwi) openTarForAppend() (err error) {
+	if err := wi.lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+		return err
+	}
+	if wi.lom.IsCompressed() {
+		return fmt.Errorf("%s: appending to compressed archive is not supported", wi.lom)
+	}
 	if err := os.Rename(wi.lom.FQN, wi.fqn); err != nil {
 		return err
 	}
@@ -523,7 +551,7 @@ func (wi *archwi) finalize() (size int64, err error) {
 func (tw *tarWriter) init(wi *archwi) {
 	tw.archwi = wi
 	tw.buf, tw.slab = memsys.PageMM().Alloc()
-	tw.wmul = cos.NewWriterMulti(wi.fh, &wi.cksum)
+	tw.wmul = cos.NewWriterMulti(wi.writerTo(), &wi.cksum)
 	tw.tw = tar.NewWriter(tw.wmul)
 	wi.writer = tw
 }
@@ -561,7 +589,7 @@ func (tw *tarWriter) write(fullname string, oah cmn.ObjAttrsHolder, reader io.Re
 func (tzw *tgzWriter) init(wi *archwi) {
 	tzw.tw.archwi = wi
 	tzw.tw.buf, tzw.tw.slab = memsys.PageMM().Alloc()
-	tzw.tw.wmul = cos.NewWriterMulti(wi.fh, &wi.cksum)
+	tzw.tw.wmul = cos.NewWriterMulti(wi.writerTo(), &wi.cksum)
 	tzw.gzw = gzip.NewWriter(tzw.tw.wmul)
 	tzw.tw.tw = tar.NewWriter(tzw.gzw)
 	wi.writer = tzw
@@ -584,7 +612,7 @@ func (tzw *tgzWriter) write(fullname string, oah cmn.ObjAttrsHolder, reader io.R
 func (zw *zipWriter) init(wi *archwi) {
 	zw.archwi = wi
 	zw.buf, zw.slab = memsys.PageMM().Alloc()
-	zw.wmul = cos.NewWriterMulti(wi.fh, &wi.cksum)
+	zw.wmul = cos.NewWriterMulti(wi.writerTo(), &wi.cksum)
 	zw.zw = zip.NewWriter(zw.wmul)
 	wi.writer = zw
 }
@@ -624,7 +652,7 @@ const dfltNumPerShard = 32
 func (mpw *msgpackWriter) init(wi *archwi) {
 	mpw.archwi = wi
 	mpw.shard = make(sglShard, dfltNumPerShard)
-	mpw.wmul = cos.NewWriterMulti(wi.fh, &wi.cksum)
+	mpw.wmul = cos.NewWriterMulti(wi.writerTo(), &wi.cksum)
 	wi.writer = mpw
 }
 
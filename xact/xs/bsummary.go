@@ -136,8 +136,8 @@ func (r *bsummXact) runBck(bck *cluster.Bck, listRemote bool) (err error) {
 	cos.CopyStruct(&msg, r.msg) // each bucket to have it's own copy of the msg (we may update it)
 	if bck.IsRemote() {
 		msg.ObjCached = msg.ObjCached || !listRemote
-		if bck.IsHTTP() && !msg.ObjCached {
-			glog.Warningf("cannot list %s buckets, assuming 'cached'", apc.DisplayProvider(bck.Provider))
+		if bck.IsHTTP() && !msg.ObjCached && bck.Props.Extra.HTTP.Manifest == "" {
+			glog.Warningf("cannot list %s buckets without manifest, assuming 'cached'", apc.DisplayProvider(bck.Provider))
 			msg.ObjCached = true
 		}
 	} else {
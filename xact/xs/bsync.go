@@ -0,0 +1,401 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// XactSyncBck reconciles the cached content of a remote bucket with its backend (see apc.ActSyncBck).
+// Each target handles the objects it owns (HRW) in two phases:
+// 1. list remote pages and refresh (cold GET) the cached objects that have changed out-of-band -
+//    as per listed size, version, and checksum (or, when the listing does not tell, as per HEAD);
+// 2. walk local objects and, for those that are no longer listed, evict the ones that were
+//    previously stored remotely (deleted out-of-band) and, optionally, upload the local-only
+//    ones (via write-back - see xs.XactWriteBack).
+// Objects written after the sync has started, as well as objects pending write-back, are skipped.
+// With DryRun, the xaction does not make any changes - only computes (and reports) the diff;
+// objects that cannot be compared by the listing alone are then reported as changed.
+// NOTE: the names of the remote objects (owned by a given target) are kept in memory for the
+//       duration of the phase 2.
+
+type (
+	syncFactory struct {
+		xreg.RenewBase
+		xctn *XactSyncBck
+		args *xreg.SyncBckArgs
+	}
+	XactSyncBck struct {
+		xact.Base
+		t       cluster.Target
+		ctx     context.Context
+		msg     *apc.SyncBckMsg
+		statsT  stats.Tracker
+		remote  map[string]struct{} // names of the remote objects owned by this target
+		started time.Time
+		diff    struct {
+			changed, deleted, local    atomic.Int64
+			changedSz, deletedSz, lcSz atomic.Int64
+			errs                       atomic.Int64
+		}
+	}
+	ExtSyncBckStats struct {
+		Changed     int64 `json:"sync.changed.n,string"`
+		ChangedSize int64 `json:"sync.changed.size,string"`
+		Deleted     int64 `json:"sync.deleted.n,string"`
+		DeletedSize int64 `json:"sync.deleted.size,string"`
+		LocalOnly   int64 `json:"sync.local.n,string"`
+		LocalSize   int64 `json:"sync.local.size,string"`
+		Errors      int64 `json:"sync.err.n,string"`
+		DryRun      bool  `json:"dry_run"`
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact   = (*XactSyncBck)(nil)
+	_ xreg.Renewable = (*syncFactory)(nil)
+)
+
+/////////////////
+// syncFactory //
+/////////////////
+
+func (*syncFactory) New(args xreg.Args, bck *cluster.Bck) xreg.Renewable {
+	return &syncFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}, args: args.Custom.(*xreg.SyncBckArgs)}
+}
+
+func (p *syncFactory) Start() error {
+	b := p.Bck
+	if err := b.Init(p.Args.T.Bowner()); err != nil {
+		return err
+	}
+	if !b.IsRemote() {
+		return fmt.Errorf("bucket %q: can only sync remote buckets", b)
+	}
+	if p.args.Msg.PushLocal && b.IsHTTP() {
+		return fmt.Errorf("bucket %q: cannot upload local-only objects to HTTP(S) backend", b)
+	}
+	r := &XactSyncBck{
+		t:      p.T,
+		ctx:    context.Background(),
+		msg:    p.args.Msg,
+		statsT: p.args.StatsT,
+		remote: make(map[string]struct{}, 1024),
+	}
+	r.InitBase(p.UUID(), apc.ActSyncBck, b)
+	p.xctn = r
+	return nil
+}
+
+func (*syncFactory) Kind() string        { return apc.ActSyncBck }
+func (p *syncFactory) Get() cluster.Xact { return p.xctn }
+
+func (p *syncFactory) WhenPrevIsRunning(prevEntry xreg.Renewable) (wpr xreg.WPR, err error) {
+	err = fmt.Errorf("%s is currently running, cannot start a new %q", prevEntry.Get(), p.Str(p.Kind()))
+	return
+}
+
+/////////////////
+// XactSyncBck //
+/////////////////
+
+func (r *XactSyncBck) Run(*sync.WaitGroup) {
+	glog.Infof("%s: prefix %q, dry-run %t, push-local %t", r.Name(), r.msg.Prefix, r.msg.DryRun, r.msg.PushLocal)
+	r.started = time.Now()
+	err := r.syncRemote()
+	if err == nil && !r.IsAborted() {
+		err = r.syncLocal()
+	}
+	r.remote = nil
+	glog.Infoln(r.summary())
+	r.Finish(err)
+}
+
+// phase 1: remote => local
+func (r *XactSyncBck) syncRemote() error {
+	var (
+		bck     = r.Bck()
+		smap    = r.t.Sowner().Get()
+		backend = r.t.Backend(bck)
+		msg     = &apc.LsoMsg{Prefix: r.msg.Prefix}
+	)
+	msg.AddProps(apc.GetPropsName, apc.GetPropsSize, apc.GetPropsVersion, apc.GetPropsChecksum)
+	for {
+		if r.IsAborted() {
+			return nil
+		}
+		lst := &cmn.LsoResult{Entries: allocLsoEntries()}
+		if _, err := backend.ListObjects(bck, msg, lst); err != nil {
+			freeLsoEntries(lst.Entries)
+			return err
+		}
+		for _, be := range lst.Entries {
+			if !be.IsStatusOK() {
+				continue
+			}
+			si, err := cluster.HrwTarget(bck.MakeUname(be.Name), smap)
+			if err != nil {
+				freeLsoEntries(lst.Entries)
+				return err
+			}
+			if si.ID() != r.t.SID() {
+				continue
+			}
+			r.remote[be.Name] = struct{}{}
+			r.visitRemote(be)
+		}
+		freeLsoEntries(lst.Entries)
+		// last page listed
+		if lst.ContinuationToken == "" {
+			return nil
+		}
+		// token for the next page
+		msg.ContinuationToken = lst.ContinuationToken
+	}
+}
+
+func (r *XactSyncBck) visitRemote(be *cmn.LsoEntry) {
+	lom := cluster.AllocLOM(be.Name)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(r.Bck().Bucket()); err != nil {
+		r.fail(lom, err)
+		return
+	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		if !cmn.IsObjNotExist(err) {
+			r.fail(lom, err)
+		}
+		return // not cached
+	}
+	if wbPending(lom) {
+		return
+	}
+	same, known := sameAsListed(lom, be)
+	if !known && !r.msg.DryRun {
+		same = r.sameAsRemote(lom)
+	}
+	if same {
+		return
+	}
+	r.diff.changed.Inc()
+	r.diff.changedSz.Add(be.Size)
+	if r.msg.DryRun {
+		if verbose {
+			glog.Infof("%s: changed %s", r, lom)
+		}
+		return
+	}
+	if _, err := r.t.GetCold(r.ctx, lom, cmn.OwtGetPrefetchLock); err != nil {
+		if err != cmn.ErrSkip {
+			r.fail(lom, err)
+		}
+		return
+	}
+	r.ObjsAdd(1, lom.SizeBytes())
+}
+
+// phase 2: local => remote
+func (r *XactSyncBck) syncLocal() error {
+	msg := &apc.LsoMsg{Prefix: r.msg.Prefix, Props: apc.GetPropsName, Flags: apc.LsObjCached | apc.LsNameOnly}
+	npg := newNpgCtx(r.t, r.Bck(), msg, noopCb)
+	npg.page.Entries = allocLsoEntries()
+	if err := npg.nextPageA(); err != nil {
+		return err
+	}
+	defer freeLsoEntries(npg.page.Entries)
+	for _, be := range npg.page.Entries {
+		if r.IsAborted() {
+			break
+		}
+		if _, ok := r.remote[be.Name]; ok {
+			continue
+		}
+		r.visitLocal(be.Name)
+	}
+	return nil
+}
+
+func (r *XactSyncBck) visitLocal(objName string) {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(r.Bck().Bucket()); err != nil {
+		r.fail(lom, err)
+		return
+	}
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+		if !cmn.IsObjNotExist(err) {
+			r.fail(lom, err)
+		}
+		return
+	}
+	// written (PUT, cold GET) after the remote listing has started
+	if finfo, err := os.Stat(lom.FQN); err != nil || !finfo.ModTime().Before(r.started) {
+		return
+	}
+	if wbPending(lom) {
+		return
+	}
+	if _, ok := lom.GetCustomKey(cmn.SourceObjMD); ok || lom.Bck().IsRemoteAIS() {
+		r.deleted(lom)
+	} else {
+		r.localOnly(lom)
+	}
+}
+
+// deleted out-of-band
+func (r *XactSyncBck) deleted(lom *cluster.LOM) {
+	r.diff.deleted.Inc()
+	r.diff.deletedSz.Add(lom.SizeBytes())
+	if r.msg.DryRun {
+		if verbose {
+			glog.Infof("%s: deleted %s", r, lom)
+		}
+		return
+	}
+	if _, err := r.t.EvictObject(lom); err != nil {
+		if !cmn.IsErrObjNought(err) {
+			r.fail(lom, err)
+		}
+		return
+	}
+	r.ObjsAdd(1, lom.SizeBytes())
+}
+
+// never stored remotely (e.g., PUT prior to the bucket getting a backend); when requested,
+// mark it and hand it over to write-back (that in turn will retry upon failure)
+func (r *XactSyncBck) localOnly(lom *cluster.LOM) {
+	r.diff.local.Inc()
+	r.diff.lcSz.Add(lom.SizeBytes())
+	if r.msg.DryRun || !r.msg.PushLocal {
+		if verbose {
+			glog.Infof("%s: local-only %s", r, lom)
+		}
+		return
+	}
+	lom.Lock(false)
+	err := lom.MarkWriteBack()
+	lom.Unlock(false)
+	if err != nil {
+		r.fail(lom, err)
+		return
+	}
+	rns := xreg.RenewWriteBack(r.t, r.statsT, r.Bck())
+	if rns.Err != nil {
+		r.fail(lom, rns.Err) // (marked - will be picked up by the periodic scan)
+		return
+	}
+	rns.Entry.Get().(*XactWriteBack).Upload(lom)
+	r.ObjsAdd(1, lom.SizeBytes())
+}
+
+func (r *XactSyncBck) fail(lom *cluster.LOM, err error) {
+	r.diff.errs.Inc()
+	glog.Warningf("%s: %s: %v", r, lom, err)
+}
+
+func (r *XactSyncBck) ext() *ExtSyncBckStats {
+	return &ExtSyncBckStats{
+		Changed:     r.diff.changed.Load(),
+		ChangedSize: r.diff.changedSz.Load(),
+		Deleted:     r.diff.deleted.Load(),
+		DeletedSize: r.diff.deletedSz.Load(),
+		LocalOnly:   r.diff.local.Load(),
+		LocalSize:   r.diff.lcSz.Load(),
+		Errors:      r.diff.errs.Load(),
+		DryRun:      r.msg.DryRun,
+	}
+}
+
+func (r *XactSyncBck) summary() string {
+	ext := r.ext()
+	s := fmt.Sprintf("%s: changed %d (%s), deleted %d (%s), local-only %d (%s)", r,
+		ext.Changed, cos.ToSizeIEC(ext.ChangedSize, 2), ext.Deleted, cos.ToSizeIEC(ext.DeletedSize, 2),
+		ext.LocalOnly, cos.ToSizeIEC(ext.LocalSize, 2))
+	if ext.Errors > 0 {
+		s += fmt.Sprintf(", errors %d", ext.Errors)
+	}
+	if ext.DryRun {
+		s += " (dry-run)"
+	}
+	return s
+}
+
+func (r *XactSyncBck) Snap() (snap *cluster.Snap) {
+	snap = &cluster.Snap{}
+	r.ToSnap(snap)
+
+	snap.Ext = r.ext()
+	snap.IdleX = r.IsIdle()
+	return
+}
+
+// (pending upload - see XactWriteBack)
+func wbPending(lom *cluster.LOM) bool { return cos.Stat(lom.WriteBackFQN()) == nil }
+
+// compare the cached object with its listed remote counterpart; the listing carries
+// size, version, and (backend-specific) checksum - e.g., MD5 or ETag;
+// returns known == false when the listing does not tell (and same == false in that case)
+func sameAsListed(lom *cluster.LOM, be *cmn.LsoEntry) (same, known bool) {
+	if lom.SizeBytes() != be.Size {
+		return false, true
+	}
+	if be.Version != "" && lom.Version() != "" {
+		return be.Version == lom.Version(), true
+	}
+	if be.Checksum == "" {
+		return false, false
+	}
+	for _, k := range []string{cmn.ETag, cmn.MD5ObjMD, cmn.CRC32CObjMD} {
+		if v, ok := lom.GetCustomKey(k); ok && v != "" {
+			if v == be.Checksum {
+				return true, true
+			}
+			known = true
+		}
+	}
+	return false, known
+}
+
+// same as above when the listing does not tell: HEAD the remote object and compare
+// checksums (if any) or, otherwise, last-modified times; when in doubt, the object
+// is considered changed (and gets refreshed)
+func (r *XactSyncBck) sameAsRemote(lom *cluster.LOM) bool {
+	oa, _, err := r.t.Backend(r.Bck()).HeadObj(r.ctx, lom)
+	if err != nil {
+		if verbose {
+			glog.Infof("%s: failed to HEAD %s: %v", r, lom, err)
+		}
+		return false
+	}
+	if oa.Size != lom.SizeBytes() {
+		return false
+	}
+	if oa.Ver != "" && lom.Version() != "" {
+		return oa.Ver == lom.Version()
+	}
+	for _, k := range []string{cmn.ETag, cmn.MD5ObjMD, cmn.CRC32CObjMD, cmn.LastModified} {
+		v, _ := oa.GetCustomKey(k)
+		if lv, ok := lom.GetCustomKey(k); ok && lv != "" && v != "" {
+			return v == lv
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+type (
+	// remote object as per HEAD
+	headBackend struct {
+		cluster.BackendProvider
+		oa *cmn.ObjAttrs
+	}
+	headTarget struct {
+		mock.TargetMock
+		bp *headBackend
+	}
+)
+
+func (b *headBackend) HeadObj(context.Context, *cluster.LOM) (*cmn.ObjAttrs, int, error) {
+	return b.oa, 0, nil
+}
+
+func (t *headTarget) Backend(*cluster.Bck) cluster.BackendProvider { return t.bp }
+
+func TestSyncBckSameAsListed(t *testing.T) {
+	const etag = "etag-1"
+	newLOM := func(ver string, custom cos.StrKVs) *cluster.LOM {
+		lom := &cluster.LOM{ObjName: "obj"}
+		lom.SetSize(cos.KiB)
+		lom.SetVersion(ver)
+		lom.SetCustomMD(custom)
+		return lom
+	}
+	tests := []struct {
+		name        string
+		lom         *cluster.LOM
+		be          *cmn.LsoEntry
+		same, known bool
+	}{
+		{"size", newLOM("", nil), &cmn.LsoEntry{Size: cos.MiB}, false, true},
+		{"version", newLOM("1", nil), &cmn.LsoEntry{Size: cos.KiB, Version: "2"}, false, true},
+		{"same-version", newLOM("1", nil), &cmn.LsoEntry{Size: cos.KiB, Version: "1"}, true, true},
+		{"etag", newLOM("", cos.StrKVs{cmn.ETag: etag}), &cmn.LsoEntry{Size: cos.KiB, Checksum: etag}, true, true},
+		{"changed-etag", newLOM("", cos.StrKVs{cmn.ETag: etag}), &cmn.LsoEntry{Size: cos.KiB, Checksum: "etag-2"}, false, true},
+		{"no-listed-checksum", newLOM("", cos.StrKVs{cmn.ETag: etag}), &cmn.LsoEntry{Size: cos.KiB}, false, false},
+		{"no-local-checksum", newLOM("", nil), &cmn.LsoEntry{Size: cos.KiB, Checksum: etag}, false, false},
+	}
+	for _, test := range tests {
+		same, known := sameAsListed(test.lom, test.be)
+		tassert.Errorf(t, same == test.same && known == test.known, "%s: expected (%t, %t), got (%t, %t)",
+			test.name, test.same, test.known, same, known)
+	}
+}
+
+// when the listing does not tell
+func TestSyncBckSameAsRemote(t *testing.T) {
+	const mtime = "2023-05-24T00:00:00Z"
+	var (
+		bp  = &headBackend{}
+		bck = cluster.NewBck("bsync", apc.AWS, cmn.NsGlobal)
+		r   = &XactSyncBck{t: &headTarget{bp: bp}, ctx: context.Background()}
+	)
+	r.InitBase(cos.GenUUID(), apc.ActSyncBck, bck)
+
+	lom := &cluster.LOM{ObjName: "obj"}
+	lom.SetSize(cos.KiB)
+	lom.SetCustomMD(cos.StrKVs{cmn.LastModified: mtime})
+
+	tests := []struct {
+		name string
+		oa   *cmn.ObjAttrs
+		same bool
+	}{
+		{"same-mtime", &cmn.ObjAttrs{Size: cos.KiB, CustomMD: cos.StrKVs{cmn.LastModified: mtime}}, true},
+		{"changed-mtime", &cmn.ObjAttrs{Size: cos.KiB, CustomMD: cos.StrKVs{cmn.LastModified: "2023-05-25T00:00:00Z"}}, false},
+		{"size", &cmn.ObjAttrs{Size: cos.MiB, CustomMD: cos.StrKVs{cmn.LastModified: mtime}}, false},
+		{"unknown", &cmn.ObjAttrs{Size: cos.KiB}, false},
+	}
+	for _, test := range tests {
+		bp.oa = test.oa
+		same := r.sameAsRemote(lom)
+		tassert.Errorf(t, same == test.same, "%s: expected %t, got %t", test.name, test.same, same)
+	}
+}
@@ -13,7 +13,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"path"
 	"path/filepath"
 	"runtime"
@@ -263,6 +262,10 @@ func (r *LsoXact) doPage() *LsoRsp {
 	)
 	debug.Assert(uint(len(lst)) >= cnt || r.walk.done)
 	if uint(len(lst)) >= cnt {
+		// (prior versions of the object always go together with the object)
+		for cnt < uint(len(lst)) && lst[cnt].IsVersion() {
+			cnt++
+		}
 		entries := lst[:cnt]
 		page = &cmn.LsoResult{UUID: r.msg.UUID, Entries: entries, ContinuationToken: entries[cnt-1].Name}
 	} else {
@@ -288,7 +291,8 @@ func (r *LsoXact) havePage(token string, cnt uint) bool {
 		return true
 	}
 	idx := r.findToken(token)
-	return idx+cnt < uint(len(r.lastPage))
+	l := uint(len(r.lastPage))
+	return idx+cnt < l && !r.lastPage[l-1].IsVersion()
 }
 
 func (r *LsoXact) nextPageR() error {
@@ -417,7 +421,7 @@ func (r *LsoXact) nextPageA() {
 	if r.havePage(r.token, r.msg.PageSize) {
 		return
 	}
-	for cnt := uint(0); cnt < r.msg.PageSize; {
+	for cnt := uint(0); cnt <= r.msg.PageSize; {
 		obj, ok := <-r.walk.pageCh
 		if !ok {
 			r.walk.done = true
@@ -427,8 +431,14 @@ func (r *LsoXact) nextPageA() {
 		if cmn.TokenGreaterEQ(r.token, obj.Name) {
 			continue
 		}
-		cnt++
 		r.lastPage = append(r.lastPage, obj)
+		if obj.IsVersion() {
+			continue
+		}
+		cnt++
+		if cnt == r.msg.PageSize && !r.msg.IsFlagSet(apc.LsVersions) {
+			break
+		}
 	}
 }
 
@@ -460,8 +470,12 @@ func (r *LsoXact) shiftLastPage(token string) {
 
 func (r *LsoXact) doWalk(msg *apc.LsoMsg) {
 	r.walk.wi = newWalkInfo(r.p.T, msg, r.LomAdd)
+	ct := fs.ObjectType
+	if msg.IsFlagSet(apc.LsDeleted) {
+		ct = fs.TrashType
+	}
 	opts := &fs.WalkBckOpts{
-		WalkOpts: fs.WalkOpts{CTs: []string{fs.ObjectType}, Callback: r.cb, Sorted: true},
+		WalkOpts: fs.WalkOpts{CTs: []string{ct}, Callback: r.cb, Sorted: true},
 	}
 	opts.WalkOpts.Bck.Copy(r.Bck().Bucket())
 	opts.ValidateCallback = func(fqn string, de fs.DirEntry) error {
@@ -496,6 +510,12 @@ func (r *LsoXact) cb(fqn string, de fs.DirEntry) error {
 		return errStopped
 	}
 
+	if msg.IsFlagSet(apc.LsVersions) && !msg.IsFlagSet(apc.LsDeleted) && entry.IsStatusOK() {
+		if err := r.listVersions(fqn, entry); err != nil {
+			return err
+		}
+	}
+
 	if !msg.IsFlagSet(apc.LsArchDir) {
 		return nil
 	}
@@ -521,6 +541,34 @@ func (r *LsoXact) cb(fqn string, de fs.DirEntry) error {
 	return nil
 }
 
+// prior versions of the object (see cmn.VersionConf.History)
+func (r *LsoXact) listVersions(fqn string, entry *cmn.LsoEntry) error {
+	lom := cluster.AllocLOM("")
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitFQN(fqn, nil); err != nil {
+		return err
+	}
+	if !lom.KeepsHistory() {
+		return nil
+	}
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+		return nil
+	}
+	wi := r.walk.wi
+	return lom.PriorVersions(func(vlom *cluster.LOM) error {
+		e := &cmn.LsoEntry{Name: entry.Name, Flags: entry.Flags | apc.EntryIsVersion, Version: vlom.Version()}
+		if !wi.msg.IsFlagSet(apc.LsNameOnly) {
+			setWanted(e, vlom, wi.msg.TimeFormat, wi.wanted)
+		}
+		select {
+		case r.walk.pageCh <- e:
+			return nil
+		case <-r.walk.stopCh.Listen():
+			return errStopped
+		}
+	})
+}
+
 func (r *LsoXact) Snap() (snap *cluster.Snap) {
 	snap = &cluster.Snap{}
 	r.ToSnap(snap)
@@ -544,28 +592,31 @@ func listArchive(fqn string) ([]*archEntry, error) {
 	if arch == "" {
 		return nil, nil
 	}
-	// list the archive content
-	var (
-		archList []*archEntry
-		finfo    os.FileInfo
-	)
-	f, err := os.Open(fqn)
-	if err == nil {
-		switch arch {
-		case cos.ExtTar:
-			archList, err = listTar(f)
-		case cos.ExtTgz, cos.ExtTarTgz:
-			archList, err = listTgz(f)
-		case cos.ExtZip:
-			finfo, err = os.Stat(fqn)
-			if err == nil {
-				archList, err = listZip(f, finfo.Size())
-			}
-		case cos.ExtMsgpack:
-			archList, err = listMsgpack(f)
-		default:
-			debug.Assert(false, arch)
-		}
+	// list the archive content (reading it via lom - to decrypt or decompress if need be)
+	lom := cluster.AllocLOM("")
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitFQN(fqn, nil); err != nil {
+		return nil, err
+	}
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+		return nil, err
+	}
+	var archList []*archEntry
+	f, err := lom.Open()
+	if err != nil {
+		return nil, err
+	}
+	switch arch {
+	case cos.ExtTar:
+		archList, err = listTar(f)
+	case cos.ExtTgz, cos.ExtTarTgz:
+		archList, err = listTgz(f)
+	case cos.ExtZip:
+		archList, err = listZip(f, lom.SizeBytes())
+	case cos.ExtMsgpack:
+		archList, err = listMsgpack(f)
+	default:
+		debug.Assert(false, arch)
 	}
 	f.Close()
 	if err != nil {
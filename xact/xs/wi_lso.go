@@ -27,6 +27,7 @@ type (
 		smap         *cluster.Smap
 		lomVisitedCb lomVisitedCb
 		msg          *apc.LsoMsg
+		tags         cos.StrKVs // filter by object tags, if specified
 		markerDir    string
 		wanted       cos.BitFlags
 	}
@@ -43,6 +44,7 @@ func newWalkInfo(t cluster.Target, msg *apc.LsoMsg, lomVisitedCb lomVisitedCb) (
 		smap:         t.Sowner().Get(),
 		lomVisitedCb: lomVisitedCb,
 		msg:          msg,
+		tags:         msg.TagFilter(),
 		wanted:       wanted(msg),
 	}
 	if msg.ContinuationToken != "" { // marker is always a filename
@@ -109,11 +111,26 @@ func (wi *walkInfo) callback(fqn string, de fs.DirEntry) (entry *cmn.LsoEntry, e
 		return
 	}
 	lom := cluster.AllocLOM("")
-	entry, err = wi.cb(lom, fqn)
+	if wi.msg.IsFlagSet(apc.LsDeleted) {
+		entry = wi.cbDeleted(lom, fqn)
+	} else {
+		entry, err = wi.cb(lom, fqn)
+	}
 	cluster.FreeLOM(lom)
 	return
 }
 
+// trashed objects (see cmn.TrashConf)
+func (wi *walkInfo) cbDeleted(lom *cluster.LOM, fqn string) *cmn.LsoEntry {
+	if err := lom.LoadTrashed(fqn); err != nil {
+		return nil // (e.g., undeleted or purged in the meantime)
+	}
+	if !wi.match(lom) {
+		return nil
+	}
+	return wi.ls(lom, apc.LocOK)
+}
+
 func (wi *walkInfo) cb(lom *cluster.LOM, fqn string) (*cmn.LsoEntry, error) {
 	status := uint16(apc.LocOK)
 	if err := lom.InitFQN(fqn, nil); err != nil {
@@ -136,7 +153,8 @@ func (wi *walkInfo) cb(lom *cluster.LOM, fqn string) (*cmn.LsoEntry, error) {
 	}
 
 	// shortcut #1: name-only optimizes-out loading md (NOTE: won't show misplaced and copies)
-	if wi.msg.IsFlagSet(apc.LsNameOnly) {
+	// (tags, if any, are part of the metadata)
+	if wi.msg.IsFlagSet(apc.LsNameOnly) && len(wi.tags) == 0 {
 		if !isOK(status) {
 			return nil, nil
 		}
@@ -149,6 +167,9 @@ func (wi *walkInfo) cb(lom *cluster.LOM, fqn string) (*cmn.LsoEntry, error) {
 		}
 		return nil, err
 	}
+	if len(wi.tags) > 0 && !lom.ObjAttrs().HasTags(wi.tags) {
+		return nil, nil
+	}
 	if local && lom.IsCopy() {
 		// still may change below
 		status = apc.LocIsCopy
@@ -0,0 +1,302 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// XactWriteBack uploads objects of a write-back bucket to its remote backend (see apc.WriteBack).
+// - PUT stores the object locally along with a (zero-length) marker, and queues the object;
+// - the marker is removed only upon successful upload of the current (unchanged) content,
+//   which makes the queue durable: objects that are still marked get re-queued by the
+//   periodic scan (see ais/tgtwb.go) - e.g., after restart, or when all retries fail;
+// - the queue is bounded - when full, new objects are left for the scan to pick up.
+
+const (
+	wbQueueSize = 1024
+	wbWorkers   = 16
+	wbRetries   = 3
+	wbBackoff   = 2 * time.Second
+)
+
+type (
+	wbFactory struct {
+		xreg.RenewBase
+		xctn *XactWriteBack
+	}
+	XactWriteBack struct {
+		// implements cluster.Xact interface
+		xact.DemandBase
+		// runtime
+		t      cluster.Target
+		statsT stats.Tracker
+		workCh chan cluster.LIF
+		stopCh cos.StopCh
+		queued sync.Map // uname => struct{}: queued or being uploaded
+		wg     sync.WaitGroup
+		failed atomic.Int64
+	}
+	ExtWriteBackStats struct {
+		Queued int64 `json:"wb.queued.n,string"`
+		Failed int64 `json:"wb.err.n,string"`
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact   = (*XactWriteBack)(nil)
+	_ xreg.Renewable = (*wbFactory)(nil)
+)
+
+///////////////
+// wbFactory //
+///////////////
+
+func (*wbFactory) New(args xreg.Args, bck *cluster.Bck) xreg.Renewable {
+	return &wbFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+}
+
+func (p *wbFactory) Start() error {
+	r := &XactWriteBack{t: p.T, statsT: p.Args.Custom.(stats.Tracker), workCh: make(chan cluster.LIF, wbQueueSize)}
+	r.DemandBase.Init(cos.GenUUID(), apc.ActWriteBack, p.Bck, 0 /*use default*/)
+	r.stopCh.Init()
+	p.xctn = r
+	go r.Run(nil)
+	return nil
+}
+
+func (*wbFactory) Kind() string        { return apc.ActWriteBack }
+func (p *wbFactory) Get() cluster.Xact { return p.xctn }
+
+func (p *wbFactory) WhenPrevIsRunning(xprev xreg.Renewable) (xreg.WPR, error) {
+	debug.Assertf(false, "%s vs %s", p.Str(p.Kind()), xprev) // xreg.usePrev() must've returned true
+	return xreg.WprUse, nil
+}
+
+///////////////////
+// XactWriteBack //
+///////////////////
+
+// control logic: stop and idle timer
+func (r *XactWriteBack) Run(*sync.WaitGroup) {
+	glog.Infoln(r.Name())
+	r.wg.Add(wbWorkers)
+	for i := 0; i < wbWorkers; i++ {
+		go r.work()
+	}
+	for {
+		select {
+		case <-r.IdleTimer():
+			r.stop()
+			r.Finish(nil)
+			return
+		case errCause := <-r.ChanAbort():
+			if n := r.stop(); n > 0 {
+				glog.Infof("%s aborted (cause %v), %d object%s remain queued", r, errCause, n, cos.Plural(n))
+			} else {
+				glog.Infof("%s aborted (cause %v)", r, errCause)
+			}
+			r.Finish(cmn.NewErrAborted(r.Name(), "", errCause))
+			return
+		}
+	}
+}
+
+// main method: queue a given object for upload; returns false if already queued or
+// when the queue is full
+func (r *XactWriteBack) Upload(lom *cluster.LOM) bool {
+	debug.Assert(!r.Finished(), r.String())
+	uname := lom.Uname()
+	if _, loaded := r.queued.LoadOrStore(uname, struct{}{}); loaded {
+		return false
+	}
+	r.IncPending() // ref-count via base to support on-demand action
+	select {
+	case r.workCh <- lom.LIF():
+		r.statsT.Add(stats.WriteBackPending, 1)
+		return true
+	default:
+		r.queued.Delete(uname)
+		r.DecPending()
+		return false
+	}
+}
+
+func (r *XactWriteBack) Full() bool { return len(r.workCh) == cap(r.workCh) }
+
+func (r *XactWriteBack) work() {
+	defer r.wg.Done()
+	for {
+		select {
+		case lif := <-r.workCh:
+			if lom, err := lif.LOM(); err == nil {
+				r.do(lom)
+				cluster.FreeLOM(lom)
+			}
+			r.done(lif)
+		case <-r.stopCh.Listen():
+			return
+		}
+	}
+}
+
+func (r *XactWriteBack) done(lif cluster.LIF) {
+	r.queued.Delete(lif.Uname)
+	r.statsT.Add(stats.WriteBackPending, -1)
+	r.DecPending()
+}
+
+// upload with retries; upon failure, the (marked) object stays in the cluster to be retried later
+func (r *XactWriteBack) do(lom *cluster.LOM) {
+	var (
+		err     error
+		errCode int
+	)
+	for i := 0; i <= wbRetries; i++ {
+		if i > 0 {
+			select {
+			case <-time.After(wbBackoff * time.Duration(i)):
+			case <-r.stopCh.Listen():
+				return
+			}
+		}
+		if errCode, err = r.upload(lom); err == nil {
+			return
+		}
+		if cmn.IsErrAborted(err) || errCode == http.StatusForbidden || errCode == http.StatusBadRequest {
+			break
+		}
+		glog.Warningf("%s: failed to upload %s (attempt %d): %v(%d)", r, lom, i+1, err, errCode)
+	}
+	r.failed.Inc()
+	r.statsT.Inc(stats.ErrWriteBackCount)
+	glog.Errorf("%s: failed to upload %s: %v(%d)", r, lom, err, errCode)
+}
+
+func (r *XactWriteBack) upload(lom *cluster.LOM) (int, error) {
+	var (
+		backend = r.t.Backend(lom.Bck())
+		mfqn    = lom.WriteBackFQN()
+	)
+	// 1. read-lock and upload
+	lom.Lock(false)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(false)
+		if cmn.IsObjNotExist(err) {
+			r.rmMarker(mfqn) // deleted in the meantime
+			return 0, nil
+		}
+		return 0, err
+	}
+	if cos.Stat(mfqn) != nil {
+		lom.Unlock(false) // uploaded in the meantime
+		return 0, nil
+	}
+	finfo, err := os.Stat(lom.FQN)
+	if err != nil {
+		lom.Unlock(false)
+		return 0, err
+	}
+	var (
+		size  = lom.SizeBytes()
+		cksum = lom.Checksum()
+		mtime = finfo.ModTime()
+	)
+	lmfh, err := lom.Open() // (decrypting and/or decompressing if need be)
+	if err != nil {
+		lom.Unlock(false)
+		return 0, err
+	}
+	errCode, err := backend.PutObj(lmfh, lom)
+	lom.Unlock(false)
+	if err != nil {
+		return errCode, err
+	}
+	var (
+		md  = lom.GetCustomMD()
+		ver = lom.Version()
+	)
+
+	// 2. write-lock, make sure the object hasn't changed while uploading, update metadata, unmark
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cmn.IsObjNotExist(err) {
+			r.rmMarker(mfqn)
+			return 0, nil
+		}
+		return 0, err
+	}
+	if finfo, err := os.Stat(lom.FQN); err != nil || !finfo.ModTime().Equal(mtime) || lom.SizeBytes() != size ||
+		(!cksum.IsEmpty() && !lom.EqCksum(cksum)) {
+		return 0, nil // overwritten - will be uploaded again
+	}
+	for k, v := range md {
+		lom.SetCustomKey(k, v)
+	}
+	if !lom.Bck().IsRemoteAIS() {
+		lom.SetCustomKey(cmn.SourceObjMD, backend.Provider())
+	}
+	if ver != "" {
+		lom.SetVersion(ver)
+	}
+	if err := lom.Persist(); err != nil {
+		return 0, err
+	}
+	r.rmMarker(mfqn)
+	r.ObjsAdd(1, size)
+	r.statsT.AddMany(
+		cos.NamedVal64{Name: stats.WriteBackCount, Value: 1},
+		cos.NamedVal64{Name: stats.WriteBackSize, Value: size},
+	)
+	return 0, nil
+}
+
+func (r *XactWriteBack) rmMarker(mfqn string) {
+	if err := cos.RemoveFile(mfqn); err != nil {
+		glog.Errorf("%s: %v", r, err)
+	}
+}
+
+// returns the number of objects that remain queued (and marked)
+func (r *XactWriteBack) stop() (n int) {
+	r.DemandBase.Stop()
+	r.stopCh.Close()
+	r.wg.Wait()
+	for {
+		select {
+		case lif := <-r.workCh:
+			r.done(lif)
+			n++
+		default:
+			return
+		}
+	}
+}
+
+func (r *XactWriteBack) Snap() (snap *cluster.Snap) {
+	snap = &cluster.Snap{}
+	r.ToSnap(snap)
+
+	snap.Ext = &ExtWriteBackStats{Queued: r.Pending(), Failed: r.failed.Load()}
+	snap.IdleX = r.IsIdle()
+	return
+}